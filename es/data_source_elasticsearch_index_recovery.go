@@ -0,0 +1,189 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/olivere/elastic/uritemplates"
+)
+
+func dataSourceElasticsearchIndexRecovery() *schema.Resource {
+	return &schema.Resource{
+		Description: "`elasticsearch_index_recovery` can be used to retrieve the progress of shard recoveries, e.g. during restores and rebalances.",
+		Read:        dataSourceElasticsearchIndexRecoveryRead,
+
+		Schema: map[string]*schema.Schema{
+			"index": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Comma separated list or wildcard expression of the indices to retrieve the recoveries of, defaults to all indices.",
+			},
+			"active_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Only return the recoveries which are still ongoing.",
+			},
+			"shards": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The shard recoveries, ordered by index and shard.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"index": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the index.",
+						},
+						"shard": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The shard number.",
+						},
+						"primary": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the shard is a primary.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of recovery, e.g. `PEER` or `SNAPSHOT`.",
+						},
+						"stage": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The stage of the recovery, e.g. `INDEX` or `DONE`.",
+						},
+						"source_node": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the node the shard is recovered from, if any.",
+						},
+						"target_node": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the node the shard is recovered to.",
+						},
+						"files_percent": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The percentage of files recovered, e.g. `100.0%`.",
+						},
+						"bytes_percent": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The percentage of bytes recovered, e.g. `100.0%`.",
+						},
+						"translog_percent": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The percentage of translog operations recovered, e.g. `100.0%`.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceElasticsearchIndexRecoveryRead(d *schema.ResourceData, m interface{}) error {
+	index := d.Get("index").(string)
+
+	path := "/_recovery"
+	if index != "" {
+		var err error
+		path, err = uritemplates.Expand("/{index}/_recovery", map[string]string{
+			"index": index,
+		})
+		if err != nil {
+			return fmt.Errorf("error building URL path for index recovery: %+v", err)
+		}
+	}
+	if d.Get("active_only").(bool) {
+		path += "?active_only=true"
+	}
+
+	body, err := elasticsearchPerformRequest(m, "GET", path, nil)
+	if err != nil {
+		return err
+	}
+
+	recoveries := make(map[string]IndexRecoveryResponse)
+	if err := json.Unmarshal(body, &recoveries); err != nil {
+		return fmt.Errorf("error unmarshalling index recovery body: %+v: %+v", err, body)
+	}
+
+	if index == "" {
+		d.SetId("_all")
+	} else {
+		d.SetId(index)
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("shards", flattenIndexRecoveries(recoveries))
+	return ds.err
+}
+
+func flattenIndexRecoveries(recoveries map[string]IndexRecoveryResponse) []map[string]interface{} {
+	indices := make([]string, 0, len(recoveries))
+	for name := range recoveries {
+		indices = append(indices, name)
+	}
+	sort.Strings(indices)
+
+	shards := make([]map[string]interface{}, 0)
+	for _, name := range indices {
+		indexShards := recoveries[name].Shards
+		sort.SliceStable(indexShards, func(i, j int) bool {
+			return indexShards[i].ID < indexShards[j].ID
+		})
+
+		for _, shard := range indexShards {
+			shards = append(shards, map[string]interface{}{
+				"index":            name,
+				"shard":            shard.ID,
+				"primary":          shard.Primary,
+				"type":             shard.Type,
+				"stage":            shard.Stage,
+				"source_node":      shard.Source.Name,
+				"target_node":      shard.Target.Name,
+				"files_percent":    shard.Index.Files.Percent,
+				"bytes_percent":    shard.Index.Size.Percent,
+				"translog_percent": shard.Translog.Percent,
+			})
+		}
+	}
+
+	return shards
+}
+
+type IndexRecoveryResponse struct {
+	Shards []IndexRecoveryShard `json:"shards"`
+}
+
+type IndexRecoveryShard struct {
+	ID      int    `json:"id"`
+	Type    string `json:"type"`
+	Stage   string `json:"stage"`
+	Primary bool   `json:"primary"`
+	Source  struct {
+		Name string `json:"name"`
+	} `json:"source"`
+	Target struct {
+		Name string `json:"name"`
+	} `json:"target"`
+	Index struct {
+		Size struct {
+			Percent string `json:"percent"`
+		} `json:"size"`
+		Files struct {
+			Percent string `json:"percent"`
+		} `json:"files"`
+	} `json:"index"`
+	Translog struct {
+		Percent string `json:"percent"`
+	} `json:"translog"`
+}
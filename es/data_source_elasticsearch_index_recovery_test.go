@@ -0,0 +1,41 @@
+package es
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccElasticsearchDataSourceIndexRecovery_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchDataSourceIndexRecovery,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticsearch_index_recovery.test", "id", "terraform-test-recovery"),
+					resource.TestCheckResourceAttr("data.elasticsearch_index_recovery.test", "shards.#", "2"),
+					resource.TestCheckResourceAttr("data.elasticsearch_index_recovery.test", "shards.0.index", "terraform-test-recovery"),
+					resource.TestCheckResourceAttr("data.elasticsearch_index_recovery.test", "shards.0.shard", "0"),
+					resource.TestCheckResourceAttr("data.elasticsearch_index_recovery.test", "shards.1.shard", "1"),
+					resource.TestCheckResourceAttrSet("data.elasticsearch_index_recovery.test", "shards.0.stage"),
+				),
+			},
+		},
+	})
+}
+
+var testAccElasticsearchDataSourceIndexRecovery = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test-recovery"
+  number_of_shards = 2
+  number_of_replicas = 0
+}
+
+data "elasticsearch_index_recovery" "test" {
+  index = elasticsearch_index.test.name
+}
+`
@@ -62,7 +62,7 @@ func dataSourceElasticsearchOpenDistroDestinationRead(d *schema.ResourceData, m
 		// the index has become a "system index", so it cannot be searched:
 		// https://opendistro.github.io/for-elasticsearch-docs/docs/alerting/settings/#alerting-indices
 		// instead we paginate through all destinations to find the first name match :|
-		id, destination, err = destinationElasticsearch7GetAll(client, destinationName)
+		id, destination, err = destinationElasticsearch7GetAll(client, destinationName, m)
 		if err != nil {
 			id, destination, err = destinationElasticsearch7Search(client, DESTINATION_INDEX, destinationName)
 		}
@@ -144,12 +144,12 @@ func destinationElasticsearch6Search(client *elastic6.Client, index string, name
 	}
 }
 
-func destinationElasticsearch7GetAll(client *elastic7.Client, name string) (string, map[string]interface{}, error) {
+func destinationElasticsearch7GetAll(client *elastic7.Client, name string, m interface{}) (string, map[string]interface{}, error) {
 	offset := 0
 	pageSize := 1000
 	destination := make(map[string]interface{})
 	for {
-		path, err := uritemplates.Expand("/_opendistro/_alerting/destinations?startIndex={startIndex}&size={size}", map[string]string{
+		path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_alerting/destinations?startIndex={startIndex}&size={size}"), map[string]string{
 			"startIndex": fmt.Sprint(offset),
 			"size":       fmt.Sprint(pageSize),
 		})
@@ -0,0 +1,225 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+)
+
+func dataSourceElasticsearchShardStores() *schema.Resource {
+	return &schema.Resource{
+		Description: "`elasticsearch_shard_stores` can be used to retrieve the store information of shard copies, e.g. to detect corrupted or missing copies.",
+		Read:        dataSourceElasticsearchShardStoresRead,
+
+		Schema: map[string]*schema.Schema{
+			"index": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Comma separated list or wildcard expression of the indices to retrieve the shard stores of, defaults to all indices.",
+			},
+			"status": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The health statuses of the shards to retrieve the stores of, any of `green`, `yellow`, `red` or `all`. Defaults to `yellow` and `red`.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"green", "yellow", "red", "all"}, false),
+				},
+			},
+			"stores": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The stores of the shard copies, ordered by index, shard and node name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"index": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the index.",
+						},
+						"shard": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The shard number.",
+						},
+						"node_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The id of the node holding the copy.",
+						},
+						"node_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the node holding the copy.",
+						},
+						"transport_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The transport address of the node holding the copy.",
+						},
+						"allocation": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the copy, `primary`, `replica` or `unused`.",
+						},
+						"allocation_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The allocation id of the copy.",
+						},
+						"store_exception": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The exception opening the store of the copy, if any, e.g. when it is corrupted.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceElasticsearchShardStoresRead(d *schema.ResourceData, m interface{}) error {
+	index := d.Get("index").(string)
+
+	path := "/_shard_stores"
+	if index != "" {
+		var err error
+		path, err = uritemplates.Expand("/{index}/_shard_stores", map[string]string{
+			"index": index,
+		})
+		if err != nil {
+			return fmt.Errorf("error building URL path for shard stores: %+v", err)
+		}
+	}
+	status := expandStringList(d.Get("status").([]interface{}))
+	if len(status) > 0 {
+		path += "?status=" + url.QueryEscape(strings.Join(status, ","))
+	}
+
+	body, err := elasticsearchPerformRequest(m, "GET", path, nil)
+	if err != nil {
+		return err
+	}
+
+	response := new(ShardStoresResponse)
+	if err := json.Unmarshal(body, response); err != nil {
+		return fmt.Errorf("error unmarshalling shard stores body: %+v: %+v", err, body)
+	}
+
+	stores, err := flattenShardStores(response)
+	if err != nil {
+		return err
+	}
+
+	id := index
+	if id == "" {
+		id = "_all"
+	}
+	if len(status) > 0 {
+		id = fmt.Sprintf("%s/%s", id, strings.Join(status, ","))
+	}
+	d.SetId(id)
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("stores", stores)
+	return ds.err
+}
+
+func flattenShardStores(response *ShardStoresResponse) ([]map[string]interface{}, error) {
+	stores := make([]map[string]interface{}, 0)
+	for index, indexStores := range response.Indices {
+		for shardNumber, shard := range indexStores.Shards {
+			shardID, err := strconv.Atoi(shardNumber)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing shard number %s of index %s: %+v", shardNumber, index, err)
+			}
+
+			for _, rawStore := range shard.Stores {
+				store, err := flattenShardStore(rawStore)
+				if err != nil {
+					return nil, err
+				}
+				store["index"] = index
+				store["shard"] = shardID
+				stores = append(stores, store)
+			}
+		}
+	}
+
+	sort.SliceStable(stores, func(i, j int) bool {
+		if stores[i]["index"] != stores[j]["index"] {
+			return stores[i]["index"].(string) < stores[j]["index"].(string)
+		}
+		if stores[i]["shard"] != stores[j]["shard"] {
+			return stores[i]["shard"].(int) < stores[j]["shard"].(int)
+		}
+		return stores[i]["node_name"].(string) < stores[j]["node_name"].(string)
+	})
+
+	return stores, nil
+}
+
+// flattenShardStore flattens a store, which apart from the allocation fields
+// is keyed by the id of the node holding it
+func flattenShardStore(rawStore map[string]json.RawMessage) (map[string]interface{}, error) {
+	store := map[string]interface{}{
+		"node_id":           "",
+		"node_name":         "",
+		"transport_address": "",
+		"allocation":        "",
+		"allocation_id":     "",
+		"store_exception":   "",
+	}
+
+	for key, value := range rawStore {
+		var err error
+		switch key {
+		case "allocation", "allocation_id":
+			var s string
+			err = json.Unmarshal(value, &s)
+			store[key] = s
+		case "store_exception":
+			var exception ShardStoreException
+			err = json.Unmarshal(value, &exception)
+			store[key] = fmt.Sprintf("%s: %s", exception.Type, exception.Reason)
+		case "legacy_version":
+		default:
+			var node ShardStoreNode
+			err = json.Unmarshal(value, &node)
+			store["node_id"] = key
+			store["node_name"] = node.Name
+			store["transport_address"] = node.TransportAddress
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshalling shard store %s: %+v: %+v", key, err, value)
+		}
+	}
+
+	return store, nil
+}
+
+type ShardStoresResponse struct {
+	Indices map[string]struct {
+		Shards map[string]struct {
+			Stores []map[string]json.RawMessage `json:"stores"`
+		} `json:"shards"`
+	} `json:"indices"`
+}
+
+type ShardStoreNode struct {
+	Name             string `json:"name"`
+	TransportAddress string `json:"transport_address"`
+}
+
+type ShardStoreException struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
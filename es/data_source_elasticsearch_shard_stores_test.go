@@ -0,0 +1,45 @@
+package es
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccElasticsearchDataSourceShardStores_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchDataSourceShardStores,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticsearch_shard_stores.test", "id", "terraform-test-shard-stores/green"),
+					resource.TestCheckResourceAttr("data.elasticsearch_shard_stores.test", "stores.#", "2"),
+					resource.TestCheckResourceAttr("data.elasticsearch_shard_stores.test", "stores.0.index", "terraform-test-shard-stores"),
+					resource.TestCheckResourceAttr("data.elasticsearch_shard_stores.test", "stores.0.shard", "0"),
+					resource.TestCheckResourceAttr("data.elasticsearch_shard_stores.test", "stores.0.allocation", "primary"),
+					resource.TestCheckResourceAttr("data.elasticsearch_shard_stores.test", "stores.0.store_exception", ""),
+					resource.TestCheckResourceAttrSet("data.elasticsearch_shard_stores.test", "stores.0.node_name"),
+					resource.TestCheckResourceAttr("data.elasticsearch_shard_stores.test", "stores.1.shard", "1"),
+				),
+			},
+		},
+	})
+}
+
+var testAccElasticsearchDataSourceShardStores = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test-shard-stores"
+  number_of_shards = 2
+  number_of_replicas = 0
+  wait_for_active_shards = "all"
+}
+
+data "elasticsearch_shard_stores" "test" {
+  index  = elasticsearch_index.test.name
+  status = ["green"]
+}
+`
@@ -0,0 +1,238 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+// snapshotStatusBatchSize limits the number of snapshots in a single status
+// request, to keep the URL length reasonable for large repositories
+const snapshotStatusBatchSize = 50
+
+func dataSourceElasticsearchSnapshotRepositoryStats() *schema.Resource {
+	return &schema.Resource{
+		Description: "`elasticsearch_snapshot_repository_stats` can be used to retrieve the number and size of the snapshots in a snapshot repository.",
+		Read:        dataSourceElasticsearchSnapshotRepositoryStatsRead,
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the snapshot repository.",
+			},
+			"snapshot_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of snapshots in the repository.",
+			},
+			"total_size_in_bytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The sum of the sizes of the snapshots in the repository, files shared between snapshots are counted for each snapshot.",
+			},
+			"oldest_snapshot": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the snapshot started first.",
+			},
+			"oldest_snapshot_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The start time of the oldest snapshot, in RFC 3339 format.",
+			},
+			"newest_snapshot": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the snapshot started last.",
+			},
+			"newest_snapshot_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The start time of the newest snapshot, in RFC 3339 format.",
+			},
+		},
+	}
+}
+
+func dataSourceElasticsearchSnapshotRepositoryStatsRead(d *schema.ResourceData, m interface{}) error {
+	repository := d.Get("repository").(string)
+
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+
+	var stats *SnapshotRepositoryStats
+	var names []string
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		stats, names, err = elastic7SnapshotRepositoryStats(client, repository)
+	case *elastic6.Client:
+		stats, names, err = elastic6SnapshotRepositoryStats(client, repository)
+	default:
+		err = errors.New("snapshot repository stats not implemented prior to Elastic v6")
+	}
+	if err != nil {
+		return err
+	}
+	if err := elasticsearchAddSnapshotStatuses(m, stats, repository, names); err != nil {
+		return err
+	}
+
+	d.SetId(repository)
+	ds := &resourceDataSetter{d: d}
+	ds.set("snapshot_count", stats.SnapshotCount)
+	ds.set("total_size_in_bytes", stats.TotalSizeInBytes)
+	ds.set("oldest_snapshot", stats.OldestSnapshot)
+	ds.set("oldest_snapshot_time", formatSnapshotTime(stats.OldestStartTimeInMillis))
+	ds.set("newest_snapshot", stats.NewestSnapshot)
+	ds.set("newest_snapshot_time", formatSnapshotTime(stats.NewestStartTimeInMillis))
+	return ds.err
+}
+
+// elastic7SnapshotRepositoryStats returns the stats of the snapshots of the
+// repository, and the names of the snapshots the status API reports the size of
+func elastic7SnapshotRepositoryStats(client *elastic7.Client, repository string) (*SnapshotRepositoryStats, []string, error) {
+	res, err := client.SnapshotGet(repository).Snapshot("_all").Do(context.TODO())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stats := new(SnapshotRepositoryStats)
+	var names []string
+	for _, snapshot := range res.Snapshots {
+		stats.add(snapshot.Snapshot, snapshot.StartTimeInMillis)
+		if snapshotHasStatus(snapshot.State) {
+			names = append(names, snapshot.Snapshot)
+		}
+	}
+	return stats, names, nil
+}
+
+// elastic6SnapshotRepositoryStats returns the stats of the snapshots of the
+// repository, and the names of the snapshots the status API reports the size of
+func elastic6SnapshotRepositoryStats(client *elastic6.Client, repository string) (*SnapshotRepositoryStats, []string, error) {
+	res, err := client.SnapshotGet(repository).Snapshot("_all").Do(context.TODO())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stats := new(SnapshotRepositoryStats)
+	var names []string
+	for _, snapshot := range res.Snapshots {
+		stats.add(snapshot.Snapshot, snapshot.StartTimeInMillis)
+		if snapshotHasStatus(snapshot.State) {
+			names = append(names, snapshot.Snapshot)
+		}
+	}
+	return stats, names, nil
+}
+
+// elasticsearchAddSnapshotStatuses adds the sizes of the snapshots to the
+// stats, requesting the status of the snapshots by batches
+func elasticsearchAddSnapshotStatuses(meta interface{}, stats *SnapshotRepositoryStats, repository string, names []string) error {
+	for len(names) > 0 {
+		batch := names
+		if len(batch) > snapshotStatusBatchSize {
+			batch = names[:snapshotStatusBatchSize]
+		}
+		names = names[len(batch):]
+
+		path, err := snapshotStatusPath(repository, batch)
+		if err != nil {
+			return err
+		}
+		body, err := elasticsearchPerformRequest(meta, "GET", path, nil)
+		if err != nil {
+			return err
+		}
+		if err := stats.addStatus(body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snapshotHasStatus reports whether the status API can report the size of a
+// snapshot in the given state, failed snapshots have no files to report
+func snapshotHasStatus(state string) bool {
+	return state != "FAILED" && state != "INCOMPATIBLE"
+}
+
+func snapshotStatusPath(repository string, snapshots []string) (string, error) {
+	path, err := uritemplates.Expand("/_snapshot/{repository}/{snapshot}/_status", map[string]string{
+		"repository": repository,
+		"snapshot":   strings.Join(snapshots, ","),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for snapshot status: %+v", err)
+	}
+	return path, nil
+}
+
+func formatSnapshotTime(millis int64) string {
+	if millis == 0 {
+		return ""
+	}
+	return time.Unix(0, millis*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+}
+
+type SnapshotRepositoryStats struct {
+	SnapshotCount           int
+	TotalSizeInBytes        int64
+	OldestSnapshot          string
+	OldestStartTimeInMillis int64
+	NewestSnapshot          string
+	NewestStartTimeInMillis int64
+}
+
+func (s *SnapshotRepositoryStats) add(name string, startTimeInMillis int64) {
+	s.SnapshotCount++
+	if s.OldestSnapshot == "" || startTimeInMillis < s.OldestStartTimeInMillis {
+		s.OldestSnapshot = name
+		s.OldestStartTimeInMillis = startTimeInMillis
+	}
+	if s.NewestSnapshot == "" || startTimeInMillis > s.NewestStartTimeInMillis {
+		s.NewestSnapshot = name
+		s.NewestStartTimeInMillis = startTimeInMillis
+	}
+}
+
+func (s *SnapshotRepositoryStats) addStatus(body json.RawMessage) error {
+	status := new(SnapshotRepositoryStatusResponse)
+	if err := json.Unmarshal(body, status); err != nil {
+		return fmt.Errorf("error unmarshalling snapshot status body: %+v: %+v", err, body)
+	}
+
+	for _, snapshot := range status.Snapshots {
+		// 7.4 moved the size into stats.total
+		if snapshot.Stats.Total.SizeInBytes > 0 {
+			s.TotalSizeInBytes += snapshot.Stats.Total.SizeInBytes
+		} else {
+			s.TotalSizeInBytes += snapshot.Stats.TotalSizeInBytes
+		}
+	}
+	return nil
+}
+
+type SnapshotRepositoryStatusResponse struct {
+	Snapshots []struct {
+		Snapshot string `json:"snapshot"`
+		Stats    struct {
+			TotalSizeInBytes int64 `json:"total_size_in_bytes"`
+			Total            struct {
+				SizeInBytes int64 `json:"size_in_bytes"`
+			} `json:"total"`
+		} `json:"stats"`
+	} `json:"snapshots"`
+}
@@ -0,0 +1,66 @@
+package es
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+)
+
+func TestAccElasticsearchDataSourceSnapshotRepositoryStats_basic(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch esClient.(type) {
+	case *elastic5.Client:
+		allowed = false
+	default:
+		allowed = true
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Snapshot repository stats only supported on ES >= 6")
+			}
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchDataSourceSnapshotRepositoryStats,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticsearch_snapshot_repository_stats.test", "id", "terraform-test"),
+					resource.TestCheckResourceAttr("data.elasticsearch_snapshot_repository_stats.test", "snapshot_count", "0"),
+					resource.TestCheckResourceAttr("data.elasticsearch_snapshot_repository_stats.test", "total_size_in_bytes", "0"),
+					resource.TestCheckResourceAttr("data.elasticsearch_snapshot_repository_stats.test", "newest_snapshot", ""),
+				),
+			},
+		},
+	})
+}
+
+var testAccElasticsearchDataSourceSnapshotRepositoryStats = `
+resource "elasticsearch_snapshot_repository" "test" {
+  name = "terraform-test"
+  type = "fs"
+
+  settings = {
+    location = "/tmp/elasticsearch"
+  }
+}
+
+data "elasticsearch_snapshot_repository_stats" "test" {
+  repository = elasticsearch_snapshot_repository.test.name
+}
+`
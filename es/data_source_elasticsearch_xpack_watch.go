@@ -0,0 +1,143 @@
+package es
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+func dataSourceElasticsearchXpackWatch() *schema.Resource {
+	return &schema.Resource{
+		Description: "`elasticsearch_xpack_watch` can be used to retrieve an existing watch, along with its state and the status of its last execution, e.g. to reference watches managed outside of Terraform.",
+		Read:        dataSourceElasticsearchXpackWatchRead,
+
+		Schema: map[string]*schema.Schema{
+			"watch_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Identifier of the watch.",
+			},
+			"body": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The JSON body of the watch.",
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the watch is active.",
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The version of the watch, incremented every time the watch is put.",
+			},
+			"execution_state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the last execution of the watch, e.g. `executed` or `execution_not_needed`, empty if it never ran.",
+			},
+			"last_checked": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the condition of the watch was last evaluated, in RFC 3339 format.",
+			},
+			"last_met_condition": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the condition of the watch was last met, in RFC 3339 format.",
+			},
+			"acked_actions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The names of the actions of the watch which are acknowledged.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceElasticsearchXpackWatchRead(d *schema.ResourceData, m interface{}) error {
+	watchID := d.Get("watch_id").(string)
+
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	var template string
+	switch esClient.(type) {
+	case *elastic7.Client:
+		template = "/_watcher/watch/{id}"
+	case *elastic6.Client:
+		template = "/_xpack/watcher/watch/{id}"
+	default:
+		return errors.New("watch data source not implemented prior to Elastic v6")
+	}
+
+	path, err := uritemplates.Expand(template, map[string]string{
+		"id": watchID,
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for watch: %+v", err)
+	}
+	res, err := elasticsearchPerformRequest(m, "GET", path, nil)
+	if err != nil {
+		return err
+	}
+
+	response := new(WatchGetResponse)
+	if err := json.Unmarshal(res, response); err != nil {
+		return fmt.Errorf("error unmarshalling watch body: %+v: %+v", err, res)
+	}
+
+	acked := make([]string, 0)
+	for name, action := range response.Status.Actions {
+		if action.Ack.State == "acked" {
+			acked = append(acked, name)
+		}
+	}
+	sort.Strings(acked)
+
+	d.SetId(watchID)
+	ds := &resourceDataSetter{d: d}
+	ds.set("body", string(response.Watch))
+	ds.set("active", response.Status.State.Active)
+	ds.set("version", response.Status.Version)
+	ds.set("execution_state", response.Status.ExecutionState)
+	ds.set("last_checked", response.Status.LastChecked)
+	ds.set("last_met_condition", response.Status.LastMetCondition)
+	ds.set("acked_actions", acked)
+	return ds.err
+}
+
+type WatchGetResponse struct {
+	ID          string          `json:"_id"`
+	SeqNo       int64           `json:"_seq_no"`
+	PrimaryTerm int64           `json:"_primary_term"`
+	Watch       json.RawMessage `json:"watch"`
+	Status      WatchStatus     `json:"status"`
+}
+
+type WatchStatus struct {
+	State struct {
+		Active bool `json:"active"`
+	} `json:"state"`
+	Version          int    `json:"version"`
+	ExecutionState   string `json:"execution_state"`
+	LastChecked      string `json:"last_checked"`
+	LastMetCondition string `json:"last_met_condition"`
+	Actions          map[string]struct {
+		Ack struct {
+			State string `json:"state"`
+		} `json:"ack"`
+	} `json:"actions"`
+}
@@ -0,0 +1,76 @@
+package es
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+)
+
+func TestAccElasticsearchDataSourceXpackWatch_basic(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch esClient.(type) {
+	case *elastic5.Client:
+		allowed = false
+	default:
+		allowed = true
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Watches only supported on ES >= 6")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchWatchDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchDataSourceXpackWatch,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticsearch_xpack_watch.test", "id", "my_watch"),
+					resource.TestCheckResourceAttr("data.elasticsearch_xpack_watch.test", "active", "false"),
+					resource.TestCheckResourceAttrSet("data.elasticsearch_xpack_watch.test", "body"),
+					resource.TestCheckResourceAttrSet("data.elasticsearch_xpack_watch.test", "version"),
+				),
+			},
+		},
+	})
+}
+
+var testAccElasticsearchDataSourceXpackWatch = `
+resource "elasticsearch_xpack_watch" "test_watch" {
+  watch_id = "my_watch"
+  active   = false
+
+  trigger = jsonencode({
+    schedule = {
+      interval = "10m"
+    }
+  })
+  actions = {
+    test_log = jsonencode({
+      logging = {
+        text = "executed"
+      }
+    })
+  }
+}
+
+data "elasticsearch_xpack_watch" "test" {
+  watch_id = elasticsearch_xpack_watch.test_watch.id
+}
+`
@@ -3,6 +3,8 @@ package es
 import (
 	"encoding/json"
 	"reflect"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
@@ -91,6 +93,126 @@ func diffSuppressDestination(k, old, new string, d *schema.ResourceData) bool {
 	return reflect.DeepEqual(oo, no)
 }
 
+func diffSuppressOpenSearchTransform(k, old, new string, d *schema.ResourceData) bool {
+	var oo, no interface{}
+	if err := json.Unmarshal([]byte(old), &oo); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &no); err != nil {
+		return false
+	}
+
+	if om, ok := oo.(map[string]interface{}); ok {
+		normalizeOpenSearchTransform(om)
+	}
+
+	if nm, ok := no.(map[string]interface{}); ok {
+		normalizeOpenSearchTransform(nm)
+	}
+
+	return reflect.DeepEqual(oo, no)
+}
+
+func diffSuppressSmPolicy(k, old, new string, d *schema.ResourceData) bool {
+	var oo, no interface{}
+	if err := json.Unmarshal([]byte(old), &oo); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &no); err != nil {
+		return false
+	}
+
+	if om, ok := oo.(map[string]interface{}); ok {
+		normalizeSmPolicy(om)
+	}
+
+	if nm, ok := no.(map[string]interface{}); ok {
+		normalizeSmPolicy(nm)
+	}
+
+	return reflect.DeepEqual(oo, no)
+}
+
+func diffSuppressRollupJob(k, old, new string, d *schema.ResourceData) bool {
+	var oo, no interface{}
+	if err := json.Unmarshal([]byte(old), &oo); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &no); err != nil {
+		return false
+	}
+
+	if om, ok := oo.(map[string]interface{}); ok {
+		normalizeRollupJob(om)
+	}
+
+	if nm, ok := no.(map[string]interface{}); ok {
+		normalizeRollupJob(nm)
+	}
+
+	return reflect.DeepEqual(oo, no)
+}
+
+func diffSuppressAnomalyDetector(k, old, new string, d *schema.ResourceData) bool {
+	var oo, no interface{}
+	if err := json.Unmarshal([]byte(old), &oo); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &no); err != nil {
+		return false
+	}
+
+	if om, ok := oo.(map[string]interface{}); ok {
+		normalizeAnomalyDetector(om)
+	}
+
+	if nm, ok := no.(map[string]interface{}); ok {
+		normalizeAnomalyDetector(nm)
+	}
+
+	return reflect.DeepEqual(oo, no)
+}
+
+func diffSuppressChannelConfiguration(k, old, new string, d *schema.ResourceData) bool {
+	var oo, no interface{}
+	if err := json.Unmarshal([]byte(old), &oo); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &no); err != nil {
+		return false
+	}
+
+	if om, ok := oo.(map[string]interface{}); ok {
+		normalizeChannelConfiguration(om)
+	}
+
+	if nm, ok := no.(map[string]interface{}); ok {
+		normalizeChannelConfiguration(nm)
+	}
+
+	return reflect.DeepEqual(oo, no)
+}
+
+func diffSuppressAlertingWorkflow(k, old, new string, d *schema.ResourceData) bool {
+	var oo, no interface{}
+	if err := json.Unmarshal([]byte(old), &oo); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &no); err != nil {
+		return false
+	}
+
+	if om, ok := oo.(map[string]interface{}); ok {
+		normalizeAlertingWorkflow(om)
+	}
+
+	if nm, ok := no.(map[string]interface{}); ok {
+		normalizeAlertingWorkflow(nm)
+	}
+
+	return reflect.DeepEqual(oo, no)
+}
+
 func diffSuppressMonitor(k, old, new string, d *schema.ResourceData) bool {
 	var oo, no interface{}
 	if err := json.Unmarshal([]byte(old), &oo); err != nil {
@@ -111,6 +233,33 @@ func diffSuppressMonitor(k, old, new string, d *schema.ResourceData) bool {
 	return reflect.DeepEqual(oo, no)
 }
 
+func diffSuppressWatch(k, old, new string, d *schema.ResourceData) bool {
+	var oo, no interface{}
+	if err := json.Unmarshal([]byte(old), &oo); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &no); err != nil {
+		return false
+	}
+
+	ignoreServerDefaults := d.Get("ignore_server_defaults").(bool)
+	if om, ok := oo.(map[string]interface{}); ok {
+		normalizeWatch(om)
+		if ignoreServerDefaults {
+			normalizeWatchServerDefaults(om)
+		}
+	}
+
+	if nm, ok := no.(map[string]interface{}); ok {
+		normalizeWatch(nm)
+		if ignoreServerDefaults {
+			normalizeWatchServerDefaults(nm)
+		}
+	}
+
+	return reflect.DeepEqual(oo, no)
+}
+
 func suppressEquivalentJson(k, old, new string, d *schema.ResourceData) bool {
 	var oldObj, newObj interface{}
 	if err := json.Unmarshal([]byte(old), &oldObj); err != nil {
@@ -171,6 +320,74 @@ func diffSuppressIngestPipeline(k, old, new string, d *schema.ResourceData) bool
 		return false
 	}
 
+	if om, ok := oo.(map[string]interface{}); ok {
+		normalizeIngestPipeline(om)
+	}
+
+	if nm, ok := no.(map[string]interface{}); ok {
+		normalizeIngestPipeline(nm)
+	}
+
+	return reflect.DeepEqual(oo, no)
+}
+
+func diffSuppressTransformSource(k, old, new string, d *schema.ResourceData) bool {
+	var oo, no interface{}
+	if err := json.Unmarshal([]byte(old), &oo); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &no); err != nil {
+		return false
+	}
+
+	if om, ok := oo.(map[string]interface{}); ok {
+		normalizeTransformSource(om)
+	}
+
+	if nm, ok := no.(map[string]interface{}); ok {
+		normalizeTransformSource(nm)
+	}
+
+	return reflect.DeepEqual(oo, no)
+}
+
+func diffSuppressTransformSync(k, old, new string, d *schema.ResourceData) bool {
+	var oo, no interface{}
+	if err := json.Unmarshal([]byte(old), &oo); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &no); err != nil {
+		return false
+	}
+
+	if om, ok := oo.(map[string]interface{}); ok {
+		normalizeTransformSync(om)
+	}
+
+	if nm, ok := no.(map[string]interface{}); ok {
+		normalizeTransformSync(nm)
+	}
+
+	return reflect.DeepEqual(oo, no)
+}
+
+func diffSuppressRollupJobGroups(k, old, new string, d *schema.ResourceData) bool {
+	var oo, no interface{}
+	if err := json.Unmarshal([]byte(old), &oo); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &no); err != nil {
+		return false
+	}
+
+	if om, ok := oo.(map[string]interface{}); ok {
+		normalizeRollupJobGroups(om)
+	}
+
+	if nm, ok := no.(map[string]interface{}); ok {
+		normalizeRollupJobGroups(nm)
+	}
+
 	return reflect.DeepEqual(oo, no)
 }
 
@@ -204,3 +421,46 @@ func diffSuppressLicense(k, old, new string, d *schema.ResourceData) bool {
 	}
 	return reflect.DeepEqual(oldObj, newObj)
 }
+
+// diffSuppressScriptSource ignores differences in whitespace only, and in the
+// formatting of JSON search templates
+func diffSuppressScriptSource(k, old, new string, d *schema.ResourceData) bool {
+	if suppressEquivalentJson(k, old, new, d) {
+		return true
+	}
+	return strings.Join(strings.Fields(old), " ") == strings.Join(strings.Fields(new), " ")
+}
+
+// suppressEquivalentRFC3339Time ignores differences in the time zone of times
+// which are read back in UTC
+func suppressEquivalentRFC3339Time(k, old, new string, d *schema.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return oldTime.Equal(newTime)
+}
+
+func diffSuppressRoleMappingRoleTemplates(k, old, new string, d *schema.ResourceData) bool {
+	var oo, no interface{}
+	if err := json.Unmarshal([]byte(old), &oo); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &no); err != nil {
+		return false
+	}
+
+	if ol, ok := oo.([]interface{}); ok {
+		normalizeRoleMappingRoleTemplates(ol)
+	}
+
+	if nl, ok := no.([]interface{}); ok {
+		normalizeRoleMappingRoleTemplates(nl)
+	}
+
+	return reflect.DeepEqual(oo, no)
+}
@@ -1,15 +1,22 @@
 package es
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"net/url"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -21,13 +28,68 @@ import (
 	"github.com/deoxxa/aws_signing_client"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/pathorcontents"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	elastic7 "github.com/olivere/elastic/v7"
 	elastic5 "gopkg.in/olivere/elastic.v5"
 	elastic6 "gopkg.in/olivere/elastic.v6"
 )
 
-var awsUrlRegexp = regexp.MustCompile(`([a-z0-9-]+).es.amazonaws.com$`)
+var awsUrlRegexp = regexp.MustCompile(`([a-z0-9-]+).(es|aoss).amazonaws.com$`)
+
+var awsServerlessUrlRegexp = regexp.MustCompile(`.aoss.amazonaws.com$`)
+
+const (
+	flavorElasticsearch = "elasticsearch"
+	flavorOpenSearch    = "opensearch"
+)
+
+// flavorNames are the display names of the flavors in the error messages
+var flavorNames = map[string]string{
+	flavorElasticsearch: "Elasticsearch",
+	flavorOpenSearch:    "OpenSearch",
+}
+
+// flavorResources maps the prefixes of the resources calling the APIs of a
+// single flavor to that flavor, the other resources are available on both
+var flavorResources = map[string]string{
+	"elasticsearch_xpack_":                      flavorElasticsearch,
+	"elasticsearch_watch":                       flavorElasticsearch,
+	"elasticsearch_kibana_action_connector":     flavorElasticsearch,
+	"elasticsearch_kibana_alert":                flavorElasticsearch,
+	"elasticsearch_kibana_data_view":            flavorElasticsearch,
+	"elasticsearch_kibana_fleet_agent_policy":   flavorElasticsearch,
+	"elasticsearch_kibana_saved_object":         flavorElasticsearch,
+	"elasticsearch_kibana_saved_objects_import": flavorElasticsearch,
+	"elasticsearch_kibana_space":                flavorElasticsearch,
+	"elasticsearch_index_lifecycle_":            flavorElasticsearch,
+	"elasticsearch_data_stream_lifecycle":       flavorElasticsearch,
+	"elasticsearch_enrich_policy":               flavorElasticsearch,
+	"elasticsearch_logstash_pipeline":           flavorElasticsearch,
+	"elasticsearch_query_ruleset":               flavorElasticsearch,
+	"elasticsearch_searchable_snapshot_mount":   flavorElasticsearch,
+	"elasticsearch_synonyms_set":                flavorElasticsearch,
+	"elasticsearch_opensearch_":                 flavorOpenSearch,
+}
+
+// serverlessUnsupportedResources are the prefixes of the resources calling
+// APIs which Amazon OpenSearch Serverless collections do not expose, e.g. the
+// _cluster, _snapshot and plugins endpoints
+var serverlessUnsupportedResources = []string{
+	"elasticsearch_cluster_settings",
+	"elasticsearch_destination",
+	"elasticsearch_index_recovery",
+	"elasticsearch_monitor",
+	"elasticsearch_opendistro_",
+	"elasticsearch_opensearch_",
+	"elasticsearch_remote_cluster",
+	"elasticsearch_shard_stores",
+	"elasticsearch_snapshot",
+	"elasticsearch_stored_script",
+	"elasticsearch_voting_config_exclusions",
+}
+
+var errOffline = errors.New("the elasticsearch provider is configured with `offline = true`, no requests can be made to the cluster, unset `offline` to apply changes or read data sources")
 
 type ProviderConf struct {
 	rawUrl             string
@@ -52,10 +114,15 @@ type ProviderConf struct {
 	keyPemPath         string
 	kibanaUrl          string
 	hostOverride       string
+	defaultHeaders     map[string]string
+	opaqueId           string
+	offline            bool
+	flavor             string
+	serverless         bool
 }
 
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"url": {
 				Type:        schema.TypeString,
@@ -135,6 +202,12 @@ func Provider() terraform.ResourceProvider {
 				Default:     "",
 				Description: "The AWS profile for use with AWS Elasticsearch Service domains",
 			},
+			"aws_opensearch_serverless": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Connect to an Amazon OpenSearch Serverless collection, signing the requests for the `aoss` service, without the version detection and with the resources calling the APIs of the collections only. Detected from the `*.aoss.amazonaws.com` URLs of the collections, set it with `host_override` when connecting through a tunnel.",
+			},
 			"aws_region": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -185,46 +258,246 @@ func Provider() terraform.ResourceProvider {
 				Default:     "",
 				Description: "If provided, sets the 'Host' header of requests and the 'ServerName' for certificate validation to this value. See the documentation on connecting to Elasticsearch via an SSH tunnel.",
 			},
+			"default_headers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Headers to send with every request to Elasticsearch and Kibana.",
+			},
+			"opaque_id_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ELASTICSEARCH_OPAQUE_ID_PREFIX", ""),
+				Description: "If provided, every request carries an `X-Opaque-Id` header of this prefix followed by an id generated for each Terraform run, so that the slow logs and tasks of the cluster can be correlated with the run.",
+			},
+			"offline": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ELASTICSEARCH_OFFLINE", false),
+				Description: "Make no requests to the cluster, e.g. to validate and plan in CI without access to the cluster. Resources are not refreshed from the cluster, applying changes or reading data sources fails.",
+			},
+			"flavor": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ELASTICSEARCH_FLAVOR", nil),
+				ValidateFunc: validation.StringInSlice([]string{flavorElasticsearch, flavorOpenSearch}, false),
+				Description:  "The flavor of the cluster, `elasticsearch` or `opensearch`, detected from the cluster along with its version if not set. The resources of the other flavor fail before making requests, and the Open Distro resources use the `_plugins` endpoints on OpenSearch.",
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"elasticsearch_destination":                     resourceElasticsearchDeprecatedDestination(),
-			"elasticsearch_index":                           resourceElasticsearchIndex(),
-			"elasticsearch_index_lifecycle_policy":          resourceElasticsearchDeprecatedIndexLifecyclePolicy(),
-			"elasticsearch_index_template":                  resourceElasticsearchIndexTemplate(),
-			"elasticsearch_composable_index_template":       resourceElasticsearchComposableIndexTemplate(),
-			"elasticsearch_component_template":              resourceElasticsearchComponentTemplate(),
-			"elasticsearch_ingest_pipeline":                 resourceElasticsearchIngestPipeline(),
-			"elasticsearch_kibana_alert":                    resourceElasticsearchKibanaAlert(),
-			"elasticsearch_kibana_object":                   resourceElasticsearchKibanaObject(),
-			"elasticsearch_monitor":                         resourceElasticsearchDeprecatedMonitor(),
-			"elasticsearch_snapshot_repository":             resourceElasticsearchSnapshotRepository(),
-			"elasticsearch_watch":                           resourceElasticsearchDeprecatedWatch(),
-			"elasticsearch_opendistro_destination":          resourceElasticsearchOpenDistroDestination(),
-			"elasticsearch_opendistro_ism_policy":           resourceElasticsearchOpenDistroISMPolicy(),
-			"elasticsearch_opendistro_ism_policy_mapping":   resourceElasticsearchOpenDistroISMPolicyMapping(),
-			"elasticsearch_opendistro_monitor":              resourceElasticsearchOpenDistroMonitor(),
-			"elasticsearch_opendistro_roles_mapping":        resourceElasticsearchOpenDistroRolesMapping(),
-			"elasticsearch_opendistro_role":                 resourceElasticsearchOpenDistroRole(),
-			"elasticsearch_opendistro_user":                 resourceElasticsearchOpenDistroUser(),
-			"elasticsearch_opendistro_kibana_tenant":        resourceElasticsearchOpenDistroKibanaTenant(),
-			"elasticsearch_xpack_index_lifecycle_policy":    resourceElasticsearchXpackIndexLifecyclePolicy(),
-			"elasticsearch_xpack_license":                   resourceElasticsearchXpackLicense(),
-			"elasticsearch_xpack_role":                      resourceElasticsearchXpackRole(),
-			"elasticsearch_xpack_role_mapping":              resourceElasticsearchXpackRoleMapping(),
-			"elasticsearch_xpack_snapshot_lifecycle_policy": resourceElasticsearchXpackSnapshotLifecyclePolicy(),
-			"elasticsearch_xpack_user":                      resourceElasticsearchXpackUser(),
-			"elasticsearch_xpack_watch":                     resourceElasticsearchXpackWatch(),
+			"elasticsearch_destination":                            resourceElasticsearchDeprecatedDestination(),
+			"elasticsearch_cluster_settings":                       resourceElasticsearchClusterSettings(),
+			"elasticsearch_enrich_policy":                          resourceElasticsearchEnrichPolicy(),
+			"elasticsearch_index":                                  resourceElasticsearchIndex(),
+			"elasticsearch_index_alias":                            resourceElasticsearchIndexAlias(),
+			"elasticsearch_index_block":                            resourceElasticsearchIndexBlock(),
+			"elasticsearch_index_lifecycle_attachment":             resourceElasticsearchIndexLifecycleAttachment(),
+			"elasticsearch_index_lifecycle_policy":                 resourceElasticsearchDeprecatedIndexLifecyclePolicy(),
+			"elasticsearch_index_rollover_bootstrap":               resourceElasticsearchIndexRolloverBootstrap(),
+			"elasticsearch_index_settings":                         resourceElasticsearchIndexSettings(),
+			"elasticsearch_index_template":                         resourceElasticsearchIndexTemplate(),
+			"elasticsearch_composable_index_template":              resourceElasticsearchComposableIndexTemplate(),
+			"elasticsearch_component_template":                     resourceElasticsearchComponentTemplate(),
+			"elasticsearch_data_stream":                            resourceElasticsearchDataStream(),
+			"elasticsearch_data_stream_lifecycle":                  resourceElasticsearchDataStreamLifecycle(),
+			"elasticsearch_ingest_pipeline":                        resourceElasticsearchIngestPipeline(),
+			"elasticsearch_kibana_action_connector":                resourceElasticsearchKibanaActionConnector(),
+			"elasticsearch_kibana_alert":                           resourceElasticsearchKibanaAlert(),
+			"elasticsearch_kibana_alert_rule":                      resourceElasticsearchKibanaAlertRule(),
+			"elasticsearch_kibana_data_view":                       resourceElasticsearchKibanaDataView(),
+			"elasticsearch_kibana_fleet_agent_policy":              resourceElasticsearchKibanaFleetAgentPolicy(),
+			"elasticsearch_kibana_object":                          resourceElasticsearchKibanaObject(),
+			"elasticsearch_kibana_saved_object":                    resourceElasticsearchKibanaSavedObject(),
+			"elasticsearch_kibana_saved_objects_import":            resourceElasticsearchKibanaSavedObjectsImport(),
+			"elasticsearch_kibana_space":                           resourceElasticsearchKibanaSpace(),
+			"elasticsearch_logstash_pipeline":                      resourceElasticsearchLogstashPipeline(),
+			"elasticsearch_monitor":                                resourceElasticsearchDeprecatedMonitor(),
+			"elasticsearch_query_ruleset":                          resourceElasticsearchQueryRuleset(),
+			"elasticsearch_reindex":                                resourceElasticsearchReindex(),
+			"elasticsearch_remote_cluster":                         resourceElasticsearchRemoteCluster(),
+			"elasticsearch_searchable_snapshot_mount":              resourceElasticsearchSearchableSnapshotMount(),
+			"elasticsearch_snapshot":                               resourceElasticsearchSnapshot(),
+			"elasticsearch_snapshot_repository":                    resourceElasticsearchSnapshotRepository(),
+			"elasticsearch_stored_script":                          resourceElasticsearchStoredScript(),
+			"elasticsearch_synonyms_set":                           resourceElasticsearchSynonymsSet(),
+			"elasticsearch_voting_config_exclusions":               resourceElasticsearchVotingConfigExclusions(),
+			"elasticsearch_watch":                                  resourceElasticsearchDeprecatedWatch(),
+			"elasticsearch_opendistro_action_group":                resourceElasticsearchOpenDistroActionGroup(),
+			"elasticsearch_opendistro_audit_config":                resourceElasticsearchOpenDistroAuditConfig(),
+			"elasticsearch_opendistro_destination":                 resourceElasticsearchOpenDistroDestination(),
+			"elasticsearch_opendistro_ism_policy":                  resourceElasticsearchOpenDistroISMPolicy(),
+			"elasticsearch_opendistro_ism_policy_mapping":          resourceElasticsearchOpenDistroISMPolicyMapping(),
+			"elasticsearch_opendistro_monitor":                     resourceElasticsearchOpenDistroMonitor(),
+			"elasticsearch_opendistro_roles_mapping":               resourceElasticsearchOpenDistroRolesMapping(),
+			"elasticsearch_opendistro_security_config":             resourceElasticsearchOpenDistroSecurityConfig(),
+			"elasticsearch_opendistro_role":                        resourceElasticsearchOpenDistroRole(),
+			"elasticsearch_opendistro_user":                        resourceElasticsearchOpenDistroUser(),
+			"elasticsearch_opendistro_user_tenants":                resourceElasticsearchOpenDistroUserTenants(),
+			"elasticsearch_opendistro_kibana_tenant":               resourceElasticsearchOpenDistroKibanaTenant(),
+			"elasticsearch_opensearch_alerting_workflow":           resourceElasticsearchOpenSearchAlertingWorkflow(),
+			"elasticsearch_opensearch_anomaly_detector":            resourceElasticsearchOpenSearchAnomalyDetector(),
+			"elasticsearch_opensearch_channel_configuration":       resourceElasticsearchOpenSearchChannelConfiguration(),
+			"elasticsearch_opensearch_dashboard_object":            resourceElasticsearchOpenSearchDashboardObject(),
+			"elasticsearch_opensearch_ml_model":                    resourceElasticsearchOpenSearchMlModel(),
+			"elasticsearch_opensearch_ml_model_group":              resourceElasticsearchOpenSearchMlModelGroup(),
+			"elasticsearch_opensearch_replication":                 resourceElasticsearchOpenSearchReplication(),
+			"elasticsearch_opensearch_replication_autofollow_rule": resourceElasticsearchOpenSearchReplicationAutofollowRule(),
+			"elasticsearch_opensearch_rollup_job":                  resourceElasticsearchOpenSearchRollupJob(),
+			"elasticsearch_opensearch_search_pipeline":             resourceElasticsearchOpenSearchSearchPipeline(),
+			"elasticsearch_opensearch_security_allowlist":          resourceElasticsearchOpenSearchSecurityAllowlist(),
+			"elasticsearch_opensearch_sm_policy":                   resourceElasticsearchOpenSearchSmPolicy(),
+			"elasticsearch_opensearch_transform":                   resourceElasticsearchOpenSearchTransform(),
+			"elasticsearch_xpack_api_key":                          resourceElasticsearchXpackApiKey(),
+			"elasticsearch_xpack_autoscaling_policy":               resourceElasticsearchXpackAutoscalingPolicy(),
+			"elasticsearch_xpack_ccr_auto_follow_pattern":          resourceElasticsearchXpackCcrAutoFollowPattern(),
+			"elasticsearch_xpack_ccr_follow":                       resourceElasticsearchXpackCcrFollow(),
+			"elasticsearch_xpack_index_lifecycle_policy":           resourceElasticsearchXpackIndexLifecyclePolicy(),
+			"elasticsearch_xpack_ilm_policy":                       resourceElasticsearchXpackIlmPolicy(),
+			"elasticsearch_xpack_license":                          resourceElasticsearchXpackLicense(),
+			"elasticsearch_xpack_ml_calendar":                      resourceElasticsearchXpackMlCalendar(),
+			"elasticsearch_xpack_ml_calendar_event":                resourceElasticsearchXpackMlCalendarEvent(),
+			"elasticsearch_xpack_ml_datafeed":                      resourceElasticsearchXpackMlDatafeed(),
+			"elasticsearch_xpack_ml_filter":                        resourceElasticsearchXpackMlFilter(),
+			"elasticsearch_xpack_ml_job":                           resourceElasticsearchXpackMlJob(),
+			"elasticsearch_xpack_role":                             resourceElasticsearchXpackRole(),
+			"elasticsearch_xpack_role_mapping":                     resourceElasticsearchXpackRoleMapping(),
+			"elasticsearch_xpack_rollup_job":                       resourceElasticsearchXpackRollupJob(),
+			"elasticsearch_xpack_service_token":                    resourceElasticsearchXpackServiceToken(),
+			"elasticsearch_xpack_snapshot_lifecycle_policy":        resourceElasticsearchXpackSnapshotLifecyclePolicy(),
+			"elasticsearch_xpack_transform":                        resourceElasticsearchXpackTransform(),
+			"elasticsearch_xpack_user":                             resourceElasticsearchXpackUser(),
+			"elasticsearch_xpack_watch":                            resourceElasticsearchXpackWatch(),
+			"elasticsearch_xpack_watch_ack":                        resourceElasticsearchXpackWatchAck(),
+			"elasticsearch_xpack_watch_execution":                  resourceElasticsearchXpackWatchExecution(),
+			"elasticsearch_xpack_watcher_settings":                 resourceElasticsearchXpackWatcherSettings(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"elasticsearch_destination":            dataSourceElasticsearchDeprecatedDestination(),
-			"elasticsearch_host":                   dataSourceElasticsearchHost(),
-			"elasticsearch_opendistro_destination": dataSourceElasticsearchOpenDistroDestination(),
+			"elasticsearch_destination":               dataSourceElasticsearchDeprecatedDestination(),
+			"elasticsearch_host":                      dataSourceElasticsearchHost(),
+			"elasticsearch_index_recovery":            dataSourceElasticsearchIndexRecovery(),
+			"elasticsearch_opendistro_destination":    dataSourceElasticsearchOpenDistroDestination(),
+			"elasticsearch_shard_stores":              dataSourceElasticsearchShardStores(),
+			"elasticsearch_snapshot_repository_stats": dataSourceElasticsearchSnapshotRepositoryStats(),
+			"elasticsearch_xpack_watch":               dataSourceElasticsearchXpackWatch(),
 		},
 
 		ConfigureFunc: providerConfigure,
 	}
+
+	for name, r := range provider.ResourcesMap {
+		checkFlavor(name, r)
+		checkServerless(name, r)
+		skipReadOffline(r)
+	}
+	for name, r := range provider.DataSourcesMap {
+		checkFlavor(name, r)
+		checkServerless(name, r)
+	}
+
+	return provider
+}
+
+// skipReadOffline keeps the state of the resource as is when the provider is
+// offline, so that plans only run the local validation and diffing
+func skipReadOffline(r *schema.Resource) {
+	read := r.Read
+	r.Read = func(d *schema.ResourceData, meta interface{}) error {
+		if meta.(*ProviderConf).offline {
+			log.Printf("[INFO] Offline, not refreshing %s", d.Id())
+			return nil
+		}
+		return read(d, meta)
+	}
+}
+
+// resourceFlavor returns the flavor of the cluster the resource is
+// available on, or an empty string if it is available on both
+func resourceFlavor(name string) string {
+	for prefix, flavor := range flavorResources {
+		if strings.HasPrefix(name, prefix) {
+			return flavor
+		}
+	}
+	return ""
+}
+
+// clusterFlavor returns the flavor of the cluster, detected along with its
+// version if neither is configured, or an empty string if it is unknown
+func clusterFlavor(conf *ProviderConf) string {
+	if conf.flavor == "" && conf.esVersion == "" && !conf.offline {
+		// the errors are returned by the requests of the caller
+		_, _ = getClient(conf)
+	}
+	return conf.flavor
+}
+
+// checkFlavor fails the operations of a resource which is not available on
+// the flavor of the cluster, before any request is made
+func checkFlavor(name string, r *schema.Resource) {
+	flavor := resourceFlavor(name)
+	if flavor == "" {
+		return
+	}
+
+	wrap := func(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+		return func(d *schema.ResourceData, meta interface{}) error {
+			if current := clusterFlavor(meta.(*ProviderConf)); current != "" && current != flavor {
+				return fmt.Errorf("%s is only available on %s clusters, the cluster is %s", name, flavorNames[flavor], flavorNames[current])
+			}
+			return f(d, meta)
+		}
+	}
+	if r.Create != nil {
+		r.Create = wrap(r.Create)
+	}
+	if r.Read != nil {
+		r.Read = wrap(r.Read)
+	}
+	if r.Update != nil {
+		r.Update = wrap(r.Update)
+	}
+	if r.Delete != nil {
+		r.Delete = wrap(r.Delete)
+	}
+}
+
+// checkServerless fails the plans with a resource which is not supported by
+// Amazon OpenSearch Serverless collections, data sources are read when
+// planning
+func checkServerless(name string, r *schema.Resource) {
+	supported := true
+	for _, prefix := range serverlessUnsupportedResources {
+		if strings.HasPrefix(name, prefix) {
+			supported = false
+		}
+	}
+	if supported {
+		return
+	}
+	err := fmt.Errorf("%s is not supported by Amazon OpenSearch Serverless collections", name)
+
+	if r.Create == nil {
+		read := r.Read
+		r.Read = func(d *schema.ResourceData, meta interface{}) error {
+			if meta.(*ProviderConf).serverless {
+				return err
+			}
+			return read(d, meta)
+		}
+		return
+	}
+	customizeDiff := r.CustomizeDiff
+	r.CustomizeDiff = func(d *schema.ResourceDiff, meta interface{}) error {
+		if meta.(*ProviderConf).serverless {
+			return err
+		}
+		if customizeDiff != nil {
+			return customizeDiff(d, meta)
+		}
+		return nil
+	}
 }
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
@@ -234,6 +507,30 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		return nil, err
 	}
 
+	defaultHeaders := make(map[string]string)
+	for k, v := range d.Get("default_headers").(map[string]interface{}) {
+		defaultHeaders[k] = v.(string)
+	}
+
+	var opaqueId string
+	if prefix := d.Get("opaque_id_prefix").(string); prefix != "" {
+		runId, err := generateRunId()
+		if err != nil {
+			return nil, err
+		}
+		opaqueId = fmt.Sprintf("%s-%s", prefix, runId)
+		log.Printf("[INFO] Sending X-Opaque-Id: %s", opaqueId)
+	}
+
+	flavor := d.Get("flavor").(string)
+	serverless := d.Get("aws_opensearch_serverless").(bool) || awsServerlessUrlRegexp.MatchString(parsedUrl.Hostname())
+	if serverless {
+		if flavor == flavorElasticsearch {
+			return nil, errors.New("Amazon OpenSearch Serverless collections are of the opensearch flavor")
+		}
+		flavor = flavorOpenSearch
+	}
+
 	return &ProviderConf{
 		rawUrl:          rawUrl,
 		kibanaUrl:       d.Get("kibana_url").(string),
@@ -258,15 +555,49 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		certPemPath:        d.Get("client_cert_path").(string),
 		keyPemPath:         d.Get("client_key_path").(string),
 		hostOverride:       d.Get("host_override").(string),
+		defaultHeaders:     defaultHeaders,
+		opaqueId:           opaqueId,
+		offline:            d.Get("offline").(bool),
+		flavor:             flavor,
+		serverless:         serverless,
 	}, nil
 }
 
+func generateRunId() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating the X-Opaque-Id: %+v", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// requestHeaders merges the headers configured on the provider with the
+// headers of a specific client, the X-Opaque-Id takes precedence
+func requestHeaders(conf *ProviderConf, headers map[string]string) map[string]string {
+	merged := make(map[string]string, len(conf.defaultHeaders)+len(headers)+1)
+	for k, v := range conf.defaultHeaders {
+		merged[k] = v
+	}
+	for k, v := range headers {
+		merged[k] = v
+	}
+	if conf.opaqueId != "" {
+		merged["X-Opaque-Id"] = conf.opaqueId
+	}
+	return merged
+}
+
 func getClient(conf *ProviderConf) (interface{}, error) {
+	if conf.offline {
+		return nil, errOffline
+	}
+
 	opts := []elastic7.ClientOptionFunc{
 		elastic7.SetURL(conf.rawUrl),
 		elastic7.SetScheme(conf.parsedUrl.Scheme),
 		elastic7.SetSniff(conf.sniffing),
-		elastic7.SetHealthcheck(conf.healthchecking),
+		// serverless collections do not expose the root endpoint of the health checks
+		elastic7.SetHealthcheck(conf.healthchecking && !conf.serverless),
 	}
 
 	if conf.parsedUrl.User.Username() != "" {
@@ -279,16 +610,16 @@ func getClient(conf *ProviderConf) (interface{}, error) {
 
 	if m := awsUrlRegexp.FindStringSubmatch(conf.parsedUrl.Hostname()); m != nil && conf.signAWSRequests {
 		log.Printf("[INFO] Using AWS: %+v", m[1])
-		opts = append(opts, elastic7.SetHttpClient(awsHttpClient(m[1], conf, map[string]string{})), elastic7.SetSniff(false))
+		opts = append(opts, elastic7.SetHttpClient(awsHttpClient(m[1], conf, requestHeaders(conf, nil))), elastic7.SetSniff(false))
 	} else if awsRegion := conf.awsRegion; conf.awsRegion != "" && conf.signAWSRequests {
 		log.Printf("[INFO] Using AWS: %+v", awsRegion)
-		opts = append(opts, elastic7.SetHttpClient(awsHttpClient(awsRegion, conf, map[string]string{})), elastic7.SetSniff(false))
+		opts = append(opts, elastic7.SetHttpClient(awsHttpClient(awsRegion, conf, requestHeaders(conf, nil))), elastic7.SetSniff(false))
 	} else if conf.insecure || conf.cacertFile != "" {
-		opts = append(opts, elastic7.SetHttpClient(tlsHttpClient(conf, map[string]string{})), elastic7.SetSniff(false))
+		opts = append(opts, elastic7.SetHttpClient(tlsHttpClient(conf, requestHeaders(conf, nil))), elastic7.SetSniff(false))
 	} else if conf.token != "" {
-		opts = append(opts, elastic7.SetHttpClient(tokenHttpClient(conf, map[string]string{})), elastic7.SetSniff(false))
+		opts = append(opts, elastic7.SetHttpClient(tokenHttpClient(conf, requestHeaders(conf, nil))), elastic7.SetSniff(false))
 	} else {
-		opts = append(opts, elastic7.SetHttpClient(defaultHttpClient(conf, map[string]string{})))
+		opts = append(opts, elastic7.SetHttpClient(defaultHttpClient(conf, requestHeaders(conf, nil))))
 	}
 
 	var relevantClient interface{}
@@ -298,14 +629,27 @@ func getClient(conf *ProviderConf) (interface{}, error) {
 	}
 	relevantClient = client
 
-	// Use the v7 client to ping the cluster to determine the version if one was not provided
-	if conf.esVersion == "" {
+	// Use the v7 client to ping the cluster to determine the version if one was
+	// not provided, serverless collections do not expose it
+	if conf.esVersion == "" && !conf.serverless {
 		log.Printf("[INFO] Pinging url to determine version %+v", conf.rawUrl)
-		info, _, err := client.Ping(conf.rawUrl).Do(context.TODO())
+		info, err := pingCluster(client)
 		if err != nil {
 			return nil, err
 		}
 		conf.esVersion = info.Version.Number
+		if conf.flavor == "" {
+			conf.flavor = flavorElasticsearch
+			if info.Version.Distribution == flavorOpenSearch {
+				conf.flavor = flavorOpenSearch
+			}
+		}
+	}
+
+	// OpenSearch implements the APIs of the v7 client from its 1.0 version
+	if conf.flavor == flavorOpenSearch {
+		log.Printf("[INFO] Using OpenSearch %s", conf.esVersion)
+		return relevantClient, nil
 	}
 
 	if conf.esVersion < "7.0.0" && conf.esVersion >= "6.0.0" {
@@ -327,16 +671,16 @@ func getClient(conf *ProviderConf) (interface{}, error) {
 
 		if m := awsUrlRegexp.FindStringSubmatch(conf.parsedUrl.Hostname()); m != nil && conf.signAWSRequests {
 			log.Printf("[INFO] Using AWS: %+v", m[1])
-			opts = append(opts, elastic6.SetHttpClient(awsHttpClient(m[1], conf, map[string]string{})), elastic6.SetSniff(false))
+			opts = append(opts, elastic6.SetHttpClient(awsHttpClient(m[1], conf, requestHeaders(conf, nil))), elastic6.SetSniff(false))
 		} else if awsRegion := conf.awsRegion; conf.awsRegion != "" && conf.signAWSRequests {
 			log.Printf("[INFO] Using AWS: %+v", conf.awsRegion)
-			opts = append(opts, elastic6.SetHttpClient(awsHttpClient(awsRegion, conf, map[string]string{})), elastic6.SetSniff(false))
+			opts = append(opts, elastic6.SetHttpClient(awsHttpClient(awsRegion, conf, requestHeaders(conf, nil))), elastic6.SetSniff(false))
 		} else if conf.insecure || conf.cacertFile != "" {
-			opts = append(opts, elastic6.SetHttpClient(tlsHttpClient(conf, map[string]string{})), elastic6.SetSniff(false))
+			opts = append(opts, elastic6.SetHttpClient(tlsHttpClient(conf, requestHeaders(conf, nil))), elastic6.SetSniff(false))
 		} else if conf.token != "" {
-			opts = append(opts, elastic6.SetHttpClient(tokenHttpClient(conf, map[string]string{})), elastic6.SetSniff(false))
+			opts = append(opts, elastic6.SetHttpClient(tokenHttpClient(conf, requestHeaders(conf, nil))), elastic6.SetSniff(false))
 		} else {
-			opts = append(opts, elastic6.SetHttpClient(defaultHttpClient(conf, map[string]string{})))
+			opts = append(opts, elastic6.SetHttpClient(defaultHttpClient(conf, requestHeaders(conf, nil))))
 		}
 
 		relevantClient, err = elastic6.NewClient(opts...)
@@ -361,16 +705,16 @@ func getClient(conf *ProviderConf) (interface{}, error) {
 		}
 
 		if m := awsUrlRegexp.FindStringSubmatch(conf.parsedUrl.Hostname()); m != nil && conf.signAWSRequests {
-			opts = append(opts, elastic5.SetHttpClient(awsHttpClient(m[1], conf, map[string]string{})), elastic5.SetSniff(false))
+			opts = append(opts, elastic5.SetHttpClient(awsHttpClient(m[1], conf, requestHeaders(conf, nil))), elastic5.SetSniff(false))
 		} else if awsRegion := conf.awsRegion; conf.awsRegion != "" && conf.signAWSRequests {
 			log.Printf("[INFO] Using AWS: %+v", conf.awsRegion)
-			opts = append(opts, elastic5.SetHttpClient(awsHttpClient(awsRegion, conf, map[string]string{})), elastic5.SetSniff(false))
+			opts = append(opts, elastic5.SetHttpClient(awsHttpClient(awsRegion, conf, requestHeaders(conf, nil))), elastic5.SetSniff(false))
 		} else if conf.insecure || conf.cacertFile != "" {
-			opts = append(opts, elastic5.SetHttpClient(tlsHttpClient(conf, map[string]string{})), elastic5.SetSniff(false))
+			opts = append(opts, elastic5.SetHttpClient(tlsHttpClient(conf, requestHeaders(conf, nil))), elastic5.SetSniff(false))
 		} else if conf.token != "" {
-			opts = append(opts, elastic5.SetHttpClient(tokenHttpClient(conf, map[string]string{})), elastic5.SetSniff(false))
+			opts = append(opts, elastic5.SetHttpClient(tokenHttpClient(conf, requestHeaders(conf, nil))), elastic5.SetSniff(false))
 		} else {
-			opts = append(opts, elastic5.SetHttpClient(defaultHttpClient(conf, map[string]string{})))
+			opts = append(opts, elastic5.SetHttpClient(defaultHttpClient(conf, requestHeaders(conf, nil))))
 		}
 
 		relevantClient, err = elastic5.NewClient(opts...)
@@ -384,6 +728,31 @@ func getClient(conf *ProviderConf) (interface{}, error) {
 	return relevantClient, nil
 }
 
+// pingCluster gets the info of the cluster, with the distribution of
+// OpenSearch clusters the ping of the client does not parse
+func pingCluster(client *elastic7.Client) (*clusterInfo, error) {
+	res, err := client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+		Method: "GET",
+		Path:   "/",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	info := new(clusterInfo)
+	if err := json.Unmarshal(res.Body, info); err != nil {
+		return nil, fmt.Errorf("error unmarshalling cluster info: %+v: %+v", err, string(res.Body))
+	}
+	return info, nil
+}
+
+type clusterInfo struct {
+	Version struct {
+		Number       string `json:"number"`
+		Distribution string `json:"distribution"`
+	} `json:"version"`
+}
+
 func getKibanaClient(conf *ProviderConf) (interface{}, error) {
 	// use either the provided version of elasticsearch or the version of
 	// elasticsearch determined by pinging the cluster. Base AWS or other auth
@@ -411,7 +780,7 @@ func getKibanaClient(conf *ProviderConf) (interface{}, error) {
 			opts = append(opts, elastic7.SetBasicAuth(conf.username, conf.password))
 		}
 
-		headers := map[string]string{"kbn-xsrf": "true"}
+		headers := requestHeaders(conf, map[string]string{"kbn-xsrf": "true"})
 
 		if m := awsUrlRegexp.FindStringSubmatch(conf.parsedUrl.Hostname()); m != nil && conf.signAWSRequests {
 			log.Printf("[INFO] Using AWS: %+v", m[1])
@@ -504,10 +873,17 @@ func awsSession(region string, conf *ProviderConf) *awssession.Session {
 func awsHttpClient(region string, conf *ProviderConf, headers map[string]string) *http.Client {
 	session := awsSession(region, conf)
 	signer := awssigv4.NewSigner(session.Config.Credentials)
-	client, err := aws_signing_client.New(signer, session.Config.HTTPClient, "es", region)
+	service := "es"
+	if conf.serverless {
+		service = "aoss"
+	}
+	client, err := aws_signing_client.New(signer, session.Config.HTTPClient, service, region)
 	if err != nil {
 		log.Fatal(err)
 	}
+	if conf.serverless {
+		client.Transport = &contentSha256Transport{transport: client.Transport}
+	}
 
 	rt := WithHeader(client.Transport)
 	rt.hostOverride = conf.hostOverride
@@ -519,6 +895,27 @@ func awsHttpClient(region string, conf *ProviderConf, headers map[string]string)
 	return client
 }
 
+// contentSha256Transport sets the hash of the body of the requests before
+// they are signed, serverless collections require it in the signed headers
+type contentSha256Transport struct {
+	transport http.RoundTripper
+}
+
+func (t *contentSha256Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	hash := sha256.Sum256(body)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(hash[:]))
+	return t.transport.RoundTrip(req)
+}
+
 func tokenHttpClient(conf *ProviderConf, headers map[string]string) *http.Client {
 	client := http.DefaultClient
 
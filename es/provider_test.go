@@ -1,10 +1,16 @@
 package es
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 )
@@ -216,6 +222,208 @@ func TestAWSCredsAssumeRole(t *testing.T) {
 	}
 }
 
+func TestProviderRequestHeaders(t *testing.T) {
+	raw := map[string]interface{}{
+		"url":              "http://localhost:9200",
+		"opaque_id_prefix": "terraform",
+		"default_headers": map[string]interface{}{
+			"X-Team":      "search",
+			"X-Opaque-Id": "ignored",
+		},
+	}
+	d := schema.TestResourceDataRaw(t, Provider().(*schema.Provider).Schema, raw)
+	meta, err := providerConfigure(d)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	conf := meta.(*ProviderConf)
+
+	headers := requestHeaders(conf, map[string]string{"kbn-xsrf": "true"})
+	if headers["X-Team"] != "search" || headers["kbn-xsrf"] != "true" {
+		t.Errorf("expected the default and client headers to be merged, got %v", headers)
+	}
+	if !strings.HasPrefix(headers["X-Opaque-Id"], "terraform-") || headers["X-Opaque-Id"] != conf.opaqueId {
+		t.Errorf("expected a generated X-Opaque-Id with the prefix, got %q", headers["X-Opaque-Id"])
+	}
+}
+
+func TestProviderFlavor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"version": {"distribution": "opensearch", "number": "2.11.0"}}`)
+	}))
+	defer server.Close()
+
+	provider := Provider().(*schema.Provider)
+	raw := map[string]interface{}{
+		"url":         server.URL,
+		"sniff":       false,
+		"healthcheck": false,
+	}
+	d := schema.TestResourceDataRaw(t, provider.Schema, raw)
+	meta, err := providerConfigure(d)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	conf := meta.(*ProviderConf)
+
+	if _, err := getClient(conf); err != nil {
+		t.Fatalf("expected OpenSearch 2.x to be supported, got: %s", err)
+	}
+	if conf.flavor != flavorOpenSearch || conf.esVersion != "2.11.0" {
+		t.Errorf("expected the flavor and version to be detected, got %q %q", conf.flavor, conf.esVersion)
+	}
+	if path := openDistroPath(conf, "/_opendistro/_security/api/roles/test"); path != "/_plugins/_security/api/roles/test" {
+		t.Errorf("expected the _plugins endpoint, got %q", path)
+	}
+
+	r := provider.ResourcesMap["elasticsearch_xpack_watch"]
+	err = r.Create(r.TestResourceData(), conf)
+	if err == nil || !strings.Contains(err.Error(), "only available on Elasticsearch clusters") {
+		t.Errorf("expected the X-Pack resource to fail on OpenSearch, got: %v", err)
+	}
+
+	conf.flavor = flavorElasticsearch
+	if path := openDistroPath(conf, "/_opendistro/_security/api/roles/test"); path != "/_opendistro/_security/api/roles/test" {
+		t.Errorf("expected the _opendistro endpoint, got %q", path)
+	}
+	r = provider.ResourcesMap["elasticsearch_opensearch_rollup_job"]
+	err = r.Read(r.TestResourceData(), conf)
+	if err == nil || !strings.Contains(err.Error(), "only available on OpenSearch clusters") {
+		t.Errorf("expected the OpenSearch resource to fail on Elasticsearch, got: %v", err)
+	}
+	if flavor := resourceFlavor("elasticsearch_index"); flavor != "" {
+		t.Errorf("expected the index resource to be available on both flavors, got %q", flavor)
+	}
+	if flavor := resourceFlavor("elasticsearch_kibana_space"); flavor != flavorElasticsearch {
+		t.Errorf("expected the Kibana space resource to be only available on Elasticsearch, got %q", flavor)
+	}
+	// the Kibana objects are documents of the index of OpenSearch Dashboards too
+	if flavor := resourceFlavor("elasticsearch_kibana_object"); flavor != "" {
+		t.Errorf("expected the Kibana object resource to be available on both flavors, got %q", flavor)
+	}
+}
+
+func TestProviderServerless(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	raw := map[string]interface{}{
+		"url": "https://abc123.us-east-1.aoss.amazonaws.com",
+	}
+	d := schema.TestResourceDataRaw(t, provider.Schema, raw)
+	meta, err := providerConfigure(d)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	conf := meta.(*ProviderConf)
+	if !conf.serverless || conf.flavor != flavorOpenSearch {
+		t.Errorf("expected a serverless OpenSearch collection, got %v %q", conf.serverless, conf.flavor)
+	}
+
+	err = provider.ResourcesMap["elasticsearch_cluster_settings"].CustomizeDiff(nil, conf)
+	if err == nil || !strings.Contains(err.Error(), "not supported by Amazon OpenSearch Serverless") {
+		t.Errorf("expected the cluster settings to fail the plan on serverless, got: %v", err)
+	}
+	r := provider.DataSourcesMap["elasticsearch_shard_stores"]
+	err = r.Read(r.TestResourceData(), conf)
+	if err == nil || !strings.Contains(err.Error(), "not supported by Amazon OpenSearch Serverless") {
+		t.Errorf("expected the shard stores to fail on serverless, got: %v", err)
+	}
+	if provider.ResourcesMap["elasticsearch_index"].CustomizeDiff != nil {
+		t.Errorf("expected the index resource to be supported on serverless")
+	}
+
+	raw["flavor"] = flavorElasticsearch
+	d = schema.TestResourceDataRaw(t, provider.Schema, raw)
+	if _, err := providerConfigure(d); err == nil {
+		t.Errorf("expected the elasticsearch flavor to be rejected on serverless")
+	}
+}
+
+func TestProviderServerlessContentSha256(t *testing.T) {
+	var hash string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hash = r.Header.Get("X-Amz-Content-Sha256")
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &contentSha256Transport{transport: http.DefaultTransport}}
+	res, err := client.Post(server.URL, "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	res.Body.Close()
+	if hash != "44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a" {
+		t.Errorf("expected the hash of the body, got %q", hash)
+	}
+}
+
+func TestProviderOffline(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		Providers: map[string]terraform.ResourceProvider{
+			"elasticsearch": Provider(),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:             testProviderOffline(`"test_log": { "logging": { "text": "executed" } }`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config:      testProviderOffline(`"test_log": { "throttle_period": "5m" }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("action test_log has no action type"),
+			},
+			{
+				Config:             testProviderOfflineSimulate(`"test_log": { "logging": { "text": "executed" } }`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config:      testProviderOffline(`"test_log": { "logging": { "text": "executed" } }`),
+				ExpectError: regexp.MustCompile("configured with `offline = true`"),
+			},
+		},
+	})
+}
+
+func testProviderOffline(actions string) string {
+	return fmt.Sprintf(`
+provider "elasticsearch" {
+  url     = "http://127.0.0.1:9299"
+  offline = true
+}
+
+resource "elasticsearch_xpack_watch" "test_watch" {
+  watch_id = "my_watch"
+  body = <<EOF
+{
+  "input": {
+    "simple": {}
+  },
+  "condition": {
+    "always": {}
+  },
+  "trigger": {
+    "schedule": {
+      "interval": "10m"
+    }
+  },
+  "actions": {
+    %s
+  }
+}
+EOF
+}
+`, actions)
+}
+
+// testProviderOfflineSimulate is the offline watch run through the execute API
+// when planning
+func testProviderOfflineSimulate(actions string) string {
+	return strings.Replace(testProviderOffline(actions), `watch_id = "my_watch"`, `watch_id           = "my_watch"
+  simulate_execution = true`, 1)
+}
+
 func getCreds(t *testing.T, region string, config map[string]interface{}) credentials.Value {
 	awsAccessKey := ""
 	awsSecretKey := ""
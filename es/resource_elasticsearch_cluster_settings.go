@@ -0,0 +1,200 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// the cluster has a single set of settings
+const clusterSettingsID = "cluster_settings"
+
+var clusterSettingsTypes = []string{"persistent", "transient"}
+
+func resourceElasticsearchClusterSettings() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceElasticsearchClusterSettingsCreate,
+		Read:   resourceElasticsearchClusterSettingsRead,
+		Update: resourceElasticsearchClusterSettingsUpdate,
+		Delete: resourceElasticsearchClusterSettingsDelete,
+		Schema: map[string]*schema.Schema{
+			"persistent": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The persistent cluster settings to manage, keyed by the flat name of the setting, e.g. `cluster.routing.allocation.enable`. Persistent settings survive a full cluster restart.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"transient": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The transient cluster settings to manage, keyed by the flat name of the setting. Transient settings are lost on a full cluster restart.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"previous_persistent": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The persistent values of the managed settings before they were first managed by this resource, restored when they are no longer managed.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"previous_transient": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The transient values of the managed settings before they were first managed by this resource, restored when they are no longer managed.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		Description: "Provides an Elasticsearch cluster settings resource. Only the settings in `persistent` and `transient` are managed, settings which are no longer managed, including on destroy, are restored to the value they had before, or reset to their default if they had none.",
+	}
+}
+
+func resourceElasticsearchClusterSettingsCreate(d *schema.ResourceData, meta interface{}) error {
+	if err := resourceElasticsearchPutClusterSettings(d, meta, false); err != nil {
+		return err
+	}
+
+	d.SetId(clusterSettingsID)
+	return resourceElasticsearchClusterSettingsRead(d, meta)
+}
+
+func resourceElasticsearchClusterSettingsRead(d *schema.ResourceData, meta interface{}) error {
+	current, err := elasticsearchGetClusterSettings(meta)
+	if err != nil {
+		return err
+	}
+
+	ds := &resourceDataSetter{d: d}
+	for _, settingsType := range clusterSettingsTypes {
+		// only read back the managed settings, a setting missing from the
+		// cluster is a diff to be applied again
+		settings := make(map[string]interface{})
+		for key := range d.Get(settingsType).(map[string]interface{}) {
+			if value, ok := current[settingsType][key]; ok {
+				settings[key] = value
+			}
+		}
+		ds.set(settingsType, settings)
+	}
+	return ds.err
+}
+
+func resourceElasticsearchClusterSettingsUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := resourceElasticsearchPutClusterSettings(d, meta, false); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchClusterSettingsRead(d, meta)
+}
+
+func resourceElasticsearchClusterSettingsDelete(d *schema.ResourceData, meta interface{}) error {
+	if err := resourceElasticsearchPutClusterSettings(d, meta, true); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceElasticsearchPutClusterSettings applies the change of the managed
+// settings, capturing the value of newly managed settings and restoring the
+// value of settings which are no longer managed, or all of them on destroy
+func resourceElasticsearchPutClusterSettings(d *schema.ResourceData, meta interface{}, destroy bool) error {
+	current, err := elasticsearchGetClusterSettings(meta)
+	if err != nil {
+		return err
+	}
+
+	body := make(map[string]interface{})
+	for _, settingsType := range clusterSettingsTypes {
+		o, n := d.GetChange(settingsType)
+		oldSettings := o.(map[string]interface{})
+		newSettings := n.(map[string]interface{})
+		if destroy {
+			newSettings = map[string]interface{}{}
+		}
+		previous := d.Get("previous_" + settingsType).(map[string]interface{})
+
+		settings := make(map[string]interface{})
+		for key, value := range newSettings {
+			if _, ok := oldSettings[key]; !ok {
+				if value, ok := current[settingsType][key]; ok {
+					previous[key] = value
+				}
+			}
+			settings[key] = value
+		}
+		for key := range oldSettings {
+			if _, ok := newSettings[key]; ok {
+				continue
+			}
+			// null resets the setting to its default
+			if value, ok := previous[key]; ok {
+				settings[key] = value
+			} else {
+				settings[key] = nil
+			}
+			delete(previous, key)
+		}
+
+		if len(settings) > 0 {
+			body[settingsType] = settings
+		}
+		if !destroy {
+			if err := d.Set("previous_"+settingsType, previous); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(body) == 0 {
+		return nil
+	}
+
+	_, err = elasticsearchPerformRequest(meta, "PUT", "/_cluster/settings", body)
+	return err
+}
+
+// elasticsearchGetClusterSettings returns the persistent and transient
+// settings of the cluster by their flat name, with their values as strings
+func elasticsearchGetClusterSettings(meta interface{}) (map[string]map[string]string, error) {
+	body, err := elasticsearchPerformRequest(meta, "GET", "/_cluster/settings?flat_settings=true", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make(map[string]map[string]interface{})
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling cluster settings body: %+v: %+v", err, body)
+	}
+
+	settings := make(map[string]map[string]string)
+	for _, settingsType := range clusterSettingsTypes {
+		settings[settingsType] = make(map[string]string)
+		for key, value := range response[settingsType] {
+			settings[settingsType][key] = clusterSettingValue(value)
+		}
+	}
+	return settings, nil
+}
+
+// clusterSettingValue formats the value of a flat setting, list settings are
+// joined with commas, which the cluster accepts when setting them
+func clusterSettingValue(value interface{}) string {
+	if list, ok := value.([]interface{}); ok {
+		values := make([]string, 0, len(list))
+		for _, v := range list {
+			values = append(values, fmt.Sprintf("%v", v))
+		}
+		return strings.Join(values, ",")
+	}
+	return fmt.Sprintf("%v", value)
+}
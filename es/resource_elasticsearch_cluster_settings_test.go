@@ -0,0 +1,127 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchClusterSettings(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckElasticsearchClusterSettingsDestroy(map[string]string{}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchClusterSettings,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchClusterSetting("persistent", "indices.recovery.max_bytes_per_sec", "50mb"),
+					testCheckElasticsearchClusterSetting("transient", "cluster.info.update.interval", "45s"),
+					resource.TestCheckResourceAttr("elasticsearch_cluster_settings.test", "persistent.indices.recovery.max_bytes_per_sec", "50mb"),
+					resource.TestCheckResourceAttr("elasticsearch_cluster_settings.test", "previous_persistent.%", "0"),
+				),
+			},
+			{
+				Config: testAccElasticsearchClusterSettingsUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchClusterSetting("persistent", "indices.recovery.max_bytes_per_sec", "60mb"),
+					// no longer managed, so reset to the default
+					testCheckElasticsearchClusterSetting("transient", "cluster.info.update.interval", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchClusterSettings_restorePrevious(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		// the value set outside of terraform is restored on destroy
+		CheckDestroy: testCheckElasticsearchClusterSettingsDestroy(map[string]string{
+			"indices.recovery.max_bytes_per_sec": "30mb",
+		}),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					_, err := elasticsearchPerformRequest(testAccProvider.Meta(), "PUT", "/_cluster/settings", map[string]interface{}{
+						"persistent": map[string]interface{}{
+							"indices.recovery.max_bytes_per_sec": "30mb",
+						},
+					})
+					if err != nil {
+						t.Fatalf("err: %s", err)
+					}
+				},
+				Config: testAccElasticsearchClusterSettings,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchClusterSetting("persistent", "indices.recovery.max_bytes_per_sec", "50mb"),
+					resource.TestCheckResourceAttr("elasticsearch_cluster_settings.test", "previous_persistent.indices.recovery.max_bytes_per_sec", "30mb"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchClusterSetting(settingsType string, key string, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		settings, err := elasticsearchGetClusterSettings(testAccProvider.Meta())
+		if err != nil {
+			return err
+		}
+
+		if value := settings[settingsType][key]; value != expected {
+			return fmt.Errorf("%s setting %s is %q, expected %q", settingsType, key, value, expected)
+		}
+		return nil
+	}
+}
+
+func testCheckElasticsearchClusterSettingsDestroy(persistent map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		meta := testAccProvider.Meta()
+
+		settings, err := elasticsearchGetClusterSettings(meta)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range []string{"indices.recovery.max_bytes_per_sec", "cluster.info.update.interval"} {
+			if value := settings["persistent"][key]; value != persistent[key] {
+				return fmt.Errorf("persistent setting %s is %q after destroy, expected %q", key, value, persistent[key])
+			}
+			if value, ok := settings["transient"][key]; ok {
+				return fmt.Errorf("transient setting %s is still set to %q", key, value)
+			}
+		}
+
+		// clean up the values set outside of terraform
+		_, err = elasticsearchPerformRequest(meta, "PUT", "/_cluster/settings", map[string]interface{}{
+			"persistent": map[string]interface{}{
+				"indices.recovery.max_bytes_per_sec": nil,
+			},
+		})
+		return err
+	}
+}
+
+var testAccElasticsearchClusterSettings = `
+resource "elasticsearch_cluster_settings" "test" {
+  persistent = {
+    "indices.recovery.max_bytes_per_sec" = "50mb"
+  }
+  transient = {
+    "cluster.info.update.interval" = "45s"
+  }
+}
+`
+
+var testAccElasticsearchClusterSettingsUpdate = `
+resource "elasticsearch_cluster_settings" "test" {
+  persistent = {
+    "indices.recovery.max_bytes_per_sec" = "60mb"
+  }
+}
+`
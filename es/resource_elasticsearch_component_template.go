@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
 	elastic7 "github.com/olivere/elastic/v7"
 )
 
@@ -64,7 +65,7 @@ func resourceElasticsearchComponentTemplateRead(d *schema.ResourceData, meta int
 
 	switch client := esClient.(type) {
 	case *elastic7.Client:
-		elasticVersion, err = elastic7GetVersion(client)
+		elasticVersion, err = elastic7GetCompatibleVersion(meta, client)
 		if err == nil {
 			if elasticVersion.LessThan(componentTemplateMinimalVersion) {
 				err = fmt.Errorf("component_template endpoint only available from ElasticSearch >= 7.8, got version %s", elasticVersion.String())
@@ -92,14 +93,33 @@ func resourceElasticsearchComponentTemplateRead(d *schema.ResourceData, meta int
 }
 
 func elastic7GetComponentTemplate(client *elastic7.Client, id string) (string, error) {
-	res, err := client.IndexGetComponentTemplate(id).Do(context.TODO())
+	path, err := uritemplates.Expand("/_component_template/{name}", map[string]string{
+		"name": id,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for component template: %+v", err)
+	}
+
+	// the typed client drops fields like _meta, so use the raw response
+	res, err := client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+		Method: "GET",
+		Path:   path,
+	})
 	if err != nil {
 		return "", err
 	}
 
+	response := new(ComponentTemplateGetResponse)
+	if err := json.Unmarshal(res.Body, response); err != nil {
+		return "", fmt.Errorf("error unmarshalling component template body: %+v: %+v", err, res.Body)
+	}
+
 	// No more than 1 element is expected, if the index template is not found, previous call should
 	// return a 404 error
-	t := res.ComponentTemplates[0].ComponentTemplate
+	if len(response.ComponentTemplates) == 0 {
+		return "", fmt.Errorf("component template %s not found", id)
+	}
+	t := response.ComponentTemplates[0].ComponentTemplate
 	tj, err := json.Marshal(t)
 	if err != nil {
 		return "", err
@@ -123,7 +143,7 @@ func resourceElasticsearchComponentTemplateDelete(d *schema.ResourceData, meta i
 
 	switch client := esClient.(type) {
 	case *elastic7.Client:
-		elasticVersion, err = elastic7GetVersion(client)
+		elasticVersion, err = elastic7GetCompatibleVersion(meta, client)
 		if err == nil {
 			if elasticVersion.LessThan(componentTemplateMinimalVersion) {
 				err = fmt.Errorf("component_template endpoint only available from ElasticSearch >= 7.8, got version %s", elasticVersion.String())
@@ -160,7 +180,7 @@ func resourceElasticsearchPutComponentTemplate(d *schema.ResourceData, meta inte
 
 	switch client := esClient.(type) {
 	case *elastic7.Client:
-		elasticVersion, err = elastic7GetVersion(client)
+		elasticVersion, err = elastic7GetCompatibleVersion(meta, client)
 		if err == nil {
 			if elasticVersion.LessThan(componentTemplateMinimalVersion) {
 				err = fmt.Errorf("component_template endpoint only available from ElasticSearch >= 7.8, got version %s", elasticVersion.String())
@@ -179,3 +199,10 @@ func elastic7PutComponentTemplate(client *elastic7.Client, name string, body str
 	_, err := client.IndexPutComponentTemplate(name).BodyString(body).Create(create).Do(context.TODO())
 	return err
 }
+
+type ComponentTemplateGetResponse struct {
+	ComponentTemplates []struct {
+		Name              string                 `json:"name"`
+		ComponentTemplate map[string]interface{} `json:"component_template"`
+	} `json:"component_templates"`
+}
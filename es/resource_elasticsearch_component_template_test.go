@@ -182,6 +182,9 @@ resource "elasticsearch_component_template" "test" {
     "aliases": {
       "mydata": { }
     }
+  },
+  "_meta": {
+    "description": "terraform test"
   }
 }
 EOF
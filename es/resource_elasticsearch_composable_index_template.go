@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
 	elastic7 "github.com/olivere/elastic/v7"
 )
 
@@ -61,7 +62,7 @@ func resourceElasticsearchComposableIndexTemplateRead(d *schema.ResourceData, me
 
 	switch client := esClient.(type) {
 	case *elastic7.Client:
-		elasticVersion, err = elastic7GetVersion(client)
+		elasticVersion, err = elastic7GetCompatibleVersion(meta, client)
 		if err == nil {
 			if elasticVersion.LessThan(minimalESComposableTemplateVersion) {
 				err = fmt.Errorf("index_template endpoint only available from ElasticSearch >= 7.8, got version %s", elasticVersion.String())
@@ -89,14 +90,33 @@ func resourceElasticsearchComposableIndexTemplateRead(d *schema.ResourceData, me
 }
 
 func elastic7GetIndexTemplate(client *elastic7.Client, id string) (string, error) {
-	res, err := client.IndexGetIndexTemplate(id).Do(context.TODO())
+	path, err := uritemplates.Expand("/_index_template/{name}", map[string]string{
+		"name": id,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for index template: %+v", err)
+	}
+
+	// the typed client drops fields like data_stream, so use the raw response
+	res, err := client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+		Method: "GET",
+		Path:   path,
+	})
 	if err != nil {
 		return "", err
 	}
 
+	response := new(ComposableIndexTemplateGetResponse)
+	if err := json.Unmarshal(res.Body, response); err != nil {
+		return "", fmt.Errorf("error unmarshalling index template body: %+v: %+v", err, res.Body)
+	}
+
 	// No more than 1 element is expected, if the index template is not found, previous call should
 	// return a 404 error
-	t := res.IndexTemplates[0].IndexTemplate
+	if len(response.IndexTemplates) == 0 {
+		return "", fmt.Errorf("index template %s not found", id)
+	}
+	t := response.IndexTemplates[0].IndexTemplate
 	tj, err := json.Marshal(t)
 	if err != nil {
 		return "", err
@@ -120,7 +140,7 @@ func resourceElasticsearchComposableIndexTemplateDelete(d *schema.ResourceData,
 
 	switch client := esClient.(type) {
 	case *elastic7.Client:
-		elasticVersion, err = elastic7GetVersion(client)
+		elasticVersion, err = elastic7GetCompatibleVersion(meta, client)
 		if err == nil {
 			if elasticVersion.LessThan(minimalESComposableTemplateVersion) {
 				err = fmt.Errorf("index_template endpoint only available from ElasticSearch >= 7.8, got version %s", elasticVersion.String())
@@ -157,7 +177,7 @@ func resourceElasticsearchPutComposableIndexTemplate(d *schema.ResourceData, met
 
 	switch client := esClient.(type) {
 	case *elastic7.Client:
-		elasticVersion, err = elastic7GetVersion(client)
+		elasticVersion, err = elastic7GetCompatibleVersion(meta, client)
 		if err == nil {
 			if elasticVersion.LessThan(minimalESComposableTemplateVersion) {
 				err = fmt.Errorf("index_template endpoint only available from ElasticSearch >= 7.8, got version %s", elasticVersion.String())
@@ -176,3 +196,10 @@ func elastic7PutIndexTemplate(client *elastic7.Client, name string, body string,
 	_, err := client.IndexPutIndexTemplate(name).BodyString(body).Create(create).Do(context.TODO())
 	return err
 }
+
+type ComposableIndexTemplateGetResponse struct {
+	IndexTemplates []struct {
+		Name          string                 `json:"name"`
+		IndexTemplate map[string]interface{} `json:"index_template"`
+	} `json:"index_templates"`
+}
@@ -53,6 +53,51 @@ func TestAccElasticsearchComposableIndexTemplate(t *testing.T) {
 	})
 }
 
+func TestAccElasticsearchComposableIndexTemplate_composedOf(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+
+	var allowed bool
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		// the data_stream object needs >= 7.9
+		elasticVersion, err := elastic7GetVersion(client)
+		if err != nil {
+			t.Skipf("err: %s", err)
+		}
+		allowed = !elasticVersion.LessThan(dataStreamMinimalVersion)
+	default:
+		allowed = false
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Data stream templates only supported on ES >= 7.9")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckElasticsearchComposableIndexTemplateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchComposableIndexTemplateComposedOf,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchComposableIndexTemplateExists("elasticsearch_composable_index_template.test"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccElasticsearchComposableIndexTemplate_importBasic(t *testing.T) {
 	provider := Provider().(*schema.Provider)
 	err := provider.Configure(&terraform.ResourceConfig{})
@@ -190,3 +235,41 @@ resource "elasticsearch_composable_index_template" "test" {
 EOF
 }
 `
+
+var testAccElasticsearchComposableIndexTemplateComposedOf = `
+resource "elasticsearch_component_template" "test" {
+  name = "terraform-test"
+  body = <<EOF
+{
+  "template": {
+    "mappings": {
+      "properties": {
+        "host_name": {
+          "type": "keyword"
+        }
+      }
+    }
+  }
+}
+EOF
+}
+
+resource "elasticsearch_composable_index_template" "test" {
+  name = "terraform-test"
+  body = <<EOF
+{
+  "index_patterns": ["terraform-test-stream*"],
+  "composed_of": ["${elasticsearch_component_template.test.name}"],
+  "data_stream": {},
+  "template": {
+    "settings": {
+      "index": {
+        "number_of_shards": 1
+      }
+    }
+  },
+  "priority": 200
+}
+EOF
+}
+`
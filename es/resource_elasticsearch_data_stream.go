@@ -0,0 +1,186 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/olivere/elastic/uritemplates"
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+var dataStreamMinimalVersion, _ = version.NewVersion("7.9.0")
+
+func resourceElasticsearchDataStream() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceElasticsearchDataStreamCreate,
+		Read:   resourceElasticsearchDataStreamRead,
+		Delete: resourceElasticsearchDataStreamDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Name of the data stream to create, it must match the index pattern of a composable index template with a `data_stream` object.",
+			},
+			"backing_indices": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The names of the backing indices of the data stream, the last one is the current write index.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"template": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the composable index template used to create the backing indices of the data stream.",
+			},
+			"timestamp_field": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the timestamp field of the data stream.",
+			},
+			"generation": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The current generation of the data stream, incremented on each rollover.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Description: "A data stream stores append-only time series data across multiple backing indices, while giving a single named resource for requests. Requires a matching composable index template with a `data_stream` object.",
+	}
+}
+
+func resourceElasticsearchDataStreamCreate(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+
+	path, err := uritemplates.Expand("/_data_stream/{name}", map[string]string{
+		"name": name,
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for data stream: %+v", err)
+	}
+
+	_, err = elastic7PerformDataStreamRequest(meta, "PUT", path)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(name)
+	return resourceElasticsearchDataStreamRead(d, meta)
+}
+
+func resourceElasticsearchDataStreamRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	path, err := uritemplates.Expand("/_data_stream/{name}", map[string]string{
+		"name": id,
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for data stream: %+v", err)
+	}
+
+	res, err := elastic7PerformDataStreamRequest(meta, "GET", path)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Data stream (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	response := new(DataStreamGetResponse)
+	if err := json.Unmarshal(res.Body, response); err != nil {
+		return fmt.Errorf("error unmarshalling data stream body: %+v: %+v", err, res.Body)
+	}
+
+	// No more than 1 element is expected, if the data stream is not found,
+	// previous call should return a 404 error
+	if len(response.DataStreams) != 1 {
+		log.Printf("[WARN] Data stream (%s) not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+	dataStream := response.DataStreams[0]
+
+	indices := make([]string, 0, len(dataStream.Indices))
+	for _, index := range dataStream.Indices {
+		indices = append(indices, index.IndexName)
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("name", dataStream.Name)
+	ds.set("backing_indices", indices)
+	ds.set("template", dataStream.Template)
+	ds.set("timestamp_field", dataStream.TimestampField.Name)
+	ds.set("generation", dataStream.Generation)
+	return ds.err
+}
+
+func resourceElasticsearchDataStreamDelete(d *schema.ResourceData, meta interface{}) error {
+	path, err := uritemplates.Expand("/_data_stream/{name}", map[string]string{
+		"name": d.Id(),
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for data stream: %+v", err)
+	}
+
+	_, err = elastic7PerformDataStreamRequest(meta, "DELETE", path)
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func elastic7PerformDataStreamRequest(meta interface{}, method string, path string) (*elastic7.Response, error) {
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return nil, err
+	}
+
+	client, ok := esClient.(*elastic7.Client)
+	if !ok {
+		return nil, fmt.Errorf("data_stream endpoint only available from ElasticSearch >= 7.9, got version < 7.0.0")
+	}
+
+	elasticVersion, err := elastic7GetCompatibleVersion(meta, client)
+	if err != nil {
+		return nil, err
+	}
+	if elasticVersion.LessThan(dataStreamMinimalVersion) {
+		return nil, fmt.Errorf("data_stream endpoint only available from ElasticSearch >= 7.9, got version %s", elasticVersion.String())
+	}
+
+	return client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+		Method: method,
+		Path:   path,
+	})
+}
+
+type DataStreamGetResponse struct {
+	DataStreams []DataStream `json:"data_streams"`
+}
+
+type DataStream struct {
+	Name           string `json:"name"`
+	TimestampField struct {
+		Name string `json:"name"`
+	} `json:"timestamp_field"`
+	Indices []struct {
+		IndexName string `json:"index_name"`
+		IndexUUID string `json:"index_uuid"`
+	} `json:"indices"`
+	Generation int    `json:"generation"`
+	Status     string `json:"status"`
+	Template   string `json:"template"`
+}
@@ -0,0 +1,204 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+var dataStreamLifecycleMinimalVersion, _ = version.NewVersion("8.11.0")
+
+func resourceElasticsearchDataStreamLifecycle() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides the lifecycle of an Elasticsearch data stream, the built-in alternative to index lifecycle policies managing the retention and the downsampling of the backing indices of the data stream. Destroying the resource removes the lifecycle, the data stream is kept. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/data-stream-lifecycle.html) for more details.",
+		Create:      resourceElasticsearchDataStreamLifecyclePut,
+		Read:        resourceElasticsearchDataStreamLifecycleRead,
+		Update:      resourceElasticsearchDataStreamLifecyclePut,
+		Delete:      resourceElasticsearchDataStreamLifecycleDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Name of the data stream.",
+			},
+			"data_retention": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateElasticsearchDuration,
+				Description:  "The minimum time the data of the data stream is kept, e.g. `7d`. The data is kept forever if not set.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the lifecycle manages the data stream, disabling it keeps the lifecycle configured.",
+			},
+			"downsampling": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJson,
+				Description:      "A JSON string of the downsampling rounds of the backing indices of time series data streams, e.g. `[{\"after\": \"1d\", \"fixed_interval\": \"1h\"}]`.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchDataStreamLifecyclePut(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+
+	if err := elastic7CheckDataStreamLifecycleVersion(meta); err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"enabled": d.Get("enabled").(bool),
+	}
+	if dataRetention, ok := d.GetOk("data_retention"); ok {
+		body["data_retention"] = dataRetention.(string)
+	}
+	if downsampling, ok := d.GetOk("downsampling"); ok {
+		body["downsampling"] = json.RawMessage(downsampling.(string))
+	}
+
+	path, err := dataStreamLifecyclePath(name)
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "PUT", path, body); err != nil {
+		return err
+	}
+
+	d.SetId(name)
+	return resourceElasticsearchDataStreamLifecycleRead(d, meta)
+}
+
+func resourceElasticsearchDataStreamLifecycleRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	if err := elastic7CheckDataStreamLifecycleVersion(meta); err != nil {
+		return err
+	}
+
+	path, err := dataStreamLifecyclePath(id)
+	if err != nil {
+		return err
+	}
+	body, err := elasticsearchPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Data stream lifecycle (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	response := new(DataStreamLifecycleGetResponse)
+	if err := json.Unmarshal(body, response); err != nil {
+		return fmt.Errorf("error unmarshalling data stream lifecycle body: %+v: %+v", err, body)
+	}
+
+	var lifecycle *DataStreamLifecycle
+	for _, dataStream := range response.DataStreams {
+		if dataStream.Name == id {
+			lifecycle = dataStream.Lifecycle
+		}
+	}
+	// data streams without a lifecycle are returned without one
+	if lifecycle == nil {
+		log.Printf("[WARN] Data stream lifecycle (%s) not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	// enabled is the default, which isn't always returned
+	enabled := true
+	if lifecycle.Enabled != nil {
+		enabled = *lifecycle.Enabled
+	}
+
+	downsampling := ""
+	if len(lifecycle.Downsampling) > 0 {
+		downsampling = string(lifecycle.Downsampling)
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("name", id)
+	ds.set("data_retention", lifecycle.DataRetention)
+	ds.set("enabled", enabled)
+	ds.set("downsampling", downsampling)
+	return ds.err
+}
+
+func resourceElasticsearchDataStreamLifecycleDelete(d *schema.ResourceData, meta interface{}) error {
+	if err := elastic7CheckDataStreamLifecycleVersion(meta); err != nil {
+		return err
+	}
+
+	path, err := dataStreamLifecyclePath(d.Id())
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "DELETE", path, nil); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func elastic7CheckDataStreamLifecycleVersion(meta interface{}) error {
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+
+	client, ok := esClient.(*elastic7.Client)
+	if !ok {
+		return fmt.Errorf("data stream lifecycles only available from ElasticSearch >= 8.11, got version < 7.0.0")
+	}
+
+	elasticVersion, err := elastic7GetVersion(client)
+	if err != nil {
+		return err
+	}
+	if elasticVersion.LessThan(dataStreamLifecycleMinimalVersion) {
+		return fmt.Errorf("data stream lifecycles only available from ElasticSearch >= 8.11, got version %s", elasticVersion.String())
+	}
+	return nil
+}
+
+func dataStreamLifecyclePath(name string) (string, error) {
+	path, err := uritemplates.Expand("/_data_stream/{name}/_lifecycle", map[string]string{
+		"name": name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for data stream lifecycle: %+v", err)
+	}
+	return path, nil
+}
+
+type DataStreamLifecycleGetResponse struct {
+	DataStreams []struct {
+		Name      string               `json:"name"`
+		Lifecycle *DataStreamLifecycle `json:"lifecycle"`
+	} `json:"data_streams"`
+}
+
+type DataStreamLifecycle struct {
+	Enabled       *bool           `json:"enabled"`
+	DataRetention string          `json:"data_retention"`
+	Downsampling  json.RawMessage `json:"downsampling"`
+}
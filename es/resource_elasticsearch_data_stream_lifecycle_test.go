@@ -0,0 +1,162 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchDataStreamLifecycle(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		elasticVersion, err := elastic7GetVersion(client)
+		if err != nil {
+			t.Skipf("err: %s", err)
+		}
+		allowed = !elasticVersion.LessThan(dataStreamLifecycleMinimalVersion)
+	default:
+		allowed = false
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Data stream lifecycles only supported on ES >= 8.11")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckElasticsearchDataStreamLifecycleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchDataStreamLifecycle("7d"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchDataStreamLifecycleExists("elasticsearch_data_stream_lifecycle.test"),
+					resource.TestCheckResourceAttr("elasticsearch_data_stream_lifecycle.test", "data_retention", "7d"),
+				),
+			},
+			{
+				Config: testAccElasticsearchDataStreamLifecycle("30d"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchDataStreamLifecycleExists("elasticsearch_data_stream_lifecycle.test"),
+					resource.TestCheckResourceAttr("elasticsearch_data_stream_lifecycle.test", "data_retention", "30d"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_data_stream_lifecycle.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchDataStreamLifecycleExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No data stream lifecycle ID is set")
+		}
+
+		lifecycle, err := testGetElasticsearchDataStreamLifecycle(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if lifecycle == nil {
+			return fmt.Errorf("Data stream %q has no lifecycle", rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func testCheckElasticsearchDataStreamLifecycleDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_data_stream_lifecycle" {
+			continue
+		}
+
+		lifecycle, err := testGetElasticsearchDataStreamLifecycle(rs.Primary.ID)
+		if err != nil || lifecycle == nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Data stream lifecycle %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testGetElasticsearchDataStreamLifecycle(name string) (*DataStreamLifecycle, error) {
+	path, err := dataStreamLifecyclePath(name)
+	if err != nil {
+		return nil, err
+	}
+	body, err := elasticsearchPerformRequest(testAccProvider.Meta(), "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(DataStreamLifecycleGetResponse)
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, err
+	}
+	for _, dataStream := range response.DataStreams {
+		if dataStream.Name == name {
+			return dataStream.Lifecycle, nil
+		}
+	}
+	return nil, nil
+}
+
+func testAccElasticsearchDataStreamLifecycle(dataRetention string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_composable_index_template" "test" {
+  name = "terraform-test"
+  body = <<EOF
+{
+  "index_patterns": ["terraform-test-stream*"],
+  "data_stream": {},
+  "template": {
+    "settings": {
+      "index": {
+        "number_of_shards": 1,
+        "number_of_replicas": 0
+      }
+    }
+  }
+}
+EOF
+}
+
+resource "elasticsearch_data_stream" "test" {
+  name = "terraform-test-stream"
+
+  depends_on = [elasticsearch_composable_index_template.test]
+}
+
+resource "elasticsearch_data_stream_lifecycle" "test" {
+  name           = elasticsearch_data_stream.test.name
+  data_retention = "%s"
+}
+`, dataRetention)
+}
@@ -0,0 +1,160 @@
+package es
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchDataStream(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		elasticVersion, err := elastic7GetVersion(client)
+		if err != nil {
+			t.Skipf("err: %s", err)
+		}
+		allowed = !elasticVersion.LessThan(dataStreamMinimalVersion)
+	default:
+		allowed = false
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("/_data_stream endpoint only supported on ES >= 7.9")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckElasticsearchDataStreamDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchDataStream,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchDataStreamExists("elasticsearch_data_stream.test"),
+					resource.TestCheckResourceAttr("elasticsearch_data_stream.test", "template", "terraform-test"),
+					resource.TestCheckResourceAttr("elasticsearch_data_stream.test", "timestamp_field", "@timestamp"),
+					resource.TestCheckResourceAttr("elasticsearch_data_stream.test", "generation", "1"),
+					resource.TestCheckResourceAttr("elasticsearch_data_stream.test", "backing_indices.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_data_stream.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchDataStreamExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No data stream ID is set")
+		}
+
+		meta := testAccProvider.Meta()
+
+		esClient, err := getClient(meta.(*ProviderConf))
+		if err != nil {
+			return err
+		}
+
+		switch client := esClient.(type) {
+		case *elastic7.Client:
+			_, err = client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+				Method: "GET",
+				Path:   "/_data_stream/" + rs.Primary.ID,
+			})
+		default:
+			err = errors.New("/_data_stream endpoint only supported on ES >= 7.9")
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func testCheckElasticsearchDataStreamDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_data_stream" {
+			continue
+		}
+
+		meta := testAccProvider.Meta()
+
+		esClient, err := getClient(meta.(*ProviderConf))
+		if err != nil {
+			return err
+		}
+
+		switch client := esClient.(type) {
+		case *elastic7.Client:
+			_, err = client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+				Method: "GET",
+				Path:   "/_data_stream/" + rs.Primary.ID,
+			})
+		default:
+			err = errors.New("/_data_stream endpoint only supported on ES >= 7.9")
+		}
+
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Data stream %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+var testAccElasticsearchDataStream = `
+resource "elasticsearch_composable_index_template" "test" {
+  name = "terraform-test"
+  body = <<EOF
+{
+  "index_patterns": ["terraform-test-stream*"],
+  "data_stream": {},
+  "template": {
+    "settings": {
+      "index": {
+        "number_of_shards": 1,
+        "number_of_replicas": 0
+      }
+    }
+  }
+}
+EOF
+}
+
+resource "elasticsearch_data_stream" "test" {
+  name = "terraform-test-stream"
+
+  depends_on = [elasticsearch_composable_index_template.test]
+}
+`
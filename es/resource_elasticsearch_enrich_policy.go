@@ -0,0 +1,327 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+var enrichPolicyMinimalVersion, _ = version.NewVersion("7.5.0")
+
+func resourceElasticsearchEnrichPolicy() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an Elasticsearch enrich policy, used by the enrich processor of ingest pipelines to add data from the source indices to incoming documents. Policies can't be updated, so changing them replaces the policy. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/enrich-apis.html) for more details.",
+		Create:      resourceElasticsearchEnrichPolicyCreate,
+		Read:        resourceElasticsearchEnrichPolicyRead,
+		Update:      resourceElasticsearchEnrichPolicyUpdate,
+		Delete:      resourceElasticsearchEnrichPolicyDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Name of the enrich policy.",
+			},
+			"policy_type": {
+				Type:         schema.TypeString,
+				ForceNew:     true,
+				Optional:     true,
+				Default:      "match",
+				ValidateFunc: validation.StringInSlice([]string{"match", "geo_match", "range"}, false),
+				Description:  "The type of the policy, how incoming documents are matched to the source documents, `match`, `geo_match` or `range`.",
+			},
+			"indices": {
+				Type:        schema.TypeList,
+				ForceNew:    true,
+				Required:    true,
+				MinItems:    1,
+				Description: "The source indices used to create the enrich index.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"match_field": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "The field of the source indices used to match incoming documents.",
+			},
+			"enrich_fields": {
+				Type:        schema.TypeList,
+				ForceNew:    true,
+				Required:    true,
+				MinItems:    1,
+				Description: "The fields of the source indices added to matching incoming documents.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"query": {
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJson,
+				Description:      "A JSON string of the query filtering the source documents used to create the enrich index.",
+			},
+			"execute": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to execute the policy after creating it, creating the enrich index. Policies can't be used until they are executed.",
+			},
+			"wait_for_completion": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to wait for the execution of the policy to complete.",
+			},
+			"execute_triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Arbitrary values which execute the policy again when changed, e.g. the time the source indices were last updated.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: resourceElasticsearchEnrichPolicyImport,
+		},
+	}
+}
+
+func resourceElasticsearchEnrichPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+
+	policy := map[string]interface{}{
+		"indices":       expandStringList(d.Get("indices").([]interface{})),
+		"match_field":   d.Get("match_field").(string),
+		"enrich_fields": expandStringList(d.Get("enrich_fields").([]interface{})),
+	}
+	if query, ok := d.GetOk("query"); ok {
+		policy["query"] = json.RawMessage(query.(string))
+	}
+	body := map[string]interface{}{
+		d.Get("policy_type").(string): policy,
+	}
+
+	client, err := elastic7EnrichPolicyClient(meta)
+	if err != nil {
+		return err
+	}
+	if err := elastic7PutEnrichPolicy(client, name, body); err != nil {
+		return err
+	}
+	d.SetId(name)
+
+	if d.Get("execute").(bool) {
+		if err := elastic7ExecuteEnrichPolicy(client, name, d.Get("wait_for_completion").(bool)); err != nil {
+			return err
+		}
+	}
+
+	return resourceElasticsearchEnrichPolicyRead(d, meta)
+}
+
+func resourceElasticsearchEnrichPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	client, err := elastic7EnrichPolicyClient(meta)
+	if err != nil {
+		return err
+	}
+
+	policyType, policy, err := elastic7GetEnrichPolicy(client, id)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Enrich policy (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	if policy == nil {
+		log.Printf("[WARN] Enrich policy (%s) not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	var query string
+	if len(policy.Query) > 0 {
+		query = string(policy.Query)
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("name", id)
+	ds.set("policy_type", policyType)
+	ds.set("indices", policy.Indices)
+	ds.set("match_field", policy.MatchField)
+	ds.set("enrich_fields", policy.EnrichFields)
+	ds.set("query", query)
+	return ds.err
+}
+
+func resourceElasticsearchEnrichPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	// all the fields of the policy force a new resource, so only the
+	// execution of the policy can change
+	execute := d.Get("execute").(bool)
+	if execute && (d.HasChange("execute") || d.HasChange("execute_triggers")) {
+		client, err := elastic7EnrichPolicyClient(meta)
+		if err != nil {
+			return err
+		}
+		if err := elastic7ExecuteEnrichPolicy(client, d.Id(), d.Get("wait_for_completion").(bool)); err != nil {
+			return err
+		}
+	}
+
+	return resourceElasticsearchEnrichPolicyRead(d, meta)
+}
+
+func resourceElasticsearchEnrichPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := elastic7EnrichPolicyClient(meta)
+	if err != nil {
+		return err
+	}
+
+	path, err := enrichPolicyPath(d.Id(), "")
+	if err != nil {
+		return err
+	}
+	_, err = client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+		Method: http.MethodDelete,
+		Path:   path,
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceElasticsearchEnrichPolicyImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// imported policies are assumed to be executed already
+	ds := &resourceDataSetter{d: d}
+	ds.set("execute", true)
+	ds.set("wait_for_completion", true)
+	return []*schema.ResourceData{d}, ds.err
+}
+
+func elastic7EnrichPolicyClient(meta interface{}) (*elastic7.Client, error) {
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return nil, err
+	}
+
+	client, ok := esClient.(*elastic7.Client)
+	if !ok {
+		return nil, fmt.Errorf("enrich policies only available from ElasticSearch >= 7.5, got version < 7.0.0")
+	}
+
+	elasticVersion, err := elastic7GetVersion(client)
+	if err != nil {
+		return nil, err
+	}
+	if elasticVersion.LessThan(enrichPolicyMinimalVersion) {
+		return nil, fmt.Errorf("enrich policies only available from ElasticSearch >= 7.5, got version %s", elasticVersion.String())
+	}
+
+	return client, nil
+}
+
+func elastic7GetEnrichPolicy(client *elastic7.Client, name string) (string, *EnrichPolicy, error) {
+	path, err := enrichPolicyPath(name, "")
+	if err != nil {
+		return "", nil, err
+	}
+	res, err := client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+		Method: http.MethodGet,
+		Path:   path,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	response := new(EnrichPolicyGetResponse)
+	if err := json.Unmarshal(res.Body, response); err != nil {
+		return "", nil, fmt.Errorf("error unmarshalling enrich policy body: %+v: %+v", err, res.Body)
+	}
+
+	// the config is keyed by the type of the policy
+	for _, policy := range response.Policies {
+		for policyType, config := range policy.Config {
+			if config.Name == name {
+				return policyType, &config, nil
+			}
+		}
+	}
+	return "", nil, nil
+}
+
+func elastic7PutEnrichPolicy(client *elastic7.Client, name string, body interface{}) error {
+	path, err := enrichPolicyPath(name, "")
+	if err != nil {
+		return err
+	}
+	_, err = client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+		Method: http.MethodPut,
+		Path:   path,
+		Body:   body,
+	})
+	return err
+}
+
+func elastic7ExecuteEnrichPolicy(client *elastic7.Client, name string, waitForCompletion bool) error {
+	path, err := enrichPolicyPath(name, "/_execute")
+	if err != nil {
+		return err
+	}
+	params := url.Values{}
+	params.Set("wait_for_completion", strconv.FormatBool(waitForCompletion))
+
+	_, err = client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+		Method: http.MethodPut,
+		Path:   path,
+		Params: params,
+	})
+	if err != nil {
+		return fmt.Errorf("error executing enrich policy %s: %+v", name, err)
+	}
+	return nil
+}
+
+func enrichPolicyPath(name string, suffix string) (string, error) {
+	path, err := uritemplates.Expand("/_enrich/policy/{name}", map[string]string{
+		"name": name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for enrich policy: %+v", err)
+	}
+	return path + suffix, nil
+}
+
+type EnrichPolicyGetResponse struct {
+	Policies []struct {
+		Config map[string]EnrichPolicy `json:"config"`
+	} `json:"policies"`
+}
+
+type EnrichPolicy struct {
+	Name         string          `json:"name"`
+	Indices      []string        `json:"indices"`
+	MatchField   string          `json:"match_field"`
+	EnrichFields []string        `json:"enrich_fields"`
+	Query        json.RawMessage `json:"query,omitempty"`
+}
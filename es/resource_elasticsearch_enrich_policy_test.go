@@ -0,0 +1,160 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchEnrichPolicy(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		elasticVersion, err := elastic7GetVersion(client)
+		if err != nil {
+			t.Skipf("err: %s", err)
+		}
+		allowed = !elasticVersion.LessThan(enrichPolicyMinimalVersion)
+	default:
+		allowed = false
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Enrich policies only supported on ES >= 7.5")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchEnrichPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchEnrichPolicy("1"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchEnrichPolicyExists("elasticsearch_enrich_policy.test"),
+					resource.TestCheckResourceAttr("elasticsearch_enrich_policy.test", "policy_type", "match"),
+					resource.TestCheckResourceAttr("elasticsearch_enrich_policy.test", "enrich_fields.#", "2"),
+				),
+			},
+			{
+				// executes the policy again
+				Config: testAccElasticsearchEnrichPolicy("2"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchEnrichPolicyExists("elasticsearch_enrich_policy.test"),
+				),
+			},
+			{
+				ResourceName:            "elasticsearch_enrich_policy.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"execute_triggers"},
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchEnrichPolicyExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No enrich policy ID is set")
+		}
+
+		client, err := elastic7EnrichPolicyClient(testAccXPackProvider.Meta())
+		if err != nil {
+			return err
+		}
+
+		_, policy, err := elastic7GetEnrichPolicy(client, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if policy == nil {
+			return fmt.Errorf("Enrich policy %q not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testCheckElasticsearchEnrichPolicyDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_enrich_policy" {
+			continue
+		}
+
+		client, err := elastic7EnrichPolicyClient(testAccXPackProvider.Meta())
+		if err != nil {
+			return err
+		}
+
+		_, policy, err := elastic7GetEnrichPolicy(client, rs.Primary.ID)
+		if err != nil || policy == nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Enrich policy %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchEnrichPolicy(trigger string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_index" "test" {
+  name               = "terraform-test-users"
+  number_of_shards   = 1
+  number_of_replicas = 0
+  mappings           = <<EOF
+{
+  "properties": {
+    "email": {
+      "type": "keyword"
+    },
+    "first_name": {
+      "type": "keyword"
+    },
+    "last_name": {
+      "type": "keyword"
+    }
+  }
+}
+EOF
+}
+
+resource "elasticsearch_enrich_policy" "test" {
+  name          = "terraform-test"
+  indices       = [elasticsearch_index.test.name]
+  match_field   = "email"
+  enrich_fields = ["first_name", "last_name"]
+  query         = <<EOF
+{
+  "match_all": {}
+}
+EOF
+
+  execute_triggers = {
+    version = %q
+  }
+}
+`, trigger)
+}
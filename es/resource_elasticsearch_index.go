@@ -5,10 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/url"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
 
 	elastic7 "github.com/olivere/elastic/v7"
 	elastic5 "gopkg.in/olivere/elastic.v5"
@@ -82,6 +86,12 @@ var (
 			Default:     false,
 			Optional:    true,
 		},
+		"wait_for_active_shards": {
+			Type:         schema.TypeString,
+			Description:  "The number of shard copies that must be active before creating the index returns, `all` or a number up to `number_of_replicas` + 1, e.g. `1` for the primaries only. Waits at most the create timeout, only used when creating the index.",
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(all|[0-9]+)$`), "must be `all` or a number"),
+		},
 		// Static settings that can only be set on creation
 		"number_of_shards": {
 			Type:        schema.TypeString,
@@ -317,11 +327,12 @@ var (
 		},
 		// Other attributes
 		"mappings": {
-			Type:         schema.TypeString,
-			Description:  "A JSON string defining how documents in the index, and the fields they contain, are stored and indexed. To avoid the complexities of field mapping updates, updates of this field are not allowed via this provider. See the upstream [Elasticsearch docs](https://www.elastic.co/guide/en/elasticsearch/reference/6.8/indices-put-mapping.html#updating-field-mappings) for more details.",
-			Optional:     true,
-			ForceNew:     true,
-			ValidateFunc: validation.StringIsJSON,
+			Type:             schema.TypeString,
+			Description:      "A JSON string defining how documents in the index, and the fields they contain, are stored and indexed. To avoid the complexities of field mapping updates, updates of this field are not allowed via this provider. See the upstream [Elasticsearch docs](https://www.elastic.co/guide/en/elasticsearch/reference/6.8/indices-put-mapping.html#updating-field-mappings) for more details.",
+			Optional:         true,
+			ForceNew:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: suppressEquivalentJson,
 		},
 		"aliases": {
 			Type:        schema.TypeString,
@@ -329,8 +340,9 @@ var (
 			Optional:    true,
 			// In order to not handle the separate endpoint of alias updates, updates
 			// are not allowed via this provider currently.
-			ForceNew:     true,
-			ValidateFunc: validation.StringIsJSON,
+			ForceNew:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: suppressEquivalentJson,
 		},
 		"analysis_analyzer": {
 			Type:         schema.TypeString,
@@ -378,7 +390,10 @@ func resourceElasticsearchIndex() *schema.Resource {
 		Delete:      resourceElasticsearchIndexDelete,
 		Schema:      configSchema,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceElasticsearchIndexImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Second),
 		},
 	}
 }
@@ -465,31 +480,62 @@ func resourceElasticsearchIndexCreate(d *schema.ResourceData, meta interface{})
 	if err != nil {
 		return err
 	}
+	waitForActiveShards := d.Get("wait_for_active_shards").(string)
+	timeout := fmt.Sprintf("%ds", int(d.Timeout(schema.TimeoutCreate).Seconds()))
+	shardsAcknowledged := true
 	switch client := esClient.(type) {
 	case *elastic7.Client:
-		resp, requestErr := client.CreateIndex(name).BodyJson(body).Do(ctx)
+		var resp *elastic7.IndicesCreateResult
+		var requestErr error
+		if waitForActiveShards != "" {
+			resp, requestErr = elastic7CreateIndexWaitForActiveShards(client, name, body, waitForActiveShards, timeout)
+		} else {
+			resp, requestErr = client.CreateIndex(name).BodyJson(body).Do(ctx)
+		}
 		err = requestErr
 		if err == nil {
 			resolvedName = resp.Index
+			shardsAcknowledged = resp.ShardsAcknowledged
 		}
 
 	case *elastic6.Client:
-		resp, requestErr := client.CreateIndex(name).BodyJson(body).Do(ctx)
+		var resp *elastic6.IndicesCreateResult
+		var requestErr error
+		if waitForActiveShards != "" {
+			resp, requestErr = elastic6CreateIndexWaitForActiveShards(client, name, body, waitForActiveShards, timeout)
+		} else {
+			resp, requestErr = client.CreateIndex(name).BodyJson(body).Do(ctx)
+		}
 		err = requestErr
 		if err == nil {
 			resolvedName = resp.Index
+			shardsAcknowledged = resp.ShardsAcknowledged
 		}
 
 	default:
 		elastic5Client := client.(*elastic5.Client)
-		resp, requestErr := elastic5Client.CreateIndex(name).BodyJson(body).Do(ctx)
+		var resp *elastic5.IndicesCreateResult
+		var requestErr error
+		if waitForActiveShards != "" {
+			resp, requestErr = elastic5CreateIndexWaitForActiveShards(elastic5Client, name, body, waitForActiveShards, timeout)
+		} else {
+			resp, requestErr = elastic5Client.CreateIndex(name).BodyJson(body).Do(ctx)
+		}
 		err = requestErr
 		if err == nil {
 			resolvedName = resp.Index
+			shardsAcknowledged = resp.ShardsAcknowledged
 		}
 
 	}
 
+	if err == nil && !shardsAcknowledged {
+		// The index exists at this point, keep it in the state so that it is
+		// tainted rather than orphaned
+		d.SetId(resolvedName)
+		return fmt.Errorf("index %s was created, but %s shard copies were not active within the create timeout of %s", resolvedName, waitForActiveShards, timeout)
+	}
+
 	if err == nil {
 		// Let terraform know the resource was created
 		d.SetId(resolvedName)
@@ -498,6 +544,133 @@ func resourceElasticsearchIndexCreate(d *schema.ResourceData, meta interface{})
 	return err
 }
 
+// The create index services do not support wait_for_active_shards, the path
+// is escaped the same way as the services do
+func elastic7CreateIndexWaitForActiveShards(client *elastic7.Client, name string, body map[string]interface{}, waitForActiveShards string, timeout string) (*elastic7.IndicesCreateResult, error) {
+	path, err := uritemplates.Expand("/{index}", map[string]string{
+		"index": name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+		Method: "PUT",
+		Path:   path,
+		Params: url.Values{
+			"wait_for_active_shards": []string{waitForActiveShards},
+			"timeout":                []string{timeout},
+		},
+		Body: body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(elastic7.IndicesCreateResult)
+	if err := json.Unmarshal(res.Body, resp); err != nil {
+		return nil, fmt.Errorf("error unmarshalling create index body: %+v: %+v", err, res.Body)
+	}
+	return resp, nil
+}
+
+func elastic6CreateIndexWaitForActiveShards(client *elastic6.Client, name string, body map[string]interface{}, waitForActiveShards string, timeout string) (*elastic6.IndicesCreateResult, error) {
+	path, err := uritemplates.Expand("/{index}", map[string]string{
+		"index": name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.PerformRequest(context.TODO(), elastic6.PerformRequestOptions{
+		Method: "PUT",
+		Path:   path,
+		Params: url.Values{
+			"wait_for_active_shards": []string{waitForActiveShards},
+			"timeout":                []string{timeout},
+		},
+		Body: body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(elastic6.IndicesCreateResult)
+	if err := json.Unmarshal(res.Body, resp); err != nil {
+		return nil, fmt.Errorf("error unmarshalling create index body: %+v: %+v", err, res.Body)
+	}
+	return resp, nil
+}
+
+func elastic5CreateIndexWaitForActiveShards(client *elastic5.Client, name string, body map[string]interface{}, waitForActiveShards string, timeout string) (*elastic5.IndicesCreateResult, error) {
+	path, err := uritemplates.Expand("/{index}", map[string]string{
+		"index": name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	params := url.Values{
+		"wait_for_active_shards": []string{waitForActiveShards},
+		"timeout":                []string{timeout},
+	}
+	res, err := client.PerformRequest(context.TODO(), "PUT", path, params, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(elastic5.IndicesCreateResult)
+	if err := json.Unmarshal(res.Body, resp); err != nil {
+		return nil, fmt.Errorf("error unmarshalling create index body: %+v: %+v", err, res.Body)
+	}
+	return resp, nil
+}
+
+// resourceElasticsearchIndexImport reads the mappings and aliases of the index,
+// which are otherwise only set from the configuration as they force a new
+// index and the cluster may return them normalized
+func resourceElasticsearchIndexImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	index := d.Id()
+	path, err := uritemplates.Expand("/{index}", map[string]string{
+		"index": index,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error building URL path for index: %+v", err)
+	}
+
+	body, err := elasticsearchPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	indices := make(map[string]IndexGetResponse)
+	if err := json.Unmarshal(body, &indices); err != nil {
+		return nil, fmt.Errorf("error unmarshalling index body: %+v: %+v", err, body)
+	}
+	resp, ok := indices[index]
+	if !ok {
+		return nil, fmt.Errorf("index %s not found", index)
+	}
+
+	ds := &resourceDataSetter{d: d}
+	if len(resp.Mappings) > 0 {
+		mappings, err := json.Marshal(resp.Mappings)
+		if err != nil {
+			return nil, err
+		}
+		ds.set("mappings", string(mappings))
+	}
+	if len(resp.Aliases) > 0 {
+		aliases, err := json.Marshal(resp.Aliases)
+		if err != nil {
+			return nil, err
+		}
+		ds.set("aliases", string(aliases))
+	}
+	if ds.err != nil {
+		return nil, ds.err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func settingsFromIndexResourceData(d *schema.ResourceData) map[string]interface{} {
 	settings := make(map[string]interface{})
 	for _, key := range settingsKeys {
@@ -801,3 +974,8 @@ func resourceElasticsearchIndexRead(d *schema.ResourceData, meta interface{}) er
 
 	return nil
 }
+
+type IndexGetResponse struct {
+	Aliases  map[string]interface{} `json:"aliases"`
+	Mappings map[string]interface{} `json:"mappings"`
+}
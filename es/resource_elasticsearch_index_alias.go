@@ -0,0 +1,223 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+func resourceElasticsearchIndexAlias() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceElasticsearchIndexAliasCreate,
+		Read:   resourceElasticsearchIndexAliasRead,
+		Update: resourceElasticsearchIndexAliasUpdate,
+		Delete: resourceElasticsearchIndexAliasDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Name of the alias.",
+			},
+			"indices": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "The names of the indices the alias points to. Changing the indices moves the alias in a single atomic request, so it always points to at least one of them.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set: schema.HashString,
+			},
+			"filter": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJson,
+				Description:      "A JSON string of the query limiting the documents visible through the alias.",
+			},
+			"index_routing": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The routing value used for indexing operations through the alias.",
+			},
+			"search_routing": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The comma separated routing values used for search operations through the alias.",
+			},
+			"is_write_index": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the alias is the write alias of the index, only one index of an alias can be the write index, so this needs a single index in `indices` (ES >= 6.4).",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Description: "Provides an Elasticsearch index alias resource, managing an alias independently of the indices it points to.",
+	}
+}
+
+func resourceElasticsearchIndexAliasCreate(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+
+	actions := indexAliasAddActions(d, expandStringList(d.Get("indices").(*schema.Set).List()))
+	if err := resourceElasticsearchPostIndexAliases(meta, actions); err != nil {
+		return err
+	}
+
+	d.SetId(name)
+	return resourceElasticsearchIndexAliasRead(d, meta)
+}
+
+func resourceElasticsearchIndexAliasRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	path, err := uritemplates.Expand("/_alias/{name}", map[string]string{
+		"name": id,
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for alias: %+v", err)
+	}
+
+	body, err := elasticsearchPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		if elastic7.IsNotFound(err) || elastic6.IsNotFound(err) || elastic5.IsNotFound(err) {
+			log.Printf("[WARN] Index alias (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	response := make(map[string]IndexAliasGetResponse)
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("error unmarshalling alias body: %+v: %+v", err, body)
+	}
+
+	indices := make([]string, 0, len(response))
+	for index := range response {
+		indices = append(indices, index)
+	}
+	if len(indices) == 0 {
+		log.Printf("[WARN] Index alias (%s) not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+	sort.Strings(indices)
+
+	// filters and routing are set the same on all indices by this resource
+	alias := response[indices[0]].Aliases[id]
+	var filter string
+	if len(alias.Filter) > 0 {
+		filter = string(alias.Filter)
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("name", id)
+	ds.set("indices", indices)
+	ds.set("filter", filter)
+	ds.set("index_routing", alias.IndexRouting)
+	ds.set("search_routing", alias.SearchRouting)
+	ds.set("is_write_index", alias.IsWriteIndex)
+	return ds.err
+}
+
+func resourceElasticsearchIndexAliasUpdate(d *schema.ResourceData, meta interface{}) error {
+	o, n := d.GetChange("indices")
+	removed := expandStringList(o.(*schema.Set).Difference(n.(*schema.Set)).List())
+
+	// adding an existing alias replaces it, so the filters and routing of the
+	// kept indices are updated in the same request
+	actions := indexAliasAddActions(d, expandStringList(n.(*schema.Set).List()))
+	if len(removed) > 0 {
+		actions = append(actions, map[string]interface{}{
+			"remove": map[string]interface{}{
+				"indices": removed,
+				"alias":   d.Get("name").(string),
+			},
+		})
+	}
+
+	if err := resourceElasticsearchPostIndexAliases(meta, actions); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchIndexAliasRead(d, meta)
+}
+
+func resourceElasticsearchIndexAliasDelete(d *schema.ResourceData, meta interface{}) error {
+	actions := []map[string]interface{}{
+		{
+			"remove": map[string]interface{}{
+				"indices": expandStringList(d.Get("indices").(*schema.Set).List()),
+				"alias":   d.Get("name").(string),
+			},
+		},
+	}
+
+	if err := resourceElasticsearchPostIndexAliases(meta, actions); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func indexAliasAddActions(d *schema.ResourceData, indices []string) []map[string]interface{} {
+	alias := map[string]interface{}{
+		"indices": indices,
+		"alias":   d.Get("name").(string),
+	}
+	if filter, ok := d.GetOk("filter"); ok {
+		alias["filter"] = json.RawMessage(filter.(string))
+	}
+	if routing, ok := d.GetOk("index_routing"); ok {
+		alias["index_routing"] = routing.(string)
+	}
+	if routing, ok := d.GetOk("search_routing"); ok {
+		alias["search_routing"] = routing.(string)
+	}
+	if writeIndex, ok := d.GetOk("is_write_index"); ok {
+		alias["is_write_index"] = writeIndex.(bool)
+	}
+
+	return []map[string]interface{}{
+		{
+			"add": alias,
+		},
+	}
+}
+
+// resourceElasticsearchPostIndexAliases runs all the actions in a single
+// request, which the cluster applies atomically
+func resourceElasticsearchPostIndexAliases(meta interface{}, actions []map[string]interface{}) error {
+	body := map[string]interface{}{
+		"actions": actions,
+	}
+
+	_, err := elasticsearchPerformRequest(meta, "POST", "/_aliases", body)
+	return err
+}
+
+type IndexAliasGetResponse struct {
+	Aliases map[string]IndexAlias `json:"aliases"`
+}
+
+type IndexAlias struct {
+	Filter        json.RawMessage `json:"filter,omitempty"`
+	IndexRouting  string          `json:"index_routing,omitempty"`
+	SearchRouting string          `json:"search_routing,omitempty"`
+	IsWriteIndex  bool            `json:"is_write_index,omitempty"`
+}
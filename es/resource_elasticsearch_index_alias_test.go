@@ -0,0 +1,106 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchIndexAlias(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckElasticsearchIndexAliasDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndexAlias("terraform-test-1"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchIndexAliasExists("elasticsearch_index_alias.test"),
+					resource.TestCheckResourceAttr("elasticsearch_index_alias.test", "indices.#", "1"),
+					resource.TestCheckResourceAttr("elasticsearch_index_alias.test", "search_routing", "1"),
+				),
+			},
+			{
+				Config: testAccElasticsearchIndexAlias("terraform-test-2"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchIndexAliasExists("elasticsearch_index_alias.test"),
+					resource.TestCheckResourceAttr("elasticsearch_index_alias.test", "indices.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_index_alias.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchIndexAliasExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No index alias ID is set")
+		}
+
+		meta := testAccProvider.Meta()
+
+		_, err := elasticsearchPerformRequest(meta, "GET", "/_alias/"+rs.Primary.ID, nil)
+		return err
+	}
+}
+
+func testCheckElasticsearchIndexAliasDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_index_alias" {
+			continue
+		}
+
+		meta := testAccProvider.Meta()
+
+		_, err := elasticsearchPerformRequest(meta, "GET", "/_alias/"+rs.Primary.ID, nil)
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Index alias %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchIndexAlias(index string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_index" "test_1" {
+  name               = "terraform-test-1"
+  number_of_shards   = 1
+  number_of_replicas = 0
+}
+
+resource "elasticsearch_index" "test_2" {
+  name               = "terraform-test-2"
+  number_of_shards   = 1
+  number_of_replicas = 0
+}
+
+resource "elasticsearch_index_alias" "test" {
+  name           = "terraform-test-alias"
+  indices        = [%q]
+  search_routing = "1"
+  filter         = <<EOF
+{
+  "term": {
+    "user": "kimchy"
+  }
+}
+EOF
+
+  depends_on = [elasticsearch_index.test_1, elasticsearch_index.test_2]
+}
+`, index)
+}
@@ -0,0 +1,203 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+var indexBlockMinimalVersion, _ = version.NewVersion("7.9.0")
+
+func resourceElasticsearchIndexBlock() *schema.Resource {
+	return &schema.Resource{
+		Description: "Adds a block to existing indices, e.g. to make them read only ahead of a migration, the block is removed on destroy. The blocks are added with the index block API, which waits for the ongoing operations of the indices to complete, except `read_only_allow_delete` which is only a setting. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/index-modules-blocks.html) for more details.",
+		Create:      resourceElasticsearchIndexBlockCreate,
+		Read:        resourceElasticsearchIndexBlockRead,
+		Delete:      resourceElasticsearchIndexBlockDelete,
+		Schema: map[string]*schema.Schema{
+			"index": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "The index, or wildcard expression of indices, to add the block to.",
+			},
+			"block": {
+				Type:         schema.TypeString,
+				ForceNew:     true,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"metadata", "read", "read_only", "read_only_allow_delete", "write"}, false),
+				Description:  "The block to add, one of `metadata`, `read`, `read_only`, `read_only_allow_delete` or `write`.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: resourceElasticsearchIndexBlockImport,
+		},
+	}
+}
+
+func resourceElasticsearchIndexBlockCreate(d *schema.ResourceData, meta interface{}) error {
+	index := d.Get("index").(string)
+	block := d.Get("block").(string)
+
+	if err := elastic7CheckIndexBlockVersion(meta); err != nil {
+		return err
+	}
+
+	// the block API doesn't support read_only_allow_delete, which is usually
+	// added by the disk watermarks
+	if block == "read_only_allow_delete" {
+		if err := elasticsearchPutIndexBlockSetting(meta, index, block, true); err != nil {
+			return err
+		}
+	} else {
+		path, err := uritemplates.Expand("/{index}/_block/{block}", map[string]string{
+			"index": index,
+			"block": block,
+		})
+		if err != nil {
+			return fmt.Errorf("error building URL path for index block: %+v", err)
+		}
+		if _, err := elasticsearchPerformRequest(meta, "PUT", path, nil); err != nil {
+			return err
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", index, block))
+	return resourceElasticsearchIndexBlockRead(d, meta)
+}
+
+func resourceElasticsearchIndexBlockRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+	index, block, err := parseIndexBlockID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := elastic7CheckIndexBlockVersion(meta); err != nil {
+		return err
+	}
+
+	path, err := indexSettingsPath(index)
+	if err != nil {
+		return err
+	}
+	body, err := elasticsearchPerformRequest(meta, "GET", path+"/index.blocks.*?flat_settings=true", nil)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Index block (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	response := make(map[string]IndexSettingsGetResponse)
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("error unmarshalling index settings body: %+v: %+v", err, body)
+	}
+
+	// the block is added again if any of the indices is missing it
+	for name, indexSettings := range response {
+		if fmt.Sprintf("%v", indexSettings.Settings["index.blocks."+block]) != "true" {
+			log.Printf("[WARN] Index block (%s) not found on index %s, removing from state", id, name)
+			d.SetId("")
+			return nil
+		}
+	}
+	if len(response) == 0 {
+		log.Printf("[WARN] Index block (%s) not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("index", index)
+	ds.set("block", block)
+	return ds.err
+}
+
+func resourceElasticsearchIndexBlockDelete(d *schema.ResourceData, meta interface{}) error {
+	index, block, err := parseIndexBlockID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if err := elastic7CheckIndexBlockVersion(meta); err != nil {
+		return err
+	}
+
+	// blocks can only be removed with the settings
+	if err := elasticsearchPutIndexBlockSetting(meta, index, block, false); err != nil {
+		if elastic7.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceElasticsearchIndexBlockImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, _, err := parseIndexBlockID(d.Id()); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// elasticsearchPutIndexBlockSetting sets the setting of the block, null
+// removes the block
+func elasticsearchPutIndexBlockSetting(meta interface{}, index string, block string, enabled bool) error {
+	path, err := indexSettingsPath(index)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"index.blocks." + block: nil,
+	}
+	if enabled {
+		body["index.blocks."+block] = true
+	}
+
+	_, err = elasticsearchPerformRequest(meta, "PUT", path, body)
+	return err
+}
+
+func elastic7CheckIndexBlockVersion(meta interface{}) error {
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+
+	client, ok := esClient.(*elastic7.Client)
+	if !ok {
+		return fmt.Errorf("index blocks only available from ElasticSearch >= 7.9, got version < 7.0.0")
+	}
+
+	elasticVersion, err := elastic7GetCompatibleVersion(meta, client)
+	if err != nil {
+		return err
+	}
+	if elasticVersion.LessThan(indexBlockMinimalVersion) {
+		return fmt.Errorf("index blocks only available from ElasticSearch >= 7.9, got version %s", elasticVersion.String())
+	}
+	return nil
+}
+
+func parseIndexBlockID(id string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("unexpected format of ID (%s), expected index/block", id)
+	}
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,135 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchIndexBlock(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		elasticVersion, err := elastic7GetVersion(client)
+		if err != nil {
+			t.Skipf("err: %s", err)
+		}
+		allowed = !elasticVersion.LessThan(indexBlockMinimalVersion)
+	default:
+		allowed = false
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Index blocks only supported on ES >= 7.9")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckElasticsearchIndexBlockDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndexBlock,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchIndexBlock("terraform-test-block", "write", "true"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_index_block.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchIndexBlock(index string, block string, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		path, err := indexSettingsPath(index)
+		if err != nil {
+			return err
+		}
+		body, err := elasticsearchPerformRequest(testAccProvider.Meta(), "GET", path+"?flat_settings=true", nil)
+		if err != nil {
+			return err
+		}
+
+		response := make(map[string]IndexSettingsGetResponse)
+		if err := json.Unmarshal(body, &response); err != nil {
+			return err
+		}
+		for name, indexSettings := range response {
+			if value := fmt.Sprintf("%v", indexSettings.Settings["index.blocks."+block]); value != expected {
+				return fmt.Errorf("Index %s has block %s %q, expected %q", name, block, value, expected)
+			}
+		}
+		return nil
+	}
+}
+
+func testCheckElasticsearchIndexBlockDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_index_block" {
+			continue
+		}
+
+		index, block, err := parseIndexBlockID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		path, err := indexSettingsPath(index)
+		if err != nil {
+			return err
+		}
+		body, err := elasticsearchPerformRequest(testAccProvider.Meta(), "GET", path+"?flat_settings=true", nil)
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		response := make(map[string]IndexSettingsGetResponse)
+		if err := json.Unmarshal(body, &response); err != nil {
+			return err
+		}
+		for name, indexSettings := range response {
+			if _, ok := indexSettings.Settings["index.blocks."+block]; ok {
+				return fmt.Errorf("Index %s still has block %s", name, block)
+			}
+		}
+	}
+
+	return nil
+}
+
+var testAccElasticsearchIndexBlock = `
+resource "elasticsearch_index" "test" {
+  name               = "terraform-test-block"
+  number_of_shards   = 1
+  number_of_replicas = 0
+
+  lifecycle {
+    ignore_changes = all
+  }
+}
+
+resource "elasticsearch_index_block" "test" {
+  index = elasticsearch_index.test.name
+  block = "write"
+}
+`
@@ -0,0 +1,211 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+const (
+	indexLifecycleNameSetting          = "index.lifecycle.name"
+	indexLifecycleRolloverAliasSetting = "index.lifecycle.rollover_alias"
+)
+
+func resourceElasticsearchIndexLifecycleAttachment() *schema.Resource {
+	return &schema.Resource{
+		Description: "Attaches an index lifecycle management policy to existing indices which are not otherwise managed, by setting `index.lifecycle.name` and `index.lifecycle.rollover_alias`. Only these settings are removed on destroy.",
+		Create:      resourceElasticsearchIndexLifecycleAttachmentCreate,
+		Read:        resourceElasticsearchIndexLifecycleAttachmentRead,
+		Update:      resourceElasticsearchIndexLifecycleAttachmentUpdate,
+		Delete:      resourceElasticsearchIndexLifecycleAttachmentDelete,
+		Schema: map[string]*schema.Schema{
+			"index": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "The index, or wildcard expression of indices, to attach the policy to, e.g. `logs-*`.",
+			},
+			"policy_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the index lifecycle policy, set as `index.lifecycle.name`.",
+			},
+			"rollover_alias": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The alias rolled over by the rollover action of the policy, set as `index.lifecycle.rollover_alias`.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchIndexLifecycleAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	index := d.Get("index").(string)
+
+	if err := resourceElasticsearchPutIndexLifecycleAttachment(index, d.Get("policy_name").(string), d.Get("rollover_alias").(string), meta); err != nil {
+		return err
+	}
+
+	d.SetId(index)
+	return resourceElasticsearchIndexLifecycleAttachmentRead(d, meta)
+}
+
+func resourceElasticsearchIndexLifecycleAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	index := d.Id()
+
+	settings, err := elasticsearchGetIndexLifecycleSettings(meta, index)
+	if err != nil {
+		if elastic7.IsNotFound(err) || elastic6.IsNotFound(err) || elastic5.IsNotFound(err) {
+			log.Printf("[WARN] Index lifecycle attachment (%s) not found, removing from state", index)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	// the indices matching the pattern may differ, so a value differing
+	// from the configured one is read back to show the drift
+	configuredPolicyName := d.Get("policy_name").(string)
+	configuredRolloverAlias := d.Get("rollover_alias").(string)
+	var policyName, rolloverAlias string
+	found := false
+	for _, name := range sortedIndexNames(settings) {
+		value, ok := settings[name][indexLifecycleNameSetting]
+		if !ok {
+			continue
+		}
+		if !found || value != configuredPolicyName {
+			policyName = value
+		}
+		if alias := settings[name][indexLifecycleRolloverAliasSetting]; !found || alias != configuredRolloverAlias {
+			rolloverAlias = alias
+		}
+		found = true
+	}
+	if !found {
+		log.Printf("[WARN] Index lifecycle attachment (%s) not found, removing from state", index)
+		d.SetId("")
+		return nil
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("index", index)
+	ds.set("policy_name", policyName)
+	ds.set("rollover_alias", rolloverAlias)
+	return ds.err
+}
+
+func resourceElasticsearchIndexLifecycleAttachmentUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := resourceElasticsearchPutIndexLifecycleAttachment(d.Id(), d.Get("policy_name").(string), d.Get("rollover_alias").(string), meta); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchIndexLifecycleAttachmentRead(d, meta)
+}
+
+func resourceElasticsearchIndexLifecycleAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	// null removes the settings, leaving all other settings of the indices
+	if err := resourceElasticsearchPutIndexLifecycleAttachment(d.Id(), "", "", meta); err != nil {
+		if elastic7.IsNotFound(err) || elastic6.IsNotFound(err) || elastic5.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceElasticsearchPutIndexLifecycleAttachment sets the lifecycle settings
+// of the indices, empty values remove the setting
+func resourceElasticsearchPutIndexLifecycleAttachment(index string, policyName string, rolloverAlias string, meta interface{}) error {
+	if err := elasticsearchCheckIlmSupported(meta); err != nil {
+		return err
+	}
+
+	path, err := indexSettingsPath(index)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		indexLifecycleNameSetting:          nil,
+		indexLifecycleRolloverAliasSetting: nil,
+	}
+	if policyName != "" {
+		body[indexLifecycleNameSetting] = policyName
+	}
+	if rolloverAlias != "" {
+		body[indexLifecycleRolloverAliasSetting] = rolloverAlias
+	}
+
+	_, err = elasticsearchPerformRequest(meta, "PUT", path, body)
+	return err
+}
+
+// elasticsearchGetIndexLifecycleSettings returns the lifecycle settings of
+// the indices matching the pattern, keyed by the name of the index
+func elasticsearchGetIndexLifecycleSettings(meta interface{}, index string) (map[string]map[string]string, error) {
+	if err := elasticsearchCheckIlmSupported(meta); err != nil {
+		return nil, err
+	}
+
+	path, err := indexSettingsPath(index)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := elasticsearchPerformRequest(meta, "GET", path+"/index.lifecycle.*?flat_settings=true", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make(map[string]IndexSettingsGetResponse)
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling index settings body: %+v: %+v", err, body)
+	}
+
+	settings := make(map[string]map[string]string)
+	for name, indexSettings := range response {
+		settings[name] = make(map[string]string)
+		for key, value := range indexSettings.Settings {
+			settings[name][key] = fmt.Sprintf("%v", value)
+		}
+	}
+	return settings, nil
+}
+
+func indexSettingsPath(index string) (string, error) {
+	path, err := uritemplates.Expand("/{index}/_settings", map[string]string{
+		"index": index,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for index settings: %+v", err)
+	}
+	return path, nil
+}
+
+func sortedIndexNames(settings map[string]map[string]string) []string {
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type IndexSettingsGetResponse struct {
+	Settings map[string]interface{} `json:"settings"`
+}
@@ -0,0 +1,139 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic5 "gopkg.in/olivere/elastic.v5"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchIndexLifecycleAttachment(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+
+	_, isElastic5 := esClient.(*elastic5.Client)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if isElastic5 {
+				t.Skip("Index lifecycles only supported on ES >= 6")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchIndexLifecycleAttachmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndexLifecycleAttachment("terraform-test-rollover"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchIndexLifecycleAttachment("terraform-test-lifecycle-*", "terraform-test-attachment", "terraform-test-rollover"),
+					resource.TestCheckResourceAttr("elasticsearch_index_lifecycle_attachment.test", "policy_name", "terraform-test-attachment"),
+				),
+			},
+			{
+				Config: testAccElasticsearchIndexLifecycleAttachment("terraform-test-rollover-2"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchIndexLifecycleAttachment("terraform-test-lifecycle-*", "terraform-test-attachment", "terraform-test-rollover-2"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_index_lifecycle_attachment.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchIndexLifecycleAttachment(index string, policyName string, rolloverAlias string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		settings, err := elasticsearchGetIndexLifecycleSettings(testAccXPackProvider.Meta(), index)
+		if err != nil {
+			return err
+		}
+		if len(settings) == 0 {
+			return fmt.Errorf("No indices match %s", index)
+		}
+
+		for name, indexSettings := range settings {
+			if value := indexSettings[indexLifecycleNameSetting]; value != policyName {
+				return fmt.Errorf("Index %s has lifecycle policy %q, expected %q", name, value, policyName)
+			}
+			if value := indexSettings[indexLifecycleRolloverAliasSetting]; value != rolloverAlias {
+				return fmt.Errorf("Index %s has rollover alias %q, expected %q", name, value, rolloverAlias)
+			}
+		}
+		return nil
+	}
+}
+
+func testCheckElasticsearchIndexLifecycleAttachmentDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_index_lifecycle_attachment" {
+			continue
+		}
+
+		settings, err := elasticsearchGetIndexLifecycleSettings(testAccXPackProvider.Meta(), rs.Primary.ID)
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		for name, indexSettings := range settings {
+			if value, ok := indexSettings[indexLifecycleNameSetting]; ok {
+				return fmt.Errorf("Index %s still has lifecycle policy %q", name, value)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccElasticsearchIndexLifecycleAttachment(rolloverAlias string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_index" "test_1" {
+  name               = "terraform-test-lifecycle-1"
+  number_of_shards   = 1
+  number_of_replicas = 0
+}
+
+resource "elasticsearch_index" "test_2" {
+  name               = "terraform-test-lifecycle-2"
+  number_of_shards   = 1
+  number_of_replicas = 0
+}
+
+resource "elasticsearch_xpack_ilm_policy" "test" {
+  name = "terraform-test-attachment"
+
+  hot {
+    rollover {
+      max_age = "7d"
+    }
+  }
+}
+
+resource "elasticsearch_index_lifecycle_attachment" "test" {
+  index          = "terraform-test-lifecycle-*"
+  policy_name    = elasticsearch_xpack_ilm_policy.test.name
+  rollover_alias = "%s"
+
+  depends_on = [
+    elasticsearch_index.test_1,
+    elasticsearch_index.test_2,
+  ]
+}
+`, rolloverAlias)
+}
@@ -0,0 +1,238 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+func resourceElasticsearchIndexRolloverBootstrap() *schema.Resource {
+	return &schema.Resource{
+		Description: "Bootstraps the indices rolled over by an index lifecycle or state management policy, by creating the initial index with the rollover alias as its write alias. The settings and mappings of the indices, including the policy, come from the index template matching them. The indices are kept on destroy unless `delete_indices_on_destroy` is set.",
+		Create:      resourceElasticsearchIndexRolloverBootstrapCreate,
+		Read:        resourceElasticsearchIndexRolloverBootstrapRead,
+		Update:      resourceElasticsearchIndexRolloverBootstrapUpdate,
+		Delete:      resourceElasticsearchIndexRolloverBootstrapDelete,
+		Schema: map[string]*schema.Schema{
+			"alias": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "The rollover alias, pointing to the write index receiving the writes.",
+			},
+			"initial_index": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Optional:    true,
+				Description: "Name of the initial index, which must end with a number incremented by the rollovers, e.g. `<logs-{now/d}-000001>` for date math. Defaults to `<alias>-000001`.",
+			},
+			"template": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Optional:    true,
+				Description: "Name of the composable or legacy index template expected to match the indices, which must exist before the initial index is created.",
+			},
+			"delete_indices_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether destroying the resource deletes all the indices of the alias, including their documents.",
+			},
+			"bootstrap_index": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the initial index created, with the date math resolved.",
+			},
+			"write_index": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the current write index of the alias.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchIndexRolloverBootstrapCreate(d *schema.ResourceData, meta interface{}) error {
+	alias := d.Get("alias").(string)
+
+	if template, ok := d.GetOk("template"); ok {
+		if err := elasticsearchCheckIndexTemplateExists(meta, template.(string)); err != nil {
+			return err
+		}
+	}
+
+	initialIndex := fmt.Sprintf("%s-000001", alias)
+	if index, ok := d.GetOk("initial_index"); ok {
+		initialIndex = index.(string)
+	}
+
+	// the name is escaped, as date math has to be
+	path, err := uritemplates.Expand("/{index}", map[string]string{
+		"index": initialIndex,
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for index: %+v", err)
+	}
+	body := map[string]interface{}{
+		"aliases": map[string]interface{}{
+			alias: map[string]interface{}{
+				"is_write_index": true,
+			},
+		},
+	}
+	res, err := elasticsearchPerformRequest(meta, "PUT", path, body)
+	if err != nil {
+		return err
+	}
+
+	response := new(IndexRolloverBootstrapCreateResponse)
+	if err := json.Unmarshal(res, response); err != nil {
+		return fmt.Errorf("error unmarshalling create index body: %+v: %+v", err, res)
+	}
+
+	d.SetId(alias)
+	if err := d.Set("bootstrap_index", response.Index); err != nil {
+		return err
+	}
+	return resourceElasticsearchIndexRolloverBootstrapRead(d, meta)
+}
+
+func resourceElasticsearchIndexRolloverBootstrapRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	indices, err := elasticsearchGetAliasIndices(meta, id)
+	if err != nil {
+		if elastic7.IsNotFound(err) || elastic6.IsNotFound(err) || elastic5.IsNotFound(err) {
+			log.Printf("[WARN] Rollover alias (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	writeIndex := ""
+	for index, alias := range indices {
+		if alias.IsWriteIndex {
+			writeIndex = index
+		}
+	}
+	if writeIndex == "" {
+		log.Printf("[WARN] Rollover alias (%s) has no write index, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("alias", id)
+	ds.set("write_index", writeIndex)
+	return ds.err
+}
+
+func resourceElasticsearchIndexRolloverBootstrapUpdate(d *schema.ResourceData, meta interface{}) error {
+	// only delete_indices_on_destroy can change, which is only used on destroy
+	return resourceElasticsearchIndexRolloverBootstrapRead(d, meta)
+}
+
+func resourceElasticsearchIndexRolloverBootstrapDelete(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	if !d.Get("delete_indices_on_destroy").(bool) {
+		log.Printf("[INFO] Keeping the indices of rollover alias %s", id)
+		d.SetId("")
+		return nil
+	}
+
+	indices, err := elasticsearchGetAliasIndices(meta, id)
+	if err != nil {
+		if elastic7.IsNotFound(err) || elastic6.IsNotFound(err) || elastic5.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	for index := range indices {
+		path, err := uritemplates.Expand("/{index}", map[string]string{
+			"index": index,
+		})
+		if err != nil {
+			return fmt.Errorf("error building URL path for index: %+v", err)
+		}
+		if _, err := elasticsearchPerformRequest(meta, "DELETE", path, nil); err != nil {
+			return fmt.Errorf("error deleting index %s of rollover alias %s: %+v", index, id, err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// elasticsearchGetAliasIndices returns the indices of the alias, with the
+// alias as configured on each of them
+func elasticsearchGetAliasIndices(meta interface{}, alias string) (map[string]IndexRolloverBootstrapAlias, error) {
+	path, err := uritemplates.Expand("/_alias/{alias}", map[string]string{
+		"alias": alias,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error building URL path for alias: %+v", err)
+	}
+
+	body, err := elasticsearchPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make(map[string]struct {
+		Aliases map[string]IndexRolloverBootstrapAlias `json:"aliases"`
+	})
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling alias body: %+v: %+v", err, body)
+	}
+
+	indices := make(map[string]IndexRolloverBootstrapAlias)
+	for index, indexAliases := range response {
+		if indexAlias, ok := indexAliases.Aliases[alias]; ok {
+			indices[index] = indexAlias
+		}
+	}
+	return indices, nil
+}
+
+// elasticsearchCheckIndexTemplateExists checks the composable index template
+// exists, or the legacy one
+func elasticsearchCheckIndexTemplateExists(meta interface{}, name string) error {
+	for _, template := range []string{"/_index_template/{name}", "/_template/{name}"} {
+		path, err := uritemplates.Expand(template, map[string]string{
+			"name": name,
+		})
+		if err != nil {
+			return fmt.Errorf("error building URL path for index template: %+v", err)
+		}
+
+		_, err = elasticsearchPerformRequest(meta, "GET", path, nil)
+		if err == nil {
+			return nil
+		}
+		if !(elastic7.IsNotFound(err) || elastic6.IsNotFound(err) || elastic5.IsNotFound(err)) {
+			log.Printf("[INFO] elasticsearchCheckIndexTemplateExists: %+v", err)
+		}
+	}
+	return fmt.Errorf("index template %s not found, it must exist before bootstrapping the indices", name)
+}
+
+type IndexRolloverBootstrapCreateResponse struct {
+	Index string `json:"index"`
+}
+
+type IndexRolloverBootstrapAlias struct {
+	IsWriteIndex bool `json:"is_write_index"`
+}
@@ -0,0 +1,118 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic5 "gopkg.in/olivere/elastic.v5"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchIndexRolloverBootstrap(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+
+	_, isElastic5 := esClient.(*elastic5.Client)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if isElastic5 {
+				t.Skip("Write indices only supported on ES >= 6")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckElasticsearchIndexRolloverBootstrapDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndexRolloverBootstrap,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchIndexRolloverBootstrapExists("elasticsearch_index_rollover_bootstrap.test"),
+					resource.TestCheckResourceAttr("elasticsearch_index_rollover_bootstrap.test", "bootstrap_index", "terraform-test-bootstrap-000001"),
+					resource.TestCheckResourceAttr("elasticsearch_index_rollover_bootstrap.test", "write_index", "terraform-test-bootstrap-000001"),
+				),
+			},
+			{
+				ResourceName:            "elasticsearch_index_rollover_bootstrap.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"bootstrap_index", "template", "delete_indices_on_destroy"},
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchIndexRolloverBootstrapExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No rollover alias ID is set")
+		}
+
+		indices, err := elasticsearchGetAliasIndices(testAccProvider.Meta(), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		for index, alias := range indices {
+			if alias.IsWriteIndex {
+				return nil
+			}
+			return fmt.Errorf("Index %s is not the write index of %s", index, rs.Primary.ID)
+		}
+		return fmt.Errorf("Rollover alias %s has no indices", rs.Primary.ID)
+	}
+}
+
+func testCheckElasticsearchIndexRolloverBootstrapDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_index_rollover_bootstrap" {
+			continue
+		}
+
+		if _, err := elasticsearchGetAliasIndices(testAccProvider.Meta(), rs.Primary.ID); err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Rollover alias %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+var testAccElasticsearchIndexRolloverBootstrap = `
+resource "elasticsearch_index_template" "test" {
+  name = "terraform-test-bootstrap"
+  body = <<EOF
+{
+  "index_patterns": ["terraform-test-bootstrap-*"],
+  "settings": {
+    "index": {
+      "number_of_shards": 1,
+      "number_of_replicas": 0
+    }
+  }
+}
+EOF
+}
+
+resource "elasticsearch_index_rollover_bootstrap" "test" {
+  alias                     = "terraform-test-bootstrap"
+  template                  = elasticsearch_index_template.test.name
+  delete_indices_on_destroy = true
+}
+`
@@ -0,0 +1,229 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+func resourceElasticsearchIndexSettings() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages dynamic settings of existing indices which are not otherwise managed, e.g. `index.number_of_replicas` or `index.refresh_interval`. Only the settings in `settings` are managed, settings which are no longer managed, including on destroy, are restored to the value they had before on each index, or reset to their default if they had none.",
+		Create:      resourceElasticsearchIndexSettingsCreate,
+		Read:        resourceElasticsearchIndexSettingsRead,
+		Update:      resourceElasticsearchIndexSettingsUpdate,
+		Delete:      resourceElasticsearchIndexSettingsDelete,
+		Schema: map[string]*schema.Schema{
+			"index": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "The index, or wildcard expression of indices, to manage the settings of, e.g. `logs-*`.",
+			},
+			"settings": {
+				Type:        schema.TypeMap,
+				Required:    true,
+				Description: "The dynamic index settings to manage, keyed by the flat name of the setting, e.g. `index.search.slowlog.threshold.query.warn`.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"previous_settings": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The values of the managed settings before they were first managed by this resource, keyed by the name of the index with a JSON string of the settings, restored when they are no longer managed.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceElasticsearchIndexSettingsCreate(d *schema.ResourceData, meta interface{}) error {
+	index := d.Get("index").(string)
+
+	if err := resourceElasticsearchPutIndexSettings(d, meta, index, false); err != nil {
+		return err
+	}
+
+	d.SetId(index)
+	return resourceElasticsearchIndexSettingsRead(d, meta)
+}
+
+func resourceElasticsearchIndexSettingsRead(d *schema.ResourceData, meta interface{}) error {
+	index := d.Id()
+
+	current, err := elasticsearchGetIndexSettings(meta, index)
+	if err != nil {
+		if elastic7.IsNotFound(err) || elastic6.IsNotFound(err) || elastic5.IsNotFound(err) {
+			log.Printf("[WARN] Index settings (%s) not found, removing from state", index)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	// the indices matching the pattern may differ, so a value differing
+	// from the configured one is read back to show the drift, and a setting
+	// missing from an index is a diff to be applied again
+	settings := make(map[string]interface{})
+	for key, configured := range d.Get("settings").(map[string]interface{}) {
+		value, found := configured, true
+		for _, name := range sortedIndexNames(current) {
+			v, ok := current[name][key]
+			if !ok {
+				found = false
+				break
+			}
+			if v != configured {
+				value = v
+				break
+			}
+		}
+		if found {
+			settings[key] = value
+		}
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("index", index)
+	ds.set("settings", settings)
+	return ds.err
+}
+
+func resourceElasticsearchIndexSettingsUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := resourceElasticsearchPutIndexSettings(d, meta, d.Id(), false); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchIndexSettingsRead(d, meta)
+}
+
+func resourceElasticsearchIndexSettingsDelete(d *schema.ResourceData, meta interface{}) error {
+	if err := resourceElasticsearchPutIndexSettings(d, meta, d.Id(), true); err != nil {
+		if elastic7.IsNotFound(err) || elastic6.IsNotFound(err) || elastic5.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceElasticsearchPutIndexSettings applies the change of the managed
+// settings to each index, capturing the value of newly managed settings, and
+// of all of them for newly matching indices, and restoring the value of
+// settings which are no longer managed, or all of them on destroy
+func resourceElasticsearchPutIndexSettings(d *schema.ResourceData, meta interface{}, index string, destroy bool) error {
+	current, err := elasticsearchGetIndexSettings(meta, index)
+	if err != nil {
+		return err
+	}
+
+	o, n := d.GetChange("settings")
+	oldSettings := o.(map[string]interface{})
+	newSettings := n.(map[string]interface{})
+	if destroy {
+		newSettings = map[string]interface{}{}
+	}
+
+	previous := make(map[string]map[string]interface{})
+	for name, value := range d.Get("previous_settings").(map[string]interface{}) {
+		indexPrevious := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(value.(string)), &indexPrevious); err != nil {
+			return fmt.Errorf("error unmarshalling previous settings of index %s: %+v", name, err)
+		}
+		previous[name] = indexPrevious
+	}
+
+	// indices which no longer match are forgotten
+	flattenedPrevious := make(map[string]interface{})
+	for _, name := range sortedIndexNames(current) {
+		indexPrevious, known := previous[name]
+		if !known {
+			indexPrevious = make(map[string]interface{})
+		}
+
+		settings := make(map[string]interface{})
+		for key, value := range newSettings {
+			if _, ok := oldSettings[key]; !ok || !known {
+				if value, ok := current[name][key]; ok {
+					indexPrevious[key] = value
+				}
+			}
+			settings[key] = value
+		}
+		for key := range oldSettings {
+			// the settings of newly matching indices were never changed
+			if _, ok := newSettings[key]; ok || !known {
+				continue
+			}
+			// null resets the setting to its default
+			if value, ok := indexPrevious[key]; ok {
+				settings[key] = value
+			} else {
+				settings[key] = nil
+			}
+			delete(indexPrevious, key)
+		}
+
+		if len(settings) > 0 {
+			path, err := indexSettingsPath(name)
+			if err != nil {
+				return err
+			}
+			if _, err := elasticsearchPerformRequest(meta, "PUT", path, settings); err != nil {
+				return fmt.Errorf("error updating settings of index %s: %+v", name, err)
+			}
+		}
+
+		value, err := json.Marshal(indexPrevious)
+		if err != nil {
+			return err
+		}
+		flattenedPrevious[name] = string(value)
+	}
+
+	if destroy {
+		return nil
+	}
+	return d.Set("previous_settings", flattenedPrevious)
+}
+
+// elasticsearchGetIndexSettings returns the settings of the indices matching
+// the pattern by their flat name, keyed by the name of the index, without
+// the defaults
+func elasticsearchGetIndexSettings(meta interface{}, index string) (map[string]map[string]string, error) {
+	path, err := indexSettingsPath(index)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := elasticsearchPerformRequest(meta, "GET", path+"?flat_settings=true", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make(map[string]IndexSettingsGetResponse)
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling index settings body: %+v: %+v", err, body)
+	}
+
+	settings := make(map[string]map[string]string)
+	for name, indexSettings := range response {
+		settings[name] = make(map[string]string)
+		for key, value := range indexSettings.Settings {
+			settings[name][key] = clusterSettingValue(value)
+		}
+	}
+	return settings, nil
+}
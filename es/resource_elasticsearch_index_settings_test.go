@@ -0,0 +1,149 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchIndexSettings(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckElasticsearchIndexSettingsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndexSettings,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchIndexSettings("terraform-test-settings-*", "index.refresh_interval", "10s"),
+					resource.TestCheckResourceAttr("elasticsearch_index_settings.test", "settings.index.refresh_interval", "10s"),
+				),
+			},
+			{
+				Config: testAccElasticsearchIndexSettingsUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchIndexSettings("terraform-test-settings-*", "index.refresh_interval", "30s"),
+					testCheckElasticsearchIndexSettings("terraform-test-settings-*", "index.search.slowlog.threshold.query.warn", "5s"),
+				),
+			},
+			{
+				Config: testAccElasticsearchIndexSettingsRemoved,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchIndexSettings("terraform-test-settings-*", "index.refresh_interval", ""),
+					testCheckElasticsearchIndexSettings("terraform-test-settings-*", "index.search.slowlog.threshold.query.warn", "5s"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchIndexSettings(index string, key string, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		settings, err := elasticsearchGetIndexSettings(testAccProvider.Meta(), index)
+		if err != nil {
+			return err
+		}
+		if len(settings) == 0 {
+			return fmt.Errorf("No indices match %s", index)
+		}
+
+		for name, indexSettings := range settings {
+			if value := indexSettings[key]; value != expected {
+				return fmt.Errorf("Index %s has %s %q, expected %q", name, key, value, expected)
+			}
+		}
+		return nil
+	}
+}
+
+func testCheckElasticsearchIndexSettingsDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_index_settings" {
+			continue
+		}
+
+		settings, err := elasticsearchGetIndexSettings(testAccProvider.Meta(), rs.Primary.ID)
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		for name, indexSettings := range settings {
+			if value, ok := indexSettings["index.search.slowlog.threshold.query.warn"]; ok {
+				return fmt.Errorf("Index %s still has slowlog threshold %q", name, value)
+			}
+		}
+	}
+
+	return nil
+}
+
+// the indices stand for indices created outside of terraform, their
+// settings are managed by elasticsearch_index_settings
+var testAccElasticsearchIndexSettingsIndices = `
+resource "elasticsearch_index" "test_1" {
+  name               = "terraform-test-settings-1"
+  number_of_shards   = 1
+  number_of_replicas = 0
+
+  lifecycle {
+    ignore_changes = all
+  }
+}
+
+resource "elasticsearch_index" "test_2" {
+  name               = "terraform-test-settings-2"
+  number_of_shards   = 1
+  number_of_replicas = 0
+
+  lifecycle {
+    ignore_changes = all
+  }
+}
+`
+
+var testAccElasticsearchIndexSettings = testAccElasticsearchIndexSettingsIndices + `
+resource "elasticsearch_index_settings" "test" {
+  index = "terraform-test-settings-*"
+  settings = {
+    "index.refresh_interval" = "10s"
+  }
+
+  depends_on = [
+    elasticsearch_index.test_1,
+    elasticsearch_index.test_2,
+  ]
+}
+`
+
+var testAccElasticsearchIndexSettingsUpdate = testAccElasticsearchIndexSettingsIndices + `
+resource "elasticsearch_index_settings" "test" {
+  index = "terraform-test-settings-*"
+  settings = {
+    "index.refresh_interval"                    = "30s"
+    "index.search.slowlog.threshold.query.warn" = "5s"
+  }
+
+  depends_on = [
+    elasticsearch_index.test_1,
+    elasticsearch_index.test_2,
+  ]
+}
+`
+
+var testAccElasticsearchIndexSettingsRemoved = testAccElasticsearchIndexSettingsIndices + `
+resource "elasticsearch_index_settings" "test" {
+  index = "terraform-test-settings-*"
+  settings = {
+    "index.search.slowlog.threshold.query.warn" = "5s"
+  }
+
+  depends_on = [
+    elasticsearch_index.test_1,
+    elasticsearch_index.test_2,
+  ]
+}
+`
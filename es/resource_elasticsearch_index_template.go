@@ -3,6 +3,7 @@ package es
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -18,6 +19,8 @@ func resourceElasticsearchIndexTemplate() *schema.Resource {
 		Read:   resourceElasticsearchIndexTemplateRead,
 		Update: resourceElasticsearchIndexTemplateUpdate,
 		Delete: resourceElasticsearchIndexTemplateDelete,
+		// the policy of the template is checked on the cluster when planning
+		CustomizeDiff: resourceElasticsearchIndexTemplateCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -37,6 +40,59 @@ func resourceElasticsearchIndexTemplate() *schema.Resource {
 	}
 }
 
+func resourceElasticsearchIndexTemplateCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	conf := meta.(*ProviderConf)
+	if !d.NewValueKnown("body") || conf.offline || clusterFlavor(conf) == flavorOpenSearch {
+		return nil
+	}
+
+	return checkIndexTemplateRolloverAlias(d.Get("body").(string), func(name string) (*IlmPolicy, error) {
+		return elasticsearchGetXpackIlmPolicy(meta, name)
+	})
+}
+
+// checkIndexTemplateRolloverAlias fails a template attaching an ILM policy
+// with a rollover action without setting index.lifecycle.rollover_alias, the
+// rollover of the indices created from the template would fail
+func checkIndexTemplateRolloverAlias(body string, getPolicy func(string) (*IlmPolicy, error)) error {
+	var tpl struct {
+		Settings map[string]interface{} `json:"settings"`
+	}
+	// invalid JSON is reported by validation.StringIsJSON
+	if err := json.Unmarshal([]byte(body), &tpl); err != nil {
+		return nil
+	}
+
+	settings := normalizedIndexSettings(tpl.Settings)
+	name, ok := settings["index.lifecycle.name"].(string)
+	if !ok {
+		return nil
+	}
+	if _, ok := settings["index.lifecycle.rollover_alias"]; ok {
+		return nil
+	}
+
+	policy, err := getPolicy(name)
+	if err != nil {
+		// e.g. the policy is created in the same apply
+		log.Printf("[WARN] Not checking the rollover alias of the index template, failed to get index lifecycle policy %s: %+v", name, err)
+		return nil
+	}
+	if policy == nil || !ilmPolicyRollsOver(policy) {
+		return nil
+	}
+	return fmt.Errorf("index.lifecycle.name is set to %s without index.lifecycle.rollover_alias, the rollover action of the policy will fail for indices created from this template", name)
+}
+
+func ilmPolicyRollsOver(policy *IlmPolicy) bool {
+	for _, phase := range policy.Phases {
+		if _, ok := phase.Actions["rollover"]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 func resourceElasticsearchIndexTemplateCreate(d *schema.ResourceData, meta interface{}) error {
 	err := resourceElasticsearchPutIndexTemplate(d, meta, true)
 	if err != nil {
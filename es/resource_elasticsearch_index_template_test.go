@@ -3,6 +3,7 @@ package es
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	elastic7 "github.com/olivere/elastic/v7"
@@ -14,6 +15,36 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 )
 
+func TestCheckIndexTemplateRolloverAlias(t *testing.T) {
+	policies := map[string]*IlmPolicy{
+		"rollover": {Phases: map[string]IlmPolicyPhase{
+			"hot": {Actions: map[string]map[string]interface{}{"rollover": {"max_size": "50gb"}}},
+		}},
+		"delete": {Phases: map[string]IlmPolicyPhase{
+			"delete": {MinAge: "30d", Actions: map[string]map[string]interface{}{"delete": {}}},
+		}},
+	}
+	getPolicy := func(name string) (*IlmPolicy, error) {
+		return policies[name], nil
+	}
+
+	err := checkIndexTemplateRolloverAlias(`{"settings": {"index": {"lifecycle": {"name": "rollover"}}}}`, getPolicy)
+	if err == nil || !strings.Contains(err.Error(), "without index.lifecycle.rollover_alias") {
+		t.Errorf("expected a rollover policy without rollover alias to fail, got: %v", err)
+	}
+
+	for _, body := range []string{
+		`{"settings": {"index.lifecycle.name": "rollover", "index.lifecycle.rollover_alias": "logs"}}`,
+		`{"settings": {"index.lifecycle.name": "delete"}}`,
+		`{"settings": {"index.lifecycle.name": "missing"}}`,
+		`{"settings": {"index.number_of_shards": 1}}`,
+	} {
+		if err := checkIndexTemplateRolloverAlias(body, getPolicy); err != nil {
+			t.Errorf("expected %s to pass, got: %v", body, err)
+		}
+	}
+}
+
 func TestAccElasticsearchIndexTemplate(t *testing.T) {
 	provider := Provider().(*schema.Provider)
 	err := provider.Configure(&terraform.ResourceConfig{})
@@ -17,6 +17,26 @@ import (
 )
 
 const (
+	testAccElasticsearchIndexWaitForActiveShards = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 0
+  wait_for_active_shards = "all"
+}
+`
+	testAccElasticsearchIndexWaitForActiveShardsTimeout = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  wait_for_active_shards = "all"
+
+  timeouts {
+    create = "2s"
+  }
+}
+`
 	testAccElasticsearchIndex = `
 resource "elasticsearch_index" "test" {
   name = "terraform-test"
@@ -116,6 +136,25 @@ resource "elasticsearch_index" "test" {
   number_of_replicas = 2
   force_destroy = true
 }
+`
+	testAccElasticsearchIndexMappingsAliases = `
+resource "elasticsearch_index" "test" {
+  name = "terraform-test"
+  number_of_shards = 1
+  number_of_replicas = 1
+  mappings = jsonencode({
+    properties = {
+      email = {
+        type = "keyword"
+      }
+    }
+  })
+  aliases = jsonencode({
+    terraform-test-alias = {
+      is_write_index = true
+    }
+  })
+}
 `
 	testAccElasticsearchIndexDateMath = `
 resource "elasticsearch_index" "test_date_math" {
@@ -264,6 +303,38 @@ func TestAccElasticsearchIndex(t *testing.T) {
 	})
 }
 
+func TestAccElasticsearchIndex_waitForActiveShards(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndexWaitForActiveShards,
+				Check: resource.ComposeTestCheckFunc(
+					checkElasticsearchIndexExists("elasticsearch_index.test"),
+					resource.TestCheckResourceAttr("elasticsearch_index.test", "wait_for_active_shards", "all"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchIndex_waitForActiveShardsTimeout(t *testing.T) {
+	// the test cluster is a single node, so replicas are never assigned
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccElasticsearchIndexWaitForActiveShardsTimeout,
+				ExpectError: regexp.MustCompile("shard copies were not active within the create timeout"),
+			},
+		},
+	})
+}
+
 func TestAccElasticsearchIndexAnalysis(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -339,6 +410,52 @@ func TestAccElasticsearchIndex_importBasic(t *testing.T) {
 	})
 }
 
+func TestAccElasticsearchIndex_importMappingsAliases(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch esClient.(type) {
+	case *elastic7.Client:
+		allowed = true
+	default:
+		// mappings need a type prior to 7
+		allowed = false
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Typeless mappings only supported on ES >= 7")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: checkElasticsearchIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIndexMappingsAliases,
+			},
+			{
+				ResourceName:      "elasticsearch_index.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					// not returned from the API
+					"force_destroy",
+				},
+			},
+		},
+	})
+}
+
 func TestAccElasticsearchIndex_dateMath(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -3,6 +3,7 @@ package es
 import (
 	"context"
 	"encoding/json"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
@@ -65,6 +66,12 @@ func resourceElasticsearchIngestPipelineRead(d *schema.ResourceData, meta interf
 		result, err = elastic5IngestGetPipeline(elastic5Client, id)
 	}
 	if err != nil {
+		if elastic7.IsNotFound(err) || elastic6.IsNotFound(err) || elastic5.IsNotFound(err) {
+			log.Printf("[WARN] Ingest pipeline (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+
 		return err
 	}
 
@@ -76,7 +83,7 @@ func resourceElasticsearchIngestPipelineRead(d *schema.ResourceData, meta interf
 
 func elastic7IngestGetPipeline(client *elastic7.Client, id string) (string, error) {
 
-	res, err := client.IngestGetPipeline().Pretty(false).Do(context.TODO())
+	res, err := client.IngestGetPipeline(id).Do(context.TODO())
 	if err != nil {
 		return "", err
 	}
@@ -52,6 +52,24 @@ func TestAccElasticsearchIngestPipeline(t *testing.T) {
 	})
 }
 
+func TestAccElasticsearchIngestPipeline_onFailure(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckElasticsearchIngestPipelineDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchIngestPipelineOnFailure,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchIngestPipelineExists("elasticsearch_ingest_pipeline.test"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccElasticsearchIngestPipeline_importBasic(t *testing.T) {
 	provider := Provider().(*schema.Provider)
 	err := provider.Configure(&terraform.ResourceConfig{})
@@ -218,3 +236,29 @@ resource "elasticsearch_ingest_pipeline" "test" {
 EOF
 }
 `
+
+var testAccElasticsearchIngestPipelineOnFailure = `
+resource "elasticsearch_ingest_pipeline" "test" {
+  name = "terraform-test"
+  body = <<EOF
+{
+  "processors" : [
+    {
+      "rename" : {
+        "field": "foo",
+        "target_field": "bar"
+      }
+    }
+  ],
+  "on_failure" : [
+    {
+      "set" : {
+        "field": "error",
+        "value": "{{ _ingest.on_failure_message }}"
+      }
+    }
+  ]
+}
+EOF
+}
+`
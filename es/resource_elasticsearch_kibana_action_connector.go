@@ -0,0 +1,205 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func resourceElasticsearchKibanaActionConnector() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a Kibana connector, e.g. to Slack, email, a webhook, PagerDuty or an index, run by the actions of the alerting rules, requires Kibana >= 7.13. The `secrets` of a connector are never read back from Kibana. Please refer to the Kibana [connectors API documentation](https://www.elastic.co/guide/en/kibana/current/actions-and-connectors-api.html) for details.",
+		Create:      resourceElasticsearchKibanaActionConnectorCreate,
+		Read:        resourceElasticsearchKibanaActionConnectorRead,
+		Update:      resourceElasticsearchKibanaActionConnectorUpdate,
+		Delete:      resourceElasticsearchKibanaActionConnectorDelete,
+		Schema: map[string]*schema.Schema{
+			"connector_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The identifier of the connector, generated if not set.",
+			},
+			"space_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The identifier of the space of the connector, the default space if not set.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The display name of the connector.",
+			},
+			"connector_type_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The type of the connector, e.g. `.slack`, `.email`, `.webhook`, `.pagerduty` or `.index`.",
+			},
+			"config": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "{}",
+				DiffSuppressFunc: suppressEquivalentJson,
+				ValidateFunc:     validation.StringIsJSON,
+				StateFunc: func(v interface{}) string {
+					json, _ := structure.NormalizeJsonString(v)
+					return json
+				},
+				Description: "The JSON settings of the connector type, e.g. the `url` of a webhook or the `index` of an index connector. Only the configured settings are read back.",
+			},
+			"secrets": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				Default:      "{}",
+				ValidateFunc: validation.StringIsJSON,
+				Description:  "The JSON secrets of the connector type, e.g. the `webhookUrl` of a Slack connector or the `password` of an email connector, sent on each update.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: importKibanaSpaceResource,
+		},
+	}
+}
+
+func resourceElasticsearchKibanaActionConnectorCreate(d *schema.ResourceData, meta interface{}) error {
+	connector, err := expandKibanaActionConnector(d)
+	if err != nil {
+		return err
+	}
+	connector.ConnectorTypeID = d.Get("connector_type_id").(string)
+
+	path := "/api/actions/connector"
+	if id, ok := d.GetOk("connector_id"); ok {
+		path, err = uritemplates.Expand("/api/actions/connector/{id}", map[string]string{
+			"id": id.(string),
+		})
+		if err != nil {
+			return fmt.Errorf("error building URL path for Kibana connector: %+v", err)
+		}
+	}
+
+	res, err := kibanaPerformRequest(meta, "POST", kibanaSpacePrefix(d.Get("space_id").(string))+path, connector)
+	if err != nil {
+		log.Printf("[INFO] Failed to create Kibana connector: %+v", err)
+		return err
+	}
+	response := new(kibanaActionConnector)
+	if err := json.Unmarshal(res, response); err != nil {
+		return fmt.Errorf("error unmarshalling Kibana connector body: %+v: %+v", err, res)
+	}
+
+	d.SetId(response.ID)
+	return resourceElasticsearchKibanaActionConnectorRead(d, meta)
+}
+
+func resourceElasticsearchKibanaActionConnectorRead(d *schema.ResourceData, meta interface{}) error {
+	path, err := kibanaActionConnectorPath(d.Get("space_id").(string), d.Id())
+	if err != nil {
+		return err
+	}
+	res, err := kibanaPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Kibana connector (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	connector := new(kibanaActionConnector)
+	if err := json.Unmarshal(res, connector); err != nil {
+		return fmt.Errorf("error unmarshalling Kibana connector body: %+v: %+v", err, res)
+	}
+
+	// only read back the configured settings, on import all of them
+	config := connector.Config
+	var configured map[string]interface{}
+	if err := json.Unmarshal([]byte(d.Get("config").(string)), &configured); err == nil {
+		config = filterJSONObject(connector.Config, configured)
+	}
+	if config == nil {
+		config = make(map[string]interface{})
+	}
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("connector_id", connector.ID)
+	ds.set("name", connector.Name)
+	ds.set("connector_type_id", connector.ConnectorTypeID)
+	ds.set("config", string(configJSON))
+	return ds.err
+}
+
+func resourceElasticsearchKibanaActionConnectorUpdate(d *schema.ResourceData, meta interface{}) error {
+	path, err := kibanaActionConnectorPath(d.Get("space_id").(string), d.Id())
+	if err != nil {
+		return err
+	}
+
+	connector, err := expandKibanaActionConnector(d)
+	if err != nil {
+		return err
+	}
+	if _, err := kibanaPerformRequest(meta, "PUT", path, connector); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchKibanaActionConnectorRead(d, meta)
+}
+
+func resourceElasticsearchKibanaActionConnectorDelete(d *schema.ResourceData, meta interface{}) error {
+	path, err := kibanaActionConnectorPath(d.Get("space_id").(string), d.Id())
+	if err != nil {
+		return err
+	}
+	_, err = kibanaPerformRequest(meta, "DELETE", path, nil)
+	return err
+}
+
+// expandKibanaActionConnector returns the fields of the connector accepted by
+// the update API
+func expandKibanaActionConnector(d *schema.ResourceData) (kibanaActionConnector, error) {
+	connector := kibanaActionConnector{
+		Name: d.Get("name").(string),
+	}
+	if err := json.Unmarshal([]byte(d.Get("config").(string)), &connector.Config); err != nil {
+		return connector, fmt.Errorf("fail to unmarshal config: %v", err)
+	}
+	if err := json.Unmarshal([]byte(d.Get("secrets").(string)), &connector.Secrets); err != nil {
+		return connector, fmt.Errorf("fail to unmarshal secrets: %v", err)
+	}
+	return connector, nil
+}
+
+func kibanaActionConnectorPath(spaceID string, id string) (string, error) {
+	path, err := uritemplates.Expand("/api/actions/connector/{id}", map[string]string{
+		"id": id,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for Kibana connector: %+v", err)
+	}
+	return kibanaSpacePrefix(spaceID) + path, nil
+}
+
+type kibanaActionConnector struct {
+	ID              string                 `json:"id,omitempty"`
+	Name            string                 `json:"name"`
+	ConnectorTypeID string                 `json:"connector_type_id,omitempty"`
+	Config          map[string]interface{} `json:"config"`
+	Secrets         map[string]interface{} `json:"secrets,omitempty"`
+}
@@ -0,0 +1,130 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchKibanaActionConnector(t *testing.T) {
+	name := "test-" + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if _, err := kibanaPerformRequest(testAccKibanaProvider.Meta(), "GET", "/api/actions/connector_types", nil); err != nil {
+				t.Skipf("Kibana connectors not available: %s", err)
+			}
+		},
+		Providers:    testAccKibanaProviders,
+		CheckDestroy: testCheckElasticsearchKibanaActionConnectorDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchKibanaActionConnector(name, "https://example.com/hooks/1"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchKibanaActionConnectorExists("elasticsearch_kibana_action_connector.webhook"),
+					testCheckElasticsearchKibanaAlertRuleExists("elasticsearch_kibana_alert_rule.test"),
+					resource.TestCheckResourceAttr("elasticsearch_kibana_alert_rule.test", "action.#", "1"),
+				),
+			},
+			{
+				Config: testAccElasticsearchKibanaActionConnector(name, "https://example.com/hooks/2"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchKibanaActionConnectorExists("elasticsearch_kibana_action_connector.webhook"),
+				),
+			},
+			{
+				ResourceName:            "elasticsearch_kibana_action_connector.webhook",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"config", "secrets"},
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchKibanaActionConnectorExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Kibana connector ID is set")
+		}
+
+		path, err := kibanaActionConnectorPath(rs.Primary.Attributes["space_id"], rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		_, err = kibanaPerformRequest(testAccKibanaProvider.Meta(), "GET", path, nil)
+		return err
+	}
+}
+
+func testCheckElasticsearchKibanaActionConnectorDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_kibana_action_connector" {
+			continue
+		}
+
+		path, err := kibanaActionConnectorPath(rs.Primary.Attributes["space_id"], rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		_, err = kibanaPerformRequest(testAccKibanaProvider.Meta(), "GET", path, nil)
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Kibana connector %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchKibanaActionConnector(name string, url string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_kibana_action_connector" "webhook" {
+  name              = "%[1]s"
+  connector_type_id = ".webhook"
+  config = jsonencode({
+    url     = "%[2]s"
+    method  = "post"
+    hasAuth = true
+  })
+  secrets = jsonencode({
+    user     = "alerting"
+    password = "passw0rd"
+  })
+}
+
+resource "elasticsearch_kibana_alert_rule" "test" {
+  name         = "%[1]s"
+  rule_type_id = ".index-threshold"
+  interval     = "1m"
+  notify_when  = "onActionGroupChange"
+  params = jsonencode({
+    index               = ["logs-*"]
+    timeField           = "@timestamp"
+    aggType             = "count"
+    groupBy             = "all"
+    thresholdComparator = ">"
+    threshold           = [100]
+    timeWindowSize      = 5
+    timeWindowUnit      = "m"
+  })
+
+  action {
+    id    = elasticsearch_kibana_action_connector.webhook.connector_id
+    group = "threshold met"
+    params = jsonencode({
+      body = "{{rule.name}} is active"
+    })
+  }
+}
+`, name, url)
+}
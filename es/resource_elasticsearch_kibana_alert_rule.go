@@ -0,0 +1,341 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func resourceElasticsearchKibanaAlertRule() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a Kibana alerting rule, checking a condition on a schedule and running its actions, e.g. through connectors, when the condition is met, requires Kibana >= 7.13. Unlike `elasticsearch_kibana_alert`, the `params` of any rule type can be set. Please refer to the Kibana [alerting rules API documentation](https://www.elastic.co/guide/en/kibana/current/alerting-apis.html) for details.",
+		Create:      resourceElasticsearchKibanaAlertRuleCreate,
+		Read:        resourceElasticsearchKibanaAlertRuleRead,
+		Update:      resourceElasticsearchKibanaAlertRuleUpdate,
+		Delete:      resourceElasticsearchKibanaAlertRuleDelete,
+		Schema: map[string]*schema.Schema{
+			"rule_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The identifier of the rule, generated if not set.",
+			},
+			"space_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The identifier of the space of the rule, the default space if not set.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The display name of the rule.",
+			},
+			"rule_type_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The type of the rule, e.g. `.index-threshold` or `.es-query`.",
+			},
+			"consumer": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "alerts",
+				ForceNew:    true,
+				Description: "The Kibana feature owning the rule, which sets the privileges required to manage it, e.g. `alerts` or `infrastructure`.",
+			},
+			"params": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressEquivalentJson,
+				ValidateFunc:     validation.StringIsJSON,
+				StateFunc: func(v interface{}) string {
+					json, _ := structure.NormalizeJsonString(v)
+					return json
+				},
+				Description: "The JSON parameters of the rule type, e.g. the `index`, `timeField` and `threshold` of an index threshold rule. Only the configured parameters are read back.",
+			},
+			"interval": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The interval between the checks of the rule, e.g. `1m`.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the rule is scheduled.",
+			},
+			"tags": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The tags of the rule, to filter the rules in Kibana.",
+			},
+			"notify_when": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"onActionGroupChange", "onActiveAlert", "onThrottleInterval"}, false),
+				Description:  "When the actions of the rule run: `onActionGroupChange`, `onActiveAlert` or `onThrottleInterval`.",
+			},
+			"throttle": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The interval between the runs of the actions of an active rule with `onThrottleInterval`, e.g. `10m`.",
+			},
+			"action": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The actions run when the condition of the rule is met.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The identifier of the connector of the action, e.g. the `connector_id` of an `elasticsearch_kibana_action_connector`.",
+						},
+						"group": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "default",
+							Description: "The action group of the rule type running the action, e.g. `threshold met`.",
+						},
+						"params": {
+							Type:             schema.TypeString,
+							Required:         true,
+							DiffSuppressFunc: suppressEquivalentJson,
+							ValidateFunc:     validation.StringIsJSON,
+							StateFunc: func(v interface{}) string {
+								json, _ := structure.NormalizeJsonString(v)
+								return json
+							},
+							Description: "The JSON parameters of the connector, e.g. the `message` posted to Slack.",
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: importKibanaSpaceResource,
+		},
+	}
+}
+
+func resourceElasticsearchKibanaAlertRuleCreate(d *schema.ResourceData, meta interface{}) error {
+	rule, err := expandKibanaAlertRule(d)
+	if err != nil {
+		return err
+	}
+	enabled := d.Get("enabled").(bool)
+	rule.RuleTypeID = d.Get("rule_type_id").(string)
+	rule.Consumer = d.Get("consumer").(string)
+	rule.Enabled = &enabled
+
+	path := "/api/alerting/rule"
+	if id, ok := d.GetOk("rule_id"); ok {
+		path, err = uritemplates.Expand("/api/alerting/rule/{id}", map[string]string{
+			"id": id.(string),
+		})
+		if err != nil {
+			return fmt.Errorf("error building URL path for Kibana alerting rule: %+v", err)
+		}
+	}
+
+	res, err := kibanaPerformRequest(meta, "POST", kibanaSpacePrefix(d.Get("space_id").(string))+path, rule)
+	if err != nil {
+		log.Printf("[INFO] Failed to create Kibana alerting rule: %+v", err)
+		return err
+	}
+	response := new(kibanaAlertRule)
+	if err := json.Unmarshal(res, response); err != nil {
+		return fmt.Errorf("error unmarshalling Kibana alerting rule body: %+v: %+v", err, res)
+	}
+
+	d.SetId(response.ID)
+	return resourceElasticsearchKibanaAlertRuleRead(d, meta)
+}
+
+func resourceElasticsearchKibanaAlertRuleRead(d *schema.ResourceData, meta interface{}) error {
+	path, err := kibanaAlertRulePath(d.Get("space_id").(string), d.Id())
+	if err != nil {
+		return err
+	}
+	res, err := kibanaPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Kibana alerting rule (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	rule := new(kibanaAlertRule)
+	if err := json.Unmarshal(res, rule); err != nil {
+		return fmt.Errorf("error unmarshalling Kibana alerting rule body: %+v: %+v", err, res)
+	}
+
+	// only read back the configured parameters, on import all of them
+	params := rule.Params
+	var configured map[string]interface{}
+	if err := json.Unmarshal([]byte(d.Get("params").(string)), &configured); err == nil {
+		params = filterJSONObject(rule.Params, configured)
+	}
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	actions := make([]interface{}, 0, len(rule.Actions))
+	for _, a := range rule.Actions {
+		actionParams, err := json.Marshal(a.Params)
+		if err != nil {
+			return err
+		}
+		actions = append(actions, map[string]interface{}{
+			"id":     a.ID,
+			"group":  a.Group,
+			"params": string(actionParams),
+		})
+	}
+
+	enabled := false
+	if rule.Enabled != nil {
+		enabled = *rule.Enabled
+	}
+	throttle := ""
+	if rule.Throttle != nil {
+		throttle = *rule.Throttle
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("rule_id", rule.ID)
+	ds.set("name", rule.Name)
+	ds.set("rule_type_id", rule.RuleTypeID)
+	ds.set("consumer", rule.Consumer)
+	ds.set("params", string(paramsJSON))
+	ds.set("interval", rule.Schedule.Interval)
+	ds.set("enabled", enabled)
+	ds.set("tags", rule.Tags)
+	ds.set("notify_when", rule.NotifyWhen)
+	ds.set("throttle", throttle)
+	ds.set("action", actions)
+	return ds.err
+}
+
+func resourceElasticsearchKibanaAlertRuleUpdate(d *schema.ResourceData, meta interface{}) error {
+	path, err := kibanaAlertRulePath(d.Get("space_id").(string), d.Id())
+	if err != nil {
+		return err
+	}
+
+	rule, err := expandKibanaAlertRule(d)
+	if err != nil {
+		return err
+	}
+	if _, err := kibanaPerformRequest(meta, "PUT", path, rule); err != nil {
+		return err
+	}
+
+	// the update API keeps the schedule of the rule, it is toggled on its own
+	if d.HasChange("enabled") {
+		toggle := path + "/_disable"
+		if d.Get("enabled").(bool) {
+			toggle = path + "/_enable"
+		}
+		if _, err := kibanaPerformRequest(meta, "POST", toggle, nil); err != nil {
+			return err
+		}
+	}
+
+	return resourceElasticsearchKibanaAlertRuleRead(d, meta)
+}
+
+func resourceElasticsearchKibanaAlertRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	path, err := kibanaAlertRulePath(d.Get("space_id").(string), d.Id())
+	if err != nil {
+		return err
+	}
+	_, err = kibanaPerformRequest(meta, "DELETE", path, nil)
+	return err
+}
+
+// expandKibanaAlertRule returns the fields of the rule accepted by the update
+// API
+func expandKibanaAlertRule(d *schema.ResourceData) (kibanaAlertRule, error) {
+	rule := kibanaAlertRule{
+		Name:       d.Get("name").(string),
+		Tags:       expandStringList(d.Get("tags").(*schema.Set).List()),
+		Schedule:   kibanaAlertRuleSchedule{Interval: d.Get("interval").(string)},
+		NotifyWhen: d.Get("notify_when").(string),
+		Actions:    make([]kibanaAlertRuleAction, 0),
+	}
+	if throttle, ok := d.GetOk("throttle"); ok {
+		t := throttle.(string)
+		rule.Throttle = &t
+	}
+
+	if err := json.Unmarshal([]byte(d.Get("params").(string)), &rule.Params); err != nil {
+		return rule, fmt.Errorf("fail to unmarshal params: %v", err)
+	}
+
+	for _, a := range d.Get("action").([]interface{}) {
+		action := a.(map[string]interface{})
+		ruleAction := kibanaAlertRuleAction{
+			ID:    action["id"].(string),
+			Group: action["group"].(string),
+		}
+		if err := json.Unmarshal([]byte(action["params"].(string)), &ruleAction.Params); err != nil {
+			return rule, fmt.Errorf("fail to unmarshal params of action %s: %v", ruleAction.ID, err)
+		}
+		rule.Actions = append(rule.Actions, ruleAction)
+	}
+
+	return rule, nil
+}
+
+func kibanaAlertRulePath(spaceID string, id string) (string, error) {
+	path, err := uritemplates.Expand("/api/alerting/rule/{id}", map[string]string{
+		"id": id,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for Kibana alerting rule: %+v", err)
+	}
+	return kibanaSpacePrefix(spaceID) + path, nil
+}
+
+type kibanaAlertRule struct {
+	ID         string                  `json:"id,omitempty"`
+	Name       string                  `json:"name"`
+	RuleTypeID string                  `json:"rule_type_id,omitempty"`
+	Consumer   string                  `json:"consumer,omitempty"`
+	Params     map[string]interface{}  `json:"params"`
+	Schedule   kibanaAlertRuleSchedule `json:"schedule"`
+	Enabled    *bool                   `json:"enabled,omitempty"`
+	Tags       []string                `json:"tags"`
+	NotifyWhen string                  `json:"notify_when,omitempty"`
+	Throttle   *string                 `json:"throttle,omitempty"`
+	Actions    []kibanaAlertRuleAction `json:"actions"`
+}
+
+type kibanaAlertRuleSchedule struct {
+	Interval string `json:"interval"`
+}
+
+type kibanaAlertRuleAction struct {
+	ID     string                 `json:"id"`
+	Group  string                 `json:"group"`
+	Params map[string]interface{} `json:"params"`
+}
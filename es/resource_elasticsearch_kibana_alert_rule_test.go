@@ -0,0 +1,112 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchKibanaAlertRule(t *testing.T) {
+	name := "test-" + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if _, err := kibanaPerformRequest(testAccKibanaProvider.Meta(), "GET", "/api/alerting/rule_types", nil); err != nil {
+				t.Skipf("Kibana alerting rules not available: %s", err)
+			}
+		},
+		Providers:    testAccKibanaProviders,
+		CheckDestroy: testCheckElasticsearchKibanaAlertRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchKibanaAlertRule(name, "1m", true),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchKibanaAlertRuleExists("elasticsearch_kibana_alert_rule.test"),
+					resource.TestCheckResourceAttr("elasticsearch_kibana_alert_rule.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("elasticsearch_kibana_alert_rule.test", "tags.#", "1"),
+				),
+			},
+			{
+				Config: testAccElasticsearchKibanaAlertRule(name, "5m", false),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchKibanaAlertRuleExists("elasticsearch_kibana_alert_rule.test"),
+					resource.TestCheckResourceAttr("elasticsearch_kibana_alert_rule.test", "interval", "5m"),
+					resource.TestCheckResourceAttr("elasticsearch_kibana_alert_rule.test", "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:            "elasticsearch_kibana_alert_rule.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"params"},
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchKibanaAlertRuleExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Kibana alerting rule ID is set")
+		}
+
+		path, err := kibanaAlertRulePath(rs.Primary.Attributes["space_id"], rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		_, err = kibanaPerformRequest(testAccKibanaProvider.Meta(), "GET", path, nil)
+		return err
+	}
+}
+
+func testCheckElasticsearchKibanaAlertRuleDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_kibana_alert_rule" {
+			continue
+		}
+
+		path, err := kibanaAlertRulePath(rs.Primary.Attributes["space_id"], rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		_, err = kibanaPerformRequest(testAccKibanaProvider.Meta(), "GET", path, nil)
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Kibana alerting rule %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchKibanaAlertRule(name string, interval string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_kibana_alert_rule" "test" {
+  name         = "%s"
+  rule_type_id = ".index-threshold"
+  interval     = "%s"
+  enabled      = %t
+  tags         = ["test"]
+  notify_when  = "onActionGroupChange"
+  params = jsonencode({
+    index               = ["logs-*"]
+    timeField           = "@timestamp"
+    aggType             = "count"
+    groupBy             = "all"
+    thresholdComparator = ">"
+    threshold           = [100]
+    timeWindowSize      = 5
+    timeWindowUnit      = "m"
+  })
+}
+`, name, interval, enabled)
+}
@@ -0,0 +1,275 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func resourceElasticsearchKibanaDataView() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a Kibana data view, formerly an index pattern, with its time field, runtime fields and field formats in a space, requires Kibana >= 8.0. Please refer to the Kibana [data views API documentation](https://www.elastic.co/guide/en/kibana/current/data-views-api.html) for details.",
+		Create:      resourceElasticsearchKibanaDataViewCreate,
+		Read:        resourceElasticsearchKibanaDataViewRead,
+		Update:      resourceElasticsearchKibanaDataViewUpdate,
+		Delete:      resourceElasticsearchKibanaDataViewDelete,
+		Schema: map[string]*schema.Schema{
+			"data_view_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The identifier of the data view, generated if not set.",
+			},
+			"space_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The identifier of the space of the data view, the default space if not set.",
+			},
+			"title": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The comma separated index patterns of the data view, e.g. `logs-*`.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The display name of the data view, its title if not set.",
+			},
+			"time_field_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The timestamp field of the data view, e.g. `@timestamp`.",
+			},
+			"runtime_field": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The fields of the data view computed at query time.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the runtime field.",
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"boolean", "composite", "date", "double", "geo_point", "ip", "keyword", "long"}, false),
+							Description:  "The type of the runtime field, e.g. `keyword` or `long`.",
+						},
+						"script": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The painless source of the runtime field, emitting its values, e.g. `emit(doc['host.name'].value)`.",
+						},
+					},
+				},
+			},
+			"field_format": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The formats of the displayed values of fields.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"field": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the formatted field.",
+						},
+						"id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The identifier of the format, e.g. `bytes`, `number` or `url`.",
+						},
+						"params": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsJSON,
+							Description:  "The JSON parameters of the format, e.g. its `pattern`, best set with `jsonencode`.",
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: importKibanaSpaceResource,
+		},
+	}
+}
+
+func resourceElasticsearchKibanaDataViewCreate(d *schema.ResourceData, meta interface{}) error {
+	dataView := expandKibanaDataView(d)
+	if id, ok := d.GetOk("data_view_id"); ok {
+		dataView.ID = id.(string)
+	}
+
+	path := kibanaSpacePrefix(d.Get("space_id").(string)) + "/api/data_views/data_view"
+	res, err := kibanaPerformRequest(meta, "POST", path, kibanaDataViewRequest{DataView: dataView})
+	if err != nil {
+		log.Printf("[INFO] Failed to create Kibana data view: %+v", err)
+		return err
+	}
+	response := new(kibanaDataViewRequest)
+	if err := json.Unmarshal(res, response); err != nil {
+		return fmt.Errorf("error unmarshalling Kibana data view body: %+v: %+v", err, res)
+	}
+
+	d.SetId(response.DataView.ID)
+	return resourceElasticsearchKibanaDataViewRead(d, meta)
+}
+
+func resourceElasticsearchKibanaDataViewRead(d *schema.ResourceData, meta interface{}) error {
+	path, err := kibanaDataViewPath(d.Get("space_id").(string), d.Id())
+	if err != nil {
+		return err
+	}
+	res, err := kibanaPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Kibana data view (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	response := new(kibanaDataViewRequest)
+	if err := json.Unmarshal(res, response); err != nil {
+		return fmt.Errorf("error unmarshalling Kibana data view body: %+v: %+v", err, res)
+	}
+	dataView := response.DataView
+
+	runtimeFields := make([]interface{}, 0, len(dataView.RuntimeFieldMap))
+	for name, f := range dataView.RuntimeFieldMap {
+		runtimeFields = append(runtimeFields, map[string]interface{}{
+			"name":   name,
+			"type":   f.Type,
+			"script": f.Script.Source,
+		})
+	}
+
+	fieldFormats := make([]interface{}, 0, len(dataView.FieldFormats))
+	for field, f := range dataView.FieldFormats {
+		params := ""
+		if len(f.Params) > 0 {
+			// normalized as the output of jsonencode
+			params, err = structure.NormalizeJsonString(string(f.Params))
+			if err != nil {
+				return err
+			}
+		}
+		fieldFormats = append(fieldFormats, map[string]interface{}{
+			"field":  field,
+			"id":     f.ID,
+			"params": params,
+		})
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("data_view_id", dataView.ID)
+	ds.set("title", dataView.Title)
+	ds.set("name", dataView.Name)
+	ds.set("time_field_name", dataView.TimeFieldName)
+	ds.set("runtime_field", runtimeFields)
+	ds.set("field_format", fieldFormats)
+	return ds.err
+}
+
+func resourceElasticsearchKibanaDataViewUpdate(d *schema.ResourceData, meta interface{}) error {
+	path, err := kibanaDataViewPath(d.Get("space_id").(string), d.Id())
+	if err != nil {
+		return err
+	}
+	if _, err := kibanaPerformRequest(meta, "POST", path, kibanaDataViewRequest{DataView: expandKibanaDataView(d)}); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchKibanaDataViewRead(d, meta)
+}
+
+func resourceElasticsearchKibanaDataViewDelete(d *schema.ResourceData, meta interface{}) error {
+	path, err := kibanaDataViewPath(d.Get("space_id").(string), d.Id())
+	if err != nil {
+		return err
+	}
+	_, err = kibanaPerformRequest(meta, "DELETE", path, nil)
+	return err
+}
+
+func expandKibanaDataView(d *schema.ResourceData) kibanaDataView {
+	dataView := kibanaDataView{
+		Title:           d.Get("title").(string),
+		Name:            d.Get("name").(string),
+		TimeFieldName:   d.Get("time_field_name").(string),
+		RuntimeFieldMap: make(map[string]*kibanaDataViewRuntimeField),
+		FieldFormats:    make(map[string]*kibanaDataViewFieldFormat),
+	}
+
+	for _, r := range d.Get("runtime_field").(*schema.Set).List() {
+		runtimeField := r.(map[string]interface{})
+		dataView.RuntimeFieldMap[runtimeField["name"].(string)] = &kibanaDataViewRuntimeField{
+			Type:   runtimeField["type"].(string),
+			Script: kibanaDataViewScript{Source: runtimeField["script"].(string)},
+		}
+	}
+
+	for _, f := range d.Get("field_format").(*schema.Set).List() {
+		fieldFormat := f.(map[string]interface{})
+		format := &kibanaDataViewFieldFormat{
+			ID: fieldFormat["id"].(string),
+		}
+		if params := fieldFormat["params"].(string); params != "" {
+			format.Params = json.RawMessage(params)
+		}
+		dataView.FieldFormats[fieldFormat["field"].(string)] = format
+	}
+
+	return dataView
+}
+
+func kibanaDataViewPath(spaceID string, id string) (string, error) {
+	path, err := uritemplates.Expand("/api/data_views/data_view/{id}", map[string]string{
+		"id": id,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for Kibana data view: %+v", err)
+	}
+	return kibanaSpacePrefix(spaceID) + path, nil
+}
+
+type kibanaDataViewRequest struct {
+	DataView kibanaDataView `json:"data_view"`
+}
+
+type kibanaDataView struct {
+	ID              string                                 `json:"id,omitempty"`
+	Title           string                                 `json:"title"`
+	Name            string                                 `json:"name,omitempty"`
+	TimeFieldName   string                                 `json:"timeFieldName,omitempty"`
+	RuntimeFieldMap map[string]*kibanaDataViewRuntimeField `json:"runtimeFieldMap"`
+	FieldFormats    map[string]*kibanaDataViewFieldFormat  `json:"fieldFormats"`
+}
+
+type kibanaDataViewRuntimeField struct {
+	Type   string               `json:"type"`
+	Script kibanaDataViewScript `json:"script"`
+}
+
+type kibanaDataViewScript struct {
+	Source string `json:"source,omitempty"`
+}
+
+type kibanaDataViewFieldFormat struct {
+	ID     string          `json:"id"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
@@ -0,0 +1,124 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchKibanaDataView(t *testing.T) {
+	spaceID := "test-" + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if _, err := kibanaPerformRequest(testAccKibanaProvider.Meta(), "GET", "/api/data_views", nil); err != nil {
+				t.Skipf("Kibana data views not available: %s", err)
+			}
+		},
+		Providers:    testAccKibanaProviders,
+		CheckDestroy: testCheckElasticsearchKibanaDataViewDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchKibanaDataView(spaceID, "Logs", ""),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchKibanaDataViewExists("elasticsearch_kibana_data_view.test"),
+					resource.TestCheckResourceAttr("elasticsearch_kibana_data_view.test", "runtime_field.#", "1"),
+					resource.TestCheckResourceAttr("elasticsearch_kibana_data_view.test", "field_format.#", "1"),
+				),
+			},
+			{
+				Config: testAccElasticsearchKibanaDataView(spaceID, "All logs", `
+  runtime_field {
+    name   = "day_of_week"
+    type   = "keyword"
+    script = "emit(doc['@timestamp'].value.dayOfWeekEnum.toString())"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchKibanaDataViewExists("elasticsearch_kibana_data_view.test"),
+					resource.TestCheckResourceAttr("elasticsearch_kibana_data_view.test", "name", "All logs"),
+					resource.TestCheckResourceAttr("elasticsearch_kibana_data_view.test", "runtime_field.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_kibana_data_view.test",
+				ImportState:       true,
+				ImportStateId:     spaceID + "/test-logs",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchKibanaDataViewExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Kibana data view ID is set")
+		}
+
+		path, err := kibanaDataViewPath(rs.Primary.Attributes["space_id"], rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		_, err = kibanaPerformRequest(testAccKibanaProvider.Meta(), "GET", path, nil)
+		return err
+	}
+}
+
+func testCheckElasticsearchKibanaDataViewDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_kibana_data_view" {
+			continue
+		}
+
+		path, err := kibanaDataViewPath(rs.Primary.Attributes["space_id"], rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		_, err = kibanaPerformRequest(testAccKibanaProvider.Meta(), "GET", path, nil)
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Kibana data view %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchKibanaDataView(spaceID string, name string, runtimeField string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_kibana_space" "test" {
+  space_id = "%s"
+  name     = "Test"
+}
+
+resource "elasticsearch_kibana_data_view" "test" {
+  space_id        = elasticsearch_kibana_space.test.space_id
+  data_view_id    = "test-logs"
+  title           = "logs-*"
+  name            = "%s"
+  time_field_name = "@timestamp"
+
+  runtime_field {
+    name   = "hour_of_day"
+    type   = "long"
+    script = "emit(doc['@timestamp'].value.getHour())"
+  }
+  %s
+  field_format {
+    field  = "bytes"
+    id     = "bytes"
+    params = jsonencode({ pattern = "0,0.[0]b" })
+  }
+}
+`, spaceID, name, runtimeField)
+}
@@ -0,0 +1,206 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func resourceElasticsearchKibanaFleetAgentPolicy() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a Fleet agent policy, the integrations and settings of the Elastic Agents enrolled in it, requires Kibana >= 8.0 with Fleet set up. Please refer to the Kibana [Fleet API documentation](https://www.elastic.co/guide/en/fleet/current/fleet-api-docs.html) for details.",
+		Create:      resourceElasticsearchKibanaFleetAgentPolicyCreate,
+		Read:        resourceElasticsearchKibanaFleetAgentPolicyRead,
+		Update:      resourceElasticsearchKibanaFleetAgentPolicyUpdate,
+		Delete:      resourceElasticsearchKibanaFleetAgentPolicyDelete,
+		Schema: map[string]*schema.Schema{
+			"policy_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The identifier of the agent policy, generated if not set.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The unique display name of the agent policy.",
+			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "default",
+				Description: "The namespace of the data streams the agents of the policy write to, e.g. `logs-system.syslog-<namespace>`.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the agent policy.",
+			},
+			"monitoring_enabled": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"logs", "metrics"}, false),
+				},
+				Description: "The monitoring data the agents of the policy collect on themselves, `logs` and `metrics`.",
+			},
+			"data_output_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The identifier of the Fleet output of the data of the agents, the default output if not set.",
+			},
+			"monitoring_output_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The identifier of the Fleet output of the monitoring data of the agents, the default output if not set.",
+			},
+			"sys_monitoring": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Whether the system integration, collecting the logs and metrics of the hosts of the agents, is added to the agent policy on creation.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchKibanaFleetAgentPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	policy := expandKibanaFleetAgentPolicy(d)
+	if id, ok := d.GetOk("policy_id"); ok {
+		policy.ID = id.(string)
+	}
+
+	path := "/api/fleet/agent_policies"
+	if d.Get("sys_monitoring").(bool) {
+		path += "?sys_monitoring=true"
+	}
+	res, err := kibanaPerformRequest(meta, "POST", path, policy)
+	if err != nil {
+		log.Printf("[INFO] Failed to create Fleet agent policy: %+v", err)
+		return err
+	}
+	response := new(kibanaFleetAgentPolicyResponse)
+	if err := json.Unmarshal(res, response); err != nil {
+		return fmt.Errorf("error unmarshalling Fleet agent policy body: %+v: %+v", err, res)
+	}
+
+	d.SetId(response.Item.ID)
+	return resourceElasticsearchKibanaFleetAgentPolicyRead(d, meta)
+}
+
+func resourceElasticsearchKibanaFleetAgentPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	path, err := kibanaFleetAgentPolicyPath(d.Id())
+	if err != nil {
+		return err
+	}
+	res, err := kibanaPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Fleet agent policy (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	response := new(kibanaFleetAgentPolicyResponse)
+	if err := json.Unmarshal(res, response); err != nil {
+		return fmt.Errorf("error unmarshalling Fleet agent policy body: %+v: %+v", err, res)
+	}
+	policy := response.Item
+
+	dataOutputID := ""
+	if policy.DataOutputID != nil {
+		dataOutputID = *policy.DataOutputID
+	}
+	monitoringOutputID := ""
+	if policy.MonitoringOutputID != nil {
+		monitoringOutputID = *policy.MonitoringOutputID
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("policy_id", policy.ID)
+	ds.set("name", policy.Name)
+	ds.set("namespace", policy.Namespace)
+	ds.set("description", policy.Description)
+	ds.set("monitoring_enabled", policy.MonitoringEnabled)
+	ds.set("data_output_id", dataOutputID)
+	ds.set("monitoring_output_id", monitoringOutputID)
+	return ds.err
+}
+
+func resourceElasticsearchKibanaFleetAgentPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	path, err := kibanaFleetAgentPolicyPath(d.Id())
+	if err != nil {
+		return err
+	}
+	if _, err := kibanaPerformRequest(meta, "PUT", path, expandKibanaFleetAgentPolicy(d)); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchKibanaFleetAgentPolicyRead(d, meta)
+}
+
+func resourceElasticsearchKibanaFleetAgentPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	// the agent policies are deleted by a post, failing while agents are
+	// enrolled in the policy
+	_, err := kibanaPerformRequest(meta, "POST", "/api/fleet/agent_policies/delete", map[string]string{
+		"agentPolicyId": d.Id(),
+	})
+	return err
+}
+
+func expandKibanaFleetAgentPolicy(d *schema.ResourceData) kibanaFleetAgentPolicy {
+	policy := kibanaFleetAgentPolicy{
+		Name:              d.Get("name").(string),
+		Namespace:         d.Get("namespace").(string),
+		Description:       d.Get("description").(string),
+		MonitoringEnabled: expandStringList(d.Get("monitoring_enabled").(*schema.Set).List()),
+	}
+	// the outputs not set are reset to the default output by nulls
+	if id, ok := d.GetOk("data_output_id"); ok {
+		dataOutputID := id.(string)
+		policy.DataOutputID = &dataOutputID
+	}
+	if id, ok := d.GetOk("monitoring_output_id"); ok {
+		monitoringOutputID := id.(string)
+		policy.MonitoringOutputID = &monitoringOutputID
+	}
+	return policy
+}
+
+func kibanaFleetAgentPolicyPath(id string) (string, error) {
+	path, err := uritemplates.Expand("/api/fleet/agent_policies/{id}", map[string]string{
+		"id": id,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for Fleet agent policy: %+v", err)
+	}
+	return path, nil
+}
+
+type kibanaFleetAgentPolicyResponse struct {
+	Item kibanaFleetAgentPolicy `json:"item"`
+}
+
+type kibanaFleetAgentPolicy struct {
+	ID                 string   `json:"id,omitempty"`
+	Name               string   `json:"name"`
+	Namespace          string   `json:"namespace"`
+	Description        string   `json:"description,omitempty"`
+	MonitoringEnabled  []string `json:"monitoring_enabled"`
+	DataOutputID       *string  `json:"data_output_id"`
+	MonitoringOutputID *string  `json:"monitoring_output_id"`
+}
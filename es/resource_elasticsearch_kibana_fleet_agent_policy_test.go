@@ -0,0 +1,98 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchKibanaFleetAgentPolicy(t *testing.T) {
+	name := "test-" + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if _, err := kibanaPerformRequest(testAccKibanaProvider.Meta(), "GET", "/api/fleet/agent_policies", nil); err != nil {
+				t.Skipf("Fleet not available: %s", err)
+			}
+		},
+		Providers:    testAccKibanaProviders,
+		CheckDestroy: testCheckElasticsearchKibanaFleetAgentPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchKibanaFleetAgentPolicy(name, `["logs", "metrics"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchKibanaFleetAgentPolicyExists("elasticsearch_kibana_fleet_agent_policy.test"),
+					resource.TestCheckResourceAttr("elasticsearch_kibana_fleet_agent_policy.test", "monitoring_enabled.#", "2"),
+				),
+			},
+			{
+				Config: testAccElasticsearchKibanaFleetAgentPolicy(name, `["logs"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchKibanaFleetAgentPolicyExists("elasticsearch_kibana_fleet_agent_policy.test"),
+					resource.TestCheckResourceAttr("elasticsearch_kibana_fleet_agent_policy.test", "monitoring_enabled.#", "1"),
+				),
+			},
+			{
+				ResourceName:            "elasticsearch_kibana_fleet_agent_policy.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"sys_monitoring"},
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchKibanaFleetAgentPolicyExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Fleet agent policy ID is set")
+		}
+
+		path, err := kibanaFleetAgentPolicyPath(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		_, err = kibanaPerformRequest(testAccKibanaProvider.Meta(), "GET", path, nil)
+		return err
+	}
+}
+
+func testCheckElasticsearchKibanaFleetAgentPolicyDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_kibana_fleet_agent_policy" {
+			continue
+		}
+
+		path, err := kibanaFleetAgentPolicyPath(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		_, err = kibanaPerformRequest(testAccKibanaProvider.Meta(), "GET", path, nil)
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Fleet agent policy %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchKibanaFleetAgentPolicy(name string, monitoring string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_kibana_fleet_agent_policy" "test" {
+  name               = "%s"
+  namespace          = "test"
+  description        = "Hosts of the test"
+  monitoring_enabled = %s
+}
+`, name, monitoring)
+}
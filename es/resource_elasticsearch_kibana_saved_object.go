@@ -0,0 +1,272 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func resourceElasticsearchKibanaSavedObject() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a Kibana saved object of any type, e.g. an `index-pattern`, a `lens` visualization or a `dashboard`, managed with the saved objects API of Kibana in a space, unlike `elasticsearch_kibana_object` which writes to the Kibana index. Only the configured `attributes` are read back, the attributes Kibana adds are ignored. Please refer to the Kibana [saved objects API documentation](https://www.elastic.co/guide/en/kibana/current/saved-objects-api.html) for details.",
+		Create:      resourceElasticsearchKibanaSavedObjectCreate,
+		Read:        resourceElasticsearchKibanaSavedObjectRead,
+		Update:      resourceElasticsearchKibanaSavedObjectUpdate,
+		Delete:      resourceElasticsearchKibanaSavedObjectDelete,
+		Schema: map[string]*schema.Schema{
+			"object_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The type of the saved object, e.g. `index-pattern`, `lens`, `search` or `dashboard`.",
+			},
+			"object_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The identifier of the saved object, generated if not set.",
+			},
+			"space_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The identifier of the space of the saved object, the default space if not set.",
+			},
+			"attributes": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressEquivalentJson,
+				ValidateFunc:     validation.StringIsJSON,
+				StateFunc: func(v interface{}) string {
+					json, _ := structure.NormalizeJsonString(v)
+					return json
+				},
+				Description: "The JSON attributes of the saved object, e.g. the `title` and `timeFieldName` of an index pattern.",
+			},
+			"reference": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The other saved objects the saved object refers to, e.g. the index pattern of a visualization.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the reference in the attributes.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The type of the referenced saved object.",
+						},
+						"id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The identifier of the referenced saved object.",
+						},
+					},
+				},
+			},
+			"overwrite": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether creating the resource overwrites an existing saved object of the same `object_id`, instead of failing.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: resourceElasticsearchKibanaSavedObjectImport,
+		},
+	}
+}
+
+func resourceElasticsearchKibanaSavedObjectCreate(d *schema.ResourceData, meta interface{}) error {
+	objectType := d.Get("object_type").(string)
+
+	// the saved objects APIs of Kibana and of the OpenSearch Dashboards take
+	// the same bodies
+	body, err := expandOpenSearchDashboardObject(d)
+	if err != nil {
+		return err
+	}
+
+	path := "/api/saved_objects/{type}"
+	params := map[string]string{
+		"type": objectType,
+	}
+	if id, ok := d.GetOk("object_id"); ok {
+		path = "/api/saved_objects/{type}/{id}"
+		params["id"] = id.(string)
+	}
+	path, err = uritemplates.Expand(path, params)
+	if err != nil {
+		return fmt.Errorf("error building URL path for Kibana saved object: %+v", err)
+	}
+	if d.Get("overwrite").(bool) {
+		path += "?overwrite=true"
+	}
+
+	res, err := kibanaPerformRequest(meta, "POST", kibanaSpacePrefix(d.Get("space_id").(string))+path, body)
+	if err != nil {
+		log.Printf("[INFO] Failed to create Kibana saved object: %+v", err)
+		return err
+	}
+	object := new(dashboardSavedObject)
+	if err := json.Unmarshal(res, object); err != nil {
+		return fmt.Errorf("error unmarshalling Kibana saved object body: %+v: %+v", err, res)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", objectType, object.ID))
+	return resourceElasticsearchKibanaSavedObjectRead(d, meta)
+}
+
+func resourceElasticsearchKibanaSavedObjectRead(d *schema.ResourceData, meta interface{}) error {
+	objectType, objectID, err := parseOpenSearchDashboardObjectID(d.Id())
+	if err != nil {
+		return err
+	}
+	path, err := kibanaSavedObjectPath(d.Get("space_id").(string), objectType, objectID)
+	if err != nil {
+		return err
+	}
+
+	res, err := kibanaPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Kibana saved object (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	object := new(dashboardSavedObject)
+	if err := json.Unmarshal(res, object); err != nil {
+		return fmt.Errorf("error unmarshalling Kibana saved object body: %+v: %+v", err, res)
+	}
+
+	// only read back the configured attributes, on import all of them
+	attributes := object.Attributes
+	var configured map[string]interface{}
+	if err := json.Unmarshal([]byte(d.Get("attributes").(string)), &configured); err == nil {
+		attributes = filterJSONObject(object.Attributes, configured)
+	}
+	if attributes == nil {
+		attributes = make(map[string]interface{})
+	}
+	attributesJSON, err := json.Marshal(attributes)
+	if err != nil {
+		return err
+	}
+
+	references := make([]interface{}, 0, len(object.References))
+	for _, r := range object.References {
+		references = append(references, map[string]interface{}{
+			"name": r.Name,
+			"type": r.Type,
+			"id":   r.ID,
+		})
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("object_type", objectType)
+	ds.set("object_id", objectID)
+	ds.set("attributes", string(attributesJSON))
+	ds.set("reference", references)
+	return ds.err
+}
+
+func resourceElasticsearchKibanaSavedObjectUpdate(d *schema.ResourceData, meta interface{}) error {
+	objectType, objectID, err := parseOpenSearchDashboardObjectID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	body, err := expandOpenSearchDashboardObject(d)
+	if err != nil {
+		return err
+	}
+	path, err := kibanaSavedObjectPath(d.Get("space_id").(string), objectType, objectID)
+	if err != nil {
+		return err
+	}
+	if _, err := kibanaPerformRequest(meta, "PUT", path, body); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchKibanaSavedObjectRead(d, meta)
+}
+
+func resourceElasticsearchKibanaSavedObjectDelete(d *schema.ResourceData, meta interface{}) error {
+	objectType, objectID, err := parseOpenSearchDashboardObjectID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	path, err := kibanaSavedObjectPath(d.Get("space_id").(string), objectType, objectID)
+	if err != nil {
+		return err
+	}
+	_, err = kibanaPerformRequest(meta, "DELETE", path, nil)
+	return err
+}
+
+// resourceElasticsearchKibanaSavedObjectImport accepts the space before the
+// ID, as in space_id/type/id
+func resourceElasticsearchKibanaSavedObjectImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	ds := &resourceDataSetter{d: d}
+	switch {
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+	case len(parts) == 3 && parts[0] != "" && parts[1] != "" && parts[2] != "":
+		ds.set("space_id", parts[0])
+		d.SetId(fmt.Sprintf("%s/%s", parts[1], parts[2]))
+	default:
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected type/id or space_id/type/id", d.Id())
+	}
+	return []*schema.ResourceData{d}, ds.err
+}
+
+// importKibanaSpaceResource accepts the space of the resources identified in
+// it before the ID, as in space_id/id
+func importKibanaSpaceResource(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	ds := &resourceDataSetter{d: d}
+	switch {
+	case len(parts) == 1 && parts[0] != "":
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		ds.set("space_id", parts[0])
+		d.SetId(parts[1])
+	default:
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected id or space_id/id", d.Id())
+	}
+	return []*schema.ResourceData{d}, ds.err
+}
+
+// kibanaSpacePrefix returns the prefix of the Kibana API paths of a space, the
+// default space has none
+func kibanaSpacePrefix(spaceID string) string {
+	if spaceID == "" || spaceID == kibanaDefaultSpaceID {
+		return ""
+	}
+	return "/s/" + spaceID
+}
+
+func kibanaSavedObjectPath(spaceID string, objectType string, objectID string) (string, error) {
+	path, err := uritemplates.Expand("/api/saved_objects/{type}/{id}", map[string]string{
+		"type": objectType,
+		"id":   objectID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for Kibana saved object: %+v", err)
+	}
+	return kibanaSpacePrefix(spaceID) + path, nil
+}
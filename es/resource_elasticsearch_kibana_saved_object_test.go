@@ -0,0 +1,139 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchKibanaSavedObject(t *testing.T) {
+	spaceID := "test-" + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if _, err := kibanaPerformRequest(testAccKibanaProvider.Meta(), "GET", "/api/spaces/space", nil); err != nil {
+				t.Skipf("Kibana spaces not available: %s", err)
+			}
+		},
+		Providers:    testAccKibanaProviders,
+		CheckDestroy: testCheckElasticsearchKibanaSavedObjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchKibanaSavedObject(spaceID, "Test search"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchKibanaSavedObjectExists("elasticsearch_kibana_saved_object.index_pattern"),
+					testCheckElasticsearchKibanaSavedObjectExists("elasticsearch_kibana_saved_object.search"),
+					resource.TestCheckResourceAttr("elasticsearch_kibana_saved_object.search", "id", "search/test-search"),
+					resource.TestCheckResourceAttr("elasticsearch_kibana_saved_object.search", "reference.#", "1"),
+				),
+			},
+			{
+				Config: testAccElasticsearchKibanaSavedObject(spaceID, "Test search renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchKibanaSavedObjectExists("elasticsearch_kibana_saved_object.search"),
+				),
+			},
+			{
+				ResourceName:            "elasticsearch_kibana_saved_object.search",
+				ImportState:             true,
+				ImportStateId:           spaceID + "/search/test-search",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"attributes", "overwrite"},
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchKibanaSavedObjectExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Kibana saved object ID is set")
+		}
+
+		objectType, objectID, err := parseOpenSearchDashboardObjectID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		path, err := kibanaSavedObjectPath(rs.Primary.Attributes["space_id"], objectType, objectID)
+		if err != nil {
+			return err
+		}
+		_, err = kibanaPerformRequest(testAccKibanaProvider.Meta(), "GET", path, nil)
+		return err
+	}
+}
+
+func testCheckElasticsearchKibanaSavedObjectDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_kibana_saved_object" {
+			continue
+		}
+
+		objectType, objectID, err := parseOpenSearchDashboardObjectID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		path, err := kibanaSavedObjectPath(rs.Primary.Attributes["space_id"], objectType, objectID)
+		if err != nil {
+			return err
+		}
+		_, err = kibanaPerformRequest(testAccKibanaProvider.Meta(), "GET", path, nil)
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Kibana saved object %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchKibanaSavedObject(spaceID string, title string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_kibana_space" "test" {
+  space_id = "%s"
+  name     = "Test"
+}
+
+resource "elasticsearch_kibana_saved_object" "index_pattern" {
+  space_id    = elasticsearch_kibana_space.test.space_id
+  object_type = "index-pattern"
+  attributes = jsonencode({
+    title         = "logs-*"
+    timeFieldName = "@timestamp"
+  })
+}
+
+resource "elasticsearch_kibana_saved_object" "search" {
+  space_id    = elasticsearch_kibana_space.test.space_id
+  object_type = "search"
+  object_id   = "test-search"
+  overwrite   = true
+  attributes = jsonencode({
+    title   = "%s"
+    columns = ["message"]
+    kibanaSavedObjectMeta = {
+      searchSourceJSON = jsonencode({
+        indexRefName = "kibanaSavedObjectMeta.searchSourceJSON.index"
+        query        = { query = "", language = "kuery" }
+        filter       = []
+      })
+    }
+  })
+
+  reference {
+    name = "kibanaSavedObjectMeta.searchSourceJSON.index"
+    type = "index-pattern"
+    id   = elasticsearch_kibana_saved_object.index_pattern.object_id
+  }
+}
+`, spaceID, title)
+}
@@ -0,0 +1,265 @@
+package es
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"mime/multipart"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func resourceElasticsearchKibanaSavedObjectsImport() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides an import of Kibana saved objects, e.g. the dashboards exported from another Kibana, from an NDJSON file or string into a space. The objects are imported again when the content changes, and are kept in Kibana on destroy. Please refer to the Kibana [import objects API documentation](https://www.elastic.co/guide/en/kibana/current/saved-objects-api-import.html) for details.",
+		Create:        resourceElasticsearchKibanaSavedObjectsImportCreate,
+		Read:          resourceElasticsearchKibanaSavedObjectsImportRead,
+		Update:        resourceElasticsearchKibanaSavedObjectsImportUpdate,
+		Delete:        resourceElasticsearchKibanaSavedObjectsImportDelete,
+		CustomizeDiff: resourceElasticsearchKibanaSavedObjectsImportCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"space_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The identifier of the space the objects are imported into, the default space if not set.",
+			},
+			"file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"content"},
+				Description:   "The path of the NDJSON file of the objects, e.g. as exported by Kibana.",
+			},
+			"content": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"file"},
+				Description:   "The NDJSON of the objects, one object per line, instead of a `file`.",
+			},
+			"overwrite": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"create_new_copies"},
+				Description:   "Whether the import overwrites the existing objects of the same identifiers, instead of failing on them.",
+			},
+			"create_new_copies": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"overwrite"},
+				Description:   "Whether the objects are imported with new identifiers, so they never conflict with the existing objects.",
+			},
+			"content_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA256 hash of the imported NDJSON, a change of which imports the objects again.",
+			},
+			"success_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of objects imported.",
+			},
+			"objects": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The imported objects.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the object.",
+						},
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The identifier of the object in the NDJSON.",
+						},
+						"destination_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The identifier of the object in the space, a new one with `create_new_copies`.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceElasticsearchKibanaSavedObjectsImportCustomizeDiff plans an import
+// when the content of the file changes, which its path does not show
+func resourceElasticsearchKibanaSavedObjectsImportCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("file") || !d.NewValueKnown("content") {
+		// unknown until applied
+		return nil
+	}
+
+	content, err := kibanaSavedObjectsImportContent(d.Get("file").(string), d.Get("content").(string))
+	if err != nil {
+		return err
+	}
+	if hash := hashSum(content); hash != d.Get("content_hash").(string) {
+		if err := d.SetNew("content_hash", hash); err != nil {
+			return err
+		}
+		// the imported objects may change with the content
+		return d.SetNewComputed("objects")
+	}
+	return nil
+}
+
+func resourceElasticsearchKibanaSavedObjectsImportCreate(d *schema.ResourceData, meta interface{}) error {
+	if err := resourceElasticsearchKibanaSavedObjectsImportPost(d, meta); err != nil {
+		log.Printf("[INFO] Failed to import Kibana saved objects: %+v", err)
+		return err
+	}
+
+	d.SetId(d.Get("content_hash").(string))
+	return resourceElasticsearchKibanaSavedObjectsImportRead(d, meta)
+}
+
+// resourceElasticsearchKibanaSavedObjectsImportRead keeps the state, the
+// import cannot be read back from Kibana
+func resourceElasticsearchKibanaSavedObjectsImportRead(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+func resourceElasticsearchKibanaSavedObjectsImportUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := resourceElasticsearchKibanaSavedObjectsImportPost(d, meta); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchKibanaSavedObjectsImportRead(d, meta)
+}
+
+func resourceElasticsearchKibanaSavedObjectsImportDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Imported Kibana saved objects are kept in Kibana, removing from state only")
+	d.SetId("")
+	return nil
+}
+
+func resourceElasticsearchKibanaSavedObjectsImportPost(d *schema.ResourceData, meta interface{}) error {
+	content, err := kibanaSavedObjectsImportContent(d.Get("file").(string), d.Get("content").(string))
+	if err != nil {
+		return err
+	}
+
+	params := url.Values{}
+	if d.Get("overwrite").(bool) {
+		params.Set("overwrite", "true")
+	}
+	if d.Get("create_new_copies").(bool) {
+		params.Set("createNewCopies", "true")
+	}
+	path := kibanaSpacePrefix(d.Get("space_id").(string)) + "/api/saved_objects/_import"
+
+	res, err := kibanaImportSavedObjects(meta, path, params, content)
+	if err != nil {
+		return err
+	}
+	response := new(kibanaSavedObjectsImportResponse)
+	if err := json.Unmarshal(res, response); err != nil {
+		return fmt.Errorf("error unmarshalling Kibana saved objects import body: %+v: %+v", err, res)
+	}
+	if !response.Success {
+		failures := make([]string, 0, len(response.Errors))
+		for _, e := range response.Errors {
+			failures = append(failures, fmt.Sprintf("%s/%s (%s)", e.Type, e.ID, e.Error.Type))
+		}
+		return fmt.Errorf("error importing Kibana saved objects, %d imported, failed: %s", response.SuccessCount, strings.Join(failures, ", "))
+	}
+
+	objects := make([]interface{}, 0, len(response.SuccessResults))
+	for _, r := range response.SuccessResults {
+		destinationID := r.DestinationID
+		if destinationID == "" {
+			destinationID = r.ID
+		}
+		objects = append(objects, map[string]interface{}{
+			"type":           r.Type,
+			"id":             r.ID,
+			"destination_id": destinationID,
+		})
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("content_hash", hashSum(content))
+	ds.set("success_count", response.SuccessCount)
+	ds.set("objects", objects)
+	return ds.err
+}
+
+func kibanaSavedObjectsImportContent(file string, content string) (string, error) {
+	if file == "" {
+		return content, nil
+	}
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("error reading the NDJSON file of the Kibana saved objects: %+v", err)
+	}
+	return string(b), nil
+}
+
+// kibanaImportSavedObjects posts the NDJSON as the file of a form, the only
+// body accepted by the import API
+func kibanaImportSavedObjects(meta interface{}, path string, params url.Values, content string) (json.RawMessage, error) {
+	kibanaClient, err := getKibanaClient(meta.(*ProviderConf))
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", "export.ndjson")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write([]byte(content)); err != nil {
+		return nil, err
+	}
+	if err := form.Close(); err != nil {
+		return nil, err
+	}
+
+	res, err := kibanaClient.(*elastic7.Client).PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+		Method:      "POST",
+		Path:        path,
+		Params:      params,
+		Body:        body.String(),
+		ContentType: form.FormDataContentType(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Body, nil
+}
+
+type kibanaSavedObjectsImportResponse struct {
+	Success        bool                             `json:"success"`
+	SuccessCount   int                              `json:"successCount"`
+	Errors         []kibanaSavedObjectsImportError  `json:"errors"`
+	SuccessResults []kibanaSavedObjectsImportResult `json:"successResults"`
+}
+
+type kibanaSavedObjectsImportError struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Error struct {
+		Type string `json:"type"`
+	} `json:"error"`
+}
+
+type kibanaSavedObjectsImportResult struct {
+	ID            string `json:"id"`
+	Type          string `json:"type"`
+	DestinationID string `json:"destinationId"`
+}
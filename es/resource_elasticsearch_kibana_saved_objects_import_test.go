@@ -0,0 +1,74 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchKibanaSavedObjectsImport(t *testing.T) {
+	objectID := "test-" + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if _, err := kibanaPerformRequest(testAccKibanaProvider.Meta(), "GET", "/api/status", nil); err != nil {
+				t.Skipf("Kibana not available: %s", err)
+			}
+		},
+		Providers: testAccKibanaProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchKibanaSavedObjectsImport(objectID, "logs-*"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchKibanaSavedObjectsImportExists("elasticsearch_kibana_saved_objects_import.test"),
+					resource.TestCheckResourceAttr("elasticsearch_kibana_saved_objects_import.test", "success_count", "1"),
+					resource.TestCheckResourceAttr("elasticsearch_kibana_saved_objects_import.test", "objects.0.destination_id", objectID),
+				),
+			},
+			{
+				Config: testAccElasticsearchKibanaSavedObjectsImport(objectID, "metrics-*"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchKibanaSavedObjectsImportExists("elasticsearch_kibana_saved_objects_import.test"),
+					resource.TestCheckResourceAttr("elasticsearch_kibana_saved_objects_import.test", "success_count", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchKibanaSavedObjectsImportExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Kibana saved objects import ID is set")
+		}
+
+		path, err := kibanaSavedObjectPath(rs.Primary.Attributes["space_id"], rs.Primary.Attributes["objects.0.type"], rs.Primary.Attributes["objects.0.destination_id"])
+		if err != nil {
+			return err
+		}
+		_, err = kibanaPerformRequest(testAccKibanaProvider.Meta(), "GET", path, nil)
+		return err
+	}
+}
+
+func testAccElasticsearchKibanaSavedObjectsImport(id string, title string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_kibana_saved_objects_import" "test" {
+  overwrite = true
+  content = jsonencode({
+    type       = "index-pattern"
+    id         = "%s"
+    attributes = { title = "%s", timeFieldName = "@timestamp" }
+    references = []
+  })
+}
+`, id, title)
+}
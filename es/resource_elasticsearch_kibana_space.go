@@ -0,0 +1,167 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+// the default space of Kibana cannot be deleted
+const kibanaDefaultSpaceID = "default"
+
+func resourceElasticsearchKibanaSpace() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a Kibana space, which organizes the saved objects, e.g. the dashboards of a team, and hides the features disabled in the space. The default space is kept in Kibana on destroy. Please refer to the Kibana [spaces API documentation](https://www.elastic.co/guide/en/kibana/current/spaces-api.html) for details.",
+		Create:      resourceElasticsearchKibanaSpaceCreate,
+		Read:        resourceElasticsearchKibanaSpaceRead,
+		Update:      resourceElasticsearchKibanaSpaceUpdate,
+		Delete:      resourceElasticsearchKibanaSpaceDelete,
+		Schema: map[string]*schema.Schema{
+			"space_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-z0-9_-]+$`), "must contain lowercase letters, numbers, underscores and hyphens only"),
+				Description:  "The identifier of the space, in the URLs of its objects.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The display name of the space.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the space.",
+			},
+			"disabled_features": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The identifiers of the features hidden in the space, e.g. `dev_tools` or `ml`.",
+			},
+			"color": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`), "must be a hex color code, e.g. #aabbcc"),
+				Description:  "The hex color code of the avatar of the space, generated by Kibana if not set.",
+			},
+			"initials": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringLenBetween(1, 2),
+				Description:  "The one or two characters of the avatar of the space, taken from its name if not set.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchKibanaSpaceCreate(d *schema.ResourceData, meta interface{}) error {
+	id := d.Get("space_id").(string)
+	if _, err := kibanaPerformRequest(meta, "POST", "/api/spaces/space", expandKibanaSpace(d)); err != nil {
+		log.Printf("[INFO] Failed to create Kibana space: %+v", err)
+		return err
+	}
+
+	d.SetId(id)
+	return resourceElasticsearchKibanaSpaceRead(d, meta)
+}
+
+func resourceElasticsearchKibanaSpaceRead(d *schema.ResourceData, meta interface{}) error {
+	path, err := kibanaSpacePath(d.Id())
+	if err != nil {
+		return err
+	}
+	res, err := kibanaPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Kibana space (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	space := new(kibanaSpace)
+	if err := json.Unmarshal(res, space); err != nil {
+		return fmt.Errorf("error unmarshalling Kibana space body: %+v: %+v", err, res)
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("space_id", space.ID)
+	ds.set("name", space.Name)
+	ds.set("description", space.Description)
+	ds.set("disabled_features", space.DisabledFeatures)
+	ds.set("color", space.Color)
+	ds.set("initials", space.Initials)
+	return ds.err
+}
+
+func resourceElasticsearchKibanaSpaceUpdate(d *schema.ResourceData, meta interface{}) error {
+	path, err := kibanaSpacePath(d.Id())
+	if err != nil {
+		return err
+	}
+	if _, err := kibanaPerformRequest(meta, "PUT", path, expandKibanaSpace(d)); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchKibanaSpaceRead(d, meta)
+}
+
+func resourceElasticsearchKibanaSpaceDelete(d *schema.ResourceData, meta interface{}) error {
+	if d.Id() == kibanaDefaultSpaceID {
+		log.Printf("[INFO] Default Kibana space is kept in Kibana, removing from state only")
+		d.SetId("")
+		return nil
+	}
+
+	path, err := kibanaSpacePath(d.Id())
+	if err != nil {
+		return err
+	}
+	_, err = kibanaPerformRequest(meta, "DELETE", path, nil)
+	return err
+}
+
+func expandKibanaSpace(d *schema.ResourceData) kibanaSpace {
+	return kibanaSpace{
+		ID:               d.Get("space_id").(string),
+		Name:             d.Get("name").(string),
+		Description:      d.Get("description").(string),
+		DisabledFeatures: expandStringList(d.Get("disabled_features").(*schema.Set).List()),
+		Color:            d.Get("color").(string),
+		Initials:         d.Get("initials").(string),
+	}
+}
+
+func kibanaSpacePath(id string) (string, error) {
+	path, err := uritemplates.Expand("/api/spaces/space/{id}", map[string]string{
+		"id": id,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for Kibana space: %+v", err)
+	}
+	return path, nil
+}
+
+type kibanaSpace struct {
+	ID               string   `json:"id"`
+	Name             string   `json:"name"`
+	Description      string   `json:"description,omitempty"`
+	DisabledFeatures []string `json:"disabledFeatures"`
+	Color            string   `json:"color,omitempty"`
+	Initials         string   `json:"initials,omitempty"`
+}
@@ -0,0 +1,101 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchKibanaSpace(t *testing.T) {
+	spaceID := "test-" + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if _, err := kibanaPerformRequest(testAccKibanaProvider.Meta(), "GET", "/api/spaces/space", nil); err != nil {
+				t.Skipf("Kibana spaces not available: %s", err)
+			}
+		},
+		Providers:    testAccKibanaProviders,
+		CheckDestroy: testCheckElasticsearchKibanaSpaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchKibanaSpace(spaceID, "Search team", `["dev_tools"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchKibanaSpaceExists("elasticsearch_kibana_space.test"),
+					resource.TestCheckResourceAttr("elasticsearch_kibana_space.test", "disabled_features.#", "1"),
+					resource.TestCheckResourceAttr("elasticsearch_kibana_space.test", "initials", "ST"),
+				),
+			},
+			{
+				Config: testAccElasticsearchKibanaSpace(spaceID, "Search", `["dev_tools", "ml"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchKibanaSpaceExists("elasticsearch_kibana_space.test"),
+					resource.TestCheckResourceAttr("elasticsearch_kibana_space.test", "name", "Search"),
+					resource.TestCheckResourceAttr("elasticsearch_kibana_space.test", "disabled_features.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_kibana_space.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchKibanaSpaceExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Kibana space ID is set")
+		}
+
+		path, err := kibanaSpacePath(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		_, err = kibanaPerformRequest(testAccKibanaProvider.Meta(), "GET", path, nil)
+		return err
+	}
+}
+
+func testCheckElasticsearchKibanaSpaceDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_kibana_space" {
+			continue
+		}
+
+		path, err := kibanaSpacePath(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		_, err = kibanaPerformRequest(testAccKibanaProvider.Meta(), "GET", path, nil)
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Kibana space %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchKibanaSpace(id string, name string, disabledFeatures string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_kibana_space" "test" {
+  space_id          = "%s"
+  name              = "%s"
+  description       = "Dashboards of the team"
+  disabled_features = %s
+  color             = "#aabbcc"
+  initials          = "ST"
+}
+`, id, name, disabledFeatures)
+}
@@ -0,0 +1,204 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+var logstashPipelineMinimalVersion, _ = version.NewVersion("7.12.0")
+
+func resourceElasticsearchLogstashPipeline() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an Elasticsearch Logstash pipeline, stored for Logstash centralized pipeline management. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/logstash-api-put-pipeline.html) for more details.",
+		Create:      resourceElasticsearchLogstashPipelinePut,
+		Read:        resourceElasticsearchLogstashPipelineRead,
+		Update:      resourceElasticsearchLogstashPipelinePut,
+		Delete:      resourceElasticsearchLogstashPipelineDelete,
+		Schema: map[string]*schema.Schema{
+			"pipeline_id": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Identifier of the pipeline, used in the `xpack.management.pipeline.id` setting of Logstash.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the pipeline.",
+			},
+			"pipeline": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The configuration of the pipeline, with its `input`, `filter` and `output` sections.",
+			},
+			"pipeline_settings": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "{}",
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJson,
+				Description:      "A JSON string of the settings of the pipeline, keyed by their flat name, e.g. `{\"pipeline.workers\": 2, \"queue.type\": \"persisted\"}`.",
+			},
+			"username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "terraform",
+				Description: "The user recorded as the last one to update the pipeline.",
+			},
+			"last_modified": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the pipeline was last updated.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchLogstashPipelinePut(d *schema.ResourceData, meta interface{}) error {
+	id := d.Get("pipeline_id").(string)
+
+	if err := elastic7CheckLogstashPipelineVersion(meta); err != nil {
+		return err
+	}
+
+	pipeline := LogstashPipeline{
+		Description:      d.Get("description").(string),
+		LastModified:     time.Now().UTC().Format("2006-01-02T15:04:05.000Z"),
+		Pipeline:         d.Get("pipeline").(string),
+		PipelineSettings: json.RawMessage(d.Get("pipeline_settings").(string)),
+		Username:         d.Get("username").(string),
+	}
+	// the metadata is required, and versions the format of the pipeline
+	pipeline.PipelineMetadata.Type = "logstash_pipeline"
+	pipeline.PipelineMetadata.Version = 1
+
+	path, err := logstashPipelinePath(id)
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "PUT", path, pipeline); err != nil {
+		return err
+	}
+
+	d.SetId(id)
+	return resourceElasticsearchLogstashPipelineRead(d, meta)
+}
+
+func resourceElasticsearchLogstashPipelineRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	if err := elastic7CheckLogstashPipelineVersion(meta); err != nil {
+		return err
+	}
+
+	path, err := logstashPipelinePath(id)
+	if err != nil {
+		return err
+	}
+	body, err := elasticsearchPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Logstash pipeline (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	response := make(map[string]LogstashPipeline)
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("error unmarshalling logstash pipeline body: %+v: %+v", err, body)
+	}
+	pipeline, ok := response[id]
+	if !ok {
+		log.Printf("[WARN] Logstash pipeline (%s) not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	settings := "{}"
+	if len(pipeline.PipelineSettings) > 0 {
+		settings = string(pipeline.PipelineSettings)
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("pipeline_id", id)
+	ds.set("description", pipeline.Description)
+	ds.set("pipeline", pipeline.Pipeline)
+	ds.set("pipeline_settings", settings)
+	ds.set("username", pipeline.Username)
+	ds.set("last_modified", pipeline.LastModified)
+	return ds.err
+}
+
+func resourceElasticsearchLogstashPipelineDelete(d *schema.ResourceData, meta interface{}) error {
+	if err := elastic7CheckLogstashPipelineVersion(meta); err != nil {
+		return err
+	}
+
+	path, err := logstashPipelinePath(d.Id())
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "DELETE", path, nil); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func elastic7CheckLogstashPipelineVersion(meta interface{}) error {
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+
+	client, ok := esClient.(*elastic7.Client)
+	if !ok {
+		return fmt.Errorf("logstash pipelines only available from ElasticSearch >= 7.12, got version < 7.0.0")
+	}
+
+	elasticVersion, err := elastic7GetVersion(client)
+	if err != nil {
+		return err
+	}
+	if elasticVersion.LessThan(logstashPipelineMinimalVersion) {
+		return fmt.Errorf("logstash pipelines only available from ElasticSearch >= 7.12, got version %s", elasticVersion.String())
+	}
+	return nil
+}
+
+func logstashPipelinePath(id string) (string, error) {
+	path, err := uritemplates.Expand("/_logstash/pipeline/{id}", map[string]string{
+		"id": id,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for logstash pipeline: %+v", err)
+	}
+	return path, nil
+}
+
+type LogstashPipeline struct {
+	Description      string `json:"description"`
+	LastModified     string `json:"last_modified"`
+	Pipeline         string `json:"pipeline"`
+	PipelineMetadata struct {
+		Type    string      `json:"type"`
+		Version interface{} `json:"version"`
+	} `json:"pipeline_metadata"`
+	PipelineSettings json.RawMessage `json:"pipeline_settings"`
+	Username         string          `json:"username"`
+}
@@ -0,0 +1,121 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchLogstashPipeline(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		elasticVersion, err := elastic7GetVersion(client)
+		if err != nil {
+			t.Skipf("err: %s", err)
+		}
+		allowed = !elasticVersion.LessThan(logstashPipelineMinimalVersion)
+	default:
+		allowed = false
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Logstash pipelines only supported on ES >= 7.12")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchLogstashPipelineDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchLogstashPipeline(1),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchLogstashPipelineExists("elasticsearch_logstash_pipeline.test"),
+					resource.TestCheckResourceAttr("elasticsearch_logstash_pipeline.test", "username", "terraform"),
+					resource.TestCheckResourceAttrSet("elasticsearch_logstash_pipeline.test", "last_modified"),
+				),
+			},
+			{
+				Config: testAccElasticsearchLogstashPipeline(2),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchLogstashPipelineExists("elasticsearch_logstash_pipeline.test"),
+					resource.TestCheckResourceAttr("elasticsearch_logstash_pipeline.test", "pipeline_settings", `{"pipeline.workers":2}`),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_logstash_pipeline.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchLogstashPipelineExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No logstash pipeline ID is set")
+		}
+
+		path, err := logstashPipelinePath(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		_, err = elasticsearchPerformRequest(testAccXPackProvider.Meta(), "GET", path, nil)
+		return err
+	}
+}
+
+func testCheckElasticsearchLogstashPipelineDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_logstash_pipeline" {
+			continue
+		}
+
+		path, err := logstashPipelinePath(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if _, err := elasticsearchPerformRequest(testAccXPackProvider.Meta(), "GET", path, nil); err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Logstash pipeline %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchLogstashPipeline(workers int) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_logstash_pipeline" "test" {
+  pipeline_id = "terraform-test"
+  description = "Test pipeline"
+  pipeline    = "input { stdin {} } output { stdout {} }"
+  pipeline_settings = jsonencode({
+    "pipeline.workers" = %d
+  })
+}
+`, workers)
+}
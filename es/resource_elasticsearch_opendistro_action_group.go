@@ -0,0 +1,231 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func resourceElasticsearchOpenDistroActionGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceElasticsearchOpenDistroActionGroupCreate,
+		Read:   resourceElasticsearchOpenDistroActionGroupRead,
+		Update: resourceElasticsearchOpenDistroActionGroupUpdate,
+		Delete: resourceElasticsearchOpenDistroActionGroupDelete,
+		Schema: map[string]*schema.Schema{
+			"action_group_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"allowed_actions": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"cluster", "index", "kibana"}, false),
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchOpenDistroActionGroupCreate(d *schema.ResourceData, m interface{}) error {
+	if _, err := resourceElasticsearchPutOpenDistroActionGroup(d, m); err != nil {
+		log.Printf("[INFO] Failed to create OpenDistroActionGroup: %+v", err)
+		return err
+	}
+
+	name := d.Get("action_group_name").(string)
+	d.SetId(name)
+	return resourceElasticsearchOpenDistroActionGroupRead(d, m)
+}
+
+func resourceElasticsearchOpenDistroActionGroupRead(d *schema.ResourceData, m interface{}) error {
+	res, err := resourceElasticsearchGetOpenDistroActionGroup(d.Id(), m)
+
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] OpenDistroActionGroup (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("action_group_name", d.Id())
+	ds.set("allowed_actions", res.AllowedActions)
+	ds.set("type", res.Type)
+	ds.set("description", res.Description)
+	return ds.err
+}
+
+func resourceElasticsearchOpenDistroActionGroupUpdate(d *schema.ResourceData, m interface{}) error {
+	if _, err := resourceElasticsearchPutOpenDistroActionGroup(d, m); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchOpenDistroActionGroupRead(d, m)
+}
+
+func resourceElasticsearchOpenDistroActionGroupDelete(d *schema.ResourceData, m interface{}) error {
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_security/api/actiongroups/{name}"), map[string]string{
+		"name": d.Get("action_group_name").(string),
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for action group: %+v", err)
+	}
+
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		_, err = client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+			Method:           "DELETE",
+			Path:             path,
+			RetryStatusCodes: []int{http.StatusConflict, http.StatusInternalServerError},
+			Retrier: elastic7.NewBackoffRetrier(
+				elastic7.NewExponentialBackoff(100*time.Millisecond, 30*time.Second),
+			),
+		})
+	default:
+		err = errors.New("action group resource not implemented prior to Elastic v7")
+	}
+
+	return err
+}
+
+func resourceElasticsearchGetOpenDistroActionGroup(actionGroupID string, m interface{}) (ActionGroupBody, error) {
+	var err error
+	actionGroup := new(ActionGroupBody)
+
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_security/api/actiongroups/{name}"), map[string]string{
+		"name": actionGroupID,
+	})
+	if err != nil {
+		return *actionGroup, fmt.Errorf("error building URL path for action group: %+v", err)
+	}
+
+	var body json.RawMessage
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return *actionGroup, err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		var res *elastic7.Response
+		res, err = client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+			Method: "GET",
+			Path:   path,
+		})
+		if err == nil {
+			body = res.Body
+		}
+	default:
+		err = errors.New("action group resource not implemented prior to Elastic v7")
+	}
+
+	if err != nil {
+		return *actionGroup, err
+	}
+	var actionGroupDefinition map[string]ActionGroupBody
+
+	if err := json.Unmarshal(body, &actionGroupDefinition); err != nil {
+		return *actionGroup, fmt.Errorf("error unmarshalling action group body: %+v: %+v", err, body)
+	}
+
+	*actionGroup = actionGroupDefinition[actionGroupID]
+
+	return *actionGroup, err
+}
+
+func resourceElasticsearchPutOpenDistroActionGroup(d *schema.ResourceData, m interface{}) (*ActionGroupResponse, error) {
+	response := new(ActionGroupResponse)
+
+	actionGroupDefinition := ActionGroupBody{
+		AllowedActions: expandStringList(d.Get("allowed_actions").(*schema.Set).List()),
+		Type:           d.Get("type").(string),
+		Description:    d.Get("description").(string),
+	}
+
+	actionGroupJSON, err := json.Marshal(actionGroupDefinition)
+	if err != nil {
+		return response, fmt.Errorf("Body Error : %s", actionGroupJSON)
+	}
+
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_security/api/actiongroups/{name}"), map[string]string{
+		"name": d.Get("action_group_name").(string),
+	})
+	if err != nil {
+		return response, fmt.Errorf("error building URL path for action group: %+v", err)
+	}
+
+	var body json.RawMessage
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return nil, err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		var res *elastic7.Response
+		res, err = client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+			Method: "PUT",
+			Path:   path,
+			Body:   string(actionGroupJSON),
+			// the security configuration is updated with an optimistic lock,
+			// concurrent changes fail with a 409 or a 500, see the role resource
+			RetryStatusCodes: []int{http.StatusConflict, http.StatusInternalServerError},
+			Retrier: elastic7.NewBackoffRetrier(
+				elastic7.NewExponentialBackoff(100*time.Millisecond, 30*time.Second),
+			),
+		})
+		if err == nil {
+			body = res.Body
+		}
+	default:
+		err = errors.New("action group resource not implemented prior to Elastic v7")
+	}
+
+	if err != nil {
+		return response, fmt.Errorf("error creating action group: %+v: %+v", err, body)
+	}
+
+	if err := json.Unmarshal(body, response); err != nil {
+		return response, fmt.Errorf("error unmarshalling action group body: %+v: %+v", err, body)
+	}
+
+	return response, nil
+}
+
+type ActionGroupResponse struct {
+	Message string `json:"message"`
+	Status  string `json:"status"`
+}
+
+type ActionGroupBody struct {
+	AllowedActions []string `json:"allowed_actions"`
+	Type           string   `json:"type,omitempty"`
+	Description    string   `json:"description,omitempty"`
+}
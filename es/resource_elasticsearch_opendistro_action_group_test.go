@@ -0,0 +1,181 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchOpenDistroActionGroup(t *testing.T) {
+
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch esClient.(type) {
+	case *elastic5.Client:
+		allowed = false
+	case *elastic6.Client:
+		allowed = false
+	default:
+		allowed = true
+	}
+
+	randomName := "test" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Action groups only supported on ES >= 7")
+			}
+		},
+		Providers:    testAccOpendistroProviders,
+		CheckDestroy: testAccCheckElasticsearchOpenDistroActionGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpenDistroActionGroupResource(randomName),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticSearchOpenDistroActionGroupExists("elasticsearch_opendistro_action_group.test"),
+					resource.TestCheckResourceAttr(
+						"elasticsearch_opendistro_action_group.test",
+						"id",
+						randomName,
+					),
+					resource.TestCheckResourceAttr(
+						"elasticsearch_opendistro_action_group.test",
+						"allowed_actions.#",
+						"1",
+					),
+					resource.TestCheckResourceAttr(
+						"elasticsearch_opendistro_role.test",
+						"index_permissions.#",
+						"1",
+					),
+				),
+			},
+			{
+				Config: testAccOpenDistroActionGroupResourceUpdated(randomName),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticSearchOpenDistroActionGroupExists("elasticsearch_opendistro_action_group.test"),
+					resource.TestCheckResourceAttr(
+						"elasticsearch_opendistro_action_group.test",
+						"allowed_actions.#",
+						"2",
+					),
+					resource.TestCheckResourceAttr(
+						"elasticsearch_opendistro_action_group.test",
+						"description",
+						"test2",
+					),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckElasticsearchOpenDistroActionGroupDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_opendistro_action_group" {
+			continue
+		}
+
+		meta := testAccOpendistroProvider.Meta()
+
+		var err error
+		esClient, err := getClient(meta.(*ProviderConf))
+		if err != nil {
+			return err
+		}
+		switch esClient.(type) {
+		case *elastic7.Client:
+			_, err = resourceElasticsearchGetOpenDistroActionGroup(rs.Primary.ID, meta.(*ProviderConf))
+		default:
+		}
+
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("ActionGroup %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testCheckElasticSearchOpenDistroActionGroupExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "elasticsearch_opendistro_action_group" {
+				continue
+			}
+
+			meta := testAccOpendistroProvider.Meta()
+
+			var err error
+			esClient, err := getClient(meta.(*ProviderConf))
+			if err != nil {
+				return err
+			}
+			switch esClient.(type) {
+			case *elastic7.Client:
+				_, err = resourceElasticsearchGetOpenDistroActionGroup(rs.Primary.ID, meta.(*ProviderConf))
+			default:
+			}
+
+			if err != nil {
+				return err
+			}
+
+			return nil
+		}
+
+		return nil
+	}
+}
+
+func testAccOpenDistroActionGroupResource(resourceName string) string {
+	return fmt.Sprintf(`
+	resource "elasticsearch_opendistro_action_group" "test" {
+		action_group_name = "%s"
+		type              = "index"
+		description       = "test"
+		allowed_actions   = ["indices:data/read/search*"]
+	}
+
+	resource "elasticsearch_opendistro_role" "test" {
+		role_name = "%s"
+
+		index_permissions {
+			index_patterns  = ["logs-*"]
+			allowed_actions = [elasticsearch_opendistro_action_group.test.id]
+		}
+	}
+	`, resourceName, resourceName)
+}
+
+func testAccOpenDistroActionGroupResourceUpdated(resourceName string) string {
+	return fmt.Sprintf(`
+	resource "elasticsearch_opendistro_action_group" "test" {
+		action_group_name = "%s"
+		type              = "index"
+		description       = "test2"
+		allowed_actions   = ["indices:data/read/search*", "indices:data/read/get*"]
+	}
+	`, resourceName)
+}
@@ -0,0 +1,354 @@
+package es
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+// the cluster has a single audit configuration
+const openDistroAuditConfigID = "audit_config"
+
+const openDistroAuditConfigPath = "/_opendistro/_security/api/audit/config"
+
+var openDistroAuditCategories = []string{
+	"AUTHENTICATED",
+	"BAD_HEADERS",
+	"FAILED_LOGIN",
+	"GRANTED_PRIVILEGES",
+	"MISSING_PRIVILEGES",
+	"SSL_EXCEPTION",
+	"INDEX_EVENT",
+	"COMPLIANCE_DOC_READ",
+	"COMPLIANCE_DOC_WRITE",
+	"COMPLIANCE_EXTERNAL_CONFIG",
+	"COMPLIANCE_INTERNAL_CONFIG_READ",
+	"COMPLIANCE_INTERNAL_CONFIG_WRITE",
+}
+
+var openDistroAuditConfigAudit = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"enable_rest": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"enable_transport": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"disabled_rest_categories": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice(openDistroAuditCategories, false),
+			},
+		},
+		"disabled_transport_categories": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice(openDistroAuditCategories, false),
+			},
+		},
+		"ignore_users": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"ignore_requests": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"log_request_body": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"resolve_indices": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"resolve_bulk_requests": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"exclude_sensitive_headers": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+	},
+}
+
+var openDistroAuditConfigCompliance = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"enabled": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"internal_config": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"external_config": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"read_metadata_only": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"read_watched_fields": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"index": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"fields": {
+						Type:     schema.TypeSet,
+						Required: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+		},
+		"read_ignore_users": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"write_metadata_only": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"write_log_diffs": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"write_watched_indices": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"write_ignore_users": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	},
+}
+
+func resourceElasticsearchOpenDistroAuditConfig() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceElasticsearchOpenDistroAuditConfigCreate,
+		Read:   resourceElasticsearchOpenDistroAuditConfigRead,
+		Update: resourceElasticsearchOpenDistroAuditConfigUpdate,
+		Delete: resourceElasticsearchOpenDistroAuditConfigDelete,
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			// the blocks which are not configured are left as they are
+			"audit": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem:     openDistroAuditConfigAudit,
+			},
+			"compliance": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem:     openDistroAuditConfigCompliance,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchOpenDistroAuditConfigCreate(d *schema.ResourceData, m interface{}) error {
+	if err := resourceElasticsearchPutOpenDistroAuditConfig(d, m); err != nil {
+		log.Printf("[INFO] Failed to put OpenDistroAuditConfig: %+v", err)
+		return err
+	}
+
+	d.SetId(openDistroAuditConfigID)
+	return resourceElasticsearchOpenDistroAuditConfigRead(d, m)
+}
+
+func resourceElasticsearchOpenDistroAuditConfigRead(d *schema.ResourceData, m interface{}) error {
+	res, err := resourceElasticsearchGetOpenDistroAuditConfig(m)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] OpenDistroAuditConfig (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	audit := flattenOpenDistroAuditConfigBlock(openDistroAuditConfigAudit, res.Audit)
+	compliance := flattenOpenDistroAuditConfigBlock(openDistroAuditConfigCompliance, res.Compliance)
+	watchedFields := make([]interface{}, 0)
+	if fields, ok := res.Compliance["read_watched_fields"].(map[string]interface{}); ok {
+		for index, indexFields := range fields {
+			watchedFields = append(watchedFields, map[string]interface{}{
+				"index":  index,
+				"fields": indexFields,
+			})
+		}
+	}
+	compliance["read_watched_fields"] = watchedFields
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("enabled", res.Enabled)
+	ds.set("audit", []interface{}{audit})
+	ds.set("compliance", []interface{}{compliance})
+	return ds.err
+}
+
+func resourceElasticsearchOpenDistroAuditConfigUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := resourceElasticsearchPutOpenDistroAuditConfig(d, m); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchOpenDistroAuditConfigRead(d, m)
+}
+
+func resourceElasticsearchOpenDistroAuditConfigDelete(d *schema.ResourceData, m interface{}) error {
+	log.Printf("[INFO] Audit configuration is kept on the cluster, removing from state only")
+	d.SetId("")
+	return nil
+}
+
+func resourceElasticsearchGetOpenDistroAuditConfig(m interface{}) (*AuditConfig, error) {
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := esClient.(*elastic7.Client); !ok {
+		return nil, errors.New("audit config resource not implemented prior to Elastic v7")
+	}
+
+	body, err := elasticsearchPerformRequest(m, "GET", openDistroPath(m, openDistroAuditConfigPath), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(AuditConfigResponse)
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling audit config body: %+v: %+v", err, body)
+	}
+	if response.Config.Audit == nil {
+		response.Config.Audit = make(map[string]interface{})
+	}
+	if response.Config.Compliance == nil {
+		response.Config.Compliance = make(map[string]interface{})
+	}
+
+	return &response.Config, nil
+}
+
+// resourceElasticsearchPutOpenDistroAuditConfig puts the configured blocks
+// over the current configuration, which the API only replaces as a whole
+func resourceElasticsearchPutOpenDistroAuditConfig(d *schema.ResourceData, m interface{}) error {
+	config, err := resourceElasticsearchGetOpenDistroAuditConfig(m)
+	if err != nil {
+		return err
+	}
+
+	config.Enabled = d.Get("enabled").(bool)
+	if audit, ok := d.GetOk("audit"); ok {
+		expandOpenDistroAuditConfigBlock(openDistroAuditConfigAudit, audit.([]interface{}), config.Audit)
+	}
+	if compliance, ok := d.GetOk("compliance"); ok {
+		blocks := compliance.([]interface{})
+		expandOpenDistroAuditConfigBlock(openDistroAuditConfigCompliance, blocks, config.Compliance)
+
+		watchedFields := make(map[string]interface{})
+		if len(blocks) > 0 && blocks[0] != nil {
+			for _, f := range blocks[0].(map[string]interface{})["read_watched_fields"].(*schema.Set).List() {
+				field := f.(map[string]interface{})
+				watchedFields[field["index"].(string)] = expandStringList(field["fields"].(*schema.Set).List())
+			}
+		}
+		config.Compliance["read_watched_fields"] = watchedFields
+	}
+
+	if _, err := elasticsearchPerformRequest(m, "PUT", openDistroPath(m, openDistroAuditConfigPath), config); err != nil {
+		return fmt.Errorf("error putting audit config: %+v", err)
+	}
+	return nil
+}
+
+// expandOpenDistroAuditConfigBlock sets the values of the block, named as
+// in the API, into the configuration, the nested blocks are left to the
+// caller
+func expandOpenDistroAuditConfigBlock(block *schema.Resource, blocks []interface{}, config map[string]interface{}) {
+	if len(blocks) == 0 || blocks[0] == nil {
+		return
+	}
+	for key, value := range blocks[0].(map[string]interface{}) {
+		if _, nested := block.Schema[key].Elem.(*schema.Resource); nested {
+			continue
+		}
+		switch v := value.(type) {
+		case *schema.Set:
+			config[key] = expandStringList(v.List())
+		default:
+			config[key] = v
+		}
+	}
+}
+
+func flattenOpenDistroAuditConfigBlock(block *schema.Resource, config map[string]interface{}) map[string]interface{} {
+	flattened := make(map[string]interface{})
+	for key, s := range block.Schema {
+		if _, nested := s.Elem.(*schema.Resource); nested {
+			continue
+		}
+		if value, ok := config[key]; ok {
+			flattened[key] = value
+		}
+	}
+	return flattened
+}
+
+type AuditConfigResponse struct {
+	Readonly []string    `json:"_readonly,omitempty"`
+	Config   AuditConfig `json:"config"`
+}
+
+type AuditConfig struct {
+	Enabled    bool                   `json:"enabled"`
+	Audit      map[string]interface{} `json:"audit"`
+	Compliance map[string]interface{} `json:"compliance"`
+}
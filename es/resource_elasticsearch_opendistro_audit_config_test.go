@@ -0,0 +1,103 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchOpenDistroAuditConfig(t *testing.T) {
+
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	_, allowed := esClient.(*elastic7.Client)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Audit config only supported on ES >= 7")
+			}
+		},
+		Providers: testAccOpendistroProviders,
+		// the audit configuration is kept on destroy
+		CheckDestroy: func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpenDistroAuditConfigResource("kibanaserver"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticSearchOpenDistroAuditConfigExists("elasticsearch_opendistro_audit_config.test"),
+					resource.TestCheckResourceAttr(
+						"elasticsearch_opendistro_audit_config.test",
+						"audit.0.ignore_users.#",
+						"1",
+					),
+					resource.TestCheckResourceAttr(
+						"elasticsearch_opendistro_audit_config.test",
+						"compliance.0.read_watched_fields.#",
+						"1",
+					),
+				),
+			},
+			{
+				Config: testAccOpenDistroAuditConfigResource("logstash"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticSearchOpenDistroAuditConfigExists("elasticsearch_opendistro_audit_config.test"),
+					resource.TestCheckResourceAttr(
+						"elasticsearch_opendistro_audit_config.test",
+						"audit.0.disabled_rest_categories.#",
+						"2",
+					),
+				),
+			},
+		},
+	})
+}
+
+func testCheckElasticSearchOpenDistroAuditConfigExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No audit config ID is set")
+		}
+
+		_, err := resourceElasticsearchGetOpenDistroAuditConfig(testAccOpendistroProvider.Meta())
+		return err
+	}
+}
+
+func testAccOpenDistroAuditConfigResource(ignoredUser string) string {
+	return fmt.Sprintf(`
+	resource "elasticsearch_opendistro_audit_config" "test" {
+		enabled = true
+
+		audit {
+			ignore_users             = ["%s"]
+			disabled_rest_categories = ["AUTHENTICATED", "GRANTED_PRIVILEGES"]
+		}
+
+		compliance {
+			read_watched_fields {
+				index  = "customers"
+				fields = ["email"]
+			}
+		}
+	}
+	`, ignoredUser)
+}
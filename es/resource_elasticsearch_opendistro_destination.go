@@ -113,7 +113,7 @@ func resourceElasticsearchOpenDistroDestinationUpdate(d *schema.ResourceData, m
 func resourceElasticsearchOpenDistroDestinationDelete(d *schema.ResourceData, m interface{}) error {
 	var err error
 
-	path, err := uritemplates.Expand("/_opendistro/_alerting/destinations/{id}", map[string]string{
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_alerting/destinations/{id}"), map[string]string{
 		"id": d.Id(),
 	})
 	if err != nil {
@@ -145,7 +145,7 @@ func resourceElasticsearchOpenDistroDestinationDelete(d *schema.ResourceData, m
 func resourceElasticsearchOpenDistroGetDestination(destinationID string, esClient interface{}) (Destination, error) {
 	switch client := esClient.(type) {
 	case *elastic7.Client:
-		path, err := uritemplates.Expand("/_opendistro/_alerting/destinations/{id}", map[string]string{
+		path, err := uritemplates.Expand(openDistroPath(esClient, "/_opendistro/_alerting/destinations/{id}"), map[string]string{
 			"id": destinationID,
 		})
 		if err != nil {
@@ -223,7 +223,7 @@ func resourceElasticsearchOpenDistroPostDestination(d *schema.ResourceData, m in
 	var err error
 	response := new(destinationResponse)
 
-	path := "/_opendistro/_alerting/destinations/"
+	path := openDistroPath(m, "/_opendistro/_alerting/destinations/")
 
 	var body json.RawMessage
 	esClient, err := getClient(m.(*ProviderConf))
@@ -238,7 +238,9 @@ func resourceElasticsearchOpenDistroPostDestination(d *schema.ResourceData, m in
 			Path:   path,
 			Body:   destinationJSON,
 		})
-		body = res.Body
+		if err == nil {
+			body = res.Body
+		}
 	case *elastic6.Client:
 		var res *elastic6.Response
 		res, err = client.PerformRequest(context.TODO(), elastic6.PerformRequestOptions{
@@ -246,7 +248,9 @@ func resourceElasticsearchOpenDistroPostDestination(d *schema.ResourceData, m in
 			Path:   path,
 			Body:   destinationJSON,
 		})
-		body = res.Body
+		if err == nil {
+			body = res.Body
+		}
 	default:
 		err = errors.New("destination resource not implemented prior to Elastic v6")
 	}
@@ -268,7 +272,7 @@ func resourceElasticsearchOpenDistroPutDestination(d *schema.ResourceData, m int
 	var err error
 	response := new(destinationResponse)
 
-	path, err := uritemplates.Expand("/_opendistro/_alerting/destinations/{id}", map[string]string{
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_alerting/destinations/{id}"), map[string]string{
 		"id": d.Id(),
 	})
 	if err != nil {
@@ -288,7 +292,9 @@ func resourceElasticsearchOpenDistroPutDestination(d *schema.ResourceData, m int
 			Path:   path,
 			Body:   destinationJSON,
 		})
-		body = res.Body
+		if err == nil {
+			body = res.Body
+		}
 	case *elastic6.Client:
 		var res *elastic6.Response
 		res, err = client.PerformRequest(context.TODO(), elastic6.PerformRequestOptions{
@@ -296,7 +302,9 @@ func resourceElasticsearchOpenDistroPutDestination(d *schema.ResourceData, m int
 			Path:   path,
 			Body:   destinationJSON,
 		})
-		body = res.Body
+		if err == nil {
+			body = res.Body
+		}
 	default:
 		err = errors.New("destination resource not implemented prior to Elastic v6")
 	}
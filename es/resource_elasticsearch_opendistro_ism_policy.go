@@ -113,7 +113,7 @@ func resourceElasticsearchOpenDistroISMPolicyUpdate(d *schema.ResourceData, m in
 }
 
 func resourceElasticsearchOpenDistroISMPolicyDelete(d *schema.ResourceData, m interface{}) error {
-	path, err := uritemplates.Expand("/_opendistro/_ism/policies/{policy_id}", map[string]string{
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_ism/policies/{policy_id}"), map[string]string{
 		"policy_id": d.Id(),
 	})
 	if err != nil {
@@ -158,7 +158,7 @@ func resourceElasticsearchGetOpenDistroISMPolicy(policyID string, m interface{})
 	var err error
 	response := new(GetPolicyResponse)
 
-	path, err := uritemplates.Expand("/_opendistro/_ism/policies/{policy_id}", map[string]string{
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_ism/policies/{policy_id}"), map[string]string{
 		"policy_id": policyID,
 	})
 
@@ -223,7 +223,7 @@ func resourceElasticsearchPutOpenDistroISMPolicy(d *schema.ResourceData, m inter
 		params.Set("if_primary_term", strconv.Itoa(primTerm))
 	}
 
-	path, err := uritemplates.Expand("/_opendistro/_ism/policies/{policy_id}", map[string]string{
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_ism/policies/{policy_id}"), map[string]string{
 		"policy_id": d.Get("policy_id").(string),
 	})
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
@@ -17,7 +18,7 @@ import (
 
 func resourceElasticsearchOpenDistroISMPolicyMapping() *schema.Resource {
 	return &schema.Resource{
-		Description: "Provides an Elasticsearch Open Distro ISM policy. Please refer to the Open Distro [ISM documentation](https://opendistro.github.io/for-elasticsearch-docs/docs/ism/) for details.",
+		Description: "Attaches an Open Distro ISM policy to existing indices, changes it or removes it, e.g. for the indices created by log shippers. The `ism_template` of policies only applies to the indices created after the policy. Only the `opendistro.index_state_management.policy_id` index setting was deprecated in ODFE 1.13 in favor of `ism_template`, the add and change policy APIs used by this resource are still the way to manage the policy of existing indices. Please refer to the Open Distro [ISM documentation](https://opendistro.github.io/for-elasticsearch-docs/docs/ism/) for details.",
 		Create:      resourceElasticsearchOpenDistroISMPolicyMappingCreate,
 		Read:        resourceElasticsearchOpenDistroISMPolicyMappingRead,
 		Update:      resourceElasticsearchOpenDistroISMPolicyMappingUpdate,
@@ -49,13 +50,14 @@ func resourceElasticsearchOpenDistroISMPolicyMapping() *schema.Resource {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
-				Description: "",
+				Description: "Whether the policy change is only applied if the new policy does not change the states or actions of the current state of the indices.",
 			},
 			"managed_indexes": {
-				Type:     schema.TypeSet,
-				Optional: true,
-				Computed: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The indices matching `indexes` which are managed by the policy.",
 			},
 		},
 		Importer: &schema.ResourceImporter{
@@ -65,7 +67,6 @@ func resourceElasticsearchOpenDistroISMPolicyMapping() *schema.Resource {
 			Create: schema.DefaultTimeout(5 * time.Minute),
 			Update: schema.DefaultTimeout(5 * time.Minute),
 		},
-		DeprecationMessage: "elasticsearch_opendistro_ism_policy_mapping is deprecated in ODFE 1.13.x please use ism_template attribute in policies instead.",
 	}
 }
 
@@ -210,7 +211,7 @@ func resourceElasticsearchPostOpendistroPolicyMapping(d *schema.ResourceData, m
 
 	}
 
-	path, err := uritemplates.Expand("/_opendistro/_ism/{action}/{indexes}", map[string]string{
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_ism/{action}/{indexes}"), map[string]string{
 		"indexes": d.Get("indexes").(string),
 		"action":  action,
 	})
@@ -247,12 +248,36 @@ func resourceElasticsearchPostOpendistroPolicyMapping(d *schema.ResourceData, m
 		return response, fmt.Errorf("error unmarshalling policy body: %+v: %+v", err, body)
 	}
 
-	return response, nil
+	warning, err := checkOpendistroPolicyMappingResponse(response, action, d.Get("policy_id").(string), d.Get("indexes").(string))
+	if warning != "" {
+		log.Printf("[WARN] %s", warning)
+	}
+
+	return response, err
+}
+
+// checkOpendistroPolicyMappingResponse reports the indices which can't be
+// updated, e.g. already managed by a policy when adding, which are only
+// reported in the response. The pattern may match some of them, so it only
+// fails if no index was updated, and warns otherwise
+func checkOpendistroPolicyMappingResponse(response *PolicyMappingResponse, action string, policyID string, indexes string) (string, error) {
+	if !response.Failures {
+		return "", nil
+	}
+
+	failures := make([]string, 0, len(response.FailedIndices))
+	for _, failed := range response.FailedIndices {
+		failures = append(failures, fmt.Sprintf("%s: %s", failed.IndexName, failed.Reason))
+	}
+	if action != "remove" && response.UpdatedIndices == 0 {
+		return "", fmt.Errorf("error applying %s of policy %s to indices %s: %s", action, policyID, indexes, strings.Join(failures, "; "))
+	}
+	return fmt.Sprintf("%s of policy %s failed for some indices of %s: %s", action, policyID, indexes, strings.Join(failures, "; ")), nil
 }
 
 func resourceElasticsearchGetOpendistroPolicyMapping(indexPattern string, m interface{}) (map[string]interface{}, error) {
 	response := new(map[string]interface{})
-	path, err := uritemplates.Expand("/_opendistro/_ism/explain/{index_pattern}", map[string]string{
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_ism/explain/{index_pattern}"), map[string]string{
 		"index_pattern": indexPattern,
 	})
 	if err != nil {
@@ -291,9 +316,15 @@ func resourceElasticsearchGetOpendistroPolicyMapping(indexPattern string, m inte
 }
 
 type PolicyMappingResponse struct {
-	UpdatedIndices int           `json:"updated_indices"`
-	Failures       bool          `json:"failures"`
-	FailedIndices  []interface{} `json:"failed_indices"`
+	UpdatedIndices int                        `json:"updated_indices"`
+	Failures       bool                       `json:"failures"`
+	FailedIndices  []PolicyMappingFailedIndex `json:"failed_indices"`
+}
+
+type PolicyMappingFailedIndex struct {
+	IndexName string `json:"index_name"`
+	IndexUUID string `json:"index_uuid"`
+	Reason    string `json:"reason"`
 }
 
 type PolicyMapping struct {
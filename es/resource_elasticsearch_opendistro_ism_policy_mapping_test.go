@@ -2,6 +2,7 @@ package es
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	elastic7 "github.com/olivere/elastic/v7"
@@ -13,6 +14,30 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 )
 
+func TestCheckOpendistroPolicyMappingResponse(t *testing.T) {
+	failed := []PolicyMappingFailedIndex{{IndexName: "filebeat-1", Reason: "This index already has a policy, use the update policy API to update index policies"}}
+
+	warning, err := checkOpendistroPolicyMappingResponse(&PolicyMappingResponse{UpdatedIndices: 0, Failures: true, FailedIndices: failed}, "add", "policy_1", "filebeat-*")
+	if err == nil || !strings.Contains(err.Error(), "filebeat-1: This index already has a policy") || warning != "" {
+		t.Errorf("expected no updated index to fail, got: %q, %v", warning, err)
+	}
+
+	warning, err = checkOpendistroPolicyMappingResponse(&PolicyMappingResponse{UpdatedIndices: 1, Failures: true, FailedIndices: failed}, "add", "policy_1", "filebeat-*")
+	if err != nil || !strings.Contains(warning, "add of policy policy_1 failed for some indices of filebeat-*: filebeat-1") {
+		t.Errorf("expected a partial failure to warn, got: %q, %v", warning, err)
+	}
+
+	warning, err = checkOpendistroPolicyMappingResponse(&PolicyMappingResponse{UpdatedIndices: 0, Failures: true, FailedIndices: failed}, "remove", "policy_1", "filebeat-*")
+	if err != nil || warning == "" {
+		t.Errorf("expected a failed remove to warn, got: %q, %v", warning, err)
+	}
+
+	warning, err = checkOpendistroPolicyMappingResponse(&PolicyMappingResponse{UpdatedIndices: 2}, "change_policy", "policy_1", "filebeat-*")
+	if err != nil || warning != "" {
+		t.Errorf("expected no failure to pass, got: %q, %v", warning, err)
+	}
+}
+
 func TestAccElasticsearchOpenDistroISMPolicyMapping(t *testing.T) {
 	provider := Provider().(*schema.Provider)
 	err := provider.Configure(&terraform.ResourceConfig{})
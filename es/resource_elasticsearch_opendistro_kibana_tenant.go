@@ -110,7 +110,7 @@ func resourceElasticsearchOpenDistroKibanaTenantUpdate(d *schema.ResourceData, m
 }
 
 func resourceElasticsearchOpenDistroKibanaTenantDelete(d *schema.ResourceData, m interface{}) error {
-	path, err := uritemplates.Expand("/_opendistro/_security/api/tenants/{name}", map[string]string{
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_security/api/tenants/{name}"), map[string]string{
 		"name": d.Get("tenant_name").(string),
 	})
 	if err != nil {
@@ -138,7 +138,7 @@ func resourceElasticsearchGetOpenDistroKibanaTenant(tenantID string, m interface
 	var err error
 	tenant := new(TenantBody)
 
-	path, err := uritemplates.Expand("/_opendistro/_security/api/tenants/{name}", map[string]string{
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_security/api/tenants/{name}"), map[string]string{
 		"name": tenantID,
 	})
 
@@ -158,7 +158,9 @@ func resourceElasticsearchGetOpenDistroKibanaTenant(tenantID string, m interface
 			Method: "GET",
 			Path:   path,
 		})
-		body = res.Body
+		if err == nil {
+			body = res.Body
+		}
 	default:
 		err = errors.New("Creating tenants requires elastic v7 client")
 	}
@@ -189,7 +191,7 @@ func resourceElasticsearchPutOpenDistroKibanaTenant(d *schema.ResourceData, m in
 		return response, fmt.Errorf("Body Error : %s", tenantJSON)
 	}
 
-	path, err := uritemplates.Expand("/_opendistro/_security/api/tenants/{name}", map[string]string{
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_security/api/tenants/{name}"), map[string]string{
 		"name": d.Get("tenant_name").(string),
 	})
 	if err != nil {
@@ -209,7 +211,9 @@ func resourceElasticsearchPutOpenDistroKibanaTenant(d *schema.ResourceData, m in
 			Path:   path,
 			Body:   string(tenantJSON),
 		})
-		body = res.Body
+		if err == nil {
+			body = res.Body
+		}
 	default:
 		err = errors.New("Creating tenants requires elastic v7 client")
 	}
@@ -113,7 +113,7 @@ func resourceElasticsearchOpenDistroMonitorUpdate(d *schema.ResourceData, m inte
 func resourceElasticsearchOpenDistroMonitorDelete(d *schema.ResourceData, m interface{}) error {
 	var err error
 
-	path, err := uritemplates.Expand("/_opendistro/_alerting/monitors/{id}", map[string]string{
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_alerting/monitors/{id}"), map[string]string{
 		"id": d.Id(),
 	})
 	if err != nil {
@@ -146,7 +146,7 @@ func resourceElasticsearchOpenDistroGetMonitor(monitorID string, m interface{})
 	var err error
 	response := new(monitorResponse)
 
-	path, err := uritemplates.Expand("/_opendistro/_alerting/monitors/{id}", map[string]string{
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_alerting/monitors/{id}"), map[string]string{
 		"id": monitorID,
 	})
 	if err != nil {
@@ -165,14 +165,18 @@ func resourceElasticsearchOpenDistroGetMonitor(monitorID string, m interface{})
 			Method: "GET",
 			Path:   path,
 		})
-		body = res.Body
+		if err == nil {
+			body = res.Body
+		}
 	case *elastic6.Client:
 		var res *elastic6.Response
 		res, err = client.PerformRequest(context.TODO(), elastic6.PerformRequestOptions{
 			Method: "GET",
 			Path:   path,
 		})
-		body = res.Body
+		if err == nil {
+			body = res.Body
+		}
 	default:
 		err = errors.New("monitor resource not implemented prior to Elastic v6")
 	}
@@ -194,7 +198,7 @@ func resourceElasticsearchOpenDistroPostMonitor(d *schema.ResourceData, m interf
 	var err error
 	response := new(monitorResponse)
 
-	path := "/_opendistro/_alerting/monitors/"
+	path := openDistroPath(m, "/_opendistro/_alerting/monitors/")
 
 	var body json.RawMessage
 	esClient, err := getClient(m.(*ProviderConf))
@@ -209,7 +213,9 @@ func resourceElasticsearchOpenDistroPostMonitor(d *schema.ResourceData, m interf
 			Path:   path,
 			Body:   monitorJSON,
 		})
-		body = res.Body
+		if err == nil {
+			body = res.Body
+		}
 	case *elastic6.Client:
 		var res *elastic6.Response
 		res, err = client.PerformRequest(context.TODO(), elastic6.PerformRequestOptions{
@@ -217,7 +223,9 @@ func resourceElasticsearchOpenDistroPostMonitor(d *schema.ResourceData, m interf
 			Path:   path,
 			Body:   monitorJSON,
 		})
-		body = res.Body
+		if err == nil {
+			body = res.Body
+		}
 	default:
 		err = errors.New("monitor resource not implemented prior to Elastic v6")
 	}
@@ -239,7 +247,7 @@ func resourceElasticsearchOpenDistroPutMonitor(d *schema.ResourceData, m interfa
 	var err error
 	response := new(monitorResponse)
 
-	path, err := uritemplates.Expand("/_opendistro/_alerting/monitors/{id}", map[string]string{
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_alerting/monitors/{id}"), map[string]string{
 		"id": d.Id(),
 	})
 	if err != nil {
@@ -259,7 +267,9 @@ func resourceElasticsearchOpenDistroPutMonitor(d *schema.ResourceData, m interfa
 			Path:   path,
 			Body:   monitorJSON,
 		})
-		body = res.Body
+		if err == nil {
+			body = res.Body
+		}
 	case *elastic6.Client:
 		var res *elastic6.Response
 		res, err = client.PerformRequest(context.TODO(), elastic6.PerformRequestOptions{
@@ -267,7 +277,9 @@ func resourceElasticsearchOpenDistroPutMonitor(d *schema.ResourceData, m interfa
 			Path:   path,
 			Body:   monitorJSON,
 		})
-		body = res.Body
+		if err == nil {
+			body = res.Body
+		}
 	default:
 		err = errors.New("monitor resource not implemented prior to Elastic v6")
 	}
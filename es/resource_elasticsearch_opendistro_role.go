@@ -173,7 +173,7 @@ func resourceElasticsearchOpenDistroRoleUpdate(d *schema.ResourceData, m interfa
 }
 
 func resourceElasticsearchOpenDistroRoleDelete(d *schema.ResourceData, m interface{}) error {
-	path, err := uritemplates.Expand("/_opendistro/_security/api/roles/{name}", map[string]string{
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_security/api/roles/{name}"), map[string]string{
 		"name": d.Get("role_name").(string),
 	})
 	if err != nil {
@@ -205,7 +205,7 @@ func resourceElasticsearchGetOpenDistroRole(roleID string, m interface{}) (RoleB
 	var err error
 	role := new(RoleBody)
 
-	path, err := uritemplates.Expand("/_opendistro/_security/api/roles/{name}", map[string]string{
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_security/api/roles/{name}"), map[string]string{
 		"name": roleID,
 	})
 
@@ -225,7 +225,9 @@ func resourceElasticsearchGetOpenDistroRole(roleID string, m interface{}) (RoleB
 			Method: "GET",
 			Path:   path,
 		})
-		body = res.Body
+		if err == nil {
+			body = res.Body
+		}
 	default:
 		err = errors.New("role resource not implemented prior to Elastic v7")
 	}
@@ -288,7 +290,7 @@ func resourceElasticsearchPutOpenDistroRole(d *schema.ResourceData, m interface{
 		return response, fmt.Errorf("Body Error : %s", roleJSON)
 	}
 
-	path, err := uritemplates.Expand("/_opendistro/_security/api/roles/{name}", map[string]string{
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_security/api/roles/{name}"), map[string]string{
 		"name": d.Get("role_name").(string),
 	})
 	if err != nil {
@@ -317,7 +319,9 @@ func resourceElasticsearchPutOpenDistroRole(d *schema.ResourceData, m interface{
 				elastic7.NewExponentialBackoff(100*time.Millisecond, 30*time.Second),
 			),
 		})
-		body = res.Body
+		if err == nil {
+			body = res.Body
+		}
 	default:
 		err = errors.New("role resource not implemented prior to Elastic v7")
 	}
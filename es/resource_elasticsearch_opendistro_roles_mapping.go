@@ -51,6 +51,14 @@ func resourceElasticsearchOpenDistroRolesMapping() *schema.Resource {
 				Optional: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+			"reserved": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"hidden": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 		},
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
@@ -59,6 +67,9 @@ func resourceElasticsearchOpenDistroRolesMapping() *schema.Resource {
 }
 
 func resourceElasticsearchOpenDistroRolesMappingCreate(d *schema.ResourceData, m interface{}) error {
+	if err := checkOpenDistroRolesMappingEditable(d.Get("role_name").(string), m); err != nil {
+		return err
+	}
 	if _, err := resourceElasticsearchPutOpenDistroRolesMapping(d, m); err != nil {
 		log.Printf("[INFO] Failed to put role mapping: %+v", err)
 		return err
@@ -99,11 +110,20 @@ func resourceElasticsearchOpenDistroRolesMappingRead(d *schema.ResourceData, m i
 	if err := d.Set("and_backend_roles", res.AndBackendRoles); err != nil {
 		return fmt.Errorf("error setting and_backend_roles: %s", err)
 	}
+	if err := d.Set("reserved", res.Reserved); err != nil {
+		return fmt.Errorf("error setting reserved: %s", err)
+	}
+	if err := d.Set("hidden", res.Hidden); err != nil {
+		return fmt.Errorf("error setting hidden: %s", err)
+	}
 
 	return nil
 }
 
 func resourceElasticsearchOpenDistroRolesMappingUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := checkOpenDistroRolesMappingEditable(d.Id(), m); err != nil {
+		return err
+	}
 	if _, err := resourceElasticsearchPutOpenDistroRolesMapping(d, m); err != nil {
 		return err
 	}
@@ -112,7 +132,14 @@ func resourceElasticsearchOpenDistroRolesMappingUpdate(d *schema.ResourceData, m
 }
 
 func resourceElasticsearchOpenDistroRolesMappingDelete(d *schema.ResourceData, m interface{}) error {
-	path, err := uritemplates.Expand("/_opendistro/_security/api/rolesmapping/{name}", map[string]string{
+	// reserved and hidden mappings are part of the security configuration,
+	// the API refuses to delete them
+	if d.Get("reserved").(bool) || d.Get("hidden").(bool) {
+		log.Printf("[WARN] Role mapping %s is reserved, removing from state only", d.Id())
+		return nil
+	}
+
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_security/api/rolesmapping/{name}"), map[string]string{
 		"name": d.Get("role_name").(string),
 	})
 	if err != nil {
@@ -144,7 +171,7 @@ func resourceElasticsearchGetOpenDistroRolesMapping(roleID string, m interface{}
 	var err error
 	var roleMapping = new(RolesMapping)
 
-	path, err := uritemplates.Expand("/_opendistro/_security/api/rolesmapping/{name}", map[string]string{
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_security/api/rolesmapping/{name}"), map[string]string{
 		"name": roleID,
 	})
 
@@ -163,7 +190,9 @@ func resourceElasticsearchGetOpenDistroRolesMapping(roleID string, m interface{}
 			Method: "GET",
 			Path:   path,
 		})
-		body = res.Body
+		if err == nil {
+			body = res.Body
+		}
 	default:
 		err = errors.New("role mapping  resource not implemented prior to Elastic v7")
 	}
@@ -182,6 +211,24 @@ func resourceElasticsearchGetOpenDistroRolesMapping(roleID string, m interface{}
 	return *roleMapping, err
 }
 
+// checkOpenDistroRolesMappingEditable fails when the role mapping exists and
+// is reserved or hidden, which the security API only reports as a forbidden
+// error without details.
+func checkOpenDistroRolesMappingEditable(roleID string, m interface{}) error {
+	res, err := resourceElasticsearchGetOpenDistroRolesMapping(roleID, m)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if res.Reserved || res.Hidden {
+		return fmt.Errorf("role mapping %s is reserved or hidden and can only be changed in the security configuration files", roleID)
+	}
+	return nil
+}
+
 func resourceElasticsearchPutOpenDistroRolesMapping(d *schema.ResourceData, m interface{}) (*RoleMappingResponse, error) {
 	var err error
 	response := new(RoleMappingResponse)
@@ -199,7 +246,7 @@ func resourceElasticsearchPutOpenDistroRolesMapping(d *schema.ResourceData, m in
 		return response, fmt.Errorf("Body Error : %s", roleJSON)
 	}
 
-	path, err := uritemplates.Expand("/_opendistro/_security/api/rolesmapping/{name}", map[string]string{
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_security/api/rolesmapping/{name}"), map[string]string{
 		"name": d.Get("role_name").(string),
 	})
 
@@ -229,7 +276,9 @@ func resourceElasticsearchPutOpenDistroRolesMapping(d *schema.ResourceData, m in
 				elastic7.NewExponentialBackoff(100*time.Millisecond, 30*time.Second),
 			),
 		})
-		body = res.Body
+		if err == nil {
+			body = res.Body
+		}
 	default:
 		err = errors.New("role mapping resource not implemented prior to Elastic v7")
 	}
@@ -256,4 +305,6 @@ type RolesMapping struct {
 	Users           []string `json:"users"`
 	Description     string   `json:"description"`
 	AndBackendRoles []string `json:"and_backend_roles"`
+	Reserved        bool     `json:"reserved,omitempty"`
+	Hidden          bool     `json:"hidden,omitempty"`
 }
@@ -67,6 +67,11 @@ func TestAccElasticsearchOpenDistroRolesMapping(t *testing.T) {
 						"description",
 						randomName,
 					),
+					resource.TestCheckResourceAttr(
+						"elasticsearch_opendistro_roles_mapping.test",
+						"reserved",
+						"false",
+					),
 				),
 			},
 			{
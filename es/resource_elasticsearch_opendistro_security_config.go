@@ -0,0 +1,176 @@
+package es
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+// the cluster has a single security configuration
+const openDistroSecurityConfigID = "security_config"
+
+// the sections of the dynamic configuration which can lock the
+// administrators out of the cluster when they are wrong
+var openDistroSecurityConfigLockoutSections = []string{"authc", "authz"}
+
+func resourceElasticsearchOpenDistroSecurityConfig() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages the dynamic configuration of the Open Distro security plugin, e.g. the multi-tenancy settings in `kibana` or the `auth_failure_listeners`. The configured settings are merged into the current configuration, the settings which are not configured are left as they are, and destroying the resource keeps the configuration on the cluster. The cluster has to allow changes with `opendistro_security.unsupported.restapi.allow_securityconfig_modification`. Please refer to the Open Distro [security configuration documentation](https://opendistro.github.io/for-elasticsearch-docs/docs/security/configuration/configuration/) for details.",
+		Create:      resourceElasticsearchOpenDistroSecurityConfigCreate,
+		Read:        resourceElasticsearchOpenDistroSecurityConfigRead,
+		Update:      resourceElasticsearchOpenDistroSecurityConfigUpdate,
+		Delete:      resourceElasticsearchOpenDistroSecurityConfigDelete,
+		Schema: map[string]*schema.Schema{
+			"body": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressEquivalentJson,
+				ValidateFunc:     validation.StringIsJSON,
+				StateFunc: func(v interface{}) string {
+					json, _ := structure.NormalizeJsonString(v)
+					return json
+				},
+				Description: "The JSON settings of the `dynamic` configuration to set, e.g. `{\"kibana\": {\"multitenancy_enabled\": true}}`.",
+			},
+			"allow_lockout_sections": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the `body` may set the `authc` and `authz` sections, which lock the administrators out of the cluster when they are wrong.",
+			},
+		},
+		CustomizeDiff: resourceElasticsearchOpenDistroSecurityConfigCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchOpenDistroSecurityConfigCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("allow_lockout_sections").(bool) {
+		return nil
+	}
+
+	var dynamic map[string]interface{}
+	if err := json.Unmarshal([]byte(d.Get("body").(string)), &dynamic); err != nil {
+		// unknown until applied
+		return nil
+	}
+	for _, section := range openDistroSecurityConfigLockoutSections {
+		if _, ok := dynamic[section]; ok {
+			return fmt.Errorf("the %s section of the security configuration can lock the administrators out of the cluster, set allow_lockout_sections to change it", section)
+		}
+	}
+	return nil
+}
+
+func resourceElasticsearchOpenDistroSecurityConfigCreate(d *schema.ResourceData, m interface{}) error {
+	if err := resourceElasticsearchPutOpenDistroSecurityConfig(d, m); err != nil {
+		log.Printf("[INFO] Failed to put OpenDistroSecurityConfig: %+v", err)
+		return err
+	}
+
+	d.SetId(openDistroSecurityConfigID)
+	return resourceElasticsearchOpenDistroSecurityConfigRead(d, m)
+}
+
+func resourceElasticsearchOpenDistroSecurityConfigRead(d *schema.ResourceData, m interface{}) error {
+	dynamic, err := resourceElasticsearchGetOpenDistroSecurityConfig(m)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] OpenDistroSecurityConfig (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	// only read back the managed settings
+	var configured map[string]interface{}
+	if err := json.Unmarshal([]byte(d.Get("body").(string)), &configured); err != nil {
+		configured = make(map[string]interface{})
+	}
+	body, err := json.Marshal(filterJSONObject(dynamic, configured))
+	if err != nil {
+		return err
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("body", string(body))
+	return ds.err
+}
+
+func resourceElasticsearchOpenDistroSecurityConfigUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := resourceElasticsearchPutOpenDistroSecurityConfig(d, m); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchOpenDistroSecurityConfigRead(d, m)
+}
+
+func resourceElasticsearchOpenDistroSecurityConfigDelete(d *schema.ResourceData, m interface{}) error {
+	log.Printf("[INFO] Security configuration is kept on the cluster, removing from state only")
+	d.SetId("")
+	return nil
+}
+
+func resourceElasticsearchGetOpenDistroSecurityConfig(m interface{}) (map[string]interface{}, error) {
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := esClient.(*elastic7.Client); !ok {
+		return nil, errors.New("security config resource not implemented prior to Elastic v7")
+	}
+
+	body, err := elasticsearchPerformRequest(m, "GET", openDistroPath(m, "/_opendistro/_security/api/securityconfig"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(SecurityConfigResponse)
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling security config body: %+v: %+v", err, body)
+	}
+	if response.Config.Dynamic == nil {
+		response.Config.Dynamic = make(map[string]interface{})
+	}
+	return response.Config.Dynamic, nil
+}
+
+// resourceElasticsearchPutOpenDistroSecurityConfig merges the configured
+// settings into the current configuration, which the API only replaces as a
+// whole
+func resourceElasticsearchPutOpenDistroSecurityConfig(d *schema.ResourceData, m interface{}) error {
+	dynamic, err := resourceElasticsearchGetOpenDistroSecurityConfig(m)
+	if err != nil {
+		return err
+	}
+
+	var configured map[string]interface{}
+	if err := json.Unmarshal([]byte(d.Get("body").(string)), &configured); err != nil {
+		return fmt.Errorf("fail to unmarshal body: %v", err)
+	}
+	mergeJSONObjects(dynamic, configured)
+
+	body := map[string]interface{}{
+		"dynamic": dynamic,
+	}
+	if _, err := elasticsearchPerformRequest(m, "PUT", openDistroPath(m, "/_opendistro/_security/api/securityconfig/config"), body); err != nil {
+		return fmt.Errorf("error putting security config: %+v", err)
+	}
+	return nil
+}
+
+type SecurityConfigResponse struct {
+	Config struct {
+		Dynamic map[string]interface{} `json:"dynamic"`
+	} `json:"config"`
+}
@@ -0,0 +1,93 @@
+package es
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchOpenDistroSecurityConfig(t *testing.T) {
+
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	_, allowed := esClient.(*elastic7.Client)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Security config only supported on ES >= 7")
+			}
+		},
+		Providers: testAccOpendistroProviders,
+		// the security configuration is kept on destroy
+		CheckDestroy: func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccOpenDistroSecurityConfigLockout,
+				ExpectError: regexp.MustCompile("set allow_lockout_sections to change it"),
+			},
+			{
+				Config: testAccOpenDistroSecurityConfigResource(true),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticSearchOpenDistroSecurityConfigExists("elasticsearch_opendistro_security_config.test"),
+				),
+			},
+			{
+				Config: testAccOpenDistroSecurityConfigResource(false),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticSearchOpenDistroSecurityConfigExists("elasticsearch_opendistro_security_config.test"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckElasticSearchOpenDistroSecurityConfigExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No security config ID is set")
+		}
+
+		_, err := resourceElasticsearchGetOpenDistroSecurityConfig(testAccOpendistroProvider.Meta())
+		return err
+	}
+}
+
+var testAccOpenDistroSecurityConfigLockout = `
+	resource "elasticsearch_opendistro_security_config" "test" {
+		body = jsonencode({
+			authc = {}
+		})
+	}
+	`
+
+func testAccOpenDistroSecurityConfigResource(multitenancy bool) string {
+	return fmt.Sprintf(`
+	resource "elasticsearch_opendistro_security_config" "test" {
+		body = jsonencode({
+			kibana = {
+				multitenancy_enabled = %t
+			}
+		})
+	}
+	`, multitenancy)
+}
@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"regexp"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/olivere/elastic/uritemplates"
 
 	elastic7 "github.com/olivere/elastic/v7"
@@ -25,6 +27,7 @@ func resourceElasticsearchOpenDistroUser() *schema.Resource {
 			"username": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"password": {
 				Type:          schema.TypeString,
@@ -39,6 +42,7 @@ func resourceElasticsearchOpenDistroUser() *schema.Resource {
 				Sensitive:     true,
 				StateFunc:     hashSum,
 				ConflictsWith: []string{"password"},
+				ValidateFunc:  validation.StringMatch(regexp.MustCompile(`^\$2[aby]?\$[0-9]{2}\$[./A-Za-z0-9]{53}$`), "must be a bcrypt hash"),
 			},
 			"backend_roles": {
 				Type:     schema.TypeSet,
@@ -85,7 +89,9 @@ func resourceElasticsearchOpenDistroUserRead(d *schema.ResourceData, m interface
 		return err
 	}
 
+	// the password is never returned, only its hash is kept in state
 	ds := &resourceDataSetter{d: d}
+	ds.set("username", d.Id())
 	ds.set("backend_roles", res.BackendRoles)
 	ds.set("attributes", res.Attributes)
 	ds.set("description", res.Description)
@@ -103,7 +109,7 @@ func resourceElasticsearchOpenDistroUserUpdate(d *schema.ResourceData, m interfa
 func resourceElasticsearchOpenDistroUserDelete(d *schema.ResourceData, m interface{}) error {
 	var err error
 
-	path, err := uritemplates.Expand("/_opendistro/_security/api/internalusers/{name}", map[string]string{
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_security/api/internalusers/{name}"), map[string]string{
 		"name": d.Get("username").(string),
 	})
 	if err != nil {
@@ -135,7 +141,7 @@ func resourceElasticsearchGetOpenDistroUser(userID string, m interface{}) (UserB
 	var err error
 	user := new(UserBody)
 
-	path, err := uritemplates.Expand("/_opendistro/_security/api/internalusers/{name}", map[string]string{
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_security/api/internalusers/{name}"), map[string]string{
 		"name": userID,
 	})
 
@@ -155,7 +161,9 @@ func resourceElasticsearchGetOpenDistroUser(userID string, m interface{}) (UserB
 			Method: "GET",
 			Path:   path,
 		})
-		body = res.Body
+		if err == nil {
+			body = res.Body
+		}
 	default:
 		err = errors.New("Role resource not implemented prior to Elastic v7")
 	}
@@ -195,7 +203,7 @@ func resourceElasticsearchPutOpenDistroUser(d *schema.ResourceData, m interface{
 		return response, fmt.Errorf("Body Error : %s", userJSON)
 	}
 
-	path, err := uritemplates.Expand("/_opendistro/_security/api/internalusers/{name}", map[string]string{
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_security/api/internalusers/{name}"), map[string]string{
 		"name": d.Get("username").(string),
 	})
 	if err != nil {
@@ -210,7 +218,7 @@ func resourceElasticsearchPutOpenDistroUser(d *schema.ResourceData, m interface{
 	switch client := esClient.(type) {
 	case *elastic7.Client:
 		var res *elastic7.Response
-		log.Printf("[INFO] put opendistro user: %+v", userDefinition)
+		log.Printf("[INFO] put opendistro user: %s", d.Get("username").(string))
 		res, err = client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
 			Method: "PUT",
 			Path:   path,
@@ -230,17 +238,17 @@ func resourceElasticsearchPutOpenDistroUser(d *schema.ResourceData, m interface{
 			if !ok {
 				log.Printf("[INFO] expected error to be of type *elastic.Error")
 			} else {
-				log.Printf("[INFO] error creating user: %v %v %v", res, res.Body, e)
+				log.Printf("[INFO] error creating user: %v", e)
 			}
+		} else {
+			body = res.Body
 		}
-
-		body = res.Body
 	default:
 		err = errors.New("User resource not implemented prior to Elastic v7")
 	}
 
 	if err != nil {
-		return response, fmt.Errorf("Error creating user: %+v: %+v: %+v", err, body, string(userJSON))
+		return response, fmt.Errorf("Error creating user: %+v: %+v", err, body)
 	}
 
 	if err := json.Unmarshal(body, response); err != nil {
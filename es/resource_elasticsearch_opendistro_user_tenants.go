@@ -0,0 +1,293 @@
+package es
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+// the attribute of the internal user holding its default tenant
+const openDistroUserDefaultTenantAttribute = "default_tenant"
+
+// the action groups granting the access to the tenants
+var openDistroUserTenantActions = map[string]string{
+	"read":  "kibana_all_read",
+	"write": "kibana_all_write",
+}
+
+func resourceElasticsearchOpenDistroUserTenants() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides the tenants of an internal user of the security plugin, for multi-tenancy: the access to the tenants is granted by a role of the user, mapped to the user, and the default tenant of the user is set as its `default_tenant` attribute, which the `tenant_patterns` of the roles can refer to as `${attr.internal.default_tenant}`. Use `lifecycle { ignore_changes = [attributes] }` on the `elasticsearch_opendistro_user` resource of the user. Please refer to the OpenSearch [multi-tenancy documentation](https://opensearch.org/docs/latest/security/multi-tenancy/tenant-index/) for details.",
+		Create:      resourceElasticsearchOpenDistroUserTenantsCreate,
+		Read:        resourceElasticsearchOpenDistroUserTenantsRead,
+		Update:      resourceElasticsearchOpenDistroUserTenantsUpdate,
+		Delete:      resourceElasticsearchOpenDistroUserTenantsDelete,
+		Schema: map[string]*schema.Schema{
+			"username": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the internal user.",
+			},
+			"tenant": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the tenant.",
+						},
+						"access": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "write",
+							ValidateFunc: validation.StringInSlice([]string{"read", "write"}, false),
+							Description:  "The access to the tenant, `read` or `write`.",
+						},
+					},
+				},
+				Description: "The tenants the user has access to.",
+			},
+			"default_tenant": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The default tenant of the user, set as its `default_tenant` attribute.",
+			},
+			"role_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The name of the role granting the access to the tenants, `<username>_tenants` if not set. The role is managed by the resource and mapped to the user only.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchOpenDistroUserTenantsCreate(d *schema.ResourceData, m interface{}) error {
+	username := d.Get("username").(string)
+	if _, ok := d.GetOk("role_name"); !ok {
+		if err := d.Set("role_name", username+"_tenants"); err != nil {
+			return err
+		}
+	}
+
+	if err := resourceElasticsearchPutOpenDistroUserTenants(d, m); err != nil {
+		log.Printf("[INFO] Failed to put user tenants: %+v", err)
+		return err
+	}
+
+	d.SetId(username)
+	return resourceElasticsearchOpenDistroUserTenantsRead(d, m)
+}
+
+func resourceElasticsearchOpenDistroUserTenantsRead(d *schema.ResourceData, m interface{}) error {
+	if err := checkOpenDistroUserTenantsClient(m); err != nil {
+		return err
+	}
+
+	roleName := d.Get("role_name").(string)
+	if roleName == "" {
+		// imported
+		roleName = d.Id() + "_tenants"
+	}
+
+	var role RoleBody
+	user, err := resourceElasticsearchGetOpenDistroUser(d.Id(), m)
+	if err == nil {
+		role, err = resourceElasticsearchGetOpenDistroRole(roleName, m)
+	}
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] User tenants (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	defaultTenant, _ := user.Attributes[openDistroUserDefaultTenantAttribute].(string)
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("username", d.Id())
+	ds.set("role_name", roleName)
+	ds.set("tenant", flattenOpenDistroUserTenants(role.TenantPermissions))
+	ds.set("default_tenant", defaultTenant)
+	return ds.err
+}
+
+func resourceElasticsearchOpenDistroUserTenantsUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := resourceElasticsearchPutOpenDistroUserTenants(d, m); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchOpenDistroUserTenantsRead(d, m)
+}
+
+func resourceElasticsearchOpenDistroUserTenantsDelete(d *schema.ResourceData, m interface{}) error {
+	if err := checkOpenDistroUserTenantsClient(m); err != nil {
+		return err
+	}
+
+	roleName := d.Get("role_name").(string)
+	for _, template := range []string{"/_opendistro/_security/api/rolesmapping/{name}", "/_opendistro/_security/api/roles/{name}"} {
+		path, err := uritemplates.Expand(openDistroPath(m, template), map[string]string{
+			"name": roleName,
+		})
+		if err != nil {
+			return fmt.Errorf("error building URL path for user tenants: %+v", err)
+		}
+		if _, err := elasticsearchPerformRequest(m, "DELETE", path, nil); err != nil && !elastic7.IsNotFound(err) {
+			return err
+		}
+	}
+
+	if d.Get("default_tenant").(string) != "" {
+		err := resourceElasticsearchPatchOpenDistroUserDefaultTenant(d.Id(), "", m)
+		if err != nil && !elastic7.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// resourceElasticsearchPutOpenDistroUserTenants puts the role of the tenants
+// and its mapping to the user, then patches the default tenant attribute of
+// the user, leaving its other attributes and its password as they are
+func resourceElasticsearchPutOpenDistroUserTenants(d *schema.ResourceData, m interface{}) error {
+	if err := checkOpenDistroUserTenantsClient(m); err != nil {
+		return err
+	}
+
+	username := d.Get("username").(string)
+	roleName := d.Get("role_name").(string)
+
+	role := RoleBody{
+		Description:       fmt.Sprintf("Tenants of the user %s", username),
+		TenantPermissions: expandOpenDistroUserTenants(d.Get("tenant").(*schema.Set).List()),
+	}
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_security/api/roles/{name}"), map[string]string{
+		"name": roleName,
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for user tenants: %+v", err)
+	}
+	if _, err := elasticsearchPerformRequest(m, "PUT", path, role); err != nil {
+		return fmt.Errorf("error putting the role %s of the user tenants: %+v", roleName, err)
+	}
+
+	path, err = uritemplates.Expand(openDistroPath(m, "/_opendistro/_security/api/rolesmapping/{name}"), map[string]string{
+		"name": roleName,
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for user tenants: %+v", err)
+	}
+	mapping := map[string]interface{}{
+		"users": []string{username},
+	}
+	if _, err := elasticsearchPerformRequest(m, "PUT", path, mapping); err != nil {
+		return fmt.Errorf("error mapping the role %s to the user %s: %+v", roleName, username, err)
+	}
+
+	if d.HasChange("default_tenant") {
+		return resourceElasticsearchPatchOpenDistroUserDefaultTenant(username, d.Get("default_tenant").(string), m)
+	}
+	return nil
+}
+
+// resourceElasticsearchPatchOpenDistroUserDefaultTenant sets the default tenant
+// attribute of the user, or removes it if the tenant is empty
+func resourceElasticsearchPatchOpenDistroUserDefaultTenant(username string, tenant string, m interface{}) error {
+	user, err := resourceElasticsearchGetOpenDistroUser(username, m)
+	if err != nil {
+		return err
+	}
+	_, exists := user.Attributes[openDistroUserDefaultTenantAttribute]
+
+	operation := map[string]interface{}{
+		"op":   "add",
+		"path": "/attributes/" + openDistroUserDefaultTenantAttribute,
+	}
+	if tenant != "" {
+		operation["value"] = tenant
+	} else if exists {
+		operation["op"] = "remove"
+	} else {
+		return nil
+	}
+
+	path, err := uritemplates.Expand(openDistroPath(m, "/_opendistro/_security/api/internalusers/{name}"), map[string]string{
+		"name": username,
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for user tenants: %+v", err)
+	}
+	if _, err := elasticsearchPerformRequest(m, "PATCH", path, []interface{}{operation}); err != nil {
+		return fmt.Errorf("error patching the default tenant of the user %s: %+v", username, err)
+	}
+	return nil
+}
+
+func expandOpenDistroUserTenants(tenants []interface{}) []TenantPermissions {
+	patterns := make(map[string][]string)
+	for _, t := range tenants {
+		tenant := t.(map[string]interface{})
+		access := tenant["access"].(string)
+		patterns[access] = append(patterns[access], tenant["name"].(string))
+	}
+
+	permissions := make([]TenantPermissions, 0, len(patterns))
+	for _, access := range []string{"read", "write"} {
+		if len(patterns[access]) == 0 {
+			continue
+		}
+		sort.Strings(patterns[access])
+		permissions = append(permissions, TenantPermissions{
+			TenantPatterns: patterns[access],
+			AllowedActions: []string{openDistroUserTenantActions[access]},
+		})
+	}
+	return permissions
+}
+
+func flattenOpenDistroUserTenants(permissions []TenantPermissions) []interface{} {
+	tenants := make([]interface{}, 0)
+	for _, permission := range permissions {
+		access := "read"
+		for _, action := range permission.AllowedActions {
+			if action == openDistroUserTenantActions["write"] {
+				access = "write"
+			}
+		}
+		for _, name := range permission.TenantPatterns {
+			tenants = append(tenants, map[string]interface{}{
+				"name":   name,
+				"access": access,
+			})
+		}
+	}
+	return tenants
+}
+
+func checkOpenDistroUserTenantsClient(m interface{}) error {
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	if _, ok := esClient.(*elastic7.Client); !ok {
+		return errors.New("user tenants resource not implemented prior to Elastic v7")
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchOpenDistroUserTenants(t *testing.T) {
+	randomName := "test" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if err := checkOpenDistroUserTenantsClient(testAccOpendistroProvider.Meta()); err != nil {
+				t.Skipf("err: %s", err)
+			}
+		},
+		Providers:    testAccOpendistroProviders,
+		CheckDestroy: testAccCheckElasticsearchOpenDistroUserTenantsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpenDistroUserTenantsResource(randomName, "read", `default_tenant = "${elasticsearch_opendistro_kibana_tenant.test.tenant_name}"`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenDistroUserTenantsExists("elasticsearch_opendistro_user_tenants.test"),
+					resource.TestCheckResourceAttr("elasticsearch_opendistro_user_tenants.test", "role_name", randomName+"_tenants"),
+					resource.TestCheckResourceAttr("elasticsearch_opendistro_user_tenants.test", "tenant.#", "2"),
+					resource.TestCheckResourceAttr("elasticsearch_opendistro_user_tenants.test", "default_tenant", randomName),
+				),
+			},
+			{
+				Config: testAccOpenDistroUserTenantsResource(randomName, "write", ""),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenDistroUserTenantsExists("elasticsearch_opendistro_user_tenants.test"),
+					resource.TestCheckResourceAttr("elasticsearch_opendistro_user_tenants.test", "default_tenant", ""),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_opendistro_user_tenants.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchOpenDistroUserTenantsExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No user tenants ID is set")
+		}
+
+		_, err := resourceElasticsearchGetOpenDistroRole(rs.Primary.Attributes["role_name"], testAccOpendistroProvider.Meta())
+		return err
+	}
+}
+
+func testAccCheckElasticsearchOpenDistroUserTenantsDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_opendistro_user_tenants" {
+			continue
+		}
+
+		_, err := resourceElasticsearchGetOpenDistroRole(rs.Primary.Attributes["role_name"], testAccOpendistroProvider.Meta())
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("User tenants role %q still exists", rs.Primary.Attributes["role_name"])
+	}
+
+	return nil
+}
+
+func testAccOpenDistroUserTenantsResource(name string, access string, defaultTenant string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_opendistro_user" "test" {
+  username = "%[1]s"
+  password = "passw0rd"
+
+  lifecycle {
+    ignore_changes = [attributes]
+  }
+}
+
+resource "elasticsearch_opendistro_kibana_tenant" "test" {
+  tenant_name = "%[1]s"
+  description = "test"
+}
+
+resource "elasticsearch_opendistro_user_tenants" "test" {
+  username = elasticsearch_opendistro_user.test.username
+
+  tenant {
+    name = elasticsearch_opendistro_kibana_tenant.test.tenant_name
+  }
+
+  tenant {
+    name   = "global_tenant"
+    access = "%[2]s"
+  }
+
+  %[3]s
+}
+`, name, access, defaultTenant)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
 
 	elastic7 "github.com/olivere/elastic/v7"
@@ -16,6 +17,19 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 )
 
+func TestResourceElasticsearchOpenDistroUserPasswordHash(t *testing.T) {
+	validate := resourceElasticsearchOpenDistroUser().Schema["password_hash"].ValidateFunc
+
+	if _, errs := validate("$2a$04$jQcEXpODnTFoGDuA7DPdSevA84CuH/7MOYkb80M3XZIrH76YMWS9G", "password_hash"); len(errs) > 0 {
+		t.Errorf("expected a bcrypt hash to pass, got: %v", errs)
+	}
+	for _, hash := range []string{"passw0rd", "5f4dcc3b5aa765d61d8327deb882cf99", "$2a$04$tooshort"} {
+		if _, errs := validate(hash, "password_hash"); len(errs) == 0 {
+			t.Errorf("expected %s to be rejected", hash)
+		}
+	}
+}
+
 func TestAccElasticsearchOpenDistroUser(t *testing.T) {
 	provider := Provider().(*schema.Provider)
 	err := provider.Configure(&terraform.ResourceConfig{})
@@ -110,6 +124,23 @@ func TestAccElasticsearchOpenDistroUser(t *testing.T) {
 					),
 				),
 			},
+			{
+				Config:      testAccOpenDistroUserResourceInvalidHash(randomName),
+				ExpectError: regexp.MustCompile("must be a bcrypt hash"),
+			},
+			{
+				// renaming replaces the user, the old one is deleted
+				Config: testAccOpenDistroUserResourceHash(randomName + "renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticSearchOpenDistroUserExists("elasticsearch_opendistro_user.test"),
+					testCheckElasticSearchOpenDistroUserNotExists(randomName),
+					resource.TestCheckResourceAttr(
+						"elasticsearch_opendistro_user.test",
+						"id",
+						randomName+"renamed",
+					),
+				),
+			},
 		},
 	})
 }
@@ -218,6 +249,18 @@ func testCheckElasticSearchOpenDistroUserExists(name string) resource.TestCheckF
 	}
 }
 
+func testCheckElasticSearchOpenDistroUserNotExists(username string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		meta := testAccOpendistroProvider.Meta()
+
+		if _, err := resourceElasticsearchGetOpenDistroUser(username, meta.(*ProviderConf)); err == nil {
+			return fmt.Errorf("User %q still exists", username)
+		}
+
+		return nil
+	}
+}
+
 func testCheckElasticSearchOpenDistroUserConnects(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		for _, rs := range s.RootModule().Resources {
@@ -281,6 +324,15 @@ func testAccOpenDistroUserResourceHash(resourceName string) string {
 	`, resourceName)
 }
 
+func testAccOpenDistroUserResourceInvalidHash(resourceName string) string {
+	return fmt.Sprintf(`
+	resource "elasticsearch_opendistro_user" "test" {
+		username      = "%s"
+		password_hash = "passw0rd"
+	}
+	`, resourceName)
+}
+
 func testAccOpenDistroUserResourceUpdated(resourceName string) string {
 	return fmt.Sprintf(`
 	resource "elasticsearch_opendistro_user" "test" {
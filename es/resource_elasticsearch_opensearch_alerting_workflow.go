@@ -0,0 +1,188 @@
+package es
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func resourceElasticsearchOpenSearchAlertingWorkflow() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an OpenSearch alerting workflow, a composite monitor running a sequence of delegate monitors, each one on the results of the previous ones, and triggering on their combined alerts, requires OpenSearch >= 2.9. Please refer to the OpenSearch [composite monitors documentation](https://opensearch.org/docs/latest/observing-your-data/alerting/composite-monitors/) for details.",
+		Create:      resourceElasticsearchOpenSearchAlertingWorkflowCreate,
+		Read:        resourceElasticsearchOpenSearchAlertingWorkflowRead,
+		Update:      resourceElasticsearchOpenSearchAlertingWorkflowUpdate,
+		Delete:      resourceElasticsearchOpenSearchAlertingWorkflowDelete,
+		Schema: map[string]*schema.Schema{
+			"body": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: diffSuppressAlertingWorkflow,
+				ValidateFunc:     validateAlertingWorkflowBody,
+				StateFunc: func(v interface{}) string {
+					json, _ := structure.NormalizeJsonString(v)
+					return json
+				},
+				Description: "The JSON body of the workflow, with its `name`, `schedule`, the `composite_input` sequence of the `delegates` monitors in its `inputs`, and its `triggers`.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchOpenSearchAlertingWorkflowCreate(d *schema.ResourceData, m interface{}) error {
+	if err := checkOpenSearchAlertingWorkflowClient(m); err != nil {
+		return err
+	}
+
+	res, err := elasticsearchPerformRequest(m, "POST", "/_plugins/_alerting/workflows", d.Get("body").(string))
+	if err != nil {
+		log.Printf("[INFO] Failed to create alerting workflow: %+v", err)
+		return err
+	}
+	response := new(alertingWorkflowResponse)
+	if err := json.Unmarshal(res, response); err != nil {
+		return fmt.Errorf("error unmarshalling alerting workflow body: %+v: %+v", err, res)
+	}
+
+	d.SetId(response.ID)
+	return resourceElasticsearchOpenSearchAlertingWorkflowRead(d, m)
+}
+
+func resourceElasticsearchOpenSearchAlertingWorkflowRead(d *schema.ResourceData, m interface{}) error {
+	res, err := resourceElasticsearchOpenSearchGetAlertingWorkflow(d.Id(), m)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Alerting workflow (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	normalizeAlertingWorkflow(res.Workflow)
+	body, err := json.Marshal(res.Workflow)
+	if err != nil {
+		return err
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("body", string(body))
+	return ds.err
+}
+
+func resourceElasticsearchOpenSearchAlertingWorkflowUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := checkOpenSearchAlertingWorkflowClient(m); err != nil {
+		return err
+	}
+
+	path, err := uritemplates.Expand("/_plugins/_alerting/workflows/{id}", map[string]string{
+		"id": d.Id(),
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for alerting workflow: %+v", err)
+	}
+	if _, err := elasticsearchPerformRequest(m, "PUT", path, d.Get("body").(string)); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchOpenSearchAlertingWorkflowRead(d, m)
+}
+
+func resourceElasticsearchOpenSearchAlertingWorkflowDelete(d *schema.ResourceData, m interface{}) error {
+	if err := checkOpenSearchAlertingWorkflowClient(m); err != nil {
+		return err
+	}
+
+	// the delegate monitors are kept, they are managed by their own resources
+	path, err := uritemplates.Expand("/_plugins/_alerting/workflows/{id}?deleteDelegateMonitors=false", map[string]string{
+		"id": d.Id(),
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for alerting workflow: %+v", err)
+	}
+	_, err = elasticsearchPerformRequest(m, "DELETE", path, nil)
+	return err
+}
+
+func resourceElasticsearchOpenSearchGetAlertingWorkflow(workflowID string, m interface{}) (*alertingWorkflowResponse, error) {
+	if err := checkOpenSearchAlertingWorkflowClient(m); err != nil {
+		return nil, err
+	}
+
+	path, err := uritemplates.Expand("/_plugins/_alerting/workflows/{id}", map[string]string{
+		"id": workflowID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error building URL path for alerting workflow: %+v", err)
+	}
+	res, err := elasticsearchPerformRequest(m, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(alertingWorkflowResponse)
+	if err := json.Unmarshal(res, response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling alerting workflow body: %+v: %+v", err, res)
+	}
+	return response, nil
+}
+
+func checkOpenSearchAlertingWorkflowClient(m interface{}) error {
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	if _, ok := esClient.(*elastic7.Client); !ok {
+		return errors.New("alerting workflow resource not implemented prior to OpenSearch 2.9")
+	}
+	return nil
+}
+
+func validateAlertingWorkflowBody(v interface{}, k string) (ws []string, errors []error) {
+	var workflow map[string]interface{}
+	if err := json.Unmarshal([]byte(v.(string)), &workflow); err != nil {
+		errors = append(errors, fmt.Errorf("%q contains an invalid JSON: %s", k, err))
+		return
+	}
+
+	var delegates []interface{}
+	inputs, _ := workflow["inputs"].([]interface{})
+	for _, i := range inputs {
+		input, _ := i.(map[string]interface{})
+		compositeInput, _ := input["composite_input"].(map[string]interface{})
+		sequence, _ := compositeInput["sequence"].(map[string]interface{})
+		if d, ok := sequence["delegates"].([]interface{}); ok {
+			delegates = append(delegates, d...)
+		}
+	}
+	if len(delegates) == 0 {
+		errors = append(errors, fmt.Errorf("%q: the workflow must set the delegates of the composite_input sequence in its inputs", k))
+		return
+	}
+	for i, d := range delegates {
+		delegate, _ := d.(map[string]interface{})
+		if monitorID, _ := delegate["monitor_id"].(string); monitorID == "" {
+			errors = append(errors, fmt.Errorf("%q: the delegate %d must set its monitor_id", k, i))
+		}
+		if _, ok := delegate["order"].(float64); !ok {
+			errors = append(errors, fmt.Errorf("%q: the delegate %d must set its order", k, i))
+		}
+	}
+	return
+}
+
+type alertingWorkflowResponse struct {
+	ID       string                 `json:"_id"`
+	Version  int                    `json:"_version"`
+	Workflow map[string]interface{} `json:"workflow"`
+}
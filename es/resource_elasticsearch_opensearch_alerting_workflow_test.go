@@ -0,0 +1,127 @@
+package es
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchOpenSearchAlertingWorkflow(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			// the workflow not existing yet is reported only when workflows are available
+			_, err := elasticsearchPerformRequest(testAccOpendistroProvider.Meta(), "GET", "/_plugins/_alerting/workflows/test-missing-workflow", nil)
+			if err != nil && !elastic7.IsNotFound(err) {
+				t.Skipf("Alerting workflows not available: %s", err)
+			}
+		},
+		Providers:    testAccOpendistroProviders,
+		CheckDestroy: testCheckElasticsearchOpenSearchAlertingWorkflowDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccElasticsearchOpenSearchAlertingWorkflowInvalid,
+				ExpectError: regexp.MustCompile("must set the delegates of the composite_input sequence"),
+			},
+			{
+				Config: testAccElasticsearchOpenSearchAlertingWorkflow(1),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchAlertingWorkflowExists("elasticsearch_opensearch_alerting_workflow.test"),
+				),
+			},
+			{
+				Config: testAccElasticsearchOpenSearchAlertingWorkflow(5),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchAlertingWorkflowExists("elasticsearch_opensearch_alerting_workflow.test"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchOpenSearchAlertingWorkflowExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No alerting workflow ID is set")
+		}
+
+		_, err := resourceElasticsearchOpenSearchGetAlertingWorkflow(rs.Primary.ID, testAccOpendistroProvider.Meta())
+		return err
+	}
+}
+
+func testCheckElasticsearchOpenSearchAlertingWorkflowDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_opensearch_alerting_workflow" {
+			continue
+		}
+
+		_, err := resourceElasticsearchOpenSearchGetAlertingWorkflow(rs.Primary.ID, testAccOpendistroProvider.Meta())
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Alerting workflow %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+var testAccElasticsearchOpenSearchAlertingWorkflowInvalid = `
+resource "elasticsearch_opensearch_alerting_workflow" "test" {
+  body = jsonencode({
+    name     = "test-workflow"
+    schedule = { period = { interval = 1, unit = "MINUTES" } }
+    inputs   = []
+  })
+}
+`
+
+func testAccElasticsearchOpenSearchAlertingWorkflow(interval int) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_opendistro_monitor" "test" {
+  count = 2
+  body = jsonencode({
+    name         = "test-workflow-delegate-${count.index}"
+    type         = "monitor"
+    monitor_type = "query_level_monitor"
+    enabled      = false
+    schedule     = { period = { interval = 1, unit = "MINUTES" } }
+    inputs = [{
+      search = {
+        indices = ["*"]
+        query   = { size = 0, query = { match_all = {} } }
+      }
+    }]
+    triggers = []
+  })
+}
+
+resource "elasticsearch_opensearch_alerting_workflow" "test" {
+  body = jsonencode({
+    name     = "test-workflow"
+    schedule = { period = { interval = %d, unit = "MINUTES" } }
+    inputs = [{
+      composite_input = {
+        sequence = {
+          delegates = [
+            { order = 1, monitor_id = elasticsearch_opendistro_monitor.test[0].id },
+            { order = 2, monitor_id = elasticsearch_opendistro_monitor.test[1].id },
+          ]
+        }
+      }
+    }]
+    triggers = []
+  })
+}
+`, interval)
+}
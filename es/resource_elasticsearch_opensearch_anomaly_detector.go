@@ -0,0 +1,212 @@
+package es
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func resourceElasticsearchOpenSearchAnomalyDetector() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an OpenSearch anomaly detector, which finds anomalies in the features aggregated from the documents of indices at every detection interval. Please refer to the OpenSearch [anomaly detection documentation](https://opensearch.org/docs/latest/observing-your-data/ad/api/) for details.",
+		Create:      resourceElasticsearchOpenSearchAnomalyDetectorCreate,
+		Read:        resourceElasticsearchOpenSearchAnomalyDetectorRead,
+		Update:      resourceElasticsearchOpenSearchAnomalyDetectorUpdate,
+		Delete:      resourceElasticsearchOpenSearchAnomalyDetectorDelete,
+		Schema: map[string]*schema.Schema{
+			"body": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: diffSuppressAnomalyDetector,
+				ValidateFunc:     validation.StringIsJSON,
+				StateFunc: func(v interface{}) string {
+					json, _ := structure.NormalizeJsonString(v)
+					return json
+				},
+				Description: "The JSON body of the detector, with its `name`, `time_field`, `indices`, `feature_attributes`, `filter_query`, `detection_interval` and `window_delay`.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the detector job is started, the detector is stopped while it is updated or deleted.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchOpenSearchAnomalyDetectorCreate(d *schema.ResourceData, m interface{}) error {
+	if err := checkOpenSearchAnomalyDetectorClient(m); err != nil {
+		return err
+	}
+
+	res, err := elasticsearchPerformRequest(m, "POST", "/_plugins/_anomaly_detection/detectors", d.Get("body").(string))
+	if err != nil {
+		log.Printf("[INFO] Failed to create anomaly detector: %+v", err)
+		return err
+	}
+	response := new(anomalyDetectorResponse)
+	if err := json.Unmarshal(res, response); err != nil {
+		return fmt.Errorf("error unmarshalling anomaly detector body: %+v: %+v", err, res)
+	}
+	d.SetId(response.ID)
+
+	if d.Get("enabled").(bool) {
+		if err := resourceElasticsearchOpenSearchAnomalyDetectorJob(d.Id(), "_start", m); err != nil {
+			return err
+		}
+	}
+
+	return resourceElasticsearchOpenSearchAnomalyDetectorRead(d, m)
+}
+
+func resourceElasticsearchOpenSearchAnomalyDetectorRead(d *schema.ResourceData, m interface{}) error {
+	res, err := resourceElasticsearchOpenSearchGetAnomalyDetector(d.Id(), m)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Anomaly detector (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	body, err := json.Marshal(res.AnomalyDetector)
+	if err != nil {
+		return err
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("body", string(body))
+	ds.set("enabled", res.AnomalyDetectorJob != nil && res.AnomalyDetectorJob.Enabled)
+	return ds.err
+}
+
+func resourceElasticsearchOpenSearchAnomalyDetectorUpdate(d *schema.ResourceData, m interface{}) error {
+	o, _ := d.GetChange("enabled")
+	running := o.(bool)
+
+	if d.HasChange("body") {
+		// running detectors cannot be updated
+		if running {
+			if err := resourceElasticsearchOpenSearchAnomalyDetectorJob(d.Id(), "_stop", m); err != nil {
+				return err
+			}
+			running = false
+		}
+
+		path, err := uritemplates.Expand("/_plugins/_anomaly_detection/detectors/{id}", map[string]string{
+			"id": d.Id(),
+		})
+		if err != nil {
+			return fmt.Errorf("error building URL path for anomaly detector: %+v", err)
+		}
+		if _, err := elasticsearchPerformRequest(m, "PUT", path, d.Get("body").(string)); err != nil {
+			return err
+		}
+	}
+
+	if enabled := d.Get("enabled").(bool); enabled != running {
+		action := "_stop"
+		if enabled {
+			action = "_start"
+		}
+		if err := resourceElasticsearchOpenSearchAnomalyDetectorJob(d.Id(), action, m); err != nil {
+			return err
+		}
+	}
+
+	return resourceElasticsearchOpenSearchAnomalyDetectorRead(d, m)
+}
+
+func resourceElasticsearchOpenSearchAnomalyDetectorDelete(d *schema.ResourceData, m interface{}) error {
+	if err := checkOpenSearchAnomalyDetectorClient(m); err != nil {
+		return err
+	}
+	// running detectors cannot be deleted
+	if d.Get("enabled").(bool) {
+		if err := resourceElasticsearchOpenSearchAnomalyDetectorJob(d.Id(), "_stop", m); err != nil {
+			return err
+		}
+	}
+
+	path, err := uritemplates.Expand("/_plugins/_anomaly_detection/detectors/{id}", map[string]string{
+		"id": d.Id(),
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for anomaly detector: %+v", err)
+	}
+	_, err = elasticsearchPerformRequest(m, "DELETE", path, nil)
+	return err
+}
+
+func resourceElasticsearchOpenSearchGetAnomalyDetector(detectorID string, m interface{}) (*anomalyDetectorResponse, error) {
+	if err := checkOpenSearchAnomalyDetectorClient(m); err != nil {
+		return nil, err
+	}
+
+	path, err := uritemplates.Expand("/_plugins/_anomaly_detection/detectors/{id}?job=true", map[string]string{
+		"id": detectorID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error building URL path for anomaly detector: %+v", err)
+	}
+	res, err := elasticsearchPerformRequest(m, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(anomalyDetectorResponse)
+	if err := json.Unmarshal(res, response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling anomaly detector body: %+v: %+v", err, res)
+	}
+	normalizeAnomalyDetector(response.AnomalyDetector)
+	return response, nil
+}
+
+// resourceElasticsearchOpenSearchAnomalyDetectorJob starts or stops the job
+// of the detector
+func resourceElasticsearchOpenSearchAnomalyDetectorJob(detectorID string, action string, m interface{}) error {
+	path, err := uritemplates.Expand("/_plugins/_anomaly_detection/detectors/{id}/{action}", map[string]string{
+		"id":     detectorID,
+		"action": action,
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for anomaly detector: %+v", err)
+	}
+	if _, err := elasticsearchPerformRequest(m, "POST", path, nil); err != nil {
+		return fmt.Errorf("error calling %s on anomaly detector %s: %+v", action, detectorID, err)
+	}
+	return nil
+}
+
+func checkOpenSearchAnomalyDetectorClient(m interface{}) error {
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	if _, ok := esClient.(*elastic7.Client); !ok {
+		return errors.New("anomaly detector resource not implemented prior to OpenSearch 1.0")
+	}
+	return nil
+}
+
+type anomalyDetectorResponse struct {
+	ID                 string                 `json:"_id"`
+	Version            int                    `json:"_version"`
+	AnomalyDetector    map[string]interface{} `json:"anomaly_detector"`
+	AnomalyDetectorJob *struct {
+		Enabled bool `json:"enabled"`
+	} `json:"anomaly_detector_job,omitempty"`
+}
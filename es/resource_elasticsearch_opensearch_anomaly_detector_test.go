@@ -0,0 +1,110 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchOpenSearchAnomalyDetector(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if _, err := elasticsearchPerformRequest(testAccOpendistroProvider.Meta(), "GET", "/_plugins/_anomaly_detection/stats", nil); err != nil {
+				t.Skipf("Anomaly detection plugin not available: %s", err)
+			}
+		},
+		Providers:    testAccOpendistroProviders,
+		CheckDestroy: testCheckElasticsearchOpenSearchAnomalyDetectorDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchOpenSearchAnomalyDetector("10", false),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchAnomalyDetectorExists("elasticsearch_opensearch_anomaly_detector.test"),
+					resource.TestCheckResourceAttr("elasticsearch_opensearch_anomaly_detector.test", "enabled", "false"),
+				),
+			},
+			{
+				Config: testAccElasticsearchOpenSearchAnomalyDetector("5", true),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchAnomalyDetectorExists("elasticsearch_opensearch_anomaly_detector.test"),
+					resource.TestCheckResourceAttr("elasticsearch_opensearch_anomaly_detector.test", "enabled", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchOpenSearchAnomalyDetectorExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No anomaly detector ID is set")
+		}
+
+		_, err := resourceElasticsearchOpenSearchGetAnomalyDetector(rs.Primary.ID, testAccOpendistroProvider.Meta())
+		return err
+	}
+}
+
+func testCheckElasticsearchOpenSearchAnomalyDetectorDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_opensearch_anomaly_detector" {
+			continue
+		}
+
+		_, err := resourceElasticsearchOpenSearchGetAnomalyDetector(rs.Primary.ID, testAccOpendistroProvider.Meta())
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Anomaly detector %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchOpenSearchAnomalyDetector(interval string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_index" "test" {
+  name               = "test-anomaly-detector"
+  number_of_shards   = 1
+  number_of_replicas = 0
+  mappings = jsonencode({
+    properties = {
+      timestamp = { type = "date" }
+      latency   = { type = "long" }
+    }
+  })
+}
+
+resource "elasticsearch_opensearch_anomaly_detector" "test" {
+  enabled = %t
+  body = jsonencode({
+    name       = "test-detector"
+    time_field = "timestamp"
+    indices    = [elasticsearch_index.test.name]
+    feature_attributes = [{
+      feature_name    = "latency"
+      feature_enabled = true
+      aggregation_query = {
+        latency = {
+          avg = { field = "latency" }
+        }
+      }
+    }]
+    detection_interval = {
+      period = { interval = %s, unit = "Minutes" }
+    }
+    window_delay = {
+      period = { interval = 1, unit = "Minutes" }
+    }
+  })
+}
+`, enabled, interval)
+}
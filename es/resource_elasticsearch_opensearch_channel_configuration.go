@@ -0,0 +1,217 @@
+package es
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+// the channel types of the notifications plugin, the settings of a channel
+// are in the object named after its type
+var channelConfigurationTypes = map[string]bool{
+	"slack":           true,
+	"chime":           true,
+	"microsoft_teams": true,
+	"webhook":         true,
+	"sns":             true,
+	"ses_account":     true,
+	"smtp_account":    true,
+	"email":           true,
+	"email_group":     true,
+}
+
+func resourceElasticsearchOpenSearchChannelConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an OpenSearch notifications channel, which replaced the alerting destinations in OpenSearch 2.0, e.g. a Slack, Chime, webhook, SES or SNS endpoint monitors send notifications to. Please refer to the OpenSearch [notifications documentation](https://opensearch.org/docs/latest/observing-your-data/notifications/api/) for details.",
+		Create:      resourceElasticsearchOpenSearchChannelConfigurationCreate,
+		Read:        resourceElasticsearchOpenSearchChannelConfigurationRead,
+		Update:      resourceElasticsearchOpenSearchChannelConfigurationUpdate,
+		Delete:      resourceElasticsearchOpenSearchChannelConfigurationDelete,
+		Schema: map[string]*schema.Schema{
+			"config_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The identifier of the channel, generated if not set.",
+			},
+			"body": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: diffSuppressChannelConfiguration,
+				ValidateFunc:     validateChannelConfigurationBody,
+				StateFunc: func(v interface{}) string {
+					json, _ := structure.NormalizeJsonString(v)
+					return json
+				},
+				Description: "The JSON configuration of the channel, with its `name`, `config_type` and the settings of the type, e.g. `slack`, `chime`, `webhook`, `ses_account` or `sns`.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchOpenSearchChannelConfigurationCreate(d *schema.ResourceData, m interface{}) error {
+	var config map[string]interface{}
+	if err := json.Unmarshal([]byte(d.Get("body").(string)), &config); err != nil {
+		return fmt.Errorf("fail to unmarshal body: %v", err)
+	}
+	body := map[string]interface{}{
+		"config": config,
+	}
+	if id, ok := d.GetOk("config_id"); ok {
+		body["config_id"] = id
+	}
+
+	if err := checkOpenSearchChannelConfigurationClient(m); err != nil {
+		return err
+	}
+	res, err := elasticsearchPerformRequest(m, "POST", "/_plugins/_notifications/configs", body)
+	if err != nil {
+		log.Printf("[INFO] Failed to create channel configuration: %+v", err)
+		return err
+	}
+
+	response := new(channelConfigurationResponse)
+	if err := json.Unmarshal(res, response); err != nil {
+		return fmt.Errorf("error unmarshalling channel configuration body: %+v: %+v", err, res)
+	}
+
+	d.SetId(response.ConfigID)
+	return resourceElasticsearchOpenSearchChannelConfigurationRead(d, m)
+}
+
+func resourceElasticsearchOpenSearchChannelConfigurationRead(d *schema.ResourceData, m interface{}) error {
+	config, err := resourceElasticsearchOpenSearchGetChannelConfiguration(d.Id(), m)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Channel configuration (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	body, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("config_id", d.Id())
+	ds.set("body", string(body))
+	return ds.err
+}
+
+func resourceElasticsearchOpenSearchChannelConfigurationUpdate(d *schema.ResourceData, m interface{}) error {
+	var config map[string]interface{}
+	if err := json.Unmarshal([]byte(d.Get("body").(string)), &config); err != nil {
+		return fmt.Errorf("fail to unmarshal body: %v", err)
+	}
+
+	path, err := uritemplates.Expand("/_plugins/_notifications/configs/{id}", map[string]string{
+		"id": d.Id(),
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for channel configuration: %+v", err)
+	}
+	if err := checkOpenSearchChannelConfigurationClient(m); err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(m, "PUT", path, map[string]interface{}{"config": config}); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchOpenSearchChannelConfigurationRead(d, m)
+}
+
+func resourceElasticsearchOpenSearchChannelConfigurationDelete(d *schema.ResourceData, m interface{}) error {
+	path, err := uritemplates.Expand("/_plugins/_notifications/configs/{id}", map[string]string{
+		"id": d.Id(),
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for channel configuration: %+v", err)
+	}
+	if err := checkOpenSearchChannelConfigurationClient(m); err != nil {
+		return err
+	}
+
+	_, err = elasticsearchPerformRequest(m, "DELETE", path, nil)
+	return err
+}
+
+func resourceElasticsearchOpenSearchGetChannelConfiguration(configID string, m interface{}) (map[string]interface{}, error) {
+	path, err := uritemplates.Expand("/_plugins/_notifications/configs/{id}", map[string]string{
+		"id": configID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error building URL path for channel configuration: %+v", err)
+	}
+	if err := checkOpenSearchChannelConfigurationClient(m); err != nil {
+		return nil, err
+	}
+
+	res, err := elasticsearchPerformRequest(m, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(channelConfigurationGetResponse)
+	if err := json.Unmarshal(res, response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling channel configuration body: %+v: %+v", err, res)
+	}
+	if len(response.ConfigList) == 0 {
+		return nil, fmt.Errorf("endpoint returned empty set of channel configurations: %+v", response)
+	}
+	return response.ConfigList[0].Config, nil
+}
+
+func checkOpenSearchChannelConfigurationClient(m interface{}) error {
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	if _, ok := esClient.(*elastic7.Client); !ok {
+		return errors.New("channel configuration resource not implemented prior to OpenSearch 2.0")
+	}
+	return nil
+}
+
+func validateChannelConfigurationBody(v interface{}, k string) (ws []string, errors []error) {
+	var config map[string]interface{}
+	if err := json.Unmarshal([]byte(v.(string)), &config); err != nil {
+		errors = append(errors, fmt.Errorf("%q contains an invalid JSON: %s", k, err))
+		return
+	}
+
+	configType, _ := config["config_type"].(string)
+	if !channelConfigurationTypes[configType] {
+		errors = append(errors, fmt.Errorf("%q: config_type must be one of slack, chime, microsoft_teams, webhook, sns, ses_account, smtp_account, email or email_group, got: %q", k, configType))
+		return
+	}
+	if _, ok := config[configType].(map[string]interface{}); !ok {
+		errors = append(errors, fmt.Errorf("%q: the settings of the %s channel must be set as the %s object", k, configType, configType))
+	}
+	return
+}
+
+type channelConfigurationResponse struct {
+	ConfigID string `json:"config_id"`
+}
+
+type channelConfigurationGetResponse struct {
+	ConfigList []struct {
+		ConfigID string                 `json:"config_id"`
+		Config   map[string]interface{} `json:"config"`
+	} `json:"config_list"`
+	TotalHits int `json:"total_hits"`
+}
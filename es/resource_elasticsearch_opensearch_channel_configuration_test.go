@@ -0,0 +1,84 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchOpenSearchChannelConfiguration(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if _, err := elasticsearchPerformRequest(testAccOpendistroProvider.Meta(), "GET", "/_plugins/_notifications/features", nil); err != nil {
+				t.Skipf("Notifications plugin not available: %s", err)
+			}
+		},
+		Providers:    testAccOpendistroProviders,
+		CheckDestroy: testCheckElasticsearchOpenSearchChannelConfigurationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchOpenSearchChannelConfiguration("https://hooks.slack.com/services/1"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchChannelConfigurationExists("elasticsearch_opensearch_channel_configuration.test"),
+					resource.TestCheckResourceAttr("elasticsearch_opensearch_channel_configuration.test", "config_id", "test-channel"),
+				),
+			},
+			{
+				Config: testAccElasticsearchOpenSearchChannelConfiguration("https://hooks.slack.com/services/2"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchChannelConfigurationExists("elasticsearch_opensearch_channel_configuration.test"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchOpenSearchChannelConfigurationExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No channel configuration ID is set")
+		}
+
+		_, err := resourceElasticsearchOpenSearchGetChannelConfiguration(rs.Primary.ID, testAccOpendistroProvider.Meta())
+		return err
+	}
+}
+
+func testCheckElasticsearchOpenSearchChannelConfigurationDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_opensearch_channel_configuration" {
+			continue
+		}
+
+		_, err := resourceElasticsearchOpenSearchGetChannelConfiguration(rs.Primary.ID, testAccOpendistroProvider.Meta())
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Channel configuration %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchOpenSearchChannelConfiguration(url string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_opensearch_channel_configuration" "test" {
+  config_id = "test-channel"
+  body = jsonencode({
+    name        = "test-channel"
+    config_type = "slack"
+    slack = {
+      url = %q
+    }
+  })
+}
+`, url)
+}
@@ -0,0 +1,328 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func resourceElasticsearchOpenSearchDashboardObject() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an OpenSearch Dashboards saved object, e.g. an `index-pattern`, a `visualization` or a `dashboard`, managed with the saved objects API of the Dashboards at the `kibana_url` of the provider. Only the configured `attributes` are read back, the attributes the Dashboards add are ignored. Please refer to the OpenSearch Dashboards [saved objects documentation](https://opensearch.org/docs/latest/dashboards/management/saved-objects-api/) for details.",
+		Create:      resourceElasticsearchOpenSearchDashboardObjectCreate,
+		Read:        resourceElasticsearchOpenSearchDashboardObjectRead,
+		Update:      resourceElasticsearchOpenSearchDashboardObjectUpdate,
+		Delete:      resourceElasticsearchOpenSearchDashboardObjectDelete,
+		Schema: map[string]*schema.Schema{
+			"object_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The type of the saved object, e.g. `index-pattern`, `visualization`, `search` or `dashboard`.",
+			},
+			"object_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The identifier of the saved object, generated if not set.",
+			},
+			"tenant": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The security tenant of the saved object, e.g. `global`, `private` or the name of a custom tenant, the default tenant of the user if not set.",
+			},
+			"attributes": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressEquivalentJson,
+				ValidateFunc:     validation.StringIsJSON,
+				StateFunc: func(v interface{}) string {
+					json, _ := structure.NormalizeJsonString(v)
+					return json
+				},
+				Description: "The JSON attributes of the saved object, e.g. the `title` and `timeFieldName` of an index pattern.",
+			},
+			"reference": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The other saved objects the saved object refers to, e.g. the index pattern of a visualization.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the reference in the attributes.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The type of the referenced saved object.",
+						},
+						"id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The identifier of the referenced saved object.",
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: resourceElasticsearchOpenSearchDashboardObjectImport,
+		},
+	}
+}
+
+func resourceElasticsearchOpenSearchDashboardObjectCreate(d *schema.ResourceData, m interface{}) error {
+	objectType := d.Get("object_type").(string)
+
+	body, err := expandOpenSearchDashboardObject(d)
+	if err != nil {
+		return err
+	}
+
+	path := "/api/saved_objects/{type}"
+	params := map[string]string{
+		"type": objectType,
+	}
+	if id, ok := d.GetOk("object_id"); ok {
+		path = "/api/saved_objects/{type}/{id}"
+		params["id"] = id.(string)
+	}
+	path, err = uritemplates.Expand(path, params)
+	if err != nil {
+		return fmt.Errorf("error building URL path for dashboard object: %+v", err)
+	}
+
+	res, err := opensearchDashboardsPerformRequest(m, "POST", path, d.Get("tenant").(string), body)
+	if err != nil {
+		log.Printf("[INFO] Failed to create dashboard object: %+v", err)
+		return err
+	}
+	object := new(dashboardSavedObject)
+	if err := json.Unmarshal(res, object); err != nil {
+		return fmt.Errorf("error unmarshalling dashboard object body: %+v: %+v", err, res)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", objectType, object.ID))
+	return resourceElasticsearchOpenSearchDashboardObjectRead(d, m)
+}
+
+func resourceElasticsearchOpenSearchDashboardObjectRead(d *schema.ResourceData, m interface{}) error {
+	objectType, objectID, err := parseOpenSearchDashboardObjectID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	object, err := opensearchDashboardsGetObject(m, objectType, objectID, d.Get("tenant").(string))
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Dashboard object (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	// only read back the configured attributes, on import all of them
+	attributes := object.Attributes
+	var configured map[string]interface{}
+	if err := json.Unmarshal([]byte(d.Get("attributes").(string)), &configured); err == nil {
+		attributes = filterJSONObject(object.Attributes, configured)
+	}
+	attributesJSON, err := json.Marshal(attributes)
+	if err != nil {
+		return err
+	}
+
+	references := make([]interface{}, 0, len(object.References))
+	for _, r := range object.References {
+		references = append(references, map[string]interface{}{
+			"name": r.Name,
+			"type": r.Type,
+			"id":   r.ID,
+		})
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("object_type", objectType)
+	ds.set("object_id", objectID)
+	ds.set("attributes", string(attributesJSON))
+	ds.set("reference", references)
+	return ds.err
+}
+
+func resourceElasticsearchOpenSearchDashboardObjectUpdate(d *schema.ResourceData, m interface{}) error {
+	objectType, objectID, err := parseOpenSearchDashboardObjectID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	body, err := expandOpenSearchDashboardObject(d)
+	if err != nil {
+		return err
+	}
+	path, err := opensearchDashboardObjectPath(objectType, objectID)
+	if err != nil {
+		return err
+	}
+	if _, err := opensearchDashboardsPerformRequest(m, "PUT", path, d.Get("tenant").(string), body); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchOpenSearchDashboardObjectRead(d, m)
+}
+
+func resourceElasticsearchOpenSearchDashboardObjectDelete(d *schema.ResourceData, m interface{}) error {
+	objectType, objectID, err := parseOpenSearchDashboardObjectID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	path, err := opensearchDashboardObjectPath(objectType, objectID)
+	if err != nil {
+		return err
+	}
+	if _, err := opensearchDashboardsPerformRequest(m, "DELETE", path, d.Get("tenant").(string), nil); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceElasticsearchOpenSearchDashboardObjectImport accepts the tenant
+// before the ID, as in tenant/type/id
+func resourceElasticsearchOpenSearchDashboardObjectImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	ds := &resourceDataSetter{d: d}
+	switch {
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+	case len(parts) == 3 && parts[0] != "" && parts[1] != "" && parts[2] != "":
+		ds.set("tenant", parts[0])
+		d.SetId(fmt.Sprintf("%s/%s", parts[1], parts[2]))
+	default:
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected type/id or tenant/type/id", d.Id())
+	}
+	return []*schema.ResourceData{d}, ds.err
+}
+
+func expandOpenSearchDashboardObject(d *schema.ResourceData) (map[string]interface{}, error) {
+	var attributes map[string]interface{}
+	if err := json.Unmarshal([]byte(d.Get("attributes").(string)), &attributes); err != nil {
+		return nil, fmt.Errorf("fail to unmarshal attributes: %v", err)
+	}
+
+	references := make([]dashboardSavedObjectReference, 0)
+	for _, r := range d.Get("reference").([]interface{}) {
+		reference := r.(map[string]interface{})
+		references = append(references, dashboardSavedObjectReference{
+			Name: reference["name"].(string),
+			Type: reference["type"].(string),
+			ID:   reference["id"].(string),
+		})
+	}
+
+	return map[string]interface{}{
+		"attributes": attributes,
+		"references": references,
+	}, nil
+}
+
+func parseOpenSearchDashboardObjectID(id string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("unexpected format of ID (%s), expected type/id", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+func opensearchDashboardsGetObject(m interface{}, objectType string, objectID string, tenant string) (*dashboardSavedObject, error) {
+	path, err := opensearchDashboardObjectPath(objectType, objectID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := opensearchDashboardsPerformRequest(m, "GET", path, tenant, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	object := new(dashboardSavedObject)
+	if err := json.Unmarshal(res, object); err != nil {
+		return nil, fmt.Errorf("error unmarshalling dashboard object body: %+v: %+v", err, res)
+	}
+	if object.Attributes == nil {
+		object.Attributes = make(map[string]interface{})
+	}
+	return object, nil
+}
+
+func opensearchDashboardObjectPath(objectType string, objectID string) (string, error) {
+	path, err := uritemplates.Expand("/api/saved_objects/{type}/{id}", map[string]string{
+		"type": objectType,
+		"id":   objectID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for dashboard object: %+v", err)
+	}
+	return path, nil
+}
+
+// opensearchDashboardsPerformRequest performs a request to the Dashboards,
+// which check the osd-xsrf header instead of the kbn-xsrf one, in the tenant
+// if set
+func opensearchDashboardsPerformRequest(m interface{}, method string, path string, tenant string, body interface{}) (json.RawMessage, error) {
+	kibanaClient, err := getKibanaClient(m.(*ProviderConf))
+	if err != nil {
+		return nil, err
+	}
+	client, ok := kibanaClient.(*elastic7.Client)
+	if !ok {
+		return nil, errors.New("dashboard object resource not implemented prior to OpenSearch 1.0")
+	}
+
+	headers := http.Header{}
+	headers.Set("osd-xsrf", "true")
+	if tenant != "" {
+		headers.Set("securitytenant", tenant)
+	}
+
+	res, err := client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+		Method:  method,
+		Path:    path,
+		Body:    body,
+		Headers: headers,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Body, nil
+}
+
+type dashboardSavedObject struct {
+	ID         string                          `json:"id"`
+	Type       string                          `json:"type"`
+	Attributes map[string]interface{}          `json:"attributes"`
+	References []dashboardSavedObjectReference `json:"references"`
+	Version    string                          `json:"version,omitempty"`
+}
+
+type dashboardSavedObjectReference struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
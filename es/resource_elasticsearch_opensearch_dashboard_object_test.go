@@ -0,0 +1,123 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchOpenSearchDashboardObject(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			// the saved objects API of the Dashboards is also the one of Kibana
+			_, err := opensearchDashboardsPerformRequest(testAccKibanaProvider.Meta(), "GET", "/api/status", "", nil)
+			if err != nil {
+				t.Skipf("Dashboards not available: %s", err)
+			}
+		},
+		Providers:    testAccKibanaProviders,
+		CheckDestroy: testCheckElasticsearchOpenSearchDashboardObjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchOpenSearchDashboardObject("Latency"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchDashboardObjectExists("elasticsearch_opensearch_dashboard_object.index_pattern"),
+					testCheckElasticsearchOpenSearchDashboardObjectExists("elasticsearch_opensearch_dashboard_object.visualization"),
+					resource.TestCheckResourceAttr("elasticsearch_opensearch_dashboard_object.index_pattern", "object_id", "terraform-test-logs"),
+					resource.TestCheckResourceAttr("elasticsearch_opensearch_dashboard_object.visualization", "reference.#", "1"),
+				),
+			},
+			{
+				Config: testAccElasticsearchOpenSearchDashboardObject("Latency per service"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchDashboardObjectExists("elasticsearch_opensearch_dashboard_object.visualization"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_opensearch_dashboard_object.index_pattern",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// all the attributes are read back on import
+				ImportStateVerifyIgnore: []string{"attributes"},
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchOpenSearchDashboardObjectExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No dashboard object ID is set")
+		}
+
+		objectType, objectID, err := parseOpenSearchDashboardObjectID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		_, err = opensearchDashboardsGetObject(testAccKibanaProvider.Meta(), objectType, objectID, rs.Primary.Attributes["tenant"])
+		return err
+	}
+}
+
+func testCheckElasticsearchOpenSearchDashboardObjectDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_opensearch_dashboard_object" {
+			continue
+		}
+
+		objectType, objectID, err := parseOpenSearchDashboardObjectID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		_, err = opensearchDashboardsGetObject(testAccKibanaProvider.Meta(), objectType, objectID, rs.Primary.Attributes["tenant"])
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Dashboard object %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchOpenSearchDashboardObject(title string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_opensearch_dashboard_object" "index_pattern" {
+  object_type = "index-pattern"
+  object_id   = "terraform-test-logs"
+  attributes = jsonencode({
+    title         = "terraform-test-logs-*"
+    timeFieldName = "@timestamp"
+  })
+}
+
+resource "elasticsearch_opensearch_dashboard_object" "visualization" {
+  object_type = "visualization"
+  attributes = jsonencode({
+    title = "%s"
+    visState = jsonencode({
+      title = "%s"
+      type  = "line"
+    })
+    kibanaSavedObjectMeta = {
+      searchSourceJSON = jsonencode({
+        indexRefName = "kibanaSavedObjectMeta.searchSourceJSON.index"
+      })
+    }
+  })
+
+  reference {
+    name = "kibanaSavedObjectMeta.searchSourceJSON.index"
+    type = "index-pattern"
+    id   = elasticsearch_opensearch_dashboard_object.index_pattern.object_id
+  }
+}
+`, title, title)
+}
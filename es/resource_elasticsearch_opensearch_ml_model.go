@@ -0,0 +1,294 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func resourceElasticsearchOpenSearchMlModel() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an OpenSearch ML Commons model, which is registered from the `body`, e.g. a pretrained or a remote model, and deployed to the ML nodes. The registration is not returned by the cluster, so changing the `body` registers a new model and models cannot be imported. Please refer to the OpenSearch [model documentation](https://opensearch.org/docs/latest/ml-commons-plugin/api/model-apis/register-model/) for details.",
+		Create:      resourceElasticsearchOpenSearchMlModelCreate,
+		Read:        resourceElasticsearchOpenSearchMlModelRead,
+		Update:      resourceElasticsearchOpenSearchMlModelUpdate,
+		Delete:      resourceElasticsearchOpenSearchMlModelDelete,
+		Schema: map[string]*schema.Schema{
+			"body": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressEquivalentJson,
+				ValidateFunc:     validation.StringIsJSON,
+				StateFunc: func(v interface{}) string {
+					json, _ := structure.NormalizeJsonString(v)
+					return json
+				},
+				Description: "The JSON body of the model registration, e.g. the `name`, `version` and `model_format` of a pretrained model, or the `function_name` and `connector_id` of a remote model.",
+			},
+			"model_group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The model group to register the model in, a group named after the model is created if not set.",
+			},
+			"deployed": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the model is deployed, the model is undeployed before it is deleted.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the model.",
+			},
+			"model_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of the model in its group.",
+			},
+			"model_state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the model, e.g. `REGISTERED`, `DEPLOYED` or `PARTIALLY_DEPLOYED`.",
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+	}
+}
+
+func resourceElasticsearchOpenSearchMlModelCreate(d *schema.ResourceData, m interface{}) error {
+	if err := checkOpenSearchMlClient(m); err != nil {
+		return err
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(d.Get("body").(string)), &body); err != nil {
+		return fmt.Errorf("fail to unmarshal body: %v", err)
+	}
+	if groupID, ok := d.GetOk("model_group_id"); ok {
+		body["model_group_id"] = groupID.(string)
+	}
+
+	res, err := elasticsearchPerformRequest(m, "POST", "/_plugins/_ml/models/_register", body)
+	if err != nil {
+		log.Printf("[INFO] Failed to register model: %+v", err)
+		return err
+	}
+	response := new(mlTaskResponse)
+	if err := json.Unmarshal(res, response); err != nil {
+		return fmt.Errorf("error unmarshalling model body: %+v: %+v", err, res)
+	}
+
+	// the models are registered, e.g. downloaded, by a task
+	modelID := response.ModelID
+	if modelID == "" {
+		task, err := elasticsearchWaitForOpenSearchMlTask(m, response.TaskID, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return fmt.Errorf("error registering model: %+v", err)
+		}
+		modelID = task.ModelID
+	}
+	d.SetId(modelID)
+
+	if d.Get("deployed").(bool) {
+		if err := resourceElasticsearchOpenSearchDeployMlModel(d, m, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+
+	return resourceElasticsearchOpenSearchMlModelRead(d, m)
+}
+
+func resourceElasticsearchOpenSearchMlModelRead(d *schema.ResourceData, m interface{}) error {
+	model, err := elasticsearchGetOpenSearchMlModel(m, d.Id())
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Model (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("model_group_id", model.ModelGroupID)
+	ds.set("deployed", openSearchMlModelDeployed(model.ModelState))
+	ds.set("name", model.Name)
+	ds.set("model_version", model.ModelVersion)
+	ds.set("model_state", model.ModelState)
+	return ds.err
+}
+
+func resourceElasticsearchOpenSearchMlModelUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChange("deployed") {
+		if d.Get("deployed").(bool) {
+			if err := resourceElasticsearchOpenSearchDeployMlModel(d, m, d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return err
+			}
+		} else if err := resourceElasticsearchOpenSearchMlModelAction(m, d.Id(), "_undeploy"); err != nil {
+			return err
+		}
+	}
+
+	return resourceElasticsearchOpenSearchMlModelRead(d, m)
+}
+
+func resourceElasticsearchOpenSearchMlModelDelete(d *schema.ResourceData, m interface{}) error {
+	model, err := elasticsearchGetOpenSearchMlModel(m, d.Id())
+	if err != nil {
+		return err
+	}
+	// deployed models cannot be deleted
+	if openSearchMlModelDeployed(model.ModelState) || model.ModelState == "DEPLOYING" {
+		if err := resourceElasticsearchOpenSearchMlModelAction(m, d.Id(), "_undeploy"); err != nil {
+			return err
+		}
+	}
+
+	path, err := openSearchMlModelPath(m, d.Id(), "")
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(m, "DELETE", path, nil); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceElasticsearchOpenSearchDeployMlModel deploys the model and waits for
+// the deployment task
+func resourceElasticsearchOpenSearchDeployMlModel(d *schema.ResourceData, m interface{}, timeout time.Duration) error {
+	path, err := openSearchMlModelPath(m, d.Id(), "/_deploy")
+	if err != nil {
+		return err
+	}
+	res, err := elasticsearchPerformRequest(m, "POST", path, nil)
+	if err != nil {
+		return fmt.Errorf("error deploying model %s: %+v", d.Id(), err)
+	}
+	response := new(mlTaskResponse)
+	if err := json.Unmarshal(res, response); err != nil {
+		return fmt.Errorf("error unmarshalling model body: %+v: %+v", err, res)
+	}
+
+	if _, err := elasticsearchWaitForOpenSearchMlTask(m, response.TaskID, timeout); err != nil {
+		return fmt.Errorf("error deploying model %s: %+v", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceElasticsearchOpenSearchMlModelAction(m interface{}, id string, action string) error {
+	path, err := openSearchMlModelPath(m, id, "/"+action)
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(m, "POST", path, nil); err != nil {
+		return fmt.Errorf("error calling %s on model %s: %+v", action, id, err)
+	}
+	return nil
+}
+
+func elasticsearchWaitForOpenSearchMlTask(m interface{}, taskID string, timeout time.Duration) (*mlTask, error) {
+	path, err := uritemplates.Expand("/_plugins/_ml/tasks/{id}", map[string]string{
+		"id": taskID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error building URL path for ML task: %+v", err)
+	}
+
+	task := new(mlTask)
+	err = resource.Retry(timeout, func() *resource.RetryError {
+		body, err := elasticsearchPerformRequest(m, "GET", path, nil)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if err := json.Unmarshal(body, task); err != nil {
+			return resource.NonRetryableError(fmt.Errorf("error unmarshalling ML task body: %+v: %+v", err, body))
+		}
+
+		switch task.State {
+		case "COMPLETED":
+			return nil
+		case "FAILED", "CANCELLED", "COMPLETED_WITH_ERROR":
+			return resource.NonRetryableError(fmt.Errorf("ML task %s is %s: %s", taskID, task.State, task.Error))
+		default:
+			log.Printf("[INFO] ML task %s is %s", taskID, task.State)
+			return resource.RetryableError(fmt.Errorf("ML task %s is %s", taskID, task.State))
+		}
+	})
+	return task, err
+}
+
+func elasticsearchGetOpenSearchMlModel(m interface{}, id string) (*mlModel, error) {
+	path, err := openSearchMlModelPath(m, id, "")
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := elasticsearchPerformRequest(m, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	model := new(mlModel)
+	if err := json.Unmarshal(body, model); err != nil {
+		return nil, fmt.Errorf("error unmarshalling model body: %+v: %+v", err, body)
+	}
+	return model, nil
+}
+
+func openSearchMlModelPath(m interface{}, id string, suffix string) (string, error) {
+	if err := checkOpenSearchMlClient(m); err != nil {
+		return "", err
+	}
+
+	path, err := uritemplates.Expand("/_plugins/_ml/models/{id}", map[string]string{
+		"id": id,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for model: %+v", err)
+	}
+	return path + suffix, nil
+}
+
+func openSearchMlModelDeployed(state string) bool {
+	return state == "DEPLOYED" || state == "PARTIALLY_DEPLOYED"
+}
+
+type mlTaskResponse struct {
+	TaskID  string `json:"task_id"`
+	ModelID string `json:"model_id,omitempty"`
+	Status  string `json:"status"`
+}
+
+type mlTask struct {
+	ModelID  string `json:"model_id"`
+	TaskType string `json:"task_type"`
+	State    string `json:"state"`
+	Error    string `json:"error,omitempty"`
+}
+
+type mlModel struct {
+	Name         string `json:"name"`
+	ModelGroupID string `json:"model_group_id"`
+	ModelVersion string `json:"model_version"`
+	ModelState   string `json:"model_state"`
+	ModelFormat  string `json:"model_format"`
+}
@@ -0,0 +1,194 @@
+package es
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func resourceElasticsearchOpenSearchMlModelGroup() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an OpenSearch ML Commons model group, which groups the versions of a model and controls the access to them. Please refer to the OpenSearch [model group documentation](https://opensearch.org/docs/latest/ml-commons-plugin/api/model-group-apis/index/) for details.",
+		Create:      resourceElasticsearchOpenSearchMlModelGroupCreate,
+		Read:        resourceElasticsearchOpenSearchMlModelGroupRead,
+		Update:      resourceElasticsearchOpenSearchMlModelGroupUpdate,
+		Delete:      resourceElasticsearchOpenSearchMlModelGroupDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the model group, unique in the cluster.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the model group.",
+			},
+			"access_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"public", "private", "restricted"}, false),
+				Description:  "The access to the models of the group, `public`, `private` or `restricted` to the `backend_roles`, when model access control is enabled.",
+			},
+			"backend_roles": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The backend roles with access to a `restricted` model group.",
+			},
+			"latest_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The latest version of the models registered in the group.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchOpenSearchMlModelGroupCreate(d *schema.ResourceData, m interface{}) error {
+	if err := checkOpenSearchMlClient(m); err != nil {
+		return err
+	}
+
+	res, err := elasticsearchPerformRequest(m, "POST", "/_plugins/_ml/model_groups/_register", expandOpenSearchMlModelGroup(d))
+	if err != nil {
+		log.Printf("[INFO] Failed to register model group: %+v", err)
+		return err
+	}
+	response := new(mlModelGroupRegisterResponse)
+	if err := json.Unmarshal(res, response); err != nil {
+		return fmt.Errorf("error unmarshalling model group body: %+v: %+v", err, res)
+	}
+
+	d.SetId(response.ModelGroupID)
+	return resourceElasticsearchOpenSearchMlModelGroupRead(d, m)
+}
+
+func resourceElasticsearchOpenSearchMlModelGroupRead(d *schema.ResourceData, m interface{}) error {
+	group, err := elasticsearchGetOpenSearchMlModelGroup(m, d.Id())
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Model group (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("name", group.Name)
+	ds.set("description", group.Description)
+	// the access mode is only returned when model access control is enabled
+	if group.Access != "" {
+		ds.set("access_mode", group.Access)
+	}
+	ds.set("backend_roles", group.BackendRoles)
+	ds.set("latest_version", group.LatestVersion)
+	return ds.err
+}
+
+func resourceElasticsearchOpenSearchMlModelGroupUpdate(d *schema.ResourceData, m interface{}) error {
+	path, err := openSearchMlModelGroupPath(m, d.Id())
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(m, "PUT", path, expandOpenSearchMlModelGroup(d)); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchOpenSearchMlModelGroupRead(d, m)
+}
+
+func resourceElasticsearchOpenSearchMlModelGroupDelete(d *schema.ResourceData, m interface{}) error {
+	path, err := openSearchMlModelGroupPath(m, d.Id())
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(m, "DELETE", path, nil); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func expandOpenSearchMlModelGroup(d *schema.ResourceData) map[string]interface{} {
+	body := map[string]interface{}{
+		"name":        d.Get("name").(string),
+		"description": d.Get("description").(string),
+	}
+	if accessMode, ok := d.GetOk("access_mode"); ok {
+		body["access_mode"] = accessMode.(string)
+	}
+	if roles := d.Get("backend_roles").(*schema.Set); roles.Len() > 0 {
+		body["backend_roles"] = expandStringList(roles.List())
+	}
+	return body
+}
+
+func elasticsearchGetOpenSearchMlModelGroup(m interface{}, id string) (*mlModelGroup, error) {
+	path, err := openSearchMlModelGroupPath(m, id)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := elasticsearchPerformRequest(m, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	group := new(mlModelGroup)
+	if err := json.Unmarshal(body, group); err != nil {
+		return nil, fmt.Errorf("error unmarshalling model group body: %+v: %+v", err, body)
+	}
+	return group, nil
+}
+
+func openSearchMlModelGroupPath(m interface{}, id string) (string, error) {
+	if err := checkOpenSearchMlClient(m); err != nil {
+		return "", err
+	}
+
+	path, err := uritemplates.Expand("/_plugins/_ml/model_groups/{id}", map[string]string{
+		"id": id,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for model group: %+v", err)
+	}
+	return path, nil
+}
+
+func checkOpenSearchMlClient(m interface{}) error {
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	if _, ok := esClient.(*elastic7.Client); !ok {
+		return errors.New("ML Commons resources not implemented prior to OpenSearch 2.7")
+	}
+	return nil
+}
+
+type mlModelGroupRegisterResponse struct {
+	ModelGroupID string `json:"model_group_id"`
+	Status       string `json:"status"`
+}
+
+type mlModelGroup struct {
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	Access        string   `json:"access"`
+	BackendRoles  []string `json:"backend_roles"`
+	LatestVersion int      `json:"latest_version"`
+}
@@ -0,0 +1,92 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func testAccOpenSearchMlPreCheck(t *testing.T) {
+	// the group not existing yet is reported only when ML Commons is available
+	_, err := elasticsearchPerformRequest(testAccOpendistroProvider.Meta(), "GET", "/_plugins/_ml/model_groups/terraform-test", nil)
+	if err != nil && !elastic7.IsNotFound(err) {
+		t.Skipf("ML Commons not available: %s", err)
+	}
+}
+
+func TestAccElasticsearchOpenSearchMlModelGroup(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccOpenSearchMlPreCheck(t)
+		},
+		Providers:    testAccOpendistroProviders,
+		CheckDestroy: testCheckElasticsearchOpenSearchMlModelGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchOpenSearchMlModelGroup("test"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchMlModelGroupExists("elasticsearch_opensearch_ml_model_group.test"),
+					resource.TestCheckResourceAttr("elasticsearch_opensearch_ml_model_group.test", "description", "test"),
+				),
+			},
+			{
+				Config: testAccElasticsearchOpenSearchMlModelGroup("updated"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchMlModelGroupExists("elasticsearch_opensearch_ml_model_group.test"),
+					resource.TestCheckResourceAttr("elasticsearch_opensearch_ml_model_group.test", "description", "updated"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_opensearch_ml_model_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchOpenSearchMlModelGroupExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No model group ID is set")
+		}
+
+		_, err := elasticsearchGetOpenSearchMlModelGroup(testAccOpendistroProvider.Meta(), rs.Primary.ID)
+		return err
+	}
+}
+
+func testCheckElasticsearchOpenSearchMlModelGroupDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_opensearch_ml_model_group" {
+			continue
+		}
+
+		_, err := elasticsearchGetOpenSearchMlModelGroup(testAccOpendistroProvider.Meta(), rs.Primary.ID)
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Model group %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchOpenSearchMlModelGroup(description string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_opensearch_ml_model_group" "test" {
+  name        = "terraform-test"
+  description = "%s"
+}
+`, description)
+}
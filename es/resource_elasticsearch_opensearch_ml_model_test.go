@@ -0,0 +1,96 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchOpenSearchMlModel(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccOpenSearchMlPreCheck(t)
+		},
+		Providers:    testAccOpendistroProviders,
+		CheckDestroy: testCheckElasticsearchOpenSearchMlModelDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchOpenSearchMlModel(true),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchMlModelExists("elasticsearch_opensearch_ml_model.test"),
+					resource.TestCheckResourceAttr("elasticsearch_opensearch_ml_model.test", "model_state", "DEPLOYED"),
+					resource.TestCheckResourceAttrPair("elasticsearch_opensearch_ml_model.test", "model_group_id", "elasticsearch_opensearch_ml_model_group.test", "id"),
+				),
+			},
+			{
+				Config: testAccElasticsearchOpenSearchMlModel(false),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchMlModelExists("elasticsearch_opensearch_ml_model.test"),
+					resource.TestCheckResourceAttr("elasticsearch_opensearch_ml_model.test", "deployed", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchOpenSearchMlModelExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No model ID is set")
+		}
+
+		_, err := elasticsearchGetOpenSearchMlModel(testAccOpendistroProvider.Meta(), rs.Primary.ID)
+		return err
+	}
+}
+
+func testCheckElasticsearchOpenSearchMlModelDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_opensearch_ml_model" {
+			continue
+		}
+
+		_, err := elasticsearchGetOpenSearchMlModel(testAccOpendistroProvider.Meta(), rs.Primary.ID)
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Model %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+// the test cluster has no dedicated ML nodes
+func testAccElasticsearchOpenSearchMlModel(deployed bool) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_cluster_settings" "test" {
+  persistent = {
+    "plugins.ml_commons.only_run_on_ml_node" = "false"
+  }
+}
+
+resource "elasticsearch_opensearch_ml_model_group" "test" {
+  name = "terraform-test"
+}
+
+resource "elasticsearch_opensearch_ml_model" "test" {
+  model_group_id = elasticsearch_opensearch_ml_model_group.test.id
+  deployed       = %t
+  body = jsonencode({
+    name         = "huggingface/sentence-transformers/all-MiniLM-L6-v2"
+    version      = "1.0.1"
+    model_format = "TORCH_SCRIPT"
+  })
+
+  depends_on = [elasticsearch_cluster_settings.test]
+}
+`, deployed)
+}
@@ -0,0 +1,246 @@
+package es
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+// the status of indices which are not (or no longer) replicated
+const openSearchReplicationNotInProgress = "REPLICATION NOT IN PROGRESS"
+
+func resourceElasticsearchOpenSearchReplication() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an OpenSearch cross-cluster replication of a leader index of a remote cluster into a follower index. Changing `paused` pauses or resumes the replication, destroying the resource stops the replication and deletes the follower index. Please refer to the OpenSearch [cross-cluster replication documentation](https://opensearch.org/docs/latest/tuning-your-cluster/replication-plugin/api/) for details.",
+		Create:      resourceElasticsearchOpenSearchReplicationCreate,
+		Read:        resourceElasticsearchOpenSearchReplicationRead,
+		Update:      resourceElasticsearchOpenSearchReplicationUpdate,
+		Delete:      resourceElasticsearchOpenSearchReplicationDelete,
+		Schema: map[string]*schema.Schema{
+			"follower_index": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the follower index.",
+			},
+			"leader_alias": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the remote cluster connection to the leader cluster.",
+			},
+			"leader_index": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the leader index in the remote cluster.",
+			},
+			"leader_cluster_role": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The role of the leader cluster the replication runs as, required when the security plugin is enabled.",
+			},
+			"follower_cluster_role": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The role of the follower cluster the replication runs as, required when the security plugin is enabled.",
+			},
+			"paused": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the replication is paused.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the replication, e.g. `BOOTSTRAPPING`, `SYNCING` or `PAUSED`.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchOpenSearchReplicationCreate(d *schema.ResourceData, m interface{}) error {
+	name := d.Get("follower_index").(string)
+
+	body := map[string]interface{}{
+		"leader_alias": d.Get("leader_alias").(string),
+		"leader_index": d.Get("leader_index").(string),
+	}
+	if roles := expandOpenSearchReplicationRoles(d); roles != nil {
+		body["use_roles"] = roles
+	}
+
+	if err := elasticsearchPerformOpenSearchReplicationRequest(m, "PUT", name, "_start", body); err != nil {
+		log.Printf("[INFO] Failed to start replication: %+v", err)
+		return err
+	}
+	d.SetId(name)
+
+	if d.Get("paused").(bool) {
+		if err := elasticsearchPerformOpenSearchReplicationRequest(m, "POST", name, "_pause", map[string]interface{}{}); err != nil {
+			return err
+		}
+	}
+
+	return resourceElasticsearchOpenSearchReplicationRead(d, m)
+}
+
+func resourceElasticsearchOpenSearchReplicationRead(d *schema.ResourceData, m interface{}) error {
+	status, err := elasticsearchGetOpenSearchReplicationStatus(m, d.Id())
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Replication (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	if status.Status == openSearchReplicationNotInProgress {
+		log.Printf("[WARN] Replication (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("follower_index", d.Id())
+	// the leader is not returned while the replication is bootstrapping
+	if status.LeaderAlias != "" {
+		ds.set("leader_alias", status.LeaderAlias)
+	}
+	if status.LeaderIndex != "" {
+		ds.set("leader_index", status.LeaderIndex)
+	}
+	ds.set("paused", status.Status == "PAUSED")
+	ds.set("status", status.Status)
+	return ds.err
+}
+
+func resourceElasticsearchOpenSearchReplicationUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChange("paused") {
+		action := "_resume"
+		if d.Get("paused").(bool) {
+			action = "_pause"
+		}
+		if err := elasticsearchPerformOpenSearchReplicationRequest(m, "POST", d.Id(), action, map[string]interface{}{}); err != nil {
+			return err
+		}
+	}
+
+	return resourceElasticsearchOpenSearchReplicationRead(d, m)
+}
+
+func resourceElasticsearchOpenSearchReplicationDelete(d *schema.ResourceData, m interface{}) error {
+	id := d.Id()
+
+	// stopping the replication turns the follower into a regular index, which
+	// can then be deleted
+	if err := elasticsearchPerformOpenSearchReplicationRequest(m, "POST", id, "_stop", map[string]interface{}{}); err != nil {
+		return fmt.Errorf("error stopping replication of %s: %+v", id, err)
+	}
+
+	path, err := uritemplates.Expand("/{index}", map[string]string{
+		"index": id,
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for follower index: %+v", err)
+	}
+	if _, err := elasticsearchPerformRequest(m, "DELETE", path, nil); err != nil {
+		return fmt.Errorf("error deleting follower index %s: %+v", id, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// expandOpenSearchReplicationRoles returns the use_roles of the replication, or
+// nil when no role is configured
+func expandOpenSearchReplicationRoles(d *schema.ResourceData) map[string]interface{} {
+	roles := make(map[string]interface{})
+	if role, ok := d.GetOk("leader_cluster_role"); ok {
+		roles["leader_cluster_role"] = role.(string)
+	}
+	if role, ok := d.GetOk("follower_cluster_role"); ok {
+		roles["follower_cluster_role"] = role.(string)
+	}
+	if len(roles) == 0 {
+		return nil
+	}
+	return roles
+}
+
+func elasticsearchGetOpenSearchReplicationStatus(m interface{}, name string) (*openSearchReplicationStatus, error) {
+	path, err := openSearchReplicationPath(m, name, "_status")
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := elasticsearchPerformRequest(m, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(openSearchReplicationStatus)
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling replication status body: %+v: %+v", err, body)
+	}
+	return response, nil
+}
+
+func elasticsearchPerformOpenSearchReplicationRequest(m interface{}, method string, name string, action string, body interface{}) error {
+	path, err := openSearchReplicationPath(m, name, action)
+	if err != nil {
+		return err
+	}
+
+	if _, err := elasticsearchPerformRequest(m, method, path, body); err != nil {
+		return fmt.Errorf("error calling %s on replication of %s: %+v", action, name, err)
+	}
+	return nil
+}
+
+func openSearchReplicationPath(m interface{}, name string, action string) (string, error) {
+	if err := checkOpenSearchReplicationClient(m); err != nil {
+		return "", err
+	}
+
+	path, err := uritemplates.Expand("/_plugins/_replication/{index}/{action}", map[string]string{
+		"index":  name,
+		"action": action,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for replication: %+v", err)
+	}
+	return path, nil
+}
+
+func checkOpenSearchReplicationClient(m interface{}) error {
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	if _, ok := esClient.(*elastic7.Client); !ok {
+		return errors.New("replication resources not implemented prior to OpenSearch 1.1")
+	}
+	return nil
+}
+
+type openSearchReplicationStatus struct {
+	Status         string                 `json:"status"`
+	Reason         string                 `json:"reason"`
+	LeaderAlias    string                 `json:"leader_alias"`
+	LeaderIndex    string                 `json:"leader_index"`
+	FollowerIndex  string                 `json:"follower_index"`
+	SyncingDetails map[string]interface{} `json:"syncing_details,omitempty"`
+}
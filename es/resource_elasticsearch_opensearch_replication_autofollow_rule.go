@@ -0,0 +1,170 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func resourceElasticsearchOpenSearchReplicationAutofollowRule() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an OpenSearch cross-cluster replication autofollow rule, starting the replication of the new indices of a remote cluster matching the pattern. Indices already replicated are kept when the rule is destroyed. Please refer to the OpenSearch [cross-cluster replication documentation](https://opensearch.org/docs/latest/tuning-your-cluster/replication-plugin/auto-follow/) for details.",
+		Create:      resourceElasticsearchOpenSearchReplicationAutofollowRuleCreate,
+		Read:        resourceElasticsearchOpenSearchReplicationAutofollowRuleRead,
+		Delete:      resourceElasticsearchOpenSearchReplicationAutofollowRuleDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the autofollow rule.",
+			},
+			"leader_alias": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the remote cluster connection to the leader cluster.",
+			},
+			"pattern": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The wildcard expression of the leader indices to replicate, e.g. `logs-*`.",
+			},
+			"leader_cluster_role": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The role of the leader cluster the replications run as, required when the security plugin is enabled.",
+			},
+			"follower_cluster_role": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The role of the follower cluster the replications run as, required when the security plugin is enabled.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: resourceElasticsearchOpenSearchReplicationAutofollowRuleImport,
+		},
+	}
+}
+
+func resourceElasticsearchOpenSearchReplicationAutofollowRuleCreate(d *schema.ResourceData, m interface{}) error {
+	leaderAlias := d.Get("leader_alias").(string)
+	name := d.Get("name").(string)
+
+	body := map[string]interface{}{
+		"leader_alias": leaderAlias,
+		"name":         name,
+		"pattern":      d.Get("pattern").(string),
+	}
+	if roles := expandOpenSearchReplicationRoles(d); roles != nil {
+		body["use_roles"] = roles
+	}
+
+	if err := checkOpenSearchReplicationClient(m); err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(m, "POST", "/_plugins/_replication/_autofollow", body); err != nil {
+		log.Printf("[INFO] Failed to create autofollow rule: %+v", err)
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", leaderAlias, name))
+	return resourceElasticsearchOpenSearchReplicationAutofollowRuleRead(d, m)
+}
+
+func resourceElasticsearchOpenSearchReplicationAutofollowRuleRead(d *schema.ResourceData, m interface{}) error {
+	rule, err := elasticsearchGetOpenSearchReplicationAutofollowRule(m, d.Get("name").(string))
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Autofollow rule (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	if rule == nil {
+		log.Printf("[WARN] Autofollow rule (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	// the leader alias and the roles are not returned
+	ds := &resourceDataSetter{d: d}
+	ds.set("pattern", rule.Pattern)
+	return ds.err
+}
+
+func resourceElasticsearchOpenSearchReplicationAutofollowRuleDelete(d *schema.ResourceData, m interface{}) error {
+	if err := checkOpenSearchReplicationClient(m); err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"leader_alias": d.Get("leader_alias").(string),
+		"name":         d.Get("name").(string),
+	}
+	if _, err := elasticsearchPerformRequest(m, "DELETE", "/_plugins/_replication/_autofollow", body); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceElasticsearchOpenSearchReplicationAutofollowRuleImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected leader_alias/name", d.Id())
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("leader_alias", parts[0])
+	ds.set("name", parts[1])
+	return []*schema.ResourceData{d}, ds.err
+}
+
+// elasticsearchGetOpenSearchReplicationAutofollowRule returns the rule from
+// the autofollow stats, which are the only API listing the rules
+func elasticsearchGetOpenSearchReplicationAutofollowRule(m interface{}, name string) (*openSearchReplicationAutofollowStats, error) {
+	if err := checkOpenSearchReplicationClient(m); err != nil {
+		return nil, err
+	}
+
+	body, err := elasticsearchPerformRequest(m, "GET", "/_plugins/_replication/autofollow_stats", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(openSearchReplicationAutofollowStatsResponse)
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling autofollow stats body: %+v: %+v", err, body)
+	}
+
+	for _, rule := range response.AutofollowStats {
+		if rule.Name == name {
+			return &rule, nil
+		}
+	}
+	return nil, nil
+}
+
+type openSearchReplicationAutofollowStatsResponse struct {
+	AutofollowStats []openSearchReplicationAutofollowStats `json:"autofollow_stats"`
+}
+
+type openSearchReplicationAutofollowStats struct {
+	Name                       string   `json:"name"`
+	Pattern                    string   `json:"pattern"`
+	NumSuccessStartReplication int      `json:"num_success_start_replication"`
+	NumFailedStartReplication  int      `json:"num_failed_start_replication"`
+	NumFailedLeaderCalls       int      `json:"num_failed_leader_calls"`
+	FailedIndices              []string `json:"failed_indices"`
+}
@@ -0,0 +1,95 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchOpenSearchReplicationAutofollowRule(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			_, err := elasticsearchPerformRequest(testAccOpendistroProvider.Meta(), "GET", "/_plugins/_replication/autofollow_stats", nil)
+			if err != nil {
+				t.Skipf("Cross-cluster replication not available: %s", err)
+			}
+		},
+		Providers:    testAccOpendistroProviders,
+		CheckDestroy: testCheckElasticsearchOpenSearchReplicationAutofollowRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchOpenSearchReplicationAutofollowRule,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchReplicationAutofollowRuleExists("elasticsearch_opensearch_replication_autofollow_rule.test"),
+					resource.TestCheckResourceAttr("elasticsearch_opensearch_replication_autofollow_rule.test", "pattern", "terraform-test-leader-*"),
+				),
+			},
+			{
+				ResourceName:            "elasticsearch_opensearch_replication_autofollow_rule.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"leader_cluster_role", "follower_cluster_role"},
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchOpenSearchReplicationAutofollowRuleExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No autofollow rule ID is set")
+		}
+
+		rule, err := elasticsearchGetOpenSearchReplicationAutofollowRule(testAccOpendistroProvider.Meta(), rs.Primary.Attributes["name"])
+		if err != nil {
+			return err
+		}
+		if rule == nil {
+			return fmt.Errorf("Autofollow rule %q not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testCheckElasticsearchOpenSearchReplicationAutofollowRuleDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_opensearch_replication_autofollow_rule" {
+			continue
+		}
+
+		rule, err := elasticsearchGetOpenSearchReplicationAutofollowRule(testAccOpendistroProvider.Meta(), rs.Primary.Attributes["name"])
+		if err != nil || rule == nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Autofollow rule %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+var testAccElasticsearchOpenSearchReplicationAutofollowRule = `
+resource "elasticsearch_cluster_settings" "test" {
+  persistent = {
+    "cluster.remote.terraform-test.seeds" = "127.0.0.1:9300"
+  }
+}
+
+resource "elasticsearch_opensearch_replication_autofollow_rule" "test" {
+  name                  = "terraform-test"
+  leader_alias          = "terraform-test"
+  pattern               = "terraform-test-leader-*"
+  leader_cluster_role   = "cross_cluster_replication_leader_full_access"
+  follower_cluster_role = "cross_cluster_replication_follower_full_access"
+
+  depends_on = [elasticsearch_cluster_settings.test]
+}
+`
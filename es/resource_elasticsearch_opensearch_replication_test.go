@@ -0,0 +1,108 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchOpenSearchReplication(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			_, err := elasticsearchPerformRequest(testAccOpendistroProvider.Meta(), "GET", "/_plugins/_replication/autofollow_stats", nil)
+			if err != nil {
+				t.Skipf("Cross-cluster replication not available: %s", err)
+			}
+		},
+		Providers:    testAccOpendistroProviders,
+		CheckDestroy: testCheckElasticsearchOpenSearchReplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchOpenSearchReplication(false),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchReplicationExists("elasticsearch_opensearch_replication.test"),
+					resource.TestCheckResourceAttr("elasticsearch_opensearch_replication.test", "paused", "false"),
+				),
+			},
+			{
+				Config: testAccElasticsearchOpenSearchReplication(true),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchReplicationExists("elasticsearch_opensearch_replication.test"),
+					resource.TestCheckResourceAttr("elasticsearch_opensearch_replication.test", "paused", "true"),
+					resource.TestCheckResourceAttr("elasticsearch_opensearch_replication.test", "status", "PAUSED"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchOpenSearchReplicationExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No follower index ID is set")
+		}
+
+		status, err := elasticsearchGetOpenSearchReplicationStatus(testAccOpendistroProvider.Meta(), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if status.Status == openSearchReplicationNotInProgress {
+			return fmt.Errorf("Follower index %q is not replicated", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testCheckElasticsearchOpenSearchReplicationDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_opensearch_replication" {
+			continue
+		}
+
+		status, err := elasticsearchGetOpenSearchReplicationStatus(testAccOpendistroProvider.Meta(), rs.Primary.ID)
+		if err != nil || status.Status == openSearchReplicationNotInProgress {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Follower index %q is still replicated", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+// the cluster replicates its own index through a remote cluster connection to
+// itself
+func testAccElasticsearchOpenSearchReplication(paused bool) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_cluster_settings" "test" {
+  persistent = {
+    "cluster.remote.terraform-test.seeds" = "127.0.0.1:9300"
+  }
+}
+
+resource "elasticsearch_index" "test" {
+  name               = "terraform-test-leader"
+  number_of_shards   = 1
+  number_of_replicas = 0
+}
+
+resource "elasticsearch_opensearch_replication" "test" {
+  follower_index        = "terraform-test-follower"
+  leader_alias          = "terraform-test"
+  leader_index          = elasticsearch_index.test.name
+  leader_cluster_role   = "cross_cluster_replication_leader_full_access"
+  follower_cluster_role = "cross_cluster_replication_follower_full_access"
+  paused                = %t
+
+  depends_on = [elasticsearch_cluster_settings.test]
+}
+`, paused)
+}
@@ -0,0 +1,200 @@
+package es
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func resourceElasticsearchOpenSearchRollupJob() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an OpenSearch index rollup job, which periodically aggregates the documents of a source index into a target index, by the dimensions and metrics of the job. Please refer to the OpenSearch [index rollups documentation](https://opensearch.org/docs/latest/im-plugin/index-rollups/rollup-api/) for details.",
+		Create:      resourceElasticsearchOpenSearchRollupJobCreate,
+		Read:        resourceElasticsearchOpenSearchRollupJobRead,
+		Update:      resourceElasticsearchOpenSearchRollupJobUpdate,
+		Delete:      resourceElasticsearchOpenSearchRollupJobDelete,
+		Schema: map[string]*schema.Schema{
+			"rollup_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Identifier of the rollup job.",
+			},
+			"body": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: diffSuppressRollupJob,
+				ValidateFunc:     validation.StringIsJSON,
+				StateFunc: func(v interface{}) string {
+					json, _ := structure.NormalizeJsonString(v)
+					return json
+				},
+				Description: "The JSON body of the rollup job, with its `source_index`, `target_index`, `schedule`, `page_size`, `dimensions` and `metrics`. Its `enabled` field is set from the `enabled` argument.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the rollup job is started.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchOpenSearchRollupJobCreate(d *schema.ResourceData, m interface{}) error {
+	id := d.Get("rollup_id").(string)
+	if err := resourceElasticsearchOpenSearchPutRollupJob(d, m, ""); err != nil {
+		log.Printf("[INFO] Failed to create rollup job: %+v", err)
+		return err
+	}
+
+	d.SetId(id)
+	return resourceElasticsearchOpenSearchRollupJobRead(d, m)
+}
+
+func resourceElasticsearchOpenSearchRollupJobRead(d *schema.ResourceData, m interface{}) error {
+	res, err := resourceElasticsearchOpenSearchGetRollupJob(d.Id(), m)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Rollup job (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	enabled, _ := res.Rollup["enabled"].(bool)
+	normalizeRollupJob(res.Rollup)
+	body, err := json.Marshal(res.Rollup)
+	if err != nil {
+		return err
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("rollup_id", d.Id())
+	ds.set("body", string(body))
+	ds.set("enabled", enabled)
+	return ds.err
+}
+
+func resourceElasticsearchOpenSearchRollupJobUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChange("body") {
+		res, err := resourceElasticsearchOpenSearchGetRollupJob(d.Id(), m)
+		if err != nil {
+			return err
+		}
+		params := fmt.Sprintf("?if_seq_no=%d&if_primary_term=%d", res.SeqNo, res.PrimaryTerm)
+		if err := resourceElasticsearchOpenSearchPutRollupJob(d, m, params); err != nil {
+			return err
+		}
+	} else if d.HasChange("enabled") {
+		action := "_stop"
+		if d.Get("enabled").(bool) {
+			action = "_start"
+		}
+		path, err := uritemplates.Expand("/_plugins/_rollup/jobs/{id}/{action}", map[string]string{
+			"id":     d.Id(),
+			"action": action,
+		})
+		if err != nil {
+			return fmt.Errorf("error building URL path for rollup job: %+v", err)
+		}
+		if _, err := elasticsearchPerformRequest(m, "POST", path, nil); err != nil {
+			return fmt.Errorf("error calling %s on rollup job %s: %+v", action, d.Id(), err)
+		}
+	}
+
+	return resourceElasticsearchOpenSearchRollupJobRead(d, m)
+}
+
+func resourceElasticsearchOpenSearchRollupJobDelete(d *schema.ResourceData, m interface{}) error {
+	if err := checkOpenSearchRollupJobClient(m); err != nil {
+		return err
+	}
+
+	path, err := uritemplates.Expand("/_plugins/_rollup/jobs/{id}", map[string]string{
+		"id": d.Id(),
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for rollup job: %+v", err)
+	}
+	_, err = elasticsearchPerformRequest(m, "DELETE", path, nil)
+	return err
+}
+
+func resourceElasticsearchOpenSearchGetRollupJob(rollupID string, m interface{}) (*rollupJobResponse, error) {
+	if err := checkOpenSearchRollupJobClient(m); err != nil {
+		return nil, err
+	}
+
+	path, err := uritemplates.Expand("/_plugins/_rollup/jobs/{id}", map[string]string{
+		"id": rollupID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error building URL path for rollup job: %+v", err)
+	}
+	res, err := elasticsearchPerformRequest(m, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(rollupJobResponse)
+	if err := json.Unmarshal(res, response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling rollup job body: %+v: %+v", err, res)
+	}
+	return response, nil
+}
+
+// resourceElasticsearchOpenSearchPutRollupJob puts the job with its enabled
+// flag, updates pass the sequence number and primary term of the current job
+// as params
+func resourceElasticsearchOpenSearchPutRollupJob(d *schema.ResourceData, m interface{}, params string) error {
+	if err := checkOpenSearchRollupJobClient(m); err != nil {
+		return err
+	}
+
+	var rollup map[string]interface{}
+	if err := json.Unmarshal([]byte(d.Get("body").(string)), &rollup); err != nil {
+		return fmt.Errorf("fail to unmarshal body: %v", err)
+	}
+	rollup["enabled"] = d.Get("enabled").(bool)
+
+	path, err := uritemplates.Expand("/_plugins/_rollup/jobs/{id}", map[string]string{
+		"id": d.Get("rollup_id").(string),
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for rollup job: %+v", err)
+	}
+	_, err = elasticsearchPerformRequest(m, "PUT", path+params, map[string]interface{}{"rollup": rollup})
+	return err
+}
+
+func checkOpenSearchRollupJobClient(m interface{}) error {
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	if _, ok := esClient.(*elastic7.Client); !ok {
+		return errors.New("rollup job resource not implemented prior to OpenSearch 1.0")
+	}
+	return nil
+}
+
+type rollupJobResponse struct {
+	ID          string                 `json:"_id"`
+	Version     int                    `json:"_version"`
+	SeqNo       int64                  `json:"_seq_no"`
+	PrimaryTerm int64                  `json:"_primary_term"`
+	Rollup      map[string]interface{} `json:"rollup"`
+}
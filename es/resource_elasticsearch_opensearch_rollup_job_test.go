@@ -0,0 +1,126 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchOpenSearchRollupJob(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			// the job not existing yet is reported only when rollups are available
+			_, err := elasticsearchPerformRequest(testAccOpendistroProvider.Meta(), "GET", "/_plugins/_rollup/jobs/test-rollup", nil)
+			if err != nil && !elastic7.IsNotFound(err) {
+				t.Skipf("Index management rollups not available: %s", err)
+			}
+		},
+		Providers:    testAccOpendistroProviders,
+		CheckDestroy: testCheckElasticsearchOpenSearchRollupJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchOpenSearchRollupJob(100, false),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchRollupJobExists("elasticsearch_opensearch_rollup_job.test"),
+					resource.TestCheckResourceAttr("elasticsearch_opensearch_rollup_job.test", "enabled", "false"),
+				),
+			},
+			{
+				Config: testAccElasticsearchOpenSearchRollupJob(200, false),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchRollupJobExists("elasticsearch_opensearch_rollup_job.test"),
+				),
+			},
+			{
+				Config: testAccElasticsearchOpenSearchRollupJob(200, true),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchRollupJobExists("elasticsearch_opensearch_rollup_job.test"),
+					resource.TestCheckResourceAttr("elasticsearch_opensearch_rollup_job.test", "enabled", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchOpenSearchRollupJobExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No rollup job ID is set")
+		}
+
+		_, err := resourceElasticsearchOpenSearchGetRollupJob(rs.Primary.ID, testAccOpendistroProvider.Meta())
+		return err
+	}
+}
+
+func testCheckElasticsearchOpenSearchRollupJobDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_opensearch_rollup_job" {
+			continue
+		}
+
+		_, err := resourceElasticsearchOpenSearchGetRollupJob(rs.Primary.ID, testAccOpendistroProvider.Meta())
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Rollup job %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchOpenSearchRollupJob(pageSize int, enabled bool) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_index" "test" {
+  name               = "test-rollup-source"
+  number_of_shards   = 1
+  number_of_replicas = 0
+  mappings = jsonencode({
+    properties = {
+      timestamp = { type = "date" }
+      latency   = { type = "long" }
+    }
+  })
+}
+
+resource "elasticsearch_opensearch_rollup_job" "test" {
+  rollup_id = "test-rollup"
+  enabled   = %t
+  body = jsonencode({
+    source_index = elasticsearch_index.test.name
+    target_index = "test-rollup-target"
+    page_size    = %d
+    description  = "test"
+    continuous   = false
+    schedule = {
+      interval = {
+        period     = 1
+        unit       = "Days"
+        start_time = 1602100553
+      }
+    }
+    dimensions = [{
+      date_histogram = {
+        source_field   = "timestamp"
+        fixed_interval = "1h"
+        timezone       = "UTC"
+      }
+    }]
+    metrics = [{
+      source_field = "latency"
+      metrics      = [{ avg = {} }, { max = {} }]
+    }]
+  })
+}
+`, enabled, pageSize)
+}
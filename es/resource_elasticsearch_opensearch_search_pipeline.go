@@ -0,0 +1,172 @@
+package es
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+// the processor lists of search pipelines, each processor is an object named
+// after its type
+var searchPipelineProcessorLists = []string{
+	"request_processors",
+	"response_processors",
+	"phase_results_processors",
+}
+
+func resourceElasticsearchOpenSearchSearchPipeline() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an OpenSearch search pipeline, which transforms the search requests and responses with processors, e.g. `filter_query` or `personalize_search_ranking`, requires OpenSearch >= 2.9. Please refer to the OpenSearch [search pipelines documentation](https://opensearch.org/docs/latest/search-plugins/search-pipelines/index/) for details.",
+		Create:      resourceElasticsearchOpenSearchSearchPipelinePut,
+		Read:        resourceElasticsearchOpenSearchSearchPipelineRead,
+		Update:      resourceElasticsearchOpenSearchSearchPipelinePut,
+		Delete:      resourceElasticsearchOpenSearchSearchPipelineDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the search pipeline.",
+			},
+			"body": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressEquivalentJson,
+				ValidateFunc:     validateSearchPipelineBody,
+				StateFunc: func(v interface{}) string {
+					json, _ := structure.NormalizeJsonString(v)
+					return json
+				},
+				Description: "The JSON body of the search pipeline, with its `description`, `request_processors`, `response_processors` and `phase_results_processors`.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchOpenSearchSearchPipelinePut(d *schema.ResourceData, m interface{}) error {
+	name := d.Get("name").(string)
+
+	path, err := openSearchSearchPipelinePath(m, name)
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(m, "PUT", path, d.Get("body").(string)); err != nil {
+		log.Printf("[INFO] Failed to put search pipeline: %+v", err)
+		return err
+	}
+
+	d.SetId(name)
+	return resourceElasticsearchOpenSearchSearchPipelineRead(d, m)
+}
+
+func resourceElasticsearchOpenSearchSearchPipelineRead(d *schema.ResourceData, m interface{}) error {
+	pipeline, err := elasticsearchGetOpenSearchSearchPipeline(m, d.Id())
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Search pipeline (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	if pipeline == nil {
+		log.Printf("[WARN] Search pipeline (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	body, err := json.Marshal(pipeline)
+	if err != nil {
+		return err
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("name", d.Id())
+	ds.set("body", string(body))
+	return ds.err
+}
+
+func resourceElasticsearchOpenSearchSearchPipelineDelete(d *schema.ResourceData, m interface{}) error {
+	path, err := openSearchSearchPipelinePath(m, d.Id())
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(m, "DELETE", path, nil); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func elasticsearchGetOpenSearchSearchPipeline(m interface{}, name string) (map[string]interface{}, error) {
+	path, err := openSearchSearchPipelinePath(m, name)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := elasticsearchPerformRequest(m, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response map[string]map[string]interface{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling search pipeline body: %+v: %+v", err, body)
+	}
+	return response[name], nil
+}
+
+func openSearchSearchPipelinePath(m interface{}, name string) (string, error) {
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return "", err
+	}
+	if _, ok := esClient.(*elastic7.Client); !ok {
+		return "", errors.New("search pipeline resource not implemented prior to OpenSearch 2.9")
+	}
+
+	path, err := uritemplates.Expand("/_search/pipeline/{name}", map[string]string{
+		"name": name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for search pipeline: %+v", err)
+	}
+	return path, nil
+}
+
+func validateSearchPipelineBody(v interface{}, k string) (ws []string, errors []error) {
+	var pipeline map[string]interface{}
+	if err := json.Unmarshal([]byte(v.(string)), &pipeline); err != nil {
+		errors = append(errors, fmt.Errorf("%q contains an invalid JSON: %s", k, err))
+		return
+	}
+
+	for _, list := range searchPipelineProcessorLists {
+		value, ok := pipeline[list]
+		if !ok {
+			continue
+		}
+		processors, ok := value.([]interface{})
+		if !ok {
+			errors = append(errors, fmt.Errorf("%q: %s must be a list of processors", k, list))
+			continue
+		}
+		for i, p := range processors {
+			if processor, ok := p.(map[string]interface{}); !ok || len(processor) != 1 {
+				errors = append(errors, fmt.Errorf("%q: processor %d of %s must be an object with a single processor type, e.g. {\"filter_query\": {...}}", k, i, list))
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,120 @@
+package es
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchOpenSearchSearchPipeline(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			// the pipeline not existing yet is reported only when search pipelines are available
+			_, err := elasticsearchPerformRequest(testAccOpendistroProvider.Meta(), "GET", "/_search/pipeline/test-search-pipeline", nil)
+			if err != nil && !elastic7.IsNotFound(err) {
+				t.Skipf("Search pipelines not available: %s", err)
+			}
+		},
+		Providers:    testAccOpendistroProviders,
+		CheckDestroy: testCheckElasticsearchOpenSearchSearchPipelineDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccElasticsearchOpenSearchSearchPipelineInvalid,
+				ExpectError: regexp.MustCompile("must be an object with a single processor type"),
+			},
+			{
+				Config: testAccElasticsearchOpenSearchSearchPipeline("published"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchSearchPipelineExists("elasticsearch_opensearch_search_pipeline.test"),
+				),
+			},
+			{
+				Config: testAccElasticsearchOpenSearchSearchPipeline("draft"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchSearchPipelineExists("elasticsearch_opensearch_search_pipeline.test"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchOpenSearchSearchPipelineExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No search pipeline ID is set")
+		}
+
+		pipeline, err := elasticsearchGetOpenSearchSearchPipeline(testAccOpendistroProvider.Meta(), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if pipeline == nil {
+			return fmt.Errorf("Search pipeline %q not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testCheckElasticsearchOpenSearchSearchPipelineDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_opensearch_search_pipeline" {
+			continue
+		}
+
+		pipeline, err := elasticsearchGetOpenSearchSearchPipeline(testAccOpendistroProvider.Meta(), rs.Primary.ID)
+		if err != nil || pipeline == nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Search pipeline %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchOpenSearchSearchPipeline(status string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_opensearch_search_pipeline" "test" {
+  name = "test-search-pipeline"
+  body = jsonencode({
+    description = "test"
+    request_processors = [{
+      filter_query = {
+        query = {
+          term = { status = "%s" }
+        }
+      }
+    }]
+    response_processors = [{
+      rename_field = {
+        field        = "message"
+        target_field = "notification"
+      }
+    }]
+  })
+}
+`, status)
+}
+
+var testAccElasticsearchOpenSearchSearchPipelineInvalid = `
+resource "elasticsearch_opensearch_search_pipeline" "test" {
+  name = "test-search-pipeline"
+  body = jsonencode({
+    request_processors = [{
+      filter_query = { query = { match_all = {} } }
+      script       = { source = "ctx._source" }
+    }]
+  })
+}
+`
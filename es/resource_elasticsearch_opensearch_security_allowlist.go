@@ -0,0 +1,171 @@
+package es
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+// the cluster has a single allowlist
+const openSearchSecurityAllowlistID = "allowlist"
+
+const openSearchSecurityAllowlistPath = "/_plugins/_security/api/allowlist"
+
+func resourceElasticsearchOpenSearchSecurityAllowlist() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages the allowlist of the OpenSearch security plugin, the only endpoints and methods the users may call when it is enabled, the super admin is not restricted. The allowlist can only be changed with the admin certificate, and destroying the resource keeps the allowlist on the cluster. The roles which may call the security REST API are set by `plugins.security.restapi.roles_enabled` in the node configuration, which cannot be changed by an API. Please refer to the OpenSearch [allowlist documentation](https://opensearch.org/docs/latest/security/access-control/api/#access-control-for-the-api) for details.",
+		Create:      resourceElasticsearchOpenSearchSecurityAllowlistCreate,
+		Read:        resourceElasticsearchOpenSearchSecurityAllowlistRead,
+		Update:      resourceElasticsearchOpenSearchSecurityAllowlistUpdate,
+		Delete:      resourceElasticsearchOpenSearchSecurityAllowlistDelete,
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether only the allowed requests may be called.",
+			},
+			"request": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The endpoints which may be called, with their methods.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The path of the endpoint, e.g. `/_cat/nodes`.",
+						},
+						"methods": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							MinItems:    1,
+							Description: "The HTTP methods allowed on the endpoint, `GET`, `PUT`, `POST`, `DELETE` or `PATCH`.",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{"GET", "PUT", "POST", "DELETE", "PATCH"}, false),
+							},
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchOpenSearchSecurityAllowlistCreate(d *schema.ResourceData, m interface{}) error {
+	if err := resourceElasticsearchPutOpenSearchSecurityAllowlist(d, m); err != nil {
+		log.Printf("[INFO] Failed to put OpenSearchSecurityAllowlist: %+v", err)
+		return err
+	}
+
+	d.SetId(openSearchSecurityAllowlistID)
+	return resourceElasticsearchOpenSearchSecurityAllowlistRead(d, m)
+}
+
+func resourceElasticsearchOpenSearchSecurityAllowlistRead(d *schema.ResourceData, m interface{}) error {
+	allowlist, err := resourceElasticsearchGetOpenSearchSecurityAllowlist(m)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] OpenSearchSecurityAllowlist (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	requests := make([]interface{}, 0, len(allowlist.Requests))
+	for path, methods := range allowlist.Requests {
+		requests = append(requests, map[string]interface{}{
+			"path":    path,
+			"methods": methods,
+		})
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("enabled", allowlist.Enabled)
+	ds.set("request", requests)
+	return ds.err
+}
+
+func resourceElasticsearchOpenSearchSecurityAllowlistUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := resourceElasticsearchPutOpenSearchSecurityAllowlist(d, m); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchOpenSearchSecurityAllowlistRead(d, m)
+}
+
+func resourceElasticsearchOpenSearchSecurityAllowlistDelete(d *schema.ResourceData, m interface{}) error {
+	log.Printf("[INFO] Security allowlist is kept on the cluster, removing from state only")
+	d.SetId("")
+	return nil
+}
+
+func resourceElasticsearchGetOpenSearchSecurityAllowlist(m interface{}) (*SecurityAllowlist, error) {
+	if err := checkOpenSearchSecurityAllowlistClient(m); err != nil {
+		return nil, err
+	}
+
+	body, err := elasticsearchPerformRequest(m, "GET", openSearchSecurityAllowlistPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(SecurityAllowlistResponse)
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling security allowlist body: %+v: %+v", err, body)
+	}
+	return &response.Config, nil
+}
+
+// resourceElasticsearchPutOpenSearchSecurityAllowlist replaces the whole
+// allowlist with the configured requests
+func resourceElasticsearchPutOpenSearchSecurityAllowlist(d *schema.ResourceData, m interface{}) error {
+	if err := checkOpenSearchSecurityAllowlistClient(m); err != nil {
+		return err
+	}
+
+	allowlist := SecurityAllowlist{
+		Enabled:  d.Get("enabled").(bool),
+		Requests: make(map[string][]string),
+	}
+	for _, r := range d.Get("request").(*schema.Set).List() {
+		request := r.(map[string]interface{})
+		allowlist.Requests[request["path"].(string)] = expandStringList(request["methods"].(*schema.Set).List())
+	}
+
+	if _, err := elasticsearchPerformRequest(m, "PUT", openSearchSecurityAllowlistPath, allowlist); err != nil {
+		return fmt.Errorf("error putting security allowlist: %+v", err)
+	}
+	return nil
+}
+
+func checkOpenSearchSecurityAllowlistClient(m interface{}) error {
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	if _, ok := esClient.(*elastic7.Client); !ok {
+		return errors.New("security allowlist resource not implemented prior to OpenSearch 1.0")
+	}
+	return nil
+}
+
+type SecurityAllowlistResponse struct {
+	Config SecurityAllowlist `json:"config"`
+}
+
+type SecurityAllowlist struct {
+	Enabled  bool                `json:"enabled"`
+	Requests map[string][]string `json:"requests"`
+}
@@ -0,0 +1,75 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchOpenSearchSecurityAllowlist(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			// the allowlist is only available to the super admin
+			_, err := elasticsearchPerformRequest(testAccOpendistroProvider.Meta(), "GET", openSearchSecurityAllowlistPath, nil)
+			if err != nil {
+				t.Skipf("Security allowlist not available: %s", err)
+			}
+		},
+		Providers: testAccOpendistroProviders,
+		// the allowlist is kept on destroy
+		CheckDestroy: func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchOpenSearchSecurityAllowlist(`["GET"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchSecurityAllowlistExists("elasticsearch_opensearch_security_allowlist.test"),
+					resource.TestCheckResourceAttr("elasticsearch_opensearch_security_allowlist.test", "request.#", "2"),
+				),
+			},
+			{
+				Config: testAccElasticsearchOpenSearchSecurityAllowlist(`["GET", "PUT"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchSecurityAllowlistExists("elasticsearch_opensearch_security_allowlist.test"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchOpenSearchSecurityAllowlistExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No security allowlist ID is set")
+		}
+
+		_, err := resourceElasticsearchGetOpenSearchSecurityAllowlist(testAccOpendistroProvider.Meta())
+		return err
+	}
+}
+
+// the allowlist is left disabled, not to restrict the requests of the other
+// tests
+func testAccElasticsearchOpenSearchSecurityAllowlist(methods string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_opensearch_security_allowlist" "test" {
+  enabled = false
+
+  request {
+    path    = "/_cat/nodes"
+    methods = ["GET"]
+  }
+
+  request {
+    path    = "/_cluster/settings"
+    methods = %s
+  }
+}
+`, methods)
+}
@@ -0,0 +1,225 @@
+package es
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func resourceElasticsearchOpenSearchSmPolicy() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an OpenSearch snapshot management policy, which replaces the snapshot lifecycle management of Elasticsearch: it takes snapshots of indices on a creation schedule and deletes the snapshots beyond its retention conditions. Please refer to the OpenSearch [snapshot management documentation](https://opensearch.org/docs/latest/tuning-your-cluster/availability-and-recovery/snapshots/sm-api/) for details.",
+		Create:      resourceElasticsearchOpenSearchSmPolicyCreate,
+		Read:        resourceElasticsearchOpenSearchSmPolicyRead,
+		Update:      resourceElasticsearchOpenSearchSmPolicyUpdate,
+		Delete:      resourceElasticsearchOpenSearchSmPolicyDelete,
+		Schema: map[string]*schema.Schema{
+			"policy_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the snapshot management policy.",
+			},
+			"body": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: diffSuppressSmPolicy,
+				ValidateFunc:     validateSmPolicyBody,
+				StateFunc: func(v interface{}) string {
+					json, _ := structure.NormalizeJsonString(v)
+					return json
+				},
+				Description: "The JSON body of the policy, with its `description`, the `creation` schedule, the `deletion` schedule and retention `condition`, the `snapshot_config` and the `notification`. Its `enabled` field is set from the `enabled` argument.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the policy is started.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchOpenSearchSmPolicyCreate(d *schema.ResourceData, m interface{}) error {
+	name := d.Get("policy_name").(string)
+	if err := resourceElasticsearchOpenSearchPutSmPolicy(d, m, "POST", ""); err != nil {
+		log.Printf("[INFO] Failed to create snapshot management policy: %+v", err)
+		return err
+	}
+
+	d.SetId(name)
+	return resourceElasticsearchOpenSearchSmPolicyRead(d, m)
+}
+
+func resourceElasticsearchOpenSearchSmPolicyRead(d *schema.ResourceData, m interface{}) error {
+	res, err := resourceElasticsearchOpenSearchGetSmPolicy(d.Id(), m)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Snapshot management policy (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	enabled, _ := res.Policy["enabled"].(bool)
+	normalizeSmPolicy(res.Policy)
+	body, err := json.Marshal(res.Policy)
+	if err != nil {
+		return err
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("policy_name", d.Id())
+	ds.set("body", string(body))
+	ds.set("enabled", enabled)
+	return ds.err
+}
+
+func resourceElasticsearchOpenSearchSmPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChange("body") {
+		res, err := resourceElasticsearchOpenSearchGetSmPolicy(d.Id(), m)
+		if err != nil {
+			return err
+		}
+		params := fmt.Sprintf("?if_seq_no=%d&if_primary_term=%d", res.SeqNo, res.PrimaryTerm)
+		if err := resourceElasticsearchOpenSearchPutSmPolicy(d, m, "PUT", params); err != nil {
+			return err
+		}
+	} else if d.HasChange("enabled") {
+		action := "_stop"
+		if d.Get("enabled").(bool) {
+			action = "_start"
+		}
+		path, err := uritemplates.Expand("/_plugins/_sm/policies/{name}/{action}", map[string]string{
+			"name":   d.Id(),
+			"action": action,
+		})
+		if err != nil {
+			return fmt.Errorf("error building URL path for snapshot management policy: %+v", err)
+		}
+		if _, err := elasticsearchPerformRequest(m, "POST", path, nil); err != nil {
+			return fmt.Errorf("error calling %s on snapshot management policy %s: %+v", action, d.Id(), err)
+		}
+	}
+
+	return resourceElasticsearchOpenSearchSmPolicyRead(d, m)
+}
+
+func resourceElasticsearchOpenSearchSmPolicyDelete(d *schema.ResourceData, m interface{}) error {
+	if err := checkOpenSearchSmPolicyClient(m); err != nil {
+		return err
+	}
+
+	path, err := uritemplates.Expand("/_plugins/_sm/policies/{name}", map[string]string{
+		"name": d.Id(),
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for snapshot management policy: %+v", err)
+	}
+	_, err = elasticsearchPerformRequest(m, "DELETE", path, nil)
+	return err
+}
+
+func resourceElasticsearchOpenSearchGetSmPolicy(name string, m interface{}) (*smPolicyResponse, error) {
+	if err := checkOpenSearchSmPolicyClient(m); err != nil {
+		return nil, err
+	}
+
+	path, err := uritemplates.Expand("/_plugins/_sm/policies/{name}", map[string]string{
+		"name": name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error building URL path for snapshot management policy: %+v", err)
+	}
+	res, err := elasticsearchPerformRequest(m, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(smPolicyResponse)
+	if err := json.Unmarshal(res, response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling snapshot management policy body: %+v: %+v", err, res)
+	}
+	return response, nil
+}
+
+// resourceElasticsearchOpenSearchPutSmPolicy creates the policy with POST, or
+// updates it with PUT and the sequence number and primary term of the current
+// policy as params
+func resourceElasticsearchOpenSearchPutSmPolicy(d *schema.ResourceData, m interface{}, method string, params string) error {
+	if err := checkOpenSearchSmPolicyClient(m); err != nil {
+		return err
+	}
+
+	var policy map[string]interface{}
+	if err := json.Unmarshal([]byte(d.Get("body").(string)), &policy); err != nil {
+		return fmt.Errorf("fail to unmarshal body: %v", err)
+	}
+	policy["enabled"] = d.Get("enabled").(bool)
+
+	path, err := uritemplates.Expand("/_plugins/_sm/policies/{name}", map[string]string{
+		"name": d.Get("policy_name").(string),
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for snapshot management policy: %+v", err)
+	}
+	_, err = elasticsearchPerformRequest(m, method, path+params, policy)
+	return err
+}
+
+func checkOpenSearchSmPolicyClient(m interface{}) error {
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	if _, ok := esClient.(*elastic7.Client); !ok {
+		return errors.New("snapshot management policy resource not implemented prior to OpenSearch 2.1")
+	}
+	return nil
+}
+
+func validateSmPolicyBody(v interface{}, k string) (ws []string, errors []error) {
+	var policy map[string]interface{}
+	if err := json.Unmarshal([]byte(v.(string)), &policy); err != nil {
+		errors = append(errors, fmt.Errorf("%q contains an invalid JSON: %s", k, err))
+		return
+	}
+
+	creation, _ := policy["creation"].(map[string]interface{})
+	if _, ok := creation["schedule"].(map[string]interface{}); !ok {
+		errors = append(errors, fmt.Errorf("%q: the policy must set the creation schedule", k))
+	}
+	snapshotConfig, _ := policy["snapshot_config"].(map[string]interface{})
+	if repository, _ := snapshotConfig["repository"].(string); repository == "" {
+		errors = append(errors, fmt.Errorf("%q: the policy must set the snapshot_config repository", k))
+	}
+	if deletion, ok := policy["deletion"].(map[string]interface{}); ok {
+		condition, _ := deletion["condition"].(map[string]interface{})
+		_, maxAge := condition["max_age"]
+		_, maxCount := condition["max_count"]
+		if !maxAge && !maxCount {
+			errors = append(errors, fmt.Errorf("%q: the deletion condition must set max_age or max_count", k))
+		}
+	}
+	return
+}
+
+type smPolicyResponse struct {
+	ID          string                 `json:"_id"`
+	Version     int                    `json:"_version"`
+	SeqNo       int64                  `json:"_seq_no"`
+	PrimaryTerm int64                  `json:"_primary_term"`
+	Policy      map[string]interface{} `json:"sm_policy"`
+}
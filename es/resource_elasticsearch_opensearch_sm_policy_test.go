@@ -0,0 +1,128 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchOpenSearchSmPolicy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			// the policy not existing yet is reported only when snapshot management is available
+			_, err := elasticsearchPerformRequest(testAccOpendistroProvider.Meta(), "GET", "/_plugins/_sm/policies/test-sm-policy", nil)
+			if err != nil && !elastic7.IsNotFound(err) {
+				t.Skipf("Snapshot management not available: %s", err)
+			}
+		},
+		Providers:    testAccOpendistroProviders,
+		CheckDestroy: testCheckElasticsearchOpenSearchSmPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchOpenSearchSmPolicy(7, true),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchSmPolicyExists("elasticsearch_opensearch_sm_policy.test"),
+					resource.TestCheckResourceAttr("elasticsearch_opensearch_sm_policy.test", "enabled", "true"),
+				),
+			},
+			{
+				Config: testAccElasticsearchOpenSearchSmPolicy(14, true),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchSmPolicyExists("elasticsearch_opensearch_sm_policy.test"),
+				),
+			},
+			{
+				Config: testAccElasticsearchOpenSearchSmPolicy(14, false),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchSmPolicyExists("elasticsearch_opensearch_sm_policy.test"),
+					resource.TestCheckResourceAttr("elasticsearch_opensearch_sm_policy.test", "enabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchOpenSearchSmPolicyExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No snapshot management policy ID is set")
+		}
+
+		_, err := resourceElasticsearchOpenSearchGetSmPolicy(rs.Primary.ID, testAccOpendistroProvider.Meta())
+		return err
+	}
+}
+
+func testCheckElasticsearchOpenSearchSmPolicyDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_opensearch_sm_policy" {
+			continue
+		}
+
+		_, err := resourceElasticsearchOpenSearchGetSmPolicy(rs.Primary.ID, testAccOpendistroProvider.Meta())
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Snapshot management policy %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchOpenSearchSmPolicy(maxCount int, enabled bool) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_snapshot_repository" "test" {
+  name = "terraform-test"
+  type = "fs"
+
+  settings = {
+    location = "/tmp/elasticsearch"
+  }
+}
+
+resource "elasticsearch_opensearch_sm_policy" "test" {
+  policy_name = "test-sm-policy"
+  enabled     = %t
+  body = jsonencode({
+    description = "test"
+    creation = {
+      schedule = {
+        cron = {
+          expression = "0 8 * * *"
+          timezone   = "UTC"
+        }
+      }
+      time_limit = "1h"
+    }
+    deletion = {
+      schedule = {
+        cron = {
+          expression = "0 1 * * *"
+          timezone   = "UTC"
+        }
+      }
+      condition = {
+        max_age   = "7d"
+        max_count = %d
+        min_count = 1
+      }
+      time_limit = "1h"
+    }
+    snapshot_config = {
+      repository = elasticsearch_snapshot_repository.test.name
+      indices    = "*"
+    }
+  })
+}
+`, enabled, maxCount)
+}
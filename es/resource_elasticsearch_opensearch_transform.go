@@ -0,0 +1,212 @@
+package es
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func resourceElasticsearchOpenSearchTransform() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an OpenSearch transform job, which periodically summarizes the documents of a source index into a target index, by the groups and aggregations of the job. Unlike rollups, the summarized documents are regular documents which can be searched directly. Please refer to the OpenSearch [index transforms documentation](https://opensearch.org/docs/latest/im-plugin/index-transforms/transforms-apis/) for details.",
+		Create:      resourceElasticsearchOpenSearchTransformCreate,
+		Read:        resourceElasticsearchOpenSearchTransformRead,
+		Update:      resourceElasticsearchOpenSearchTransformUpdate,
+		Delete:      resourceElasticsearchOpenSearchTransformDelete,
+		Schema: map[string]*schema.Schema{
+			"transform_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Identifier of the transform job.",
+			},
+			"body": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: diffSuppressOpenSearchTransform,
+				ValidateFunc:     validation.StringIsJSON,
+				StateFunc: func(v interface{}) string {
+					json, _ := structure.NormalizeJsonString(v)
+					return json
+				},
+				Description: "The JSON body of the transform job, with its `source_index`, `target_index`, `data_selection_query`, `schedule`, `page_size`, `groups` and `aggregations`. Its `enabled` field is set from the `enabled` argument.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the transform job is started.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchOpenSearchTransformCreate(d *schema.ResourceData, m interface{}) error {
+	id := d.Get("transform_id").(string)
+	if err := resourceElasticsearchOpenSearchPutTransform(d, m, ""); err != nil {
+		log.Printf("[INFO] Failed to create transform job: %+v", err)
+		return err
+	}
+
+	d.SetId(id)
+	return resourceElasticsearchOpenSearchTransformRead(d, m)
+}
+
+func resourceElasticsearchOpenSearchTransformRead(d *schema.ResourceData, m interface{}) error {
+	res, err := resourceElasticsearchOpenSearchGetTransform(d.Id(), m)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Transform job (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	enabled, _ := res.Transform["enabled"].(bool)
+	normalizeOpenSearchTransform(res.Transform)
+	body, err := json.Marshal(res.Transform)
+	if err != nil {
+		return err
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("transform_id", d.Id())
+	ds.set("body", string(body))
+	ds.set("enabled", enabled)
+	return ds.err
+}
+
+func resourceElasticsearchOpenSearchTransformUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChange("body") {
+		res, err := resourceElasticsearchOpenSearchGetTransform(d.Id(), m)
+		if err != nil {
+			return err
+		}
+		params := fmt.Sprintf("?if_seq_no=%d&if_primary_term=%d", res.SeqNo, res.PrimaryTerm)
+		if err := resourceElasticsearchOpenSearchPutTransform(d, m, params); err != nil {
+			return err
+		}
+	} else if d.HasChange("enabled") {
+		action := "_stop"
+		if d.Get("enabled").(bool) {
+			action = "_start"
+		}
+		path, err := uritemplates.Expand("/_plugins/_transform/{id}/{action}", map[string]string{
+			"id":     d.Id(),
+			"action": action,
+		})
+		if err != nil {
+			return fmt.Errorf("error building URL path for transform job: %+v", err)
+		}
+		if _, err := elasticsearchPerformRequest(m, "POST", path, nil); err != nil {
+			return fmt.Errorf("error calling %s on transform job %s: %+v", action, d.Id(), err)
+		}
+	}
+
+	return resourceElasticsearchOpenSearchTransformRead(d, m)
+}
+
+func resourceElasticsearchOpenSearchTransformDelete(d *schema.ResourceData, m interface{}) error {
+	if err := checkOpenSearchTransformClient(m); err != nil {
+		return err
+	}
+	// enabled transforms cannot be deleted
+	if d.Get("enabled").(bool) {
+		path, err := uritemplates.Expand("/_plugins/_transform/{id}/_stop", map[string]string{
+			"id": d.Id(),
+		})
+		if err != nil {
+			return fmt.Errorf("error building URL path for transform job: %+v", err)
+		}
+		if _, err := elasticsearchPerformRequest(m, "POST", path, nil); err != nil {
+			return fmt.Errorf("error calling _stop on transform job %s: %+v", d.Id(), err)
+		}
+	}
+
+	path, err := uritemplates.Expand("/_plugins/_transform/{id}", map[string]string{
+		"id": d.Id(),
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for transform job: %+v", err)
+	}
+	_, err = elasticsearchPerformRequest(m, "DELETE", path, nil)
+	return err
+}
+
+func resourceElasticsearchOpenSearchGetTransform(transformID string, m interface{}) (*openSearchTransformResponse, error) {
+	if err := checkOpenSearchTransformClient(m); err != nil {
+		return nil, err
+	}
+
+	path, err := uritemplates.Expand("/_plugins/_transform/{id}", map[string]string{
+		"id": transformID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error building URL path for transform job: %+v", err)
+	}
+	res, err := elasticsearchPerformRequest(m, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(openSearchTransformResponse)
+	if err := json.Unmarshal(res, response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling transform job body: %+v: %+v", err, res)
+	}
+	return response, nil
+}
+
+// resourceElasticsearchOpenSearchPutTransform puts the job with its enabled
+// flag, updates pass the sequence number and primary term of the current job
+// as params
+func resourceElasticsearchOpenSearchPutTransform(d *schema.ResourceData, m interface{}, params string) error {
+	if err := checkOpenSearchTransformClient(m); err != nil {
+		return err
+	}
+
+	var transform map[string]interface{}
+	if err := json.Unmarshal([]byte(d.Get("body").(string)), &transform); err != nil {
+		return fmt.Errorf("fail to unmarshal body: %v", err)
+	}
+	transform["enabled"] = d.Get("enabled").(bool)
+
+	path, err := uritemplates.Expand("/_plugins/_transform/{id}", map[string]string{
+		"id": d.Get("transform_id").(string),
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for transform job: %+v", err)
+	}
+	_, err = elasticsearchPerformRequest(m, "PUT", path+params, map[string]interface{}{"transform": transform})
+	return err
+}
+
+func checkOpenSearchTransformClient(m interface{}) error {
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	if _, ok := esClient.(*elastic7.Client); !ok {
+		return errors.New("transform job resource not implemented prior to OpenSearch 1.0")
+	}
+	return nil
+}
+
+type openSearchTransformResponse struct {
+	ID          string                 `json:"_id"`
+	Version     int                    `json:"_version"`
+	SeqNo       int64                  `json:"_seq_no"`
+	PrimaryTerm int64                  `json:"_primary_term"`
+	Transform   map[string]interface{} `json:"transform"`
+}
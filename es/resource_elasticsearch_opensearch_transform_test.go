@@ -0,0 +1,123 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchOpenSearchTransform(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			// the job not existing yet is reported only when transforms are available
+			_, err := elasticsearchPerformRequest(testAccOpendistroProvider.Meta(), "GET", "/_plugins/_transform/test-transform", nil)
+			if err != nil && !elastic7.IsNotFound(err) {
+				t.Skipf("Index management transforms not available: %s", err)
+			}
+		},
+		Providers:    testAccOpendistroProviders,
+		CheckDestroy: testCheckElasticsearchOpenSearchTransformDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchOpenSearchTransform("test", false),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchTransformExists("elasticsearch_opensearch_transform.test"),
+					resource.TestCheckResourceAttr("elasticsearch_opensearch_transform.test", "enabled", "false"),
+				),
+			},
+			{
+				Config: testAccElasticsearchOpenSearchTransform("updated", false),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchTransformExists("elasticsearch_opensearch_transform.test"),
+				),
+			},
+			{
+				Config: testAccElasticsearchOpenSearchTransform("updated", true),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchOpenSearchTransformExists("elasticsearch_opensearch_transform.test"),
+					resource.TestCheckResourceAttr("elasticsearch_opensearch_transform.test", "enabled", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchOpenSearchTransformExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No transform job ID is set")
+		}
+
+		_, err := resourceElasticsearchOpenSearchGetTransform(rs.Primary.ID, testAccOpendistroProvider.Meta())
+		return err
+	}
+}
+
+func testCheckElasticsearchOpenSearchTransformDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_opensearch_transform" {
+			continue
+		}
+
+		_, err := resourceElasticsearchOpenSearchGetTransform(rs.Primary.ID, testAccOpendistroProvider.Meta())
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Transform job %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchOpenSearchTransform(description string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_index" "test" {
+  name               = "test-transform-source"
+  number_of_shards   = 1
+  number_of_replicas = 0
+  mappings = jsonencode({
+    properties = {
+      service = { type = "keyword" }
+      latency = { type = "long" }
+    }
+  })
+}
+
+resource "elasticsearch_opensearch_transform" "test" {
+  transform_id = "test-transform"
+  enabled      = %t
+  body = jsonencode({
+    source_index = elasticsearch_index.test.name
+    target_index = "test-transform-target"
+    page_size    = 100
+    description  = %q
+    schedule = {
+      interval = {
+        period     = 1
+        unit       = "Hours"
+        start_time = 1602100553
+      }
+    }
+    groups = [{
+      terms = {
+        source_field = "service"
+        target_field = "service"
+      }
+    }]
+    aggregations = {
+      avg_latency = { avg = { field = "latency" } }
+    }
+  })
+}
+`, enabled, description)
+}
@@ -0,0 +1,318 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+var queryRulesetMinimalVersion, _ = version.NewVersion("8.10.0")
+
+func resourceElasticsearchQueryRuleset() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an Elasticsearch query ruleset, the rules of a ruleset pin or exclude documents of the results of rule queries whose metadata match their criteria. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/search-using-query-rules.html) for more details.",
+		Create:      resourceElasticsearchQueryRulesetPut,
+		Read:        resourceElasticsearchQueryRulesetRead,
+		Update:      resourceElasticsearchQueryRulesetPut,
+		Delete:      resourceElasticsearchQueryRulesetDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Identifier of the query ruleset.",
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The rules of the ruleset, applied in order.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"rule_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Identifier of the rule, unique in the ruleset.",
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"pinned", "exclude"}, false),
+							Description:  "The type of the rule, `pinned` to promote the documents to the top of the results, or `exclude` to remove them from the results. Excluding requires ElasticSearch >= 8.15.",
+						},
+						"criteria": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Description: "The criteria matching the metadata of the rule queries, all of them must match.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice([]string{"always", "exact", "exact_fuzzy", "fuzzy", "prefix", "suffix", "contains", "lt", "lte", "gt", "gte"}, false),
+										Description:  "The type of the criteria, e.g. `exact` or `prefix`, `always` matches all the queries.",
+									},
+									"metadata": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "The metadata field of the rule queries to match, e.g. `user_query`, required unless the type is `always`.",
+									},
+									"values": {
+										Type:        schema.TypeList,
+										Optional:    true,
+										Description: "The values to match the metadata against, any of them can match.",
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+								},
+							},
+						},
+						"ids": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The identifiers of the documents to pin or exclude, for a single index. Exactly one of `ids` or `docs` must be set.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"docs": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The documents to pin or exclude, by index and identifier.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"index": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "Name of the index of the document.",
+									},
+									"id": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "Identifier of the document.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchQueryRulesetPut(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+
+	if err := elastic7CheckQueryRulesetVersion(meta); err != nil {
+		return err
+	}
+
+	rules, err := expandQueryRules(d.Get("rule").([]interface{}))
+	if err != nil {
+		return err
+	}
+	body := map[string]interface{}{
+		"rules": rules,
+	}
+
+	path, err := queryRulesetPath(name)
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "PUT", path, body); err != nil {
+		return err
+	}
+
+	d.SetId(name)
+	return resourceElasticsearchQueryRulesetRead(d, meta)
+}
+
+func resourceElasticsearchQueryRulesetRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	if err := elastic7CheckQueryRulesetVersion(meta); err != nil {
+		return err
+	}
+
+	path, err := queryRulesetPath(id)
+	if err != nil {
+		return err
+	}
+	body, err := elasticsearchPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Query ruleset (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	ruleset := new(QueryRuleset)
+	if err := json.Unmarshal(body, ruleset); err != nil {
+		return fmt.Errorf("error unmarshalling query ruleset body: %+v: %+v", err, body)
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("name", id)
+	ds.set("rule", flattenQueryRules(ruleset.Rules))
+	return ds.err
+}
+
+func resourceElasticsearchQueryRulesetDelete(d *schema.ResourceData, meta interface{}) error {
+	if err := elastic7CheckQueryRulesetVersion(meta); err != nil {
+		return err
+	}
+
+	path, err := queryRulesetPath(d.Id())
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "DELETE", path, nil); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func expandQueryRules(configured []interface{}) ([]QueryRule, error) {
+	rules := make([]QueryRule, 0, len(configured))
+	for _, r := range configured {
+		rule := r.(map[string]interface{})
+
+		criteria := make([]QueryRuleCriteria, 0)
+		for _, c := range rule["criteria"].([]interface{}) {
+			criterion := c.(map[string]interface{})
+			criteria = append(criteria, QueryRuleCriteria{
+				Type:     criterion["type"].(string),
+				Metadata: criterion["metadata"].(string),
+				Values:   expandStringList(criterion["values"].([]interface{})),
+			})
+		}
+
+		var actions QueryRuleActions
+		actions.IDs = expandStringList(rule["ids"].([]interface{}))
+		for _, doc := range rule["docs"].([]interface{}) {
+			doc := doc.(map[string]interface{})
+			actions.Docs = append(actions.Docs, QueryRuleDoc{
+				Index: doc["index"].(string),
+				ID:    doc["id"].(string),
+			})
+		}
+		if (len(actions.IDs) == 0) == (len(actions.Docs) == 0) {
+			return nil, fmt.Errorf("exactly one of ids or docs must be set for query rule %s", rule["rule_id"].(string))
+		}
+
+		rules = append(rules, QueryRule{
+			RuleID:   rule["rule_id"].(string),
+			Type:     rule["type"].(string),
+			Criteria: criteria,
+			Actions:  actions,
+		})
+	}
+	return rules, nil
+}
+
+func flattenQueryRules(rules []QueryRule) []interface{} {
+	flattened := make([]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		criteria := make([]interface{}, 0, len(rule.Criteria))
+		for _, criterion := range rule.Criteria {
+			criteria = append(criteria, map[string]interface{}{
+				"type":     criterion.Type,
+				"metadata": criterion.Metadata,
+				"values":   criterion.Values,
+			})
+		}
+
+		docs := make([]interface{}, 0, len(rule.Actions.Docs))
+		for _, doc := range rule.Actions.Docs {
+			docs = append(docs, map[string]interface{}{
+				"index": doc.Index,
+				"id":    doc.ID,
+			})
+		}
+
+		flattened = append(flattened, map[string]interface{}{
+			"rule_id":  rule.RuleID,
+			"type":     rule.Type,
+			"criteria": criteria,
+			"ids":      rule.Actions.IDs,
+			"docs":     docs,
+		})
+	}
+	return flattened
+}
+
+func elastic7CheckQueryRulesetVersion(meta interface{}) error {
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+
+	client, ok := esClient.(*elastic7.Client)
+	if !ok {
+		return fmt.Errorf("query rulesets only available from ElasticSearch >= 8.10, got version < 7.0.0")
+	}
+
+	elasticVersion, err := elastic7GetVersion(client)
+	if err != nil {
+		return err
+	}
+	if elasticVersion.LessThan(queryRulesetMinimalVersion) {
+		return fmt.Errorf("query rulesets only available from ElasticSearch >= 8.10, got version %s", elasticVersion.String())
+	}
+	return nil
+}
+
+func queryRulesetPath(name string) (string, error) {
+	path, err := uritemplates.Expand("/_query_rules/{name}", map[string]string{
+		"name": name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for query ruleset: %+v", err)
+	}
+	return path, nil
+}
+
+type QueryRuleset struct {
+	RulesetID string      `json:"ruleset_id"`
+	Rules     []QueryRule `json:"rules"`
+}
+
+type QueryRule struct {
+	RuleID   string              `json:"rule_id"`
+	Type     string              `json:"type"`
+	Criteria []QueryRuleCriteria `json:"criteria"`
+	Actions  QueryRuleActions    `json:"actions"`
+}
+
+type QueryRuleCriteria struct {
+	Type     string   `json:"type"`
+	Metadata string   `json:"metadata,omitempty"`
+	Values   []string `json:"values,omitempty"`
+}
+
+type QueryRuleActions struct {
+	IDs  []string       `json:"ids,omitempty"`
+	Docs []QueryRuleDoc `json:"docs,omitempty"`
+}
+
+type QueryRuleDoc struct {
+	Index string `json:"_index"`
+	ID    string `json:"_id"`
+}
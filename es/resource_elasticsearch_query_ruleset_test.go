@@ -0,0 +1,129 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchQueryRuleset(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		elasticVersion, err := elastic7GetVersion(client)
+		if err != nil {
+			t.Skipf("err: %s", err)
+		}
+		allowed = !elasticVersion.LessThan(queryRulesetMinimalVersion)
+	default:
+		allowed = false
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Query rulesets only supported on ES >= 8.10")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchQueryRulesetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchQueryRuleset("doc-1"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchQueryRulesetExists("elasticsearch_query_ruleset.test"),
+					resource.TestCheckResourceAttr("elasticsearch_query_ruleset.test", "rule.#", "1"),
+					resource.TestCheckResourceAttr("elasticsearch_query_ruleset.test", "rule.0.ids.0", "doc-1"),
+				),
+			},
+			{
+				Config: testAccElasticsearchQueryRuleset("doc-2"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchQueryRulesetExists("elasticsearch_query_ruleset.test"),
+					resource.TestCheckResourceAttr("elasticsearch_query_ruleset.test", "rule.0.ids.0", "doc-2"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_query_ruleset.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchQueryRulesetExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No query ruleset ID is set")
+		}
+
+		path, err := queryRulesetPath(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		_, err = elasticsearchPerformRequest(testAccXPackProvider.Meta(), "GET", path, nil)
+		return err
+	}
+}
+
+func testCheckElasticsearchQueryRulesetDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_query_ruleset" {
+			continue
+		}
+
+		path, err := queryRulesetPath(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if _, err := elasticsearchPerformRequest(testAccXPackProvider.Meta(), "GET", path, nil); err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Query ruleset %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchQueryRuleset(id string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_query_ruleset" "test" {
+  name = "terraform-test"
+
+  rule {
+    rule_id = "promote-docs"
+    type    = "pinned"
+
+    criteria {
+      type     = "exact"
+      metadata = "user_query"
+      values   = ["pugs", "puggles"]
+    }
+
+    ids = ["%s"]
+  }
+}
+`, id)
+}
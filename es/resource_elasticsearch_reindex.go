@@ -0,0 +1,278 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+func resourceElasticsearchReindex() *schema.Resource {
+	return &schema.Resource{
+		Description: "Runs an Elasticsearch reindex once when the resource is created, copying the documents of the source to the destination. The reindex runs as a task, which is polled for completion unless `wait_for_completion` is false, destroying the resource cancels the task if it is still running and keeps the destination. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/docs-reindex.html) for more details.",
+		Create:      resourceElasticsearchReindexCreate,
+		Read:        resourceElasticsearchReindexRead,
+		Delete:      resourceElasticsearchReindexDelete,
+		Schema: map[string]*schema.Schema{
+			"source": {
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJson,
+				Description:      "A JSON string of the source of the reindex, e.g. `{\"index\": \"logs-old\", \"query\": {...}}`.",
+			},
+			"dest": {
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJson,
+				Description:      "A JSON string of the destination of the reindex, e.g. `{\"index\": \"logs-new\", \"op_type\": \"create\"}`.",
+			},
+			"script": {
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJson,
+				Description:      "A JSON string of the script transforming the documents, e.g. `{\"source\": \"ctx._source.remove('tmp')\"}`.",
+			},
+			"conflicts": {
+				Type:         schema.TypeString,
+				ForceNew:     true,
+				Optional:     true,
+				Default:      "abort",
+				ValidateFunc: validation.StringInSlice([]string{"abort", "proceed"}, false),
+				Description:  "Whether to `abort` the reindex on version conflicts, or `proceed` and count them.",
+			},
+			"max_docs": {
+				Type:         schema.TypeInt,
+				ForceNew:     true,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The maximum number of documents to reindex, all the documents are reindexed if not set.",
+			},
+			"slices": {
+				Type:         schema.TypeString,
+				ForceNew:     true,
+				Optional:     true,
+				Default:      "1",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(auto|[1-9][0-9]*)$`), "must be `auto` or a number"),
+				Description:  "The number of slices the reindex is divided into, or `auto`.",
+			},
+			"wait_for_completion": {
+				Type:        schema.TypeBool,
+				ForceNew:    true,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether creating the resource waits for the reindex to complete, failing if the reindex failed. Otherwise the progress of the task is refreshed on each read.",
+			},
+			"task_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Identifier of the reindex task.",
+			},
+			"completed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the reindex task completed.",
+			},
+			"total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of documents to reindex.",
+			},
+			"created": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of documents created in the destination.",
+			},
+			"updated": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of documents updated in the destination.",
+			},
+			"version_conflicts": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of version conflicts.",
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+	}
+}
+
+func resourceElasticsearchReindexCreate(d *schema.ResourceData, meta interface{}) error {
+	body := map[string]interface{}{
+		"source":    json.RawMessage(d.Get("source").(string)),
+		"dest":      json.RawMessage(d.Get("dest").(string)),
+		"conflicts": d.Get("conflicts").(string),
+	}
+	if script, ok := d.GetOk("script"); ok {
+		body["script"] = json.RawMessage(script.(string))
+	}
+	if maxDocs, ok := d.GetOk("max_docs"); ok {
+		body["max_docs"] = maxDocs.(int)
+	}
+
+	// the reindex always runs as a task, which is polled for completion
+	// rather than waiting in the request, so the create timeout applies
+	path := fmt.Sprintf("/_reindex?wait_for_completion=false&slices=%s", d.Get("slices").(string))
+	res, err := elasticsearchPerformRequest(meta, "POST", path, body)
+	if err != nil {
+		return err
+	}
+
+	response := new(ReindexResponse)
+	if err := json.Unmarshal(res, response); err != nil {
+		return fmt.Errorf("error unmarshalling reindex body: %+v: %+v", err, res)
+	}
+	if response.Task == "" {
+		return fmt.Errorf("no task returned by reindex: %s", res)
+	}
+	d.SetId(response.Task)
+
+	if d.Get("wait_for_completion").(bool) {
+		err = resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+			task, err := elasticsearchGetReindexTask(meta, response.Task)
+			if err != nil {
+				return resource.NonRetryableError(err)
+			}
+
+			status := task.Task.Status
+			if !task.Completed {
+				log.Printf("[INFO] Reindex task %s in progress: %d of %d documents, %d created, %d updated", response.Task, status.Created+status.Updated+status.Deleted+status.VersionConflicts, status.Total, status.Created, status.Updated)
+				return resource.RetryableError(fmt.Errorf("reindex task %s is running", response.Task))
+			}
+			if err := reindexTaskFailure(task); err != nil {
+				return resource.NonRetryableError(err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceElasticsearchReindexRead(d, meta)
+}
+
+func resourceElasticsearchReindexRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	// the reindex is not undone, the resource is kept once the task is
+	// no longer known
+	task, err := elasticsearchGetReindexTask(meta, id)
+	if err != nil {
+		if elastic7.IsNotFound(err) || elastic6.IsNotFound(err) || elastic5.IsNotFound(err) {
+			log.Printf("[WARN] Reindex task (%s) not found, keeping the last known progress", id)
+			return nil
+		}
+		return err
+	}
+
+	status := task.Task.Status
+	ds := &resourceDataSetter{d: d}
+	ds.set("task_id", id)
+	ds.set("completed", task.Completed)
+	ds.set("total", status.Total)
+	ds.set("created", status.Created)
+	ds.set("updated", status.Updated)
+	ds.set("version_conflicts", status.VersionConflicts)
+	return ds.err
+}
+
+func resourceElasticsearchReindexDelete(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	task, err := elasticsearchGetReindexTask(meta, id)
+	if err != nil && !(elastic7.IsNotFound(err) || elastic6.IsNotFound(err) || elastic5.IsNotFound(err)) {
+		return err
+	}
+	if err == nil && !task.Completed {
+		path, err := reindexTaskPath(id)
+		if err != nil {
+			return err
+		}
+		if _, err := elasticsearchPerformRequest(meta, "POST", path+"/_cancel", nil); err != nil {
+			return fmt.Errorf("error cancelling reindex task %s: %+v", id, err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func elasticsearchGetReindexTask(meta interface{}, id string) (*ReindexTask, error) {
+	path, err := reindexTaskPath(id)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := elasticsearchPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	task := new(ReindexTask)
+	if err := json.Unmarshal(body, task); err != nil {
+		return nil, fmt.Errorf("error unmarshalling reindex task body: %+v: %+v", err, body)
+	}
+	return task, nil
+}
+
+// reindexTaskFailure returns the error of a completed task, or of its first
+// failed document
+func reindexTaskFailure(task *ReindexTask) error {
+	if len(task.Error) > 0 {
+		return fmt.Errorf("reindex failed: %s", task.Error)
+	}
+	if len(task.Response.Failures) > 0 {
+		return fmt.Errorf("reindex failed for %d documents, e.g. %s", len(task.Response.Failures), task.Response.Failures[0])
+	}
+	return nil
+}
+
+func reindexTaskPath(id string) (string, error) {
+	path, err := uritemplates.Expand("/_tasks/{id}", map[string]string{
+		"id": id,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for reindex task: %+v", err)
+	}
+	return path, nil
+}
+
+type ReindexResponse struct {
+	Task string `json:"task"`
+}
+
+type ReindexTask struct {
+	Completed bool `json:"completed"`
+	Task      struct {
+		Status struct {
+			Total            int `json:"total"`
+			Created          int `json:"created"`
+			Updated          int `json:"updated"`
+			Deleted          int `json:"deleted"`
+			VersionConflicts int `json:"version_conflicts"`
+		} `json:"status"`
+	} `json:"task"`
+	Error    json.RawMessage `json:"error"`
+	Response struct {
+		Failures []json.RawMessage `json:"failures"`
+	} `json:"response"`
+}
@@ -0,0 +1,88 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchReindex(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckElasticsearchReindexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchReindex,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchReindexExists("elasticsearch_reindex.test"),
+					resource.TestCheckResourceAttr("elasticsearch_reindex.test", "completed", "true"),
+					resource.TestCheckResourceAttr("elasticsearch_reindex.test", "version_conflicts", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchReindexExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No reindex task ID is set")
+		}
+
+		_, err := elasticsearchGetReindexTask(testAccProvider.Meta(), rs.Primary.ID)
+		return err
+	}
+}
+
+func testCheckElasticsearchReindexDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_reindex" {
+			continue
+		}
+
+		task, err := elasticsearchGetReindexTask(testAccProvider.Meta(), rs.Primary.ID)
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		// the task result is kept, it must not be running anymore
+		if !task.Completed {
+			return fmt.Errorf("Reindex task %q still running", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+var testAccElasticsearchReindex = `
+resource "elasticsearch_index" "source" {
+  name               = "terraform-test-reindex-source"
+  number_of_shards   = 1
+  number_of_replicas = 0
+}
+
+resource "elasticsearch_index" "dest" {
+  name               = "terraform-test-reindex-dest"
+  number_of_shards   = 1
+  number_of_replicas = 0
+}
+
+resource "elasticsearch_reindex" "test" {
+  source = jsonencode({
+    index = elasticsearch_index.source.name
+  })
+  dest = jsonencode({
+    index = elasticsearch_index.dest.name
+  })
+  conflicts = "proceed"
+}
+`
@@ -0,0 +1,270 @@
+package es
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	elastic5 "gopkg.in/olivere/elastic.v5"
+)
+
+// the attributes of remote clusters, by the name of their setting under
+// cluster.remote.<name>, with the mode they can only be used in
+var remoteClusterSettings = []struct {
+	name string
+	mode string
+}{
+	{"mode", ""},
+	{"skip_unavailable", ""},
+	{"seeds", "sniff"},
+	{"node_connections", "sniff"},
+	{"proxy_address", "proxy"},
+	{"proxy_socket_connections", "proxy"},
+	{"server_name", "proxy"},
+}
+
+func resourceElasticsearchRemoteCluster() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an Elasticsearch remote cluster connection, used by cross-cluster search and replication. The connection is configured through the persistent cluster settings under `cluster.remote.<name>`, which are removed on destroy.",
+		Create:      resourceElasticsearchRemoteClusterCreate,
+		Read:        resourceElasticsearchRemoteClusterRead,
+		Update:      resourceElasticsearchRemoteClusterUpdate,
+		Delete:      resourceElasticsearchRemoteClusterDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Name of the remote cluster, used to refer to its indices, e.g. `name:index`.",
+			},
+			"mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"sniff", "proxy"}, false),
+				Description:  "The connection mode, `sniff` to connect to the nodes discovered from the seeds, or `proxy` to connect through a single address. Requires ElasticSearch >= 7.6, clusters before only support sniffing.",
+			},
+			"seeds": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The addresses of the nodes of the remote cluster to discover the cluster from in sniff mode, e.g. `10.0.0.1:9300`.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"node_connections": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The number of nodes of the remote cluster to connect to in sniff mode.",
+			},
+			"proxy_address": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The address to connect to in proxy mode, e.g. `remote.example.com:9300`.",
+			},
+			"proxy_socket_connections": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The number of socket connections opened to the proxy address in proxy mode.",
+			},
+			"server_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The server name sent in the TLS server name indication extension in proxy mode.",
+			},
+			"skip_unavailable": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether searches across clusters skip the remote cluster when it is unavailable, instead of failing.",
+			},
+			"connected": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the cluster is connected to the remote cluster.",
+			},
+			"num_nodes_connected": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of nodes of the remote cluster connected to in sniff mode.",
+			},
+			"num_proxy_sockets_connected": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of socket connections opened to the proxy address in proxy mode.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchRemoteClusterCreate(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+
+	if err := elasticsearchPutRemoteClusterSettings(d, meta, name, false); err != nil {
+		return err
+	}
+
+	d.SetId(name)
+	return resourceElasticsearchRemoteClusterRead(d, meta)
+}
+
+func resourceElasticsearchRemoteClusterRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	remote, err := elasticsearchGetRemoteCluster(meta, id)
+	if err != nil {
+		return err
+	}
+	if remote == nil {
+		log.Printf("[WARN] Remote cluster (%s) not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("name", id)
+	ds.set("skip_unavailable", remote.SkipUnavailable)
+	ds.set("connected", remote.Connected)
+	ds.set("num_nodes_connected", remote.NumNodesConnected)
+	ds.set("num_proxy_sockets_connected", remote.NumProxySocketsConnected)
+	ds.set("seeds", remote.Seeds)
+	ds.set("proxy_address", remote.ProxyAddress)
+	ds.set("server_name", remote.ServerName)
+	// clusters without modes return the connection count shared by all the
+	// remote clusters, which can't be set per remote cluster
+	if remote.Mode != "" {
+		ds.set("mode", remote.Mode)
+		ds.set("node_connections", remote.MaxConnectionsPerCluster)
+		ds.set("proxy_socket_connections", remote.MaxProxySocketConnections)
+	}
+	return ds.err
+}
+
+func resourceElasticsearchRemoteClusterUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := elasticsearchPutRemoteClusterSettings(d, meta, d.Id(), false); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchRemoteClusterRead(d, meta)
+}
+
+func resourceElasticsearchRemoteClusterDelete(d *schema.ResourceData, meta interface{}) error {
+	if err := elasticsearchPutRemoteClusterSettings(d, meta, d.Id(), true); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// elasticsearchPutRemoteClusterSettings sets the configured settings of the
+// remote cluster, and resets the previously configured ones which are no
+// longer configured, or can't be used in the configured mode, or all of them
+// on destroy
+func elasticsearchPutRemoteClusterSettings(d *schema.ResourceData, meta interface{}, name string, destroy bool) error {
+	if err := elasticsearchCheckRemoteClusterSupported(meta); err != nil {
+		return err
+	}
+
+	mode := d.Get("mode").(string)
+	if mode == "" {
+		mode = "sniff"
+	}
+
+	settings := make(map[string]interface{})
+	for _, setting := range remoteClusterSettings {
+		o, n := d.GetChange(setting.name)
+
+		var value interface{}
+		if !destroy && (setting.mode == "" || setting.mode == mode) && !isZeroRemoteClusterSetting(n) {
+			value = n
+			if list, ok := n.([]interface{}); ok {
+				value = expandStringList(list)
+			}
+		}
+		// null resets the setting, removing it
+		if value == nil && isZeroRemoteClusterSetting(o) {
+			continue
+		}
+		settings[fmt.Sprintf("cluster.remote.%s.%s", name, setting.name)] = value
+	}
+
+	if len(settings) == 0 {
+		return nil
+	}
+
+	body := map[string]interface{}{
+		"persistent": settings,
+	}
+	if _, err := elasticsearchPerformRequest(meta, "PUT", "/_cluster/settings", body); err != nil {
+		return fmt.Errorf("error updating settings of remote cluster %s: %+v", name, err)
+	}
+	return nil
+}
+
+func isZeroRemoteClusterSetting(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return v == ""
+	case int:
+		return v == 0
+	case bool:
+		return !v
+	case []interface{}:
+		return len(v) == 0
+	}
+	return value == nil
+}
+
+func elasticsearchGetRemoteCluster(meta interface{}, name string) (*RemoteClusterInfo, error) {
+	if err := elasticsearchCheckRemoteClusterSupported(meta); err != nil {
+		return nil, err
+	}
+
+	body, err := elasticsearchPerformRequest(meta, "GET", "/_remote/info", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make(map[string]RemoteClusterInfo)
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling remote cluster info body: %+v: %+v", err, body)
+	}
+
+	if remote, ok := response[name]; ok {
+		return &remote, nil
+	}
+	return nil, nil
+}
+
+func elasticsearchCheckRemoteClusterSupported(meta interface{}) error {
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	if _, ok := esClient.(*elastic5.Client); ok {
+		return errors.New("Remote clusters are only supported by the elastic library >= v6!")
+	}
+	return nil
+}
+
+type RemoteClusterInfo struct {
+	Connected                 bool     `json:"connected"`
+	Mode                      string   `json:"mode"`
+	Seeds                     []string `json:"seeds"`
+	NumNodesConnected         int      `json:"num_nodes_connected"`
+	MaxConnectionsPerCluster  int      `json:"max_connections_per_cluster"`
+	ProxyAddress              string   `json:"proxy_address"`
+	ServerName                string   `json:"server_name"`
+	NumProxySocketsConnected  int      `json:"num_proxy_sockets_connected"`
+	MaxProxySocketConnections int      `json:"max_proxy_socket_connections"`
+	SkipUnavailable           bool     `json:"skip_unavailable"`
+}
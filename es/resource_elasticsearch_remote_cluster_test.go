@@ -0,0 +1,110 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic5 "gopkg.in/olivere/elastic.v5"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchRemoteCluster(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	_, isElastic5 := esClient.(*elastic5.Client)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if isElastic5 {
+				t.Skip("Remote clusters only supported on ES >= 6")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckElasticsearchRemoteClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchRemoteCluster(true),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchRemoteClusterExists("elasticsearch_remote_cluster.test"),
+					resource.TestCheckResourceAttr("elasticsearch_remote_cluster.test", "seeds.#", "1"),
+					resource.TestCheckResourceAttr("elasticsearch_remote_cluster.test", "seeds.0", "127.0.0.1:9300"),
+					resource.TestCheckResourceAttr("elasticsearch_remote_cluster.test", "skip_unavailable", "true"),
+				),
+			},
+			{
+				Config: testAccElasticsearchRemoteCluster(false),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchRemoteClusterExists("elasticsearch_remote_cluster.test"),
+					resource.TestCheckResourceAttr("elasticsearch_remote_cluster.test", "skip_unavailable", "false"),
+				),
+			},
+			{
+				ResourceName:            "elasticsearch_remote_cluster.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"connected", "num_nodes_connected"},
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchRemoteClusterExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No remote cluster ID is set")
+		}
+
+		remote, err := elasticsearchGetRemoteCluster(testAccProvider.Meta(), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if remote == nil {
+			return fmt.Errorf("Remote cluster %q not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testCheckElasticsearchRemoteClusterDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_remote_cluster" {
+			continue
+		}
+
+		remote, err := elasticsearchGetRemoteCluster(testAccProvider.Meta(), rs.Primary.ID)
+		if err != nil || remote == nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Remote cluster %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchRemoteCluster(skipUnavailable bool) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_remote_cluster" "test" {
+  name             = "terraform-test"
+  seeds            = ["127.0.0.1:9300"]
+  skip_unavailable = %t
+}
+`, skipUnavailable)
+}
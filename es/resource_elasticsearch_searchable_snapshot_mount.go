@@ -0,0 +1,217 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+var searchableSnapshotMinimalVersion, _ = version.NewVersion("7.10.0")
+
+func resourceElasticsearchSearchableSnapshotMount() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an Elasticsearch searchable snapshot index, an index of a snapshot mounted into the cluster, e.g. for the cold and frozen tiers. Destroying the resource deletes the mounted index, the snapshot is kept. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/searchable-snapshots-api-mount-snapshot.html) for more details.",
+		Create:      resourceElasticsearchSearchableSnapshotMountCreate,
+		Read:        resourceElasticsearchSearchableSnapshotMountRead,
+		Update:      resourceElasticsearchSearchableSnapshotMountUpdate,
+		Delete:      resourceElasticsearchSearchableSnapshotMountDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Name of the mounted index.",
+			},
+			"repository": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Name of the snapshot repository.",
+			},
+			"snapshot": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Name of the snapshot.",
+			},
+			"index": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Name of the index in the snapshot to mount.",
+			},
+			"storage": {
+				Type:         schema.TypeString,
+				ForceNew:     true,
+				Optional:     true,
+				Default:      "full_copy",
+				ValidateFunc: validation.StringInSlice([]string{"full_copy", "shared_cache"}, false),
+				Description:  "How the index is stored, `full_copy` to copy the whole index on the nodes, or `shared_cache` to only cache the recently used parts, for the frozen tier. `shared_cache` requires ElasticSearch >= 7.12.",
+			},
+			"index_settings": {
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJson,
+				Description:      "A JSON string of the settings added to the mounted index, e.g. `{\"index.number_of_replicas\": 0}`.",
+			},
+			"ignore_index_settings": {
+				Type:        schema.TypeList,
+				ForceNew:    true,
+				Optional:    true,
+				Description: "The settings of the index in the snapshot to remove from the mounted index.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"wait_for_completion": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to wait for the mounted index to be recovered from the snapshot, the index can be searched once it is.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchSearchableSnapshotMountCreate(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+
+	if err := elastic7CheckSearchableSnapshotVersion(meta); err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"index":         d.Get("index").(string),
+		"renamed_index": name,
+	}
+	if settings, ok := d.GetOk("index_settings"); ok {
+		body["index_settings"] = json.RawMessage(settings.(string))
+	}
+	if ignored, ok := d.GetOk("ignore_index_settings"); ok {
+		body["ignore_index_settings"] = expandStringList(ignored.([]interface{}))
+	}
+
+	path, err := uritemplates.Expand("/_snapshot/{repository}/{snapshot}/_mount", map[string]string{
+		"repository": d.Get("repository").(string),
+		"snapshot":   d.Get("snapshot").(string),
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for snapshot mount: %+v", err)
+	}
+	path += fmt.Sprintf("?wait_for_completion=%t", d.Get("wait_for_completion").(bool))
+	// the storage option is only sent when not the default, clusters before
+	// it was added only support full copies
+	if storage := d.Get("storage").(string); storage != "full_copy" {
+		path += "&storage=" + storage
+	}
+
+	if _, err := elasticsearchPerformRequest(meta, "POST", path, body); err != nil {
+		return err
+	}
+
+	d.SetId(name)
+	return resourceElasticsearchSearchableSnapshotMountRead(d, meta)
+}
+
+func resourceElasticsearchSearchableSnapshotMountRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	if err := elastic7CheckSearchableSnapshotVersion(meta); err != nil {
+		return err
+	}
+
+	path, err := indexSettingsPath(id)
+	if err != nil {
+		return err
+	}
+	body, err := elasticsearchPerformRequest(meta, "GET", path+"/index.store.*?flat_settings=true", nil)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Searchable snapshot index (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	response := make(map[string]IndexSettingsGetResponse)
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("error unmarshalling index settings body: %+v: %+v", err, body)
+	}
+	settings, ok := response[id]
+	if !ok {
+		log.Printf("[WARN] Searchable snapshot index (%s) not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	storage := "full_copy"
+	if partial, ok := settings.Settings["index.store.snapshot.partial"]; ok && fmt.Sprintf("%v", partial) == "true" {
+		storage = "shared_cache"
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("name", id)
+	ds.set("repository", settings.Settings["index.store.snapshot.repository_name"])
+	ds.set("snapshot", settings.Settings["index.store.snapshot.snapshot_name"])
+	ds.set("index", settings.Settings["index.store.snapshot.index_name"])
+	ds.set("storage", storage)
+	return ds.err
+}
+
+// only wait_for_completion can be updated, which is only used when mounting
+func resourceElasticsearchSearchableSnapshotMountUpdate(d *schema.ResourceData, meta interface{}) error {
+	return resourceElasticsearchSearchableSnapshotMountRead(d, meta)
+}
+
+func resourceElasticsearchSearchableSnapshotMountDelete(d *schema.ResourceData, meta interface{}) error {
+	if err := elastic7CheckSearchableSnapshotVersion(meta); err != nil {
+		return err
+	}
+
+	path, err := uritemplates.Expand("/{index}", map[string]string{
+		"index": d.Id(),
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for index: %+v", err)
+	}
+	if _, err := elasticsearchPerformRequest(meta, "DELETE", path, nil); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func elastic7CheckSearchableSnapshotVersion(meta interface{}) error {
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+
+	client, ok := esClient.(*elastic7.Client)
+	if !ok {
+		return fmt.Errorf("searchable snapshots only available from ElasticSearch >= 7.10, got version < 7.0.0")
+	}
+
+	elasticVersion, err := elastic7GetVersion(client)
+	if err != nil {
+		return err
+	}
+	if elasticVersion.LessThan(searchableSnapshotMinimalVersion) {
+		return fmt.Errorf("searchable snapshots only available from ElasticSearch >= 7.10, got version %s", elasticVersion.String())
+	}
+	return nil
+}
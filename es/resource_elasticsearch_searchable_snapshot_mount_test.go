@@ -0,0 +1,136 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchSearchableSnapshotMount(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		elasticVersion, err := elastic7GetVersion(client)
+		if err != nil {
+			t.Skipf("err: %s", err)
+		}
+		allowed = !elasticVersion.LessThan(searchableSnapshotMinimalVersion)
+	default:
+		allowed = false
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Searchable snapshots only supported on ES >= 7.10")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchSearchableSnapshotMountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchSearchableSnapshotMount,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchSearchableSnapshotMountExists("elasticsearch_searchable_snapshot_mount.test"),
+					resource.TestCheckResourceAttr("elasticsearch_searchable_snapshot_mount.test", "index", "terraform-test"),
+					resource.TestCheckResourceAttr("elasticsearch_searchable_snapshot_mount.test", "storage", "full_copy"),
+				),
+			},
+			{
+				ResourceName:            "elasticsearch_searchable_snapshot_mount.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"index_settings", "wait_for_completion"},
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchSearchableSnapshotMountExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No searchable snapshot index ID is set")
+		}
+
+		path, err := indexSettingsPath(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		_, err = elasticsearchPerformRequest(testAccXPackProvider.Meta(), "GET", path, nil)
+		return err
+	}
+}
+
+func testCheckElasticsearchSearchableSnapshotMountDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_searchable_snapshot_mount" {
+			continue
+		}
+
+		path, err := indexSettingsPath(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if _, err := elasticsearchPerformRequest(testAccXPackProvider.Meta(), "GET", path, nil); err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Searchable snapshot index %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+var testAccElasticsearchSearchableSnapshotMount = `
+resource "elasticsearch_snapshot_repository" "test" {
+  name = "terraform-test"
+  type = "fs"
+
+  settings = {
+    location = "/tmp/elasticsearch"
+  }
+}
+
+resource "elasticsearch_index" "test" {
+  name               = "terraform-test"
+  number_of_shards   = 1
+  number_of_replicas = 0
+}
+
+resource "elasticsearch_snapshot" "test" {
+  repository           = elasticsearch_snapshot_repository.test.name
+  name                 = "terraform-test-mount"
+  indices              = [elasticsearch_index.test.name]
+  include_global_state = false
+}
+
+resource "elasticsearch_searchable_snapshot_mount" "test" {
+  name       = "terraform-test-mounted"
+  repository = elasticsearch_snapshot_repository.test.name
+  snapshot   = elasticsearch_snapshot.test.name
+  index      = elasticsearch_index.test.name
+  index_settings = jsonencode({
+    "index.number_of_replicas" = 0
+  })
+}
+`
@@ -0,0 +1,288 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+func resourceElasticsearchSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an Elasticsearch snapshot, taken once when the resource is created and deleted along with the resource. Creating the resource waits for the snapshot to complete.",
+		Create:      resourceElasticsearchSnapshotCreate,
+		Read:        resourceElasticsearchSnapshotRead,
+		Delete:      resourceElasticsearchSnapshotDelete,
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Name of the repository to store the snapshot in.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Name of the snapshot.",
+			},
+			"indices": {
+				Type:        schema.TypeList,
+				ForceNew:    true,
+				Optional:    true,
+				Description: "The indices, or wildcard expressions of indices, to snapshot, defaults to all the indices.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"snapshot_indices": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The names of the indices in the snapshot, sorted.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"include_global_state": {
+				Type:        schema.TypeBool,
+				ForceNew:    true,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to include the cluster state, e.g. templates and persistent settings, in the snapshot.",
+			},
+			"ignore_unavailable": {
+				Type:        schema.TypeBool,
+				ForceNew:    true,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to ignore missing or closed indices instead of failing the snapshot.",
+			},
+			"partial": {
+				Type:        schema.TypeBool,
+				ForceNew:    true,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to allow a partial snapshot of indices with unavailable primary shards instead of failing the snapshot.",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the snapshot, `SUCCESS` or `PARTIAL` once completed.",
+			},
+			"start_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The start time of the snapshot, in RFC 3339 format.",
+			},
+			"end_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The end time of the snapshot, in RFC 3339 format.",
+			},
+			"duration_in_millis": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The duration of the snapshot in milliseconds.",
+			},
+			"shards_total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of shards in the snapshot.",
+			},
+			"shards_successful": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of shards successfully snapshotted.",
+			},
+			"shards_failed": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of shards which failed to snapshot.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: resourceElasticsearchSnapshotImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+	}
+}
+
+func resourceElasticsearchSnapshotCreate(d *schema.ResourceData, meta interface{}) error {
+	repository := d.Get("repository").(string)
+	name := d.Get("name").(string)
+
+	path, err := snapshotPath(repository, name)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"include_global_state": d.Get("include_global_state").(bool),
+		"ignore_unavailable":   d.Get("ignore_unavailable").(bool),
+		"partial":              d.Get("partial").(bool),
+	}
+	if indices := expandStringList(d.Get("indices").([]interface{})); len(indices) > 0 {
+		body["indices"] = strings.Join(indices, ",")
+	}
+
+	// the snapshot is polled for completion rather than waiting in the
+	// request, so the create timeout applies
+	if _, err := elasticsearchPerformRequest(meta, "PUT", path, body); err != nil {
+		return err
+	}
+	d.SetId(fmt.Sprintf("%s/%s", repository, name))
+
+	err = resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		snapshot, err := elasticsearchGetSnapshot(meta, repository, name)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if snapshot == nil {
+			return resource.NonRetryableError(fmt.Errorf("snapshot %s not found in repository %s", name, repository))
+		}
+
+		switch snapshot.State {
+		case "SUCCESS", "PARTIAL":
+			return nil
+		case "IN_PROGRESS", "STARTED":
+			return resource.RetryableError(fmt.Errorf("snapshot %s is %s", name, snapshot.State))
+		default:
+			return resource.NonRetryableError(fmt.Errorf("snapshot %s is %s: %s", name, snapshot.State, snapshot.Reason))
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	return resourceElasticsearchSnapshotRead(d, meta)
+}
+
+func resourceElasticsearchSnapshotRead(d *schema.ResourceData, meta interface{}) error {
+	repository := d.Get("repository").(string)
+	name := d.Get("name").(string)
+
+	snapshot, err := elasticsearchGetSnapshot(meta, repository, name)
+	if err != nil {
+		if elastic7.IsNotFound(err) || elastic6.IsNotFound(err) || elastic5.IsNotFound(err) {
+			log.Printf("[WARN] Snapshot (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	if snapshot == nil {
+		log.Printf("[WARN] Snapshot (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	indices := snapshot.Indices
+	sort.Strings(indices)
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("snapshot_indices", indices)
+	ds.set("state", snapshot.State)
+	ds.set("start_time", formatSnapshotTime(snapshot.StartTimeInMillis))
+	ds.set("end_time", formatSnapshotTime(snapshot.EndTimeInMillis))
+	ds.set("duration_in_millis", snapshot.DurationInMillis)
+	ds.set("shards_total", snapshot.Shards.Total)
+	ds.set("shards_successful", snapshot.Shards.Successful)
+	ds.set("shards_failed", snapshot.Shards.Failed)
+	return ds.err
+}
+
+func resourceElasticsearchSnapshotDelete(d *schema.ResourceData, meta interface{}) error {
+	path, err := snapshotPath(d.Get("repository").(string), d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+
+	if _, err := elasticsearchPerformRequest(meta, "DELETE", path, nil); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceElasticsearchSnapshotImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected repository/snapshot", d.Id())
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("repository", parts[0])
+	ds.set("name", parts[1])
+	// the options of the snapshot are not returned, assume the defaults
+	ds.set("include_global_state", true)
+	ds.set("ignore_unavailable", false)
+	ds.set("partial", false)
+	return []*schema.ResourceData{d}, ds.err
+}
+
+func elasticsearchGetSnapshot(meta interface{}, repository string, name string) (*SnapshotInfo, error) {
+	path, err := snapshotPath(repository, name)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := elasticsearchPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(SnapshotGetResponse)
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling snapshot body: %+v: %+v", err, body)
+	}
+
+	for _, snapshot := range response.Snapshots {
+		if snapshot.Snapshot == name {
+			return &snapshot, nil
+		}
+	}
+	return nil, nil
+}
+
+func snapshotPath(repository string, name string) (string, error) {
+	path, err := uritemplates.Expand("/_snapshot/{repository}/{snapshot}", map[string]string{
+		"repository": repository,
+		"snapshot":   name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for snapshot: %+v", err)
+	}
+	return path, nil
+}
+
+type SnapshotGetResponse struct {
+	Snapshots []SnapshotInfo `json:"snapshots"`
+}
+
+type SnapshotInfo struct {
+	Snapshot          string   `json:"snapshot"`
+	Indices           []string `json:"indices"`
+	State             string   `json:"state"`
+	Reason            string   `json:"reason"`
+	StartTimeInMillis int64    `json:"start_time_in_millis"`
+	EndTimeInMillis   int64    `json:"end_time_in_millis"`
+	DurationInMillis  int64    `json:"duration_in_millis"`
+	Shards            struct {
+		Total      int `json:"total"`
+		Failed     int `json:"failed"`
+		Successful int `json:"successful"`
+	} `json:"shards"`
+}
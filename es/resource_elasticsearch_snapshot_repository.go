@@ -2,13 +2,20 @@ package es
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	elastic7 "github.com/olivere/elastic/v7"
 	elastic5 "gopkg.in/olivere/elastic.v5"
 	elastic6 "gopkg.in/olivere/elastic.v6"
 )
 
+// snapshotRepositoryTypes are the repository types with a typed settings block
+var snapshotRepositoryTypes = []string{"fs", "s3", "gcs", "azure", "hdfs"}
+
 func resourceElasticsearchSnapshotRepository() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceElasticsearchSnapshotRepositoryCreate,
@@ -22,16 +29,142 @@ func resourceElasticsearchSnapshotRepository() *schema.Resource {
 				Required: true,
 			},
 			"type": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The type of the repository, required unless one of the typed settings blocks is set.",
 			},
 			"settings": {
-				Type:     schema.TypeMap,
-				Optional: true,
+				Type:          schema.TypeMap,
+				Optional:      true,
+				ConflictsWith: snapshotRepositoryTypes,
+				Description:   "The settings of the repository, for repository types without a typed settings block.",
 			},
+			"verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to verify that the repository is usable by all the nodes when it is created or updated.",
+			},
+			"fs": snapshotRepositorySettingsSchema("fs", "Settings of a shared file system repository.", map[string]*schema.Schema{
+				"location": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The location of the snapshots, it must be registered in `path.repo` on all the nodes.",
+				},
+			}),
+			"s3": snapshotRepositorySettingsSchema("s3", "Settings of an AWS S3 repository.", map[string]*schema.Schema{
+				"bucket": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The name of the bucket.",
+				},
+				"client": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The name of the S3 client configured on the nodes.",
+				},
+				"base_path": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The path of the repository in the bucket.",
+				},
+				"region": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The region of the bucket, e.g. for AWS Elasticsearch domains.",
+				},
+				"role_arn": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The ARN of the role used to access the bucket, for AWS Elasticsearch domains.",
+				},
+				"server_side_encryption": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "Whether files are encrypted on the server side using AES256.",
+				},
+				"storage_class": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The S3 storage class of the objects, e.g. `standard_ia`.",
+				},
+				"canned_acl": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The canned ACL of the buckets and objects, e.g. `bucket-owner-full-control`.",
+				},
+				"buffer_size": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The size above which chunks are uploaded in multiple parts, e.g. `100mb`.",
+				},
+			}),
+			"gcs": snapshotRepositorySettingsSchema("gcs", "Settings of a Google Cloud Storage repository.", map[string]*schema.Schema{
+				"bucket": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The name of the bucket.",
+				},
+				"client": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The name of the GCS client configured on the nodes.",
+				},
+				"base_path": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The path of the repository in the bucket.",
+				},
+			}),
+			"azure": snapshotRepositorySettingsSchema("azure", "Settings of an Azure blob storage repository.", map[string]*schema.Schema{
+				"container": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The name of the container.",
+				},
+				"client": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The name of the Azure client configured on the nodes.",
+				},
+				"base_path": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The path of the repository in the container.",
+				},
+				"location_mode": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringInSlice([]string{"primary_only", "secondary_only"}, false),
+					Description:  "The location of the storage used, `primary_only` or `secondary_only`.",
+				},
+			}),
+			"hdfs": snapshotRepositorySettingsSchema("hdfs", "Settings of a Hadoop HDFS repository.", map[string]*schema.Schema{
+				"uri": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The URI of the file system, e.g. `hdfs://namenode:8020/`.",
+				},
+				"path": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The path of the repository in the file system.",
+				},
+				"load_defaults": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "Whether to load the default Hadoop configuration.",
+				},
+				"security_principal": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The Kerberos principal used to connect to a secured cluster.",
+				},
+			}),
 		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceElasticsearchSnapshotRepositoryImport,
 		},
 	}
 }
@@ -45,6 +178,12 @@ func resourceElasticsearchSnapshotRepositoryCreate(d *schema.ResourceData, meta
 	return nil
 }
 
+func resourceElasticsearchSnapshotRepositoryImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	ds := &resourceDataSetter{d: d}
+	ds.set("verify", true)
+	return []*schema.ResourceData{d}, ds.err
+}
+
 func resourceElasticsearchSnapshotRepositoryRead(d *schema.ResourceData, meta interface{}) error {
 	id := d.Id()
 
@@ -66,13 +205,23 @@ func resourceElasticsearchSnapshotRepositoryRead(d *schema.ResourceData, meta in
 	}
 
 	if err != nil {
+		if elastic7.IsNotFound(err) || elastic6.IsNotFound(err) || elastic5.IsNotFound(err) {
+			log.Printf("[WARN] Snapshot repository (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
 
 	ds := &resourceDataSetter{d: d}
 	ds.set("name", id)
 	ds.set("type", repositoryType)
-	ds.set("settings", settings)
+	// the settings are read into the typed block if it is used
+	if _, ok := d.GetOk(repositoryType); ok {
+		ds.set(repositoryType, flattenSnapshotRepositorySettings(repositoryType, settings))
+	} else {
+		ds.set("settings", settings)
+	}
 	return ds.err
 }
 
@@ -104,60 +253,57 @@ func elastic5SnapshotGetRepository(client *elastic5.Client, id string) (string,
 }
 
 func resourceElasticsearchSnapshotRepositoryUpdate(d *schema.ResourceData, meta interface{}) error {
-	repositoryType := d.Get("type").(string)
-	name := d.Get("name").(string)
-
-	var settings map[string]interface{}
-
-	if v, ok := d.GetOk("settings"); ok {
-		settings = v.(map[string]interface{})
+	repositoryType, settings, err := expandSnapshotRepositorySettings(d)
+	if err != nil {
+		return err
 	}
+	name := d.Get("name").(string)
+	verify := d.Get("verify").(bool)
 
-	var err error
 	esClient, err := getClient(meta.(*ProviderConf))
 	if err != nil {
 		return err
 	}
 	switch client := esClient.(type) {
 	case *elastic7.Client:
-		err = elastic7SnapshotCreateRepository(client, name, repositoryType, settings)
+		err = elastic7SnapshotCreateRepository(client, name, repositoryType, settings, verify)
 	case *elastic6.Client:
-		err = elastic6SnapshotCreateRepository(client, name, repositoryType, settings)
+		err = elastic6SnapshotCreateRepository(client, name, repositoryType, settings, verify)
 	default:
 		elastic5Client := client.(*elastic5.Client)
-		err = elastic5SnapshotCreateRepository(elastic5Client, name, repositoryType, settings)
+		err = elastic5SnapshotCreateRepository(elastic5Client, name, repositoryType, settings, verify)
 	}
 
 	return err
 }
 
-func elastic7SnapshotCreateRepository(client *elastic7.Client, name string, repositoryType string, settings map[string]interface{}) error {
+func elastic7SnapshotCreateRepository(client *elastic7.Client, name string, repositoryType string, settings map[string]interface{}, verify bool) error {
 	repo := elastic7.SnapshotRepositoryMetaData{
 		Type:     repositoryType,
 		Settings: settings,
 	}
 
-	_, err := client.SnapshotCreateRepository(name).BodyJson(&repo).Do(context.TODO())
+	_, err := client.SnapshotCreateRepository(name).BodyJson(&repo).Verify(verify).Do(context.TODO())
 	return err
 }
 
-func elastic6SnapshotCreateRepository(client *elastic6.Client, name string, repositoryType string, settings map[string]interface{}) error {
+func elastic6SnapshotCreateRepository(client *elastic6.Client, name string, repositoryType string, settings map[string]interface{}, verify bool) error {
 	repo := elastic6.SnapshotRepositoryMetaData{
 		Type:     repositoryType,
 		Settings: settings,
 	}
 
-	_, err := client.SnapshotCreateRepository(name).BodyJson(&repo).Do(context.TODO())
+	_, err := client.SnapshotCreateRepository(name).BodyJson(&repo).Verify(verify).Do(context.TODO())
 	return err
 }
 
-func elastic5SnapshotCreateRepository(client *elastic5.Client, name string, repositoryType string, settings map[string]interface{}) error {
+func elastic5SnapshotCreateRepository(client *elastic5.Client, name string, repositoryType string, settings map[string]interface{}, verify bool) error {
 	repo := elastic5.SnapshotRepositoryMetaData{
 		Type:     repositoryType,
 		Settings: settings,
 	}
 
-	_, err := client.SnapshotCreateRepository(name).BodyJson(&repo).Do(context.TODO())
+	_, err := client.SnapshotCreateRepository(name).BodyJson(&repo).Verify(verify).Do(context.TODO())
 	return err
 }
 
@@ -200,3 +346,113 @@ func elastic5SnapshotDeleteRepository(client *elastic5.Client, id string) error
 	_, err := client.SnapshotDeleteRepository(id).Do(context.TODO())
 	return err
 }
+
+// snapshotRepositorySettingsSchema builds the typed settings block of a
+// repository type, adding the settings shared by all repository types
+func snapshotRepositorySettingsSchema(repositoryType string, description string, fields map[string]*schema.Schema) *schema.Schema {
+	fields["compress"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Description: "Whether the metadata files are compressed.",
+	}
+	fields["chunk_size"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "The size above which files are split into chunks, e.g. `1gb`.",
+	}
+	fields["max_snapshot_bytes_per_sec"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "The maximum rate of snapshots per node, e.g. `40mb`.",
+	}
+	fields["max_restore_bytes_per_sec"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "The maximum rate of restores per node, e.g. `40mb`.",
+	}
+	fields["readonly"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Description: "Whether the repository is read only, e.g. when it is registered to several clusters.",
+	}
+
+	conflicts := []string{"settings"}
+	for _, t := range snapshotRepositoryTypes {
+		if t != repositoryType {
+			conflicts = append(conflicts, t)
+		}
+	}
+
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: conflicts,
+		Description:   description + " Sets the `type` of the repository to `" + repositoryType + "`.",
+		Elem: &schema.Resource{
+			Schema: fields,
+		},
+	}
+}
+
+// expandSnapshotRepositorySettings returns the type and settings of the
+// repository, from the typed settings block if one is set
+func expandSnapshotRepositorySettings(d *schema.ResourceData) (string, map[string]interface{}, error) {
+	for _, repositoryType := range snapshotRepositoryTypes {
+		blocks := d.Get(repositoryType).([]interface{})
+		if len(blocks) == 0 || blocks[0] == nil {
+			continue
+		}
+
+		settings := make(map[string]interface{})
+		for key, value := range blocks[0].(map[string]interface{}) {
+			switch v := value.(type) {
+			case string:
+				if v != "" {
+					settings[key] = v
+				}
+			case bool:
+				// unset booleans are left to the defaults of the repository
+				if v {
+					settings[key] = strconv.FormatBool(v)
+				}
+			}
+		}
+		return repositoryType, settings, nil
+	}
+
+	repositoryType := d.Get("type").(string)
+	if repositoryType == "" {
+		return "", nil, fmt.Errorf("snapshot repository %s needs either a type or one of the settings blocks %v", d.Get("name").(string), snapshotRepositoryTypes)
+	}
+
+	var settings map[string]interface{}
+	if v, ok := d.GetOk("settings"); ok {
+		settings = v.(map[string]interface{})
+	}
+	return repositoryType, settings, nil
+}
+
+func flattenSnapshotRepositorySettings(repositoryType string, settings map[string]interface{}) []interface{} {
+	fields := resourceElasticsearchSnapshotRepository().Schema[repositoryType].Elem.(*schema.Resource).Schema
+
+	block := make(map[string]interface{})
+	for key, field := range fields {
+		value, ok := settings[key]
+		if !ok {
+			continue
+		}
+		s := fmt.Sprintf("%v", value)
+		if field.Type == schema.TypeBool {
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				log.Printf("[WARN] Snapshot repository setting %s is not a boolean: %s", key, s)
+				continue
+			}
+			block[key] = b
+		} else {
+			block[key] = s
+		}
+	}
+	return []interface{}{block}
+}
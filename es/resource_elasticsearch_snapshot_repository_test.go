@@ -29,6 +29,25 @@ func TestAccElasticsearchSnapshotRepository(t *testing.T) {
 	})
 }
 
+func TestAccElasticsearchSnapshotRepository_typedSettings(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckElasticsearchSnapshotRepositoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchSnapshotRepositoryTypedSettings,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchSnapshotRepositoryExists("elasticsearch_snapshot_repository.test"),
+					resource.TestCheckResourceAttr("elasticsearch_snapshot_repository.test", "type", "fs"),
+					resource.TestCheckResourceAttr("elasticsearch_snapshot_repository.test", "fs.0.location", "/tmp/elasticsearch"),
+					resource.TestCheckResourceAttr("elasticsearch_snapshot_repository.test", "fs.0.compress", "true"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccElasticsearchSnapshotRepository_importBasic(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -125,3 +144,16 @@ resource "elasticsearch_snapshot_repository" "test" {
   }
 }
 `
+
+var testAccElasticsearchSnapshotRepositoryTypedSettings = `
+resource "elasticsearch_snapshot_repository" "test" {
+  name   = "terraform-test"
+  verify = false
+
+  fs {
+    location   = "/tmp/elasticsearch"
+    compress   = true
+    chunk_size = "100mb"
+  }
+}
+`
@@ -0,0 +1,102 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchSnapshot(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckElasticsearchSnapshotDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchSnapshot,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchSnapshotExists("elasticsearch_snapshot.test"),
+					resource.TestCheckResourceAttr("elasticsearch_snapshot.test", "state", "SUCCESS"),
+					resource.TestCheckResourceAttr("elasticsearch_snapshot.test", "snapshot_indices.#", "1"),
+					resource.TestCheckResourceAttr("elasticsearch_snapshot.test", "snapshot_indices.0", "terraform-test"),
+					resource.TestCheckResourceAttr("elasticsearch_snapshot.test", "shards_failed", "0"),
+				),
+			},
+			{
+				ResourceName:            "elasticsearch_snapshot.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"indices", "include_global_state"},
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchSnapshotExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No snapshot ID is set")
+		}
+
+		meta := testAccProvider.Meta()
+
+		snapshot, err := elasticsearchGetSnapshot(meta, rs.Primary.Attributes["repository"], rs.Primary.Attributes["name"])
+		if err != nil {
+			return err
+		}
+		if snapshot == nil {
+			return fmt.Errorf("Snapshot %q not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testCheckElasticsearchSnapshotDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_snapshot" {
+			continue
+		}
+
+		meta := testAccProvider.Meta()
+
+		snapshot, err := elasticsearchGetSnapshot(meta, rs.Primary.Attributes["repository"], rs.Primary.Attributes["name"])
+		if err != nil || snapshot == nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Snapshot %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+var testAccElasticsearchSnapshot = `
+resource "elasticsearch_snapshot_repository" "test" {
+  name = "terraform-test"
+  type = "fs"
+
+  settings = {
+    location = "/tmp/elasticsearch"
+  }
+}
+
+resource "elasticsearch_index" "test" {
+  name               = "terraform-test"
+  number_of_shards   = 1
+  number_of_replicas = 0
+}
+
+resource "elasticsearch_snapshot" "test" {
+  repository           = elasticsearch_snapshot_repository.test.name
+  name                 = "terraform-test-snapshot"
+  indices              = [elasticsearch_index.test.name]
+  include_global_state = false
+}
+`
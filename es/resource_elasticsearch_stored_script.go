@@ -0,0 +1,160 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+func resourceElasticsearchStoredScript() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceElasticsearchStoredScriptCreate,
+		Read:   resourceElasticsearchStoredScriptRead,
+		Update: resourceElasticsearchStoredScriptUpdate,
+		Delete: resourceElasticsearchStoredScriptDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Identifier of the script, used to reference it from queries, aggregations and pipelines.",
+			},
+			"lang": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "painless",
+				ValidateFunc: validation.StringInSlice([]string{"painless", "mustache", "expression"}, false),
+				Description:  "The language of the script, `painless`, `mustache` for search templates or `expression`.",
+			},
+			"source": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: diffSuppressScriptSource,
+				Description:      "The source of the script, or the search template for `mustache` scripts. Differences in whitespace only are ignored.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Description: "Provides an Elasticsearch stored script resource, managing scripts and search templates through the `_scripts` API.",
+	}
+}
+
+func resourceElasticsearchStoredScriptCreate(d *schema.ResourceData, meta interface{}) error {
+	if err := resourceElasticsearchPutStoredScript(d, meta); err != nil {
+		return err
+	}
+
+	d.SetId(d.Get("name").(string))
+	return resourceElasticsearchStoredScriptRead(d, meta)
+}
+
+func resourceElasticsearchStoredScriptRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	path, err := storedScriptPath(id)
+	if err != nil {
+		return err
+	}
+
+	body, err := elasticsearchPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		if elastic7.IsNotFound(err) || elastic6.IsNotFound(err) || elastic5.IsNotFound(err) {
+			log.Printf("[WARN] Stored script (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	response := new(StoredScriptGetResponse)
+	if err := json.Unmarshal(body, response); err != nil {
+		return fmt.Errorf("error unmarshalling stored script body: %+v: %+v", err, body)
+	}
+	if !response.Found {
+		log.Printf("[WARN] Stored script (%s) not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	source := response.Script.Source
+	if source == "" {
+		// ES 5 returns the source as code
+		source = response.Script.Code
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("name", id)
+	ds.set("lang", response.Script.Lang)
+	ds.set("source", source)
+	return ds.err
+}
+
+func resourceElasticsearchStoredScriptUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := resourceElasticsearchPutStoredScript(d, meta); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchStoredScriptRead(d, meta)
+}
+
+func resourceElasticsearchStoredScriptDelete(d *schema.ResourceData, meta interface{}) error {
+	path, err := storedScriptPath(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if _, err := elasticsearchPerformRequest(meta, "DELETE", path, nil); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceElasticsearchPutStoredScript(d *schema.ResourceData, meta interface{}) error {
+	path, err := storedScriptPath(d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"script": StoredScript{
+			Lang:   d.Get("lang").(string),
+			Source: d.Get("source").(string),
+		},
+	}
+
+	_, err = elasticsearchPerformRequest(meta, "PUT", path, body)
+	return err
+}
+
+func storedScriptPath(name string) (string, error) {
+	path, err := uritemplates.Expand("/_scripts/{name}", map[string]string{
+		"name": name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for stored script: %+v", err)
+	}
+	return path, nil
+}
+
+type StoredScriptGetResponse struct {
+	ID     string       `json:"_id"`
+	Found  bool         `json:"found"`
+	Script StoredScript `json:"script"`
+}
+
+type StoredScript struct {
+	Lang   string `json:"lang"`
+	Source string `json:"source"`
+	Code   string `json:"code,omitempty"`
+}
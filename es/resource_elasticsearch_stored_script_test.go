@@ -0,0 +1,120 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchStoredScript(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckElasticsearchStoredScriptDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchStoredScript("doc['my_field'].value * params['factor']"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchStoredScriptExists("elasticsearch_stored_script.test"),
+					resource.TestCheckResourceAttr("elasticsearch_stored_script.test", "lang", "painless"),
+				),
+			},
+			{
+				// whitespace only changes are ignored
+				Config:   testAccElasticsearchStoredScript("  doc['my_field'].value *\n  params['factor']\n"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccElasticsearchStoredScript("doc['my_field'].value * params['factor'] * 2"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchStoredScriptExists("elasticsearch_stored_script.test"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_stored_script.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchStoredScript_searchTemplate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckElasticsearchStoredScriptDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchStoredScriptSearchTemplate,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchStoredScriptExists("elasticsearch_stored_script.test"),
+					resource.TestCheckResourceAttr("elasticsearch_stored_script.test", "lang", "mustache"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchStoredScriptExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No stored script ID is set")
+		}
+
+		meta := testAccProvider.Meta()
+
+		_, err := elasticsearchPerformRequest(meta, "GET", "/_scripts/"+rs.Primary.ID, nil)
+		return err
+	}
+}
+
+func testCheckElasticsearchStoredScriptDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_stored_script" {
+			continue
+		}
+
+		meta := testAccProvider.Meta()
+
+		_, err := elasticsearchPerformRequest(meta, "GET", "/_scripts/"+rs.Primary.ID, nil)
+		if err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Stored script %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchStoredScript(source string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_stored_script" "test" {
+  name   = "terraform-test"
+  source = %q
+}
+`, source)
+}
+
+var testAccElasticsearchStoredScriptSearchTemplate = `
+resource "elasticsearch_stored_script" "test" {
+  name   = "terraform-test"
+  lang   = "mustache"
+  source = <<EOF
+{
+  "query": {
+    "match": {
+      "title": "{{query_string}}"
+    }
+  }
+}
+EOF
+}
+`
@@ -0,0 +1,260 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+var synonymsSetMinimalVersion, _ = version.NewVersion("8.10.0")
+
+// the maximum number of synonym rules returned per request
+const synonymsSetPageSize = 1000
+
+func resourceElasticsearchSynonymsSet() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an Elasticsearch synonyms set, referenced by the `synonyms_set` of synonym token filters. Changing the rules updates them one by one, the analyzers using the set are reloaded on each change. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/synonyms-apis.html) for more details.",
+		Create:      resourceElasticsearchSynonymsSetCreate,
+		Read:        resourceElasticsearchSynonymsSetRead,
+		Update:      resourceElasticsearchSynonymsSetUpdate,
+		Delete:      resourceElasticsearchSynonymsSetDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Identifier of the synonyms set.",
+			},
+			"rule": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The synonym rules of the set.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Identifier of the rule, unique in the set.",
+						},
+						"synonyms": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The synonyms of the rule in the Solr format, e.g. `hello, hi` or `universe => cosmos`.",
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchSynonymsSetCreate(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+
+	if err := elastic7CheckSynonymsSetVersion(meta); err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"synonyms_set": expandSynonymRules(d.Get("rule").(*schema.Set).List()),
+	}
+
+	path, err := synonymsSetPath(name, "")
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "PUT", path, body); err != nil {
+		return err
+	}
+
+	d.SetId(name)
+	return resourceElasticsearchSynonymsSetRead(d, meta)
+}
+
+func resourceElasticsearchSynonymsSetRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	if err := elastic7CheckSynonymsSetVersion(meta); err != nil {
+		return err
+	}
+
+	rules, err := elasticsearchGetSynonymRules(meta, id)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Synonyms set (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	flattened := make([]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		flattened = append(flattened, map[string]interface{}{
+			"id":       rule.ID,
+			"synonyms": rule.Synonyms,
+		})
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("name", id)
+	ds.set("rule", flattened)
+	return ds.err
+}
+
+func resourceElasticsearchSynonymsSetUpdate(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	if err := elastic7CheckSynonymsSetVersion(meta); err != nil {
+		return err
+	}
+
+	// only the changed rules are sent, so the other rules are kept while
+	// updating large sets
+	o, n := d.GetChange("rule")
+	oldRules := make(map[string]string)
+	for _, rule := range expandSynonymRules(o.(*schema.Set).List()) {
+		oldRules[rule.ID] = rule.Synonyms
+	}
+	newRules := make(map[string]string)
+	for _, rule := range expandSynonymRules(n.(*schema.Set).List()) {
+		newRules[rule.ID] = rule.Synonyms
+	}
+
+	for ruleID, synonyms := range newRules {
+		if previous, ok := oldRules[ruleID]; ok && previous == synonyms {
+			continue
+		}
+		path, err := synonymsSetPath(id, "/"+url.PathEscape(ruleID))
+		if err != nil {
+			return err
+		}
+		body := map[string]interface{}{
+			"synonyms": synonyms,
+		}
+		if _, err := elasticsearchPerformRequest(meta, "PUT", path, body); err != nil {
+			return fmt.Errorf("error updating rule %s of synonyms set %s: %+v", ruleID, id, err)
+		}
+	}
+	for ruleID := range oldRules {
+		if _, ok := newRules[ruleID]; ok {
+			continue
+		}
+		path, err := synonymsSetPath(id, "/"+url.PathEscape(ruleID))
+		if err != nil {
+			return err
+		}
+		if _, err := elasticsearchPerformRequest(meta, "DELETE", path, nil); err != nil {
+			return fmt.Errorf("error deleting rule %s of synonyms set %s: %+v", ruleID, id, err)
+		}
+	}
+
+	return resourceElasticsearchSynonymsSetRead(d, meta)
+}
+
+func resourceElasticsearchSynonymsSetDelete(d *schema.ResourceData, meta interface{}) error {
+	if err := elastic7CheckSynonymsSetVersion(meta); err != nil {
+		return err
+	}
+
+	path, err := synonymsSetPath(d.Id(), "")
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "DELETE", path, nil); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func expandSynonymRules(configured []interface{}) []SynonymRule {
+	rules := make([]SynonymRule, 0, len(configured))
+	for _, r := range configured {
+		rule := r.(map[string]interface{})
+		rules = append(rules, SynonymRule{
+			ID:       rule["id"].(string),
+			Synonyms: rule["synonyms"].(string),
+		})
+	}
+	return rules
+}
+
+// elasticsearchGetSynonymRules returns all the rules of the synonyms set,
+// which are paginated
+func elasticsearchGetSynonymRules(meta interface{}, name string) ([]SynonymRule, error) {
+	path, err := synonymsSetPath(name, "")
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]SynonymRule, 0)
+	for {
+		body, err := elasticsearchPerformRequest(meta, "GET", fmt.Sprintf("%s?from=%d&size=%d", path, len(rules), synonymsSetPageSize), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		response := new(SynonymsSetGetResponse)
+		if err := json.Unmarshal(body, response); err != nil {
+			return nil, fmt.Errorf("error unmarshalling synonyms set body: %+v: %+v", err, body)
+		}
+
+		rules = append(rules, response.SynonymsSet...)
+		if len(response.SynonymsSet) == 0 || len(rules) >= response.Count {
+			return rules, nil
+		}
+	}
+}
+
+func elastic7CheckSynonymsSetVersion(meta interface{}) error {
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+
+	client, ok := esClient.(*elastic7.Client)
+	if !ok {
+		return fmt.Errorf("synonyms sets only available from ElasticSearch >= 8.10, got version < 7.0.0")
+	}
+
+	elasticVersion, err := elastic7GetVersion(client)
+	if err != nil {
+		return err
+	}
+	if elasticVersion.LessThan(synonymsSetMinimalVersion) {
+		return fmt.Errorf("synonyms sets only available from ElasticSearch >= 8.10, got version %s", elasticVersion.String())
+	}
+	return nil
+}
+
+func synonymsSetPath(name string, suffix string) (string, error) {
+	path, err := uritemplates.Expand("/_synonyms/{name}", map[string]string{
+		"name": name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for synonyms set: %+v", err)
+	}
+	return path + suffix, nil
+}
+
+type SynonymsSetGetResponse struct {
+	Count       int           `json:"count"`
+	SynonymsSet []SynonymRule `json:"synonyms_set"`
+}
+
+type SynonymRule struct {
+	ID       string `json:"id"`
+	Synonyms string `json:"synonyms"`
+}
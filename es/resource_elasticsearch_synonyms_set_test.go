@@ -0,0 +1,125 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchSynonymsSet(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		elasticVersion, err := elastic7GetVersion(client)
+		if err != nil {
+			t.Skipf("err: %s", err)
+		}
+		allowed = !elasticVersion.LessThan(synonymsSetMinimalVersion)
+	default:
+		allowed = false
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Synonyms sets only supported on ES >= 8.10")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchSynonymsSetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchSynonymsSet("hello, hi"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchSynonymsSetExists("elasticsearch_synonyms_set.test"),
+					resource.TestCheckResourceAttr("elasticsearch_synonyms_set.test", "rule.#", "2"),
+				),
+			},
+			{
+				Config: testAccElasticsearchSynonymsSet("hello, hi, howdy"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchSynonymsSetExists("elasticsearch_synonyms_set.test"),
+					resource.TestCheckResourceAttr("elasticsearch_synonyms_set.test", "rule.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_synonyms_set.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchSynonymsSetExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No synonyms set ID is set")
+		}
+
+		path, err := synonymsSetPath(rs.Primary.ID, "")
+		if err != nil {
+			return err
+		}
+		_, err = elasticsearchPerformRequest(testAccXPackProvider.Meta(), "GET", path, nil)
+		return err
+	}
+}
+
+func testCheckElasticsearchSynonymsSetDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_synonyms_set" {
+			continue
+		}
+
+		path, err := synonymsSetPath(rs.Primary.ID, "")
+		if err != nil {
+			return err
+		}
+		if _, err := elasticsearchPerformRequest(testAccXPackProvider.Meta(), "GET", path, nil); err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Synonyms set %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchSynonymsSet(greetings string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_synonyms_set" "test" {
+  name = "terraform-test"
+
+  rule {
+    id       = "greetings"
+    synonyms = "%s"
+  }
+
+  rule {
+    id       = "universe"
+    synonyms = "universe => cosmos"
+  }
+}
+`, greetings)
+}
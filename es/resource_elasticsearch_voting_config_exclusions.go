@@ -0,0 +1,233 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+var votingConfigExclusionsNodeNamesMinimalVersion, _ = version.NewVersion("7.8.0")
+
+func resourceElasticsearchVotingConfigExclusions() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceElasticsearchVotingConfigExclusionsCreate,
+		Read:   resourceElasticsearchVotingConfigExclusionsRead,
+		Update: resourceElasticsearchVotingConfigExclusionsUpdate,
+		Delete: resourceElasticsearchVotingConfigExclusionsDelete,
+		Schema: map[string]*schema.Schema{
+			"node_names": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the master-eligible nodes to exclude from the voting configuration.",
+			},
+			"timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateElasticsearchDuration,
+				Description:  "How long to wait for the nodes to be removed from the voting configuration, e.g. `30s`.",
+			},
+			"wait_for_removal": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether destroying the resource waits for the excluded nodes to leave the cluster before clearing the exclusions, defaults `true`.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: resourceElasticsearchVotingConfigExclusionsImport,
+		},
+		Description: "Voting configuration exclusions remove master-eligible nodes from the voting configuration, they need to be added before removing master-eligible nodes from a cluster. As the API only supports clearing all exclusions, destroying this resource clears every exclusion in the cluster.",
+	}
+}
+
+func resourceElasticsearchVotingConfigExclusionsCreate(d *schema.ResourceData, meta interface{}) error {
+	nodeNames := expandStringList(d.Get("node_names").(*schema.Set).List())
+	sort.Strings(nodeNames)
+
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		err = elastic7PostVotingConfigExclusions(meta, client, nodeNames, d.Get("timeout").(string))
+	default:
+		err = fmt.Errorf("voting_config_exclusions endpoint only available from ElasticSearch >= 7.0, got version < 7.0.0")
+	}
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strings.Join(nodeNames, ","))
+	return resourceElasticsearchVotingConfigExclusionsRead(d, meta)
+}
+
+func resourceElasticsearchVotingConfigExclusionsRead(d *schema.ResourceData, meta interface{}) error {
+	var exclusions []VotingConfigExclusion
+
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		exclusions, err = elastic7GetVotingConfigExclusions(client)
+	default:
+		err = fmt.Errorf("voting_config_exclusions endpoint only available from ElasticSearch >= 7.0, got version < 7.0.0")
+	}
+	if err != nil {
+		return err
+	}
+
+	excluded := make(map[string]bool, len(exclusions))
+	for _, exclusion := range exclusions {
+		excluded[exclusion.NodeName] = true
+	}
+
+	nodeNames := make([]string, 0)
+	for _, name := range strings.Split(d.Id(), ",") {
+		if excluded[name] {
+			nodeNames = append(nodeNames, name)
+		}
+	}
+
+	if len(nodeNames) == 0 {
+		log.Printf("[WARN] Voting config exclusions (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("node_names", nodeNames)
+	return ds.err
+}
+
+func resourceElasticsearchVotingConfigExclusionsUpdate(d *schema.ResourceData, meta interface{}) error {
+	// Only wait_for_removal can change in place, which is used on destroy
+	return resourceElasticsearchVotingConfigExclusionsRead(d, meta)
+}
+
+func resourceElasticsearchVotingConfigExclusionsDelete(d *schema.ResourceData, meta interface{}) error {
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		err = elastic7DeleteVotingConfigExclusions(client, d.Get("wait_for_removal").(bool))
+	default:
+		err = fmt.Errorf("voting_config_exclusions endpoint only available from ElasticSearch >= 7.0, got version < 7.0.0")
+	}
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceElasticsearchVotingConfigExclusionsImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	nodeNames := strings.Split(d.Id(), ",")
+	sort.Strings(nodeNames)
+	d.SetId(strings.Join(nodeNames, ","))
+	if err := d.Set("wait_for_removal", true); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func elastic7PostVotingConfigExclusions(meta interface{}, client *elastic7.Client, nodeNames []string, timeout string) error {
+	elasticVersion, err := elastic7GetCompatibleVersion(meta, client)
+	if err != nil {
+		return err
+	}
+
+	path := "/_cluster/voting_config_exclusions"
+	params := url.Values{}
+	if elasticVersion.LessThan(votingConfigExclusionsNodeNamesMinimalVersion) {
+		// Prior to 7.8 the nodes are given as a path parameter
+		path, err = uritemplates.Expand("/_cluster/voting_config_exclusions/{node_name}", map[string]string{
+			"node_name": strings.Join(nodeNames, ","),
+		})
+		if err != nil {
+			return fmt.Errorf("error building URL path for voting config exclusions: %+v", err)
+		}
+	} else {
+		params.Set("node_names", strings.Join(nodeNames, ","))
+	}
+	if timeout != "" {
+		params.Set("timeout", timeout)
+	}
+
+	_, err = client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+		Method: "POST",
+		Path:   path,
+		Params: params,
+	})
+	return err
+}
+
+func elastic7GetVotingConfigExclusions(client *elastic7.Client) ([]VotingConfigExclusion, error) {
+	res, err := client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+		Method: "GET",
+		Path:   "/_cluster/state/metadata",
+		Params: url.Values{
+			"filter_path": []string{"metadata.cluster_coordination.voting_config_exclusions"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	state := new(VotingConfigExclusionsClusterState)
+	if err := json.Unmarshal(res.Body, state); err != nil {
+		return nil, fmt.Errorf("error unmarshalling cluster state body: %+v: %+v", err, res.Body)
+	}
+
+	return state.Metadata.ClusterCoordination.VotingConfigExclusions, nil
+}
+
+func elastic7DeleteVotingConfigExclusions(client *elastic7.Client, waitForRemoval bool) error {
+	params := url.Values{}
+	if !waitForRemoval {
+		params.Set("wait_for_removal", "false")
+	}
+
+	_, err := client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+		Method: "DELETE",
+		Path:   "/_cluster/voting_config_exclusions",
+		Params: params,
+	})
+	return err
+}
+
+type VotingConfigExclusion struct {
+	NodeID   string `json:"node_id"`
+	NodeName string `json:"node_name"`
+}
+
+type VotingConfigExclusionsClusterState struct {
+	Metadata struct {
+		ClusterCoordination struct {
+			VotingConfigExclusions []VotingConfigExclusion `json:"voting_config_exclusions"`
+		} `json:"cluster_coordination"`
+	} `json:"metadata"`
+}
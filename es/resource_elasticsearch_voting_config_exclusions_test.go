@@ -0,0 +1,128 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func TestAccElasticsearchVotingConfigExclusions(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		// excluding nodes which are absent from the cluster needs >= 7.8
+		elasticVersion, err := elastic7GetVersion(client)
+		if err != nil {
+			t.Skipf("err: %s", err)
+		}
+		allowed = !elasticVersion.LessThan(votingConfigExclusionsNodeNamesMinimalVersion)
+	default:
+		allowed = false
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Voting config exclusions of absent nodes only supported on ES >= 7.8")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckElasticsearchVotingConfigExclusionsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchVotingConfigExclusions,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchVotingConfigExclusionsExists("elasticsearch_voting_config_exclusions.test"),
+					resource.TestCheckResourceAttr("elasticsearch_voting_config_exclusions.test", "id", "absent-master-1,absent-master-2"),
+					resource.TestCheckResourceAttr("elasticsearch_voting_config_exclusions.test", "node_names.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchVotingConfigExclusionsExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No voting config exclusions ID is set")
+		}
+
+		meta := testAccProvider.Meta()
+
+		var exclusions []VotingConfigExclusion
+		esClient, err := getClient(meta.(*ProviderConf))
+		if err != nil {
+			return err
+		}
+		switch client := esClient.(type) {
+		case *elastic7.Client:
+			exclusions, err = elastic7GetVotingConfigExclusions(client)
+		default:
+		}
+
+		if err != nil {
+			return err
+		}
+		if len(exclusions) != 2 {
+			return fmt.Errorf("expected 2 voting config exclusions, got %+v", exclusions)
+		}
+
+		return nil
+	}
+}
+
+func testCheckElasticsearchVotingConfigExclusionsDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_voting_config_exclusions" {
+			continue
+		}
+
+		meta := testAccProvider.Meta()
+
+		var exclusions []VotingConfigExclusion
+		esClient, err := getClient(meta.(*ProviderConf))
+		if err != nil {
+			return err
+		}
+		switch client := esClient.(type) {
+		case *elastic7.Client:
+			exclusions, err = elastic7GetVotingConfigExclusions(client)
+		default:
+		}
+
+		if err != nil {
+			return err
+		}
+		if len(exclusions) > 0 {
+			return fmt.Errorf("Voting config exclusions %q still exist", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+var testAccElasticsearchVotingConfigExclusions = `
+resource "elasticsearch_voting_config_exclusions" "test" {
+  node_names       = ["absent-master-2", "absent-master-1"]
+  wait_for_removal = false
+}
+`
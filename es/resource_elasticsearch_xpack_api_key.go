@@ -0,0 +1,257 @@
+package es
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+func resourceElasticsearchXpackApiKey() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an Elasticsearch XPack API key, with the privileges of the user of the provider, optionally restricted by role descriptors. The key is only returned when it is created, and API keys can't be updated, so changing them creates a new key and invalidates the previous one. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/security-api-create-api-key.html) for more details.",
+		Create:      resourceElasticsearchXpackApiKeyCreate,
+		Read:        resourceElasticsearchXpackApiKeyRead,
+		Update:      resourceElasticsearchXpackApiKeyUpdate,
+		Delete:      resourceElasticsearchXpackApiKeyDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Name of the API key.",
+			},
+			"role_descriptors": {
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJson,
+				Description:      "A JSON string of the roles of the API key, keyed by name, restricting the privileges of the user, e.g. `{\"read-logs\": {\"indices\": [{\"names\": [\"logs-*\"], \"privileges\": [\"read\"]}]}}`. The key has all the privileges of the user if not set.",
+			},
+			"expiration": {
+				Type:         schema.TypeString,
+				ForceNew:     true,
+				Optional:     true,
+				ValidateFunc: validateElasticsearchDuration,
+				Description:  "The time after which the API key expires, e.g. `30d`. The key doesn't expire if not set.",
+			},
+			"force_recreate_before_expiry": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateElasticsearchDuration,
+				Description:  "Replaces the API key when it expires within this duration, e.g. `7d`, so a new key is available before the current one expires. Expired keys are always replaced.",
+			},
+			"metadata": {
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJson,
+				Description:      "A JSON string of arbitrary metadata of the API key, requires ElasticSearch >= 7.13.",
+			},
+			"api_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The secret of the API key.",
+			},
+			"encoded": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The base64 encoding of `id:api_key`, used in the `Authorization: ApiKey` header.",
+			},
+			"expiration_timestamp": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the API key expires, in RFC 3339 format.",
+			},
+		},
+		CustomizeDiff: resourceElasticsearchXpackApiKeyCustomizeDiff,
+	}
+}
+
+func resourceElasticsearchXpackApiKeyCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	expiration := d.Get("expiration_timestamp").(string)
+	if d.Id() == "" || expiration == "" {
+		return nil
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, expiration)
+	if err != nil {
+		return err
+	}
+	var before time.Duration
+	if value, ok := d.GetOk("force_recreate_before_expiry"); ok {
+		if before, err = parseElasticsearchDuration(value.(string)); err != nil {
+			return err
+		}
+	}
+	if time.Until(expiresAt) > before {
+		return nil
+	}
+
+	log.Printf("[INFO] API key %s expires at %s, replacing it", d.Id(), expiration)
+	if err := d.SetNewComputed("api_key"); err != nil {
+		return err
+	}
+	return d.ForceNew("api_key")
+}
+
+func resourceElasticsearchXpackApiKeyCreate(d *schema.ResourceData, meta interface{}) error {
+	if err := elasticsearchCheckApiKeySupported(meta); err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"name": d.Get("name").(string),
+	}
+	if roleDescriptors, ok := d.GetOk("role_descriptors"); ok {
+		body["role_descriptors"] = json.RawMessage(roleDescriptors.(string))
+	}
+	if expiration, ok := d.GetOk("expiration"); ok {
+		body["expiration"] = expiration.(string)
+	}
+	if metadata, ok := d.GetOk("metadata"); ok {
+		body["metadata"] = json.RawMessage(metadata.(string))
+	}
+
+	res, err := elasticsearchPerformRequest(meta, "POST", "/_security/api_key", body)
+	if err != nil {
+		return err
+	}
+
+	response := new(ApiKeyCreateResponse)
+	if err := json.Unmarshal(res, response); err != nil {
+		return fmt.Errorf("error unmarshalling API key body: %+v", err)
+	}
+
+	// the encoded key is only returned from ElasticSearch 7.16
+	encoded := response.Encoded
+	if encoded == "" {
+		encoded = base64.StdEncoding.EncodeToString([]byte(response.ID + ":" + response.ApiKey))
+	}
+
+	d.SetId(response.ID)
+	ds := &resourceDataSetter{d: d}
+	ds.set("api_key", response.ApiKey)
+	ds.set("encoded", encoded)
+	if ds.err != nil {
+		return ds.err
+	}
+
+	return resourceElasticsearchXpackApiKeyRead(d, meta)
+}
+
+func resourceElasticsearchXpackApiKeyRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	if err := elasticsearchCheckApiKeySupported(meta); err != nil {
+		return err
+	}
+
+	apiKey, err := elasticsearchGetApiKey(meta, id)
+	if err != nil {
+		if elastic7.IsNotFound(err) || elastic6.IsNotFound(err) {
+			log.Printf("[WARN] API key (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	if apiKey == nil || apiKey.Invalidated {
+		log.Printf("[WARN] API key (%s) not found or invalidated, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("name", apiKey.Name)
+	ds.set("expiration_timestamp", formatSnapshotTime(apiKey.Expiration))
+	return ds.err
+}
+
+// only force_recreate_before_expiry can be updated, which is only used when
+// planning
+func resourceElasticsearchXpackApiKeyUpdate(d *schema.ResourceData, meta interface{}) error {
+	return resourceElasticsearchXpackApiKeyRead(d, meta)
+}
+
+func resourceElasticsearchXpackApiKeyDelete(d *schema.ResourceData, meta interface{}) error {
+	if err := elasticsearchCheckApiKeySupported(meta); err != nil {
+		return err
+	}
+
+	// invalidated keys are kept by the cluster for a while, but can't be used
+	body := map[string]interface{}{
+		"ids": []string{d.Id()},
+	}
+	if _, err := elasticsearchPerformRequest(meta, "DELETE", "/_security/api_key", body); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func elasticsearchGetApiKey(meta interface{}, id string) (*ApiKey, error) {
+	body, err := elasticsearchPerformRequest(meta, "GET", "/_security/api_key?id="+url.QueryEscape(id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(ApiKeyGetResponse)
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling API key body: %+v: %+v", err, body)
+	}
+
+	for _, apiKey := range response.ApiKeys {
+		if apiKey.ID == id {
+			return &apiKey, nil
+		}
+	}
+	return nil, nil
+}
+
+func elasticsearchCheckApiKeySupported(meta interface{}) error {
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	if _, ok := esClient.(*elastic5.Client); ok {
+		return errors.New("API keys are only supported by the elastic library >= v6!")
+	}
+	return nil
+}
+
+type ApiKeyCreateResponse struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	ApiKey  string `json:"api_key"`
+	Encoded string `json:"encoded"`
+}
+
+type ApiKeyGetResponse struct {
+	ApiKeys []ApiKey `json:"api_keys"`
+}
+
+type ApiKey struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Creation    int64  `json:"creation"`
+	Expiration  int64  `json:"expiration"`
+	Invalidated bool   `json:"invalidated"`
+	Username    string `json:"username"`
+	Realm       string `json:"realm"`
+}
@@ -0,0 +1,113 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic5 "gopkg.in/olivere/elastic.v5"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchXpackApiKey(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	_, isElastic5 := esClient.(*elastic5.Client)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if isElastic5 {
+				t.Skip("API keys only supported on ES >= 6")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchXpackApiKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchXpackApiKey(""),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackApiKeyExists("elasticsearch_xpack_api_key.test"),
+					resource.TestCheckResourceAttrSet("elasticsearch_xpack_api_key.test", "api_key"),
+					resource.TestCheckResourceAttrSet("elasticsearch_xpack_api_key.test", "encoded"),
+					resource.TestCheckResourceAttrSet("elasticsearch_xpack_api_key.test", "expiration_timestamp"),
+				),
+			},
+			{
+				// the key expires within the duration, so it is always replaced
+				Config: testAccElasticsearchXpackApiKey(`force_recreate_before_expiry = "2d"`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackApiKeyExists("elasticsearch_xpack_api_key.test"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchXpackApiKeyExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No API key ID is set")
+		}
+
+		apiKey, err := elasticsearchGetApiKey(testAccXPackProvider.Meta(), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if apiKey == nil || apiKey.Invalidated {
+			return fmt.Errorf("API key %q not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testCheckElasticsearchXpackApiKeyDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_xpack_api_key" {
+			continue
+		}
+
+		apiKey, err := elasticsearchGetApiKey(testAccXPackProvider.Meta(), rs.Primary.ID)
+		if err != nil || apiKey == nil || apiKey.Invalidated {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("API key %q is still valid", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchXpackApiKey(extra string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_xpack_api_key" "test" {
+  name       = "terraform-test"
+  expiration = "1d"
+  role_descriptors = jsonencode({
+    "read-logs" = {
+      indices = [{
+        names      = ["logs-*"]
+        privileges = ["read"]
+      }]
+    }
+  })
+  %s
+}
+`, extra)
+}
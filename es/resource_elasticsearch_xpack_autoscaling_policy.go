@@ -0,0 +1,168 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+var autoscalingPolicyMinimalVersion, _ = version.NewVersion("7.11.0")
+
+func resourceElasticsearchXpackAutoscalingPolicy() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an Elasticsearch XPack autoscaling policy, the deciders of a policy compute the capacity required by the nodes with its roles, used by orchestrators such as ECK or Elastic Cloud to size the nodes. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/autoscaling-put-autoscaling-policy.html) for more details.",
+		Create:      resourceElasticsearchXpackAutoscalingPolicyPut,
+		Read:        resourceElasticsearchXpackAutoscalingPolicyRead,
+		Update:      resourceElasticsearchXpackAutoscalingPolicyPut,
+		Delete:      resourceElasticsearchXpackAutoscalingPolicyDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Name of the autoscaling policy.",
+			},
+			"roles": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "The node roles the policy applies to, e.g. `data_hot` and `data_content`. The roles of policies can't overlap.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"deciders": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "{}",
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJson,
+				Description:      "A JSON string of the deciders of the policy, keyed by name, with their settings, e.g. `{\"fixed\": {\"nodes\": 1}}`. The default deciders of the roles are used if not set.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchXpackAutoscalingPolicyPut(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+
+	if err := elastic7CheckAutoscalingPolicyVersion(meta); err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"roles":    expandStringList(d.Get("roles").(*schema.Set).List()),
+		"deciders": json.RawMessage(d.Get("deciders").(string)),
+	}
+
+	path, err := autoscalingPolicyPath(name)
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "PUT", path, body); err != nil {
+		return err
+	}
+
+	d.SetId(name)
+	return resourceElasticsearchXpackAutoscalingPolicyRead(d, meta)
+}
+
+func resourceElasticsearchXpackAutoscalingPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	if err := elastic7CheckAutoscalingPolicyVersion(meta); err != nil {
+		return err
+	}
+
+	path, err := autoscalingPolicyPath(id)
+	if err != nil {
+		return err
+	}
+	body, err := elasticsearchPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Autoscaling policy (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	policy := new(AutoscalingPolicy)
+	if err := json.Unmarshal(body, policy); err != nil {
+		return fmt.Errorf("error unmarshalling autoscaling policy body: %+v: %+v", err, body)
+	}
+
+	deciders := "{}"
+	if len(policy.Deciders) > 0 {
+		deciders = string(policy.Deciders)
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("name", id)
+	ds.set("roles", policy.Roles)
+	ds.set("deciders", deciders)
+	return ds.err
+}
+
+func resourceElasticsearchXpackAutoscalingPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	if err := elastic7CheckAutoscalingPolicyVersion(meta); err != nil {
+		return err
+	}
+
+	path, err := autoscalingPolicyPath(d.Id())
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "DELETE", path, nil); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func elastic7CheckAutoscalingPolicyVersion(meta interface{}) error {
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+
+	client, ok := esClient.(*elastic7.Client)
+	if !ok {
+		return fmt.Errorf("autoscaling policies only available from ElasticSearch >= 7.11, got version < 7.0.0")
+	}
+
+	elasticVersion, err := elastic7GetVersion(client)
+	if err != nil {
+		return err
+	}
+	if elasticVersion.LessThan(autoscalingPolicyMinimalVersion) {
+		return fmt.Errorf("autoscaling policies only available from ElasticSearch >= 7.11, got version %s", elasticVersion.String())
+	}
+	return nil
+}
+
+func autoscalingPolicyPath(name string) (string, error) {
+	path, err := uritemplates.Expand("/_autoscaling/policy/{name}", map[string]string{
+		"name": name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for autoscaling policy: %+v", err)
+	}
+	return path, nil
+}
+
+type AutoscalingPolicy struct {
+	Roles    []string        `json:"roles"`
+	Deciders json.RawMessage `json:"deciders"`
+}
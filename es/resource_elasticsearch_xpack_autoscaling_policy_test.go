@@ -0,0 +1,121 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchXpackAutoscalingPolicy(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		elasticVersion, err := elastic7GetVersion(client)
+		if err != nil {
+			t.Skipf("err: %s", err)
+		}
+		allowed = !elasticVersion.LessThan(autoscalingPolicyMinimalVersion)
+	default:
+		allowed = false
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Autoscaling policies only supported on ES >= 7.11")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchXpackAutoscalingPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchXpackAutoscalingPolicy("1gb"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackAutoscalingPolicyExists("elasticsearch_xpack_autoscaling_policy.test"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_autoscaling_policy.test", "roles.#", "0"),
+				),
+			},
+			{
+				Config: testAccElasticsearchXpackAutoscalingPolicy("2gb"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackAutoscalingPolicyExists("elasticsearch_xpack_autoscaling_policy.test"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_autoscaling_policy.test", "deciders", `{"fixed":{"storage":"2gb"}}`),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_xpack_autoscaling_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchXpackAutoscalingPolicyExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No autoscaling policy ID is set")
+		}
+
+		path, err := autoscalingPolicyPath(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		_, err = elasticsearchPerformRequest(testAccXPackProvider.Meta(), "GET", path, nil)
+		return err
+	}
+}
+
+func testCheckElasticsearchXpackAutoscalingPolicyDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_xpack_autoscaling_policy" {
+			continue
+		}
+
+		path, err := autoscalingPolicyPath(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if _, err := elasticsearchPerformRequest(testAccXPackProvider.Meta(), "GET", path, nil); err != nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Autoscaling policy %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchXpackAutoscalingPolicy(storage string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_xpack_autoscaling_policy" "test" {
+  name  = "terraform-test"
+  roles = []
+  deciders = jsonencode({
+    fixed = {
+      storage = "%s"
+    }
+  })
+}
+`, storage)
+}
@@ -0,0 +1,220 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+func resourceElasticsearchXpackCcrAutoFollowPattern() *schema.Resource {
+	patternSchema := map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			ForceNew:    true,
+			Required:    true,
+			Description: "Name of the auto-follow pattern.",
+		},
+		"remote_cluster": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of the remote cluster of the leader indices.",
+		},
+		"leader_index_patterns": {
+			Type:        schema.TypeSet,
+			Required:    true,
+			MinItems:    1,
+			Description: "The wildcard expressions of the leader indices to follow.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"leader_index_exclusion_patterns": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "The wildcard expressions of the leader indices not to follow, requires ElasticSearch >= 7.14.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"follow_index_pattern": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "{{leader_index}}",
+			Description: "The name of the follower indices, `{{leader_index}}` is replaced by the name of the leader index, e.g. `{{leader_index}}-follower`.",
+		},
+	}
+	for parameter, description := range ccrFollowIntParameters {
+		patternSchema[parameter] = &schema.Schema{
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Computed:    true,
+			Description: description,
+		}
+	}
+	for parameter, description := range ccrFollowStringParameters {
+		patternSchema[parameter] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: description,
+		}
+	}
+
+	return &schema.Resource{
+		Description: "Provides an Elasticsearch XPack cross-cluster replication auto-follow pattern, creating follower indices for the new indices of a remote cluster matching the patterns. Existing indices are not followed. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/ccr-put-auto-follow-pattern.html) for more details.",
+		Create:      resourceElasticsearchXpackCcrAutoFollowPatternPut,
+		Read:        resourceElasticsearchXpackCcrAutoFollowPatternRead,
+		Update:      resourceElasticsearchXpackCcrAutoFollowPatternPut,
+		Delete:      resourceElasticsearchXpackCcrAutoFollowPatternDelete,
+		Schema:      patternSchema,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchXpackCcrAutoFollowPatternPut(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+
+	// the whole pattern is replaced, followers already created are kept
+	body := expandCcrFollowParameters(d)
+	body["remote_cluster"] = d.Get("remote_cluster").(string)
+	body["leader_index_patterns"] = expandStringList(d.Get("leader_index_patterns").(*schema.Set).List())
+	body["follow_index_pattern"] = d.Get("follow_index_pattern").(string)
+	if exclusions := d.Get("leader_index_exclusion_patterns").(*schema.Set); exclusions.Len() > 0 {
+		body["leader_index_exclusion_patterns"] = expandStringList(exclusions.List())
+	}
+
+	path, err := ccrAutoFollowPatternPath(meta, name)
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "PUT", path, body); err != nil {
+		return err
+	}
+
+	d.SetId(name)
+	return resourceElasticsearchXpackCcrAutoFollowPatternRead(d, meta)
+}
+
+func resourceElasticsearchXpackCcrAutoFollowPatternRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	pattern, err := elasticsearchGetCcrAutoFollowPattern(meta, id)
+	if err != nil {
+		if elastic7.IsNotFound(err) || elastic6.IsNotFound(err) {
+			log.Printf("[WARN] Auto-follow pattern (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	if pattern == nil {
+		log.Printf("[WARN] Auto-follow pattern (%s) not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("name", id)
+	ds.set("remote_cluster", pattern.RemoteCluster)
+	ds.set("leader_index_patterns", pattern.LeaderIndexPatterns)
+	ds.set("leader_index_exclusion_patterns", pattern.LeaderIndexExclusionPatterns)
+	ds.set("follow_index_pattern", pattern.FollowIndexPattern)
+	// only the configured parameters are returned
+	for parameter := range ccrFollowIntParameters {
+		if value, ok := pattern.Parameters[parameter].(float64); ok {
+			ds.set(parameter, int(value))
+		}
+	}
+	for parameter := range ccrFollowStringParameters {
+		if value, ok := pattern.Parameters[parameter].(string); ok {
+			ds.set(parameter, value)
+		}
+	}
+	return ds.err
+}
+
+func resourceElasticsearchXpackCcrAutoFollowPatternDelete(d *schema.ResourceData, meta interface{}) error {
+	path, err := ccrAutoFollowPatternPath(meta, d.Id())
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "DELETE", path, nil); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func elasticsearchGetCcrAutoFollowPattern(meta interface{}, name string) (*CcrAutoFollowPatternBody, error) {
+	path, err := ccrAutoFollowPatternPath(meta, name)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := elasticsearchPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(CcrAutoFollowPatternGetResponse)
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling auto-follow pattern body: %+v: %+v", err, body)
+	}
+
+	for _, p := range response.Patterns {
+		if p.Name != name {
+			continue
+		}
+
+		// the parameters are returned alongside the other fields
+		pattern := new(CcrAutoFollowPatternBody)
+		if err := json.Unmarshal(p.Pattern, pattern); err != nil {
+			return nil, fmt.Errorf("error unmarshalling auto-follow pattern body: %+v: %+v", err, p.Pattern)
+		}
+		if err := json.Unmarshal(p.Pattern, &pattern.Parameters); err != nil {
+			return nil, fmt.Errorf("error unmarshalling auto-follow pattern body: %+v: %+v", err, p.Pattern)
+		}
+		return pattern, nil
+	}
+	return nil, nil
+}
+
+func ccrAutoFollowPatternPath(meta interface{}, name string) (string, error) {
+	if err := elasticsearchCheckCcrSupported(meta); err != nil {
+		return "", err
+	}
+
+	path, err := uritemplates.Expand("/_ccr/auto_follow/{name}", map[string]string{
+		"name": name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for auto-follow pattern: %+v", err)
+	}
+	return path, nil
+}
+
+type CcrAutoFollowPatternGetResponse struct {
+	Patterns []CcrAutoFollowPattern `json:"patterns"`
+}
+
+type CcrAutoFollowPattern struct {
+	Name    string          `json:"name"`
+	Pattern json.RawMessage `json:"pattern"`
+}
+
+type CcrAutoFollowPatternBody struct {
+	RemoteCluster                string                 `json:"remote_cluster"`
+	LeaderIndexPatterns          []string               `json:"leader_index_patterns"`
+	LeaderIndexExclusionPatterns []string               `json:"leader_index_exclusion_patterns"`
+	FollowIndexPattern           string                 `json:"follow_index_pattern"`
+	Parameters                   map[string]interface{} `json:"-"`
+}
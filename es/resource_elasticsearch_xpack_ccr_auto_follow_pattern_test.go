@@ -0,0 +1,119 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic5 "gopkg.in/olivere/elastic.v5"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchXpackCcrAutoFollowPattern(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	_, isElastic5 := esClient.(*elastic5.Client)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if isElastic5 {
+				t.Skip("Cross-cluster replication only supported on ES >= 6")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchXpackCcrAutoFollowPatternDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchXpackCcrAutoFollowPattern("{{leader_index}}-follower"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackCcrAutoFollowPatternExists("elasticsearch_xpack_ccr_auto_follow_pattern.test"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ccr_auto_follow_pattern.test", "follow_index_pattern", "{{leader_index}}-follower"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ccr_auto_follow_pattern.test", "leader_index_patterns.#", "1"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ccr_auto_follow_pattern.test", "max_read_request_operation_count", "1024"),
+				),
+			},
+			{
+				Config: testAccElasticsearchXpackCcrAutoFollowPattern("{{leader_index}}-replica"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackCcrAutoFollowPatternExists("elasticsearch_xpack_ccr_auto_follow_pattern.test"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ccr_auto_follow_pattern.test", "follow_index_pattern", "{{leader_index}}-replica"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_xpack_ccr_auto_follow_pattern.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchXpackCcrAutoFollowPatternExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No auto-follow pattern ID is set")
+		}
+
+		pattern, err := elasticsearchGetCcrAutoFollowPattern(testAccXPackProvider.Meta(), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if pattern == nil {
+			return fmt.Errorf("Auto-follow pattern %q not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testCheckElasticsearchXpackCcrAutoFollowPatternDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_xpack_ccr_auto_follow_pattern" {
+			continue
+		}
+
+		pattern, err := elasticsearchGetCcrAutoFollowPattern(testAccXPackProvider.Meta(), rs.Primary.ID)
+		if err != nil || pattern == nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Auto-follow pattern %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchXpackCcrAutoFollowPattern(followIndexPattern string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_cluster_settings" "test" {
+  persistent = {
+    "cluster.remote.terraform-test.seeds" = "127.0.0.1:9300"
+  }
+}
+
+resource "elasticsearch_xpack_ccr_auto_follow_pattern" "test" {
+  name                             = "terraform-test"
+  remote_cluster                   = "terraform-test"
+  leader_index_patterns            = ["terraform-test-leader-*"]
+  follow_index_pattern             = "%s"
+  max_read_request_operation_count = 1024
+
+  depends_on = [elasticsearch_cluster_settings.test]
+}
+`, followIndexPattern)
+}
@@ -0,0 +1,260 @@
+package es
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+// the parameters of the replication of follower indices, which are read back
+// with their defaults
+var ccrFollowIntParameters = map[string]string{
+	"max_read_request_operation_count":  "The maximum number of operations pulled per read from the leader.",
+	"max_outstanding_read_requests":     "The maximum number of outstanding reads from the leader.",
+	"max_write_request_operation_count": "The maximum number of operations per bulk write to the follower.",
+	"max_outstanding_write_requests":    "The maximum number of outstanding bulk writes to the follower.",
+	"max_write_buffer_count":            "The maximum number of operations queued for writing, reads are deferred when the limit is reached.",
+}
+
+var ccrFollowStringParameters = map[string]string{
+	"max_read_request_size":  "The maximum size of a batch of operations pulled from the leader, e.g. `32mb`.",
+	"max_write_request_size": "The maximum size of a bulk write of operations to the follower, e.g. `32mb`.",
+	"max_write_buffer_size":  "The maximum size of the operations queued for writing, reads are deferred when the limit is reached, e.g. `512mb`.",
+	"max_retry_delay":        "The maximum time to wait before retrying a failed operation, e.g. `500ms`.",
+	"read_poll_timeout":      "The maximum time to wait for new operations on the leader when the follower is caught up, e.g. `1m`.",
+}
+
+func resourceElasticsearchXpackCcrFollow() *schema.Resource {
+	followSchema := map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			ForceNew:    true,
+			Required:    true,
+			Description: "Name of the follower index.",
+		},
+		"remote_cluster": {
+			Type:        schema.TypeString,
+			ForceNew:    true,
+			Required:    true,
+			Description: "Name of the remote cluster of the leader index.",
+		},
+		"leader_index": {
+			Type:        schema.TypeString,
+			ForceNew:    true,
+			Required:    true,
+			Description: "Name of the leader index in the remote cluster.",
+		},
+	}
+	for parameter, description := range ccrFollowIntParameters {
+		followSchema[parameter] = &schema.Schema{
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Computed:    true,
+			Description: description,
+		}
+	}
+	for parameter, description := range ccrFollowStringParameters {
+		followSchema[parameter] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: description,
+		}
+	}
+
+	return &schema.Resource{
+		Description: "Provides an Elasticsearch XPack cross-cluster replication follower index, replicating a leader index of a remote cluster. Changing the replication parameters pauses and resumes the replication, destroying the resource unfollows the leader and deletes the follower index. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/ccr-put-follow.html) for more details.",
+		Create:      resourceElasticsearchXpackCcrFollowCreate,
+		Read:        resourceElasticsearchXpackCcrFollowRead,
+		Update:      resourceElasticsearchXpackCcrFollowUpdate,
+		Delete:      resourceElasticsearchXpackCcrFollowDelete,
+		Schema:      followSchema,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchXpackCcrFollowCreate(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+
+	body := expandCcrFollowParameters(d)
+	body["remote_cluster"] = d.Get("remote_cluster").(string)
+	body["leader_index"] = d.Get("leader_index").(string)
+
+	if err := elasticsearchPerformCcrFollowRequest(meta, "PUT", name, "/_ccr/follow", body); err != nil {
+		return err
+	}
+
+	d.SetId(name)
+	return resourceElasticsearchXpackCcrFollowRead(d, meta)
+}
+
+func resourceElasticsearchXpackCcrFollowRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	follower, err := elasticsearchGetCcrFollower(meta, id)
+	if err != nil {
+		if elastic7.IsNotFound(err) || elastic6.IsNotFound(err) {
+			log.Printf("[WARN] Follower index (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	if follower == nil {
+		log.Printf("[WARN] Follower index (%s) not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("name", id)
+	ds.set("remote_cluster", follower.RemoteCluster)
+	ds.set("leader_index", follower.LeaderIndex)
+	// the parameters are only returned while the replication is active
+	for parameter := range ccrFollowIntParameters {
+		if value, ok := follower.Parameters[parameter].(float64); ok {
+			ds.set(parameter, int(value))
+		}
+	}
+	for parameter := range ccrFollowStringParameters {
+		if value, ok := follower.Parameters[parameter].(string); ok {
+			ds.set(parameter, value)
+		}
+	}
+	return ds.err
+}
+
+func resourceElasticsearchXpackCcrFollowUpdate(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	// the parameters can only be changed when resuming the replication
+	if err := elasticsearchPerformCcrFollowRequest(meta, "POST", id, "/_ccr/pause_follow", nil); err != nil {
+		return err
+	}
+	if err := elasticsearchPerformCcrFollowRequest(meta, "POST", id, "/_ccr/resume_follow", expandCcrFollowParameters(d)); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchXpackCcrFollowRead(d, meta)
+}
+
+func resourceElasticsearchXpackCcrFollowDelete(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	// follower indices have to be paused and closed to be converted to
+	// regular indices, which can then be deleted
+	for _, step := range []struct {
+		method string
+		suffix string
+	}{
+		{"POST", "/_ccr/pause_follow"},
+		{"POST", "/_close"},
+		{"POST", "/_ccr/unfollow"},
+		{"DELETE", ""},
+	} {
+		if err := elasticsearchPerformCcrFollowRequest(meta, step.method, id, step.suffix, nil); err != nil {
+			return fmt.Errorf("error deleting follower index %s: %+v", id, err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// expandCcrFollowParameters returns the configured replication parameters,
+// and the ones read back from the follower index
+func expandCcrFollowParameters(d *schema.ResourceData) map[string]interface{} {
+	body := make(map[string]interface{})
+	for parameter := range ccrFollowIntParameters {
+		if value, ok := d.GetOk(parameter); ok {
+			body[parameter] = value.(int)
+		}
+	}
+	for parameter := range ccrFollowStringParameters {
+		if value, ok := d.GetOk(parameter); ok {
+			body[parameter] = value.(string)
+		}
+	}
+	return body
+}
+
+func elasticsearchGetCcrFollower(meta interface{}, name string) (*CcrFollowerIndex, error) {
+	path, err := ccrFollowPath(meta, name, "/_ccr/info")
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := elasticsearchPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(CcrFollowInfoResponse)
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling follower index info body: %+v: %+v", err, body)
+	}
+
+	for _, follower := range response.FollowerIndices {
+		if follower.FollowerIndex == name {
+			return &follower, nil
+		}
+	}
+	return nil, nil
+}
+
+func elasticsearchPerformCcrFollowRequest(meta interface{}, method string, name string, suffix string, body interface{}) error {
+	path, err := ccrFollowPath(meta, name, suffix)
+	if err != nil {
+		return err
+	}
+
+	_, err = elasticsearchPerformRequest(meta, method, path, body)
+	return err
+}
+
+func ccrFollowPath(meta interface{}, name string, suffix string) (string, error) {
+	if err := elasticsearchCheckCcrSupported(meta); err != nil {
+		return "", err
+	}
+
+	path, err := uritemplates.Expand("/{index}", map[string]string{
+		"index": name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for follower index: %+v", err)
+	}
+	return path + suffix, nil
+}
+
+func elasticsearchCheckCcrSupported(meta interface{}) error {
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	if _, ok := esClient.(*elastic5.Client); ok {
+		return errors.New("Cross-cluster replication is only supported by the elastic library >= v6!")
+	}
+	return nil
+}
+
+type CcrFollowInfoResponse struct {
+	FollowerIndices []CcrFollowerIndex `json:"follower_indices"`
+}
+
+type CcrFollowerIndex struct {
+	FollowerIndex string                 `json:"follower_index"`
+	RemoteCluster string                 `json:"remote_cluster"`
+	LeaderIndex   string                 `json:"leader_index"`
+	Status        string                 `json:"status"`
+	Parameters    map[string]interface{} `json:"parameters"`
+}
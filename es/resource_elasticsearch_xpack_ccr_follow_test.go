@@ -0,0 +1,124 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic5 "gopkg.in/olivere/elastic.v5"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchXpackCcrFollow(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	_, isElastic5 := esClient.(*elastic5.Client)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if isElastic5 {
+				t.Skip("Cross-cluster replication only supported on ES >= 6")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchXpackCcrFollowDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchXpackCcrFollow("1m"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackCcrFollowExists("elasticsearch_xpack_ccr_follow.test"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ccr_follow.test", "read_poll_timeout", "1m"),
+					resource.TestCheckResourceAttrSet("elasticsearch_xpack_ccr_follow.test", "max_read_request_size"),
+				),
+			},
+			{
+				// the replication is paused and resumed with the new parameters
+				Config: testAccElasticsearchXpackCcrFollow("30s"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackCcrFollowExists("elasticsearch_xpack_ccr_follow.test"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ccr_follow.test", "read_poll_timeout", "30s"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchXpackCcrFollowExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No follower index ID is set")
+		}
+
+		follower, err := elasticsearchGetCcrFollower(testAccXPackProvider.Meta(), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if follower == nil {
+			return fmt.Errorf("Follower index %q not found", rs.Primary.ID)
+		}
+		if follower.Status != "active" {
+			return fmt.Errorf("Follower index %s is %s, expected active", rs.Primary.ID, follower.Status)
+		}
+
+		return nil
+	}
+}
+
+func testCheckElasticsearchXpackCcrFollowDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_xpack_ccr_follow" {
+			continue
+		}
+
+		follower, err := elasticsearchGetCcrFollower(testAccXPackProvider.Meta(), rs.Primary.ID)
+		if err != nil || follower == nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Follower index %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+// the cluster follows its own index through a remote cluster connection to
+// itself
+func testAccElasticsearchXpackCcrFollow(readPollTimeout string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_cluster_settings" "test" {
+  persistent = {
+    "cluster.remote.terraform-test.seeds" = "127.0.0.1:9300"
+  }
+}
+
+resource "elasticsearch_index" "test" {
+  name               = "terraform-test-leader"
+  number_of_shards   = 1
+  number_of_replicas = 0
+}
+
+resource "elasticsearch_xpack_ccr_follow" "test" {
+  name              = "terraform-test-follower"
+  remote_cluster    = "terraform-test"
+  leader_index      = elasticsearch_index.test.name
+  read_poll_timeout = "%s"
+
+  depends_on = [elasticsearch_cluster_settings.test]
+}
+`, readPollTimeout)
+}
@@ -0,0 +1,511 @@
+package es
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic5 "gopkg.in/olivere/elastic.v5"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+// the phases of a policy, in the order indices move through them
+var ilmPolicyPhases = []string{"hot", "warm", "cold", "frozen", "delete"}
+
+// the actions allowed in each phase, the delete action itself is always part
+// of the delete phase
+var ilmPolicyPhaseActions = map[string][]string{
+	"hot":    {"rollover", "set_priority", "forcemerge", "shrink", "readonly", "searchable_snapshot", "unfollow"},
+	"warm":   {"set_priority", "allocate", "migrate", "shrink", "forcemerge", "readonly", "unfollow"},
+	"cold":   {"set_priority", "allocate", "migrate", "freeze", "readonly", "searchable_snapshot", "unfollow"},
+	"frozen": {"searchable_snapshot", "unfollow"},
+	"delete": {"wait_for_snapshot"},
+}
+
+var ilmPolicyPhaseDescriptions = map[string]string{
+	"hot":    "The hot phase, for indices which are actively written to and queried.",
+	"warm":   "The warm phase, for indices which are no longer written to but still queried.",
+	"cold":   "The cold phase, for indices which are rarely queried.",
+	"frozen": "The frozen phase, for indices which are queried very rarely, only available from ElasticSearch >= 7.12.",
+	"delete": "The delete phase, deleting indices once they reach the minimum age of the phase.",
+}
+
+func resourceElasticsearchXpackIlmPolicy() *schema.Resource {
+	policySchema := map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			ForceNew:    true,
+			Required:    true,
+			Description: "Name of the index lifecycle policy.",
+		},
+	}
+	for _, phase := range ilmPolicyPhases {
+		policySchema[phase] = ilmPolicyPhaseSchema(phase)
+	}
+
+	return &schema.Resource{
+		Description: "Provides an Elasticsearch XPack index lifecycle management policy, with the phases and their actions as blocks rather than a JSON body. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/ilm-actions.html) for the actions available in each phase.",
+		Create:      resourceElasticsearchXpackIlmPolicyCreate,
+		Read:        resourceElasticsearchXpackIlmPolicyRead,
+		Update:      resourceElasticsearchXpackIlmPolicyUpdate,
+		Delete:      resourceElasticsearchXpackIlmPolicyDelete,
+		Schema:      policySchema,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func ilmPolicyPhaseSchema(phase string) *schema.Schema {
+	actionSchemas := ilmPolicyActionSchemas()
+	phaseSchema := map[string]*schema.Schema{
+		"min_age": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "The minimum age of an index, since its creation or rollover, before it enters the phase, e.g. `30d`.",
+		},
+	}
+	for _, action := range ilmPolicyPhaseActions[phase] {
+		phaseSchema[action] = actionSchemas[action]
+	}
+	if phase == "delete" {
+		phaseSchema["delete_searchable_snapshot"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Whether to delete the searchable snapshot of the index along with the index.",
+		}
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: ilmPolicyPhaseDescriptions[phase],
+		Elem: &schema.Resource{
+			Schema: phaseSchema,
+		},
+	}
+}
+
+func ilmPolicyActionSchema(description string, fields map[string]*schema.Schema) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: fields,
+		},
+	}
+}
+
+// ilmPolicyActionSchemas returns the schema of the actions, options which are
+// unset, or set to their default, are left out of the policy
+func ilmPolicyActionSchemas() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"rollover": ilmPolicyActionSchema("Rolls over the alias or data stream to a new index once any of the conditions is met.", map[string]*schema.Schema{
+			"max_size": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The maximum total size of the primary shards of the index, e.g. `50gb`.",
+			},
+			"max_primary_shard_size": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The maximum size of the largest primary shard of the index, e.g. `50gb`.",
+			},
+			"max_age": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The maximum age of the index since its creation, e.g. `7d`.",
+			},
+			"max_docs": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The maximum number of documents in the index.",
+			},
+		}),
+		"set_priority": ilmPolicyActionSchema("Sets the priority of the index for recovery after a node restart.", map[string]*schema.Schema{
+			"priority": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The priority of the index, indices with a higher priority are recovered first.",
+			},
+		}),
+		"forcemerge": ilmPolicyActionSchema("Force merges the index into at most the given number of segments, making it read-only.", map[string]*schema.Schema{
+			"max_num_segments": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The number of segments to merge to.",
+			},
+			"index_codec": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The codec used to compress the index, `best_compression` or the default.",
+			},
+		}),
+		"shrink": ilmPolicyActionSchema("Shrinks the index into a new index with fewer primary shards, making it read-only.", map[string]*schema.Schema{
+			"number_of_shards": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The number of primary shards of the shrunken index, a factor of the current number of shards.",
+			},
+			"max_primary_shard_size": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The maximum size of the primary shards of the shrunken index, instead of `number_of_shards`.",
+			},
+		}),
+		"allocate": ilmPolicyActionSchema("Updates the allocation filters and number of replicas of the index.", map[string]*schema.Schema{
+			"number_of_replicas": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      -1,
+				ValidateFunc: validation.IntAtLeast(-1),
+				Description:  "The number of replicas of the index, `-1` leaves the number unchanged.",
+			},
+			"include": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Assigns the index to nodes with any of the given attribute values.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"exclude": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Assigns the index to nodes with none of the given attribute values.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"require": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Assigns the index to nodes with all of the given attribute values.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		}),
+		"migrate": ilmPolicyActionSchema("Moves the index to the data tier of the phase.", map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to move the index, disabling the automatic migration of the phase.",
+			},
+		}),
+		"searchable_snapshot": ilmPolicyActionSchema("Takes a snapshot of the index and mounts it as a searchable snapshot.", map[string]*schema.Schema{
+			"snapshot_repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The repository to store the snapshot in.",
+			},
+			"force_merge_index": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to force merge the index into a single segment before taking the snapshot.",
+			},
+		}),
+		"wait_for_snapshot": ilmPolicyActionSchema("Waits for the snapshot lifecycle policy to take a snapshot before deleting the index.", map[string]*schema.Schema{
+			"policy": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the snapshot lifecycle policy to wait for.",
+			},
+		}),
+		"readonly": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Whether to make the index read-only.",
+		},
+		"freeze": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Whether to freeze the index, minimizing its memory footprint.",
+		},
+		"unfollow": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Whether to convert a cross-cluster replication follower index into a regular index.",
+		},
+	}
+}
+
+func resourceElasticsearchXpackIlmPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	if err := resourceElasticsearchPutXpackIlmPolicy(d, meta); err != nil {
+		return err
+	}
+
+	d.SetId(d.Get("name").(string))
+	return resourceElasticsearchXpackIlmPolicyRead(d, meta)
+}
+
+func resourceElasticsearchXpackIlmPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	policy, err := elasticsearchGetXpackIlmPolicy(meta, id)
+	if err != nil {
+		if elastic7.IsNotFound(err) || elastic6.IsNotFound(err) || elastic5.IsNotFound(err) {
+			log.Printf("[WARN] Index lifecycle policy (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	if policy == nil {
+		log.Printf("[WARN] Index lifecycle policy (%s) not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("name", id)
+	for _, phase := range ilmPolicyPhases {
+		ds.set(phase, flattenIlmPolicyPhase(phase, policy.Phases))
+	}
+	return ds.err
+}
+
+func resourceElasticsearchXpackIlmPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := resourceElasticsearchPutXpackIlmPolicy(d, meta); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchXpackIlmPolicyRead(d, meta)
+}
+
+func resourceElasticsearchXpackIlmPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	path, err := ilmPolicyPath(meta, d.Id())
+	if err != nil {
+		return err
+	}
+
+	if _, err := elasticsearchPerformRequest(meta, "DELETE", path, nil); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceElasticsearchPutXpackIlmPolicy(d *schema.ResourceData, meta interface{}) error {
+	path, err := ilmPolicyPath(meta, d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+
+	phases := make(map[string]IlmPolicyPhase)
+	for _, phase := range ilmPolicyPhases {
+		l := d.Get(phase).([]interface{})
+		if len(l) == 0 {
+			continue
+		}
+		// an empty block has no values
+		config, _ := l[0].(map[string]interface{})
+		phases[phase] = expandIlmPolicyPhase(phase, config)
+	}
+
+	body := map[string]interface{}{
+		"policy": IlmPolicy{
+			Phases: phases,
+		},
+	}
+
+	_, err = elasticsearchPerformRequest(meta, "PUT", path, body)
+	return err
+}
+
+func expandIlmPolicyPhase(phase string, config map[string]interface{}) IlmPolicyPhase {
+	actionSchemas := ilmPolicyActionSchemas()
+	actions := make(map[string]map[string]interface{})
+	for _, action := range ilmPolicyPhaseActions[phase] {
+		s := actionSchemas[action]
+		switch s.Type {
+		case schema.TypeBool:
+			// actions without options are enabled with a bool
+			if enabled, _ := config[action].(bool); enabled {
+				actions[action] = map[string]interface{}{}
+			}
+		case schema.TypeList:
+			if l, _ := config[action].([]interface{}); len(l) > 0 {
+				actionConfig, _ := l[0].(map[string]interface{})
+				actions[action] = expandIlmPolicyAction(s.Elem.(*schema.Resource), actionConfig)
+			}
+		}
+	}
+	if phase == "delete" {
+		// only sent when disabled, older versions don't know the option
+		actions["delete"] = map[string]interface{}{}
+		if deleteSnapshot, ok := config["delete_searchable_snapshot"].(bool); ok && !deleteSnapshot {
+			actions["delete"]["delete_searchable_snapshot"] = false
+		}
+	}
+
+	minAge, _ := config["min_age"].(string)
+	return IlmPolicyPhase{
+		MinAge:  minAge,
+		Actions: actions,
+	}
+}
+
+func expandIlmPolicyAction(r *schema.Resource, config map[string]interface{}) map[string]interface{} {
+	action := make(map[string]interface{})
+	for key, s := range r.Schema {
+		value, ok := config[key]
+		if !ok || value == nil {
+			continue
+		}
+		if s.Default != nil {
+			if value == s.Default {
+				continue
+			}
+		} else if !s.Required && isZeroIlmPolicyValue(value) {
+			continue
+		}
+		action[key] = value
+	}
+	return action
+}
+
+func isZeroIlmPolicyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return v == ""
+	case int:
+		return v == 0
+	case bool:
+		return !v
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+func flattenIlmPolicyPhase(phase string, phases map[string]IlmPolicyPhase) []interface{} {
+	p, ok := phases[phase]
+	if !ok {
+		return []interface{}{}
+	}
+
+	actionSchemas := ilmPolicyActionSchemas()
+	config := map[string]interface{}{
+		"min_age": p.MinAge,
+	}
+	for _, action := range ilmPolicyPhaseActions[phase] {
+		actionConfig, ok := p.Actions[action]
+		if !ok {
+			continue
+		}
+		s := actionSchemas[action]
+		switch s.Type {
+		case schema.TypeBool:
+			config[action] = true
+		case schema.TypeList:
+			config[action] = []interface{}{flattenIlmPolicyAction(s.Elem.(*schema.Resource), actionConfig)}
+		}
+	}
+	if phase == "delete" {
+		config["delete_searchable_snapshot"] = true
+		if deleteSnapshot, ok := p.Actions["delete"]["delete_searchable_snapshot"].(bool); ok {
+			config["delete_searchable_snapshot"] = deleteSnapshot
+		}
+	}
+
+	return []interface{}{config}
+}
+
+func flattenIlmPolicyAction(r *schema.Resource, action map[string]interface{}) map[string]interface{} {
+	config := make(map[string]interface{})
+	for key, s := range r.Schema {
+		value, ok := action[key]
+		if !ok {
+			// options left out of the policy have their default
+			if s.Default != nil {
+				config[key] = s.Default
+			}
+			continue
+		}
+		if number, ok := value.(float64); ok && s.Type == schema.TypeInt {
+			value = int(number)
+		}
+		config[key] = value
+	}
+	return config
+}
+
+func elasticsearchGetXpackIlmPolicy(meta interface{}, name string) (*IlmPolicy, error) {
+	path, err := ilmPolicyPath(meta, name)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := elasticsearchPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make(map[string]IlmPolicyGetResponse)
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling index lifecycle policy body: %+v: %+v", err, body)
+	}
+
+	policy, ok := response[name]
+	if !ok {
+		return nil, nil
+	}
+	return &policy.Policy, nil
+}
+
+func ilmPolicyPath(meta interface{}, name string) (string, error) {
+	if err := elasticsearchCheckIlmSupported(meta); err != nil {
+		return "", err
+	}
+
+	path, err := uritemplates.Expand("/_ilm/policy/{name}", map[string]string{
+		"name": name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for index lifecycle policy: %+v", err)
+	}
+	return path, nil
+}
+
+func elasticsearchCheckIlmSupported(meta interface{}) error {
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	if _, ok := esClient.(*elastic5.Client); ok {
+		return errors.New("Index Lifecycle Management is only supported by the elastic library >= v6!")
+	}
+	return nil
+}
+
+type IlmPolicyGetResponse struct {
+	Version      int       `json:"version"`
+	ModifiedDate string    `json:"modified_date"`
+	Policy       IlmPolicy `json:"policy"`
+}
+
+type IlmPolicy struct {
+	Phases map[string]IlmPolicyPhase `json:"phases"`
+}
+
+type IlmPolicyPhase struct {
+	MinAge  string                            `json:"min_age,omitempty"`
+	Actions map[string]map[string]interface{} `json:"actions"`
+}
@@ -0,0 +1,169 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic5 "gopkg.in/olivere/elastic.v5"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchXpackIlmPolicy(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+
+	_, isElastic5 := esClient.(*elastic5.Client)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if isElastic5 {
+				t.Skip("Index lifecycles only supported on ES >= 6")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchXpackIlmPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchXpackIlmPolicy,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackIlmPolicyExists("elasticsearch_xpack_ilm_policy.test"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ilm_policy.test", "hot.0.min_age", "0ms"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ilm_policy.test", "hot.0.rollover.0.max_size", "50gb"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ilm_policy.test", "warm.0.forcemerge.0.max_num_segments", "1"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ilm_policy.test", "warm.0.allocate.0.number_of_replicas", "-1"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ilm_policy.test", "delete.0.min_age", "30d"),
+				),
+			},
+			{
+				Config: testAccElasticsearchXpackIlmPolicyUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ilm_policy.test", "hot.0.rollover.0.max_size", "20gb"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ilm_policy.test", "warm.0.allocate.0.number_of_replicas", "0"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ilm_policy.test", "delete.0.min_age", "60d"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_xpack_ilm_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchXpackIlmPolicyExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No index lifecycle policy ID is set")
+		}
+
+		policy, err := elasticsearchGetXpackIlmPolicy(testAccXPackProvider.Meta(), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if policy == nil {
+			return fmt.Errorf("Index lifecycle policy %q not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testCheckElasticsearchXpackIlmPolicyDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_xpack_ilm_policy" {
+			continue
+		}
+
+		policy, err := elasticsearchGetXpackIlmPolicy(testAccXPackProvider.Meta(), rs.Primary.ID)
+		if err != nil || policy == nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Index lifecycle policy %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+var testAccElasticsearchXpackIlmPolicy = `
+resource "elasticsearch_xpack_ilm_policy" "test" {
+  name = "terraform-test-structured"
+
+  hot {
+    rollover {
+      max_size = "50gb"
+      max_age  = "7d"
+    }
+  }
+
+  warm {
+    min_age  = "10d"
+    readonly = true
+
+    forcemerge {
+      max_num_segments = 1
+    }
+
+    allocate {
+      require = {
+        box_type = "warm"
+      }
+    }
+  }
+
+  delete {
+    min_age = "30d"
+  }
+}
+`
+
+var testAccElasticsearchXpackIlmPolicyUpdate = `
+resource "elasticsearch_xpack_ilm_policy" "test" {
+  name = "terraform-test-structured"
+
+  hot {
+    rollover {
+      max_size = "20gb"
+      max_age  = "7d"
+    }
+  }
+
+  warm {
+    min_age  = "10d"
+    readonly = true
+
+    forcemerge {
+      max_num_segments = 1
+    }
+
+    allocate {
+      number_of_replicas = 0
+      require = {
+        box_type = "warm"
+      }
+    }
+  }
+
+  delete {
+    min_age = "60d"
+  }
+}
+`
@@ -29,10 +29,27 @@ func resourceElasticsearchXpackLicense() *schema.Resource {
 				Type:     schema.TypeBool,
 				Required: true,
 			},
+			"revert_to_basic_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"license_json": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"expiry_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
@@ -51,9 +68,12 @@ func resourceElasticsearchLicenseCreate(d *schema.ResourceData, meta interface{}
 }
 
 func resourceElasticsearchLicenseUpdate(d *schema.ResourceData, meta interface{}) error {
-	_, err := resourceElasticsearchCreateXpackLicense(d, meta)
-	if err != nil {
-		return err
+	// changing only the behaviour on destroy doesn't upload the license again
+	if d.HasChanges("license", "use_basic_license") {
+		_, err := resourceElasticsearchCreateXpackLicense(d, meta)
+		if err != nil {
+			return err
+		}
 	}
 
 	return resourceElasticsearchLicenseRead(d, meta)
@@ -68,6 +88,9 @@ func resourceElasticsearchLicenseRead(d *schema.ResourceData, meta interface{})
 	ds := &resourceDataSetter{d: d}
 	ds.set("use_basic_license", d.Get("use_basic_license").(bool))
 	ds.set("license", d.Get("license").(string))
+	ds.set("type", l.Type)
+	ds.set("status", l.Status)
+	ds.set("expiry_date", l.ExpiryDate)
 
 	out, err := json.Marshal(l)
 	if err != nil {
@@ -78,6 +101,17 @@ func resourceElasticsearchLicenseRead(d *schema.ResourceData, meta interface{})
 }
 
 func resourceElasticsearchLicenseDelete(d *schema.ResourceData, meta interface{}) error {
+	// starting a basic license replaces the license, instead of leaving the
+	// cluster without one
+	if d.Get("revert_to_basic_on_destroy").(bool) {
+		if _, err := resourceElasticsearchPostBasicLicense(meta); err != nil {
+			return err
+		}
+
+		d.SetId("")
+		return nil
+	}
+
 	var err error
 
 	esClient, err := getClient(meta.(*ProviderConf))
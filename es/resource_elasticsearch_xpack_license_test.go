@@ -55,6 +55,8 @@ func TestAccElasticsearchXpackLicense_Basic(t *testing.T) {
 				Config: testElasticsearchLicense,
 				Check: resource.ComposeTestCheckFunc(
 					testCheckElasticsearchLicenseExists("elasticsearch_xpack_license.test"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_license.test", "type", "basic"),
+					resource.TestCheckResourceAttrSet("elasticsearch_xpack_license.test", "status"),
 				),
 			},
 		},
@@ -0,0 +1,197 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/olivere/elastic/uritemplates"
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func resourceElasticsearchXpackMlCalendar() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an Elasticsearch XPack machine learning calendar, the scheduled events of a calendar, e.g. maintenance windows, suppress the anomalies of the jobs of the calendar.",
+		Create:      resourceElasticsearchXpackMlCalendarCreate,
+		Read:        resourceElasticsearchXpackMlCalendarRead,
+		Update:      resourceElasticsearchXpackMlCalendarUpdate,
+		Delete:      resourceElasticsearchXpackMlCalendarDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Identifier of the calendar.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Optional:    true,
+				Description: "Description of the calendar.",
+			},
+			"job_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The identifiers of the jobs, or groups of jobs, using the calendar.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchXpackMlCalendarCreate(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+
+	if err := elastic7CheckMlSupported(meta); err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"job_ids": expandStringList(d.Get("job_ids").(*schema.Set).List()),
+	}
+	if description, ok := d.GetOk("description"); ok {
+		body["description"] = description.(string)
+	}
+
+	path, err := mlCalendarPath(name, "")
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "PUT", path, body); err != nil {
+		return err
+	}
+
+	d.SetId(name)
+	return resourceElasticsearchXpackMlCalendarRead(d, meta)
+}
+
+func resourceElasticsearchXpackMlCalendarRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	if err := elastic7CheckMlSupported(meta); err != nil {
+		return err
+	}
+
+	calendar, err := elasticsearchGetMlCalendar(meta, id)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Machine learning calendar (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	if calendar == nil {
+		log.Printf("[WARN] Machine learning calendar (%s) not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("name", id)
+	ds.set("description", calendar.Description)
+	ds.set("job_ids", calendar.JobIDs)
+	return ds.err
+}
+
+func resourceElasticsearchXpackMlCalendarUpdate(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	if err := elastic7CheckMlSupported(meta); err != nil {
+		return err
+	}
+
+	// only the jobs can change, they are added and removed one by one
+	o, n := d.GetChange("job_ids")
+	oldJobIDs := o.(*schema.Set)
+	newJobIDs := n.(*schema.Set)
+
+	for _, jobID := range expandStringList(newJobIDs.Difference(oldJobIDs).List()) {
+		path, err := mlCalendarPath(id, "/jobs/"+url.PathEscape(jobID))
+		if err != nil {
+			return err
+		}
+		if _, err := elasticsearchPerformRequest(meta, "PUT", path, nil); err != nil {
+			return fmt.Errorf("error adding job %s to machine learning calendar %s: %+v", jobID, id, err)
+		}
+	}
+	for _, jobID := range expandStringList(oldJobIDs.Difference(newJobIDs).List()) {
+		path, err := mlCalendarPath(id, "/jobs/"+url.PathEscape(jobID))
+		if err != nil {
+			return err
+		}
+		if _, err := elasticsearchPerformRequest(meta, "DELETE", path, nil); err != nil {
+			return fmt.Errorf("error removing job %s from machine learning calendar %s: %+v", jobID, id, err)
+		}
+	}
+
+	return resourceElasticsearchXpackMlCalendarRead(d, meta)
+}
+
+func resourceElasticsearchXpackMlCalendarDelete(d *schema.ResourceData, meta interface{}) error {
+	if err := elastic7CheckMlSupported(meta); err != nil {
+		return err
+	}
+
+	path, err := mlCalendarPath(d.Id(), "")
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "DELETE", path, nil); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func elasticsearchGetMlCalendar(meta interface{}, name string) (*MlCalendar, error) {
+	path, err := mlCalendarPath(name, "")
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := elasticsearchPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(MlCalendarGetResponse)
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling machine learning calendar body: %+v: %+v", err, body)
+	}
+
+	for _, calendar := range response.Calendars {
+		if calendar.CalendarID == name {
+			return &calendar, nil
+		}
+	}
+	return nil, nil
+}
+
+func mlCalendarPath(name string, suffix string) (string, error) {
+	path, err := uritemplates.Expand("/_ml/calendars/{name}", map[string]string{
+		"name": name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for machine learning calendar: %+v", err)
+	}
+	return path + suffix, nil
+}
+
+type MlCalendarGetResponse struct {
+	Calendars []MlCalendar `json:"calendars"`
+}
+
+type MlCalendar struct {
+	CalendarID  string   `json:"calendar_id"`
+	Description string   `json:"description"`
+	JobIDs      []string `json:"job_ids"`
+}
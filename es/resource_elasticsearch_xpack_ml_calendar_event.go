@@ -0,0 +1,222 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+// the maximum number of calendar events returned per request
+const mlCalendarEventsPageSize = 1000
+
+func resourceElasticsearchXpackMlCalendarEvent() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a scheduled event of an Elasticsearch XPack machine learning calendar, e.g. a maintenance window, during which the anomalies of the jobs of the calendar are suppressed. Events can't be updated, so changing them replaces the event.",
+		Create:      resourceElasticsearchXpackMlCalendarEventCreate,
+		Read:        resourceElasticsearchXpackMlCalendarEventRead,
+		Delete:      resourceElasticsearchXpackMlCalendarEventDelete,
+		Schema: map[string]*schema.Schema{
+			"calendar_id": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Identifier of the calendar of the event.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Description of the event.",
+			},
+			"start_time": {
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Required:         true,
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppressEquivalentRFC3339Time,
+				Description:      "The start of the event, in RFC 3339 format, e.g. `2021-06-05T22:00:00Z`.",
+			},
+			"end_time": {
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Required:         true,
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppressEquivalentRFC3339Time,
+				Description:      "The end of the event, in RFC 3339 format.",
+			},
+			"event_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Identifier of the event, generated when it is created.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: resourceElasticsearchXpackMlCalendarEventImport,
+		},
+	}
+}
+
+func resourceElasticsearchXpackMlCalendarEventCreate(d *schema.ResourceData, meta interface{}) error {
+	calendarID := d.Get("calendar_id").(string)
+
+	if err := elastic7CheckMlSupported(meta); err != nil {
+		return err
+	}
+
+	// the times are validated by the schema
+	startTime, _ := time.Parse(time.RFC3339, d.Get("start_time").(string))
+	endTime, _ := time.Parse(time.RFC3339, d.Get("end_time").(string))
+
+	body := map[string]interface{}{
+		"events": []MlCalendarEvent{
+			{
+				Description: d.Get("description").(string),
+				StartTime:   startTime.UnixNano() / int64(time.Millisecond),
+				EndTime:     endTime.UnixNano() / int64(time.Millisecond),
+			},
+		},
+	}
+
+	path, err := mlCalendarPath(calendarID, "/events")
+	if err != nil {
+		return err
+	}
+	res, err := elasticsearchPerformRequest(meta, "POST", path, body)
+	if err != nil {
+		return err
+	}
+
+	response := new(MlCalendarEventsResponse)
+	if err := json.Unmarshal(res, response); err != nil {
+		return fmt.Errorf("error unmarshalling machine learning calendar events body: %+v: %+v", err, res)
+	}
+	if len(response.Events) != 1 || response.Events[0].EventID == "" {
+		return fmt.Errorf("unexpected events created in machine learning calendar %s: %+v", calendarID, response.Events)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", calendarID, response.Events[0].EventID))
+	return resourceElasticsearchXpackMlCalendarEventRead(d, meta)
+}
+
+func resourceElasticsearchXpackMlCalendarEventRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+	calendarID, eventID, err := parseMlCalendarEventID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := elastic7CheckMlSupported(meta); err != nil {
+		return err
+	}
+
+	event, err := elasticsearchGetMlCalendarEvent(meta, calendarID, eventID)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Machine learning calendar event (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	if event == nil {
+		log.Printf("[WARN] Machine learning calendar event (%s) not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("calendar_id", calendarID)
+	ds.set("event_id", eventID)
+	ds.set("description", event.Description)
+	ds.set("start_time", formatSnapshotTime(event.StartTime))
+	ds.set("end_time", formatSnapshotTime(event.EndTime))
+	return ds.err
+}
+
+func resourceElasticsearchXpackMlCalendarEventDelete(d *schema.ResourceData, meta interface{}) error {
+	calendarID, eventID, err := parseMlCalendarEventID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if err := elastic7CheckMlSupported(meta); err != nil {
+		return err
+	}
+
+	path, err := mlCalendarPath(calendarID, "/events/"+url.PathEscape(eventID))
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "DELETE", path, nil); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceElasticsearchXpackMlCalendarEventImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, _, err := parseMlCalendarEventID(d.Id()); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func elasticsearchGetMlCalendarEvent(meta interface{}, calendarID string, eventID string) (*MlCalendarEvent, error) {
+	path, err := mlCalendarPath(calendarID, "/events")
+	if err != nil {
+		return nil, err
+	}
+
+	// the events are searched page by page, there is no API to get one event
+	from := 0
+	for {
+		body, err := elasticsearchPerformRequest(meta, "GET", fmt.Sprintf("%s?from=%d&size=%d", path, from, mlCalendarEventsPageSize), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		response := new(MlCalendarEventsResponse)
+		if err := json.Unmarshal(body, response); err != nil {
+			return nil, fmt.Errorf("error unmarshalling machine learning calendar events body: %+v: %+v", err, body)
+		}
+
+		for _, event := range response.Events {
+			if event.EventID == eventID {
+				return &event, nil
+			}
+		}
+		from += len(response.Events)
+		if len(response.Events) == 0 || from >= response.Count {
+			return nil, nil
+		}
+	}
+}
+
+func parseMlCalendarEventID(id string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("unexpected format of ID (%s), expected calendar/event", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+type MlCalendarEventsResponse struct {
+	Count  int               `json:"count"`
+	Events []MlCalendarEvent `json:"events"`
+}
+
+type MlCalendarEvent struct {
+	EventID     string `json:"event_id,omitempty"`
+	CalendarID  string `json:"calendar_id,omitempty"`
+	Description string `json:"description"`
+	StartTime   int64  `json:"start_time"`
+	EndTime     int64  `json:"end_time"`
+}
@@ -0,0 +1,113 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchXpackMlCalendarEvent(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	_, allowed := esClient.(*elastic7.Client)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Machine learning calendars only supported on ES >= 7")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchXpackMlCalendarEventDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchXpackMlCalendarEvent,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackMlCalendarEventExists("elasticsearch_xpack_ml_calendar_event.test"),
+					resource.TestCheckResourceAttrSet("elasticsearch_xpack_ml_calendar_event.test", "event_id"),
+					// read back in UTC
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ml_calendar_event.test", "start_time", "2030-06-05T20:00:00Z"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_xpack_ml_calendar_event.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchXpackMlCalendarEventExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No machine learning calendar event ID is set")
+		}
+
+		calendarID, eventID, err := parseMlCalendarEventID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		event, err := elasticsearchGetMlCalendarEvent(testAccXPackProvider.Meta(), calendarID, eventID)
+		if err != nil {
+			return err
+		}
+		if event == nil {
+			return fmt.Errorf("Machine learning calendar event %q not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testCheckElasticsearchXpackMlCalendarEventDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_xpack_ml_calendar_event" {
+			continue
+		}
+
+		calendarID, eventID, err := parseMlCalendarEventID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		event, err := elasticsearchGetMlCalendarEvent(testAccXPackProvider.Meta(), calendarID, eventID)
+		if err != nil || event == nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Machine learning calendar event %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+var testAccElasticsearchXpackMlCalendarEvent = `
+resource "elasticsearch_xpack_ml_calendar" "test" {
+  name = "terraform-test-events"
+}
+
+resource "elasticsearch_xpack_ml_calendar_event" "test" {
+  calendar_id = elasticsearch_xpack_ml_calendar.test.name
+  description = "Release weekend"
+  start_time  = "2030-06-05T22:00:00+02:00"
+  end_time    = "2030-06-07T22:00:00+02:00"
+}
+`
@@ -0,0 +1,134 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchXpackMlCalendar(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	_, allowed := esClient.(*elastic7.Client)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Machine learning calendars only supported on ES >= 7")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchXpackMlCalendarDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchXpackMlCalendar(`["terraform"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackMlCalendarExists("elasticsearch_xpack_ml_calendar.test"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ml_calendar.test", "job_ids.#", "1"),
+				),
+			},
+			{
+				// the jobs are updated in place
+				Config: testAccElasticsearchXpackMlCalendar(`[elasticsearch_xpack_ml_job.test.name]`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackMlCalendarExists("elasticsearch_xpack_ml_calendar.test"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ml_calendar.test", "job_ids.#", "1"),
+				),
+			},
+			{
+				Config: testAccElasticsearchXpackMlCalendar(`[]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ml_calendar.test", "job_ids.#", "0"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_xpack_ml_calendar.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchXpackMlCalendarExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No machine learning calendar ID is set")
+		}
+
+		calendar, err := elasticsearchGetMlCalendar(testAccXPackProvider.Meta(), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if calendar == nil {
+			return fmt.Errorf("Machine learning calendar %q not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testCheckElasticsearchXpackMlCalendarDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_xpack_ml_calendar" {
+			continue
+		}
+
+		calendar, err := elasticsearchGetMlCalendar(testAccXPackProvider.Meta(), rs.Primary.ID)
+		if err != nil || calendar == nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Machine learning calendar %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchXpackMlCalendar(jobIDs string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_xpack_ml_job" "test" {
+  name   = "terraform-test-calendar"
+  groups = ["terraform"]
+  opened = false
+
+  analysis_config = jsonencode({
+    bucket_span = "15m"
+    detectors = [
+      {
+        function = "count"
+      }
+    ]
+  })
+  data_description = jsonencode({
+    time_field = "@timestamp"
+  })
+}
+
+resource "elasticsearch_xpack_ml_calendar" "test" {
+  name        = "terraform-test"
+  description = "Maintenance windows"
+  job_ids     = %s
+
+  depends_on = [elasticsearch_xpack_ml_job.test]
+}
+`, jobIDs)
+}
@@ -0,0 +1,321 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func resourceElasticsearchXpackMlDatafeed() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an Elasticsearch XPack machine learning datafeed, retrieving the data analyzed by an anomaly detection job from indices. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/ml-put-datafeed.html) for more details.",
+		Create:      resourceElasticsearchXpackMlDatafeedCreate,
+		Read:        resourceElasticsearchXpackMlDatafeedRead,
+		Update:      resourceElasticsearchXpackMlDatafeedUpdate,
+		Delete:      resourceElasticsearchXpackMlDatafeedDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Identifier of the datafeed.",
+			},
+			"job_id": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Identifier of the anomaly detection job the datafeed sends data to.",
+			},
+			"indices": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The indices, or wildcard expressions of indices, to retrieve data from.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"query": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJson,
+				Description:      "A JSON string of the query filtering the data retrieved, all the documents are retrieved if not set.",
+			},
+			"frequency": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The interval between retrievals of data while the datafeed runs in real time, e.g. `150s`.",
+			},
+			"query_delay": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The delay behind real time at which data is retrieved, allowing for ingestion delays, e.g. `90s`.",
+			},
+			"scroll_size": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The number of documents retrieved per search request.",
+			},
+			"started": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the datafeed is started, which requires the job to be opened.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchXpackMlDatafeedCreate(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+
+	if err := elastic7CheckMlSupported(meta); err != nil {
+		return err
+	}
+
+	body := expandMlDatafeedUpdatableFields(d)
+	body["job_id"] = d.Get("job_id").(string)
+
+	path, err := mlDatafeedPath(name, "")
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "PUT", path, body); err != nil {
+		return err
+	}
+	d.SetId(name)
+
+	if d.Get("started").(bool) {
+		if err := elasticsearchSetMlDatafeedStarted(meta, name, true); err != nil {
+			return err
+		}
+	}
+
+	return resourceElasticsearchXpackMlDatafeedRead(d, meta)
+}
+
+func resourceElasticsearchXpackMlDatafeedRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	if err := elastic7CheckMlSupported(meta); err != nil {
+		return err
+	}
+
+	datafeed, err := elasticsearchGetMlDatafeed(meta, id)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Machine learning datafeed (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	if datafeed == nil {
+		log.Printf("[WARN] Machine learning datafeed (%s) not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	state, err := elasticsearchGetMlDatafeedState(meta, id)
+	if err != nil {
+		return err
+	}
+
+	// queries are read back rewritten with the defaults of every clause, so
+	// they are only read back on import, when the job is not known yet
+	importing := d.Get("job_id").(string) == ""
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("name", id)
+	ds.set("job_id", datafeed.JobID)
+	ds.set("indices", datafeed.Indices)
+	if importing {
+		ds.set("query", string(datafeed.Query))
+	}
+	ds.set("frequency", datafeed.Frequency)
+	ds.set("query_delay", datafeed.QueryDelay)
+	ds.set("scroll_size", datafeed.ScrollSize)
+	ds.set("started", state == "started" || state == "starting")
+	return ds.err
+}
+
+func resourceElasticsearchXpackMlDatafeedUpdate(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	if err := elastic7CheckMlSupported(meta); err != nil {
+		return err
+	}
+
+	o, _ := d.GetChange("started")
+	wasStarted := o.(bool)
+
+	if d.HasChanges("indices", "query", "frequency", "query_delay", "scroll_size") {
+		// started datafeeds only use the changes once they are started again
+		if wasStarted {
+			if err := elasticsearchSetMlDatafeedStarted(meta, id, false); err != nil {
+				return err
+			}
+			wasStarted = false
+		}
+
+		path, err := mlDatafeedPath(id, "/_update")
+		if err != nil {
+			return err
+		}
+		if _, err := elasticsearchPerformRequest(meta, "POST", path, expandMlDatafeedUpdatableFields(d)); err != nil {
+			return err
+		}
+	}
+
+	if started := d.Get("started").(bool); started != wasStarted {
+		if err := elasticsearchSetMlDatafeedStarted(meta, id, started); err != nil {
+			return err
+		}
+	}
+
+	return resourceElasticsearchXpackMlDatafeedRead(d, meta)
+}
+
+func resourceElasticsearchXpackMlDatafeedDelete(d *schema.ResourceData, meta interface{}) error {
+	if err := elastic7CheckMlSupported(meta); err != nil {
+		return err
+	}
+
+	// force also deletes started datafeeds
+	path, err := mlDatafeedPath(d.Id(), "?force=true")
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "DELETE", path, nil); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func expandMlDatafeedUpdatableFields(d *schema.ResourceData) map[string]interface{} {
+	body := map[string]interface{}{
+		"indices": expandStringList(d.Get("indices").([]interface{})),
+	}
+	if query, ok := d.GetOk("query"); ok {
+		body["query"] = json.RawMessage(query.(string))
+	}
+	if frequency, ok := d.GetOk("frequency"); ok {
+		body["frequency"] = frequency.(string)
+	}
+	if queryDelay, ok := d.GetOk("query_delay"); ok {
+		body["query_delay"] = queryDelay.(string)
+	}
+	if scrollSize, ok := d.GetOk("scroll_size"); ok {
+		body["scroll_size"] = scrollSize.(int)
+	}
+	return body
+}
+
+func elasticsearchSetMlDatafeedStarted(meta interface{}, name string, started bool) error {
+	suffix := "/_stop"
+	if started {
+		suffix = "/_start"
+	}
+
+	path, err := mlDatafeedPath(name, suffix)
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "POST", path, nil); err != nil {
+		return fmt.Errorf("error setting started to %t for machine learning datafeed %s: %+v", started, name, err)
+	}
+	return nil
+}
+
+func elasticsearchGetMlDatafeed(meta interface{}, name string) (*MlDatafeed, error) {
+	path, err := mlDatafeedPath(name, "")
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := elasticsearchPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(MlDatafeedGetResponse)
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling machine learning datafeed body: %+v: %+v", err, body)
+	}
+
+	for _, datafeed := range response.Datafeeds {
+		if datafeed.DatafeedID == name {
+			return &datafeed, nil
+		}
+	}
+	return nil, nil
+}
+
+func elasticsearchGetMlDatafeedState(meta interface{}, name string) (string, error) {
+	path, err := mlDatafeedPath(name, "/_stats")
+	if err != nil {
+		return "", err
+	}
+
+	body, err := elasticsearchPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		return "", err
+	}
+
+	response := new(MlDatafeedStatsResponse)
+	if err := json.Unmarshal(body, response); err != nil {
+		return "", fmt.Errorf("error unmarshalling machine learning datafeed stats body: %+v: %+v", err, body)
+	}
+
+	for _, stats := range response.Datafeeds {
+		if stats.DatafeedID == name {
+			return stats.State, nil
+		}
+	}
+	return "", fmt.Errorf("machine learning datafeed %s not found in stats", name)
+}
+
+func mlDatafeedPath(name string, suffix string) (string, error) {
+	path, err := uritemplates.Expand("/_ml/datafeeds/{name}", map[string]string{
+		"name": name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for machine learning datafeed: %+v", err)
+	}
+	return path + suffix, nil
+}
+
+type MlDatafeedGetResponse struct {
+	Datafeeds []MlDatafeed `json:"datafeeds"`
+}
+
+type MlDatafeed struct {
+	DatafeedID string          `json:"datafeed_id"`
+	JobID      string          `json:"job_id"`
+	Indices    []string        `json:"indices"`
+	Query      json.RawMessage `json:"query"`
+	Frequency  string          `json:"frequency"`
+	QueryDelay string          `json:"query_delay"`
+	ScrollSize int             `json:"scroll_size"`
+}
+
+type MlDatafeedStatsResponse struct {
+	Datafeeds []struct {
+		DatafeedID string `json:"datafeed_id"`
+		State      string `json:"state"`
+	} `json:"datafeeds"`
+}
@@ -0,0 +1,145 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchXpackMlDatafeed(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	_, allowed := esClient.(*elastic7.Client)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Machine learning datafeeds only supported on ES >= 7")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchXpackMlDatafeedDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchXpackMlDatafeed("150s"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackMlDatafeedState("elasticsearch_xpack_ml_datafeed.test", "started"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ml_datafeed.test", "frequency", "150s"),
+				),
+			},
+			{
+				// the datafeed is stopped for the update, and started again
+				Config: testAccElasticsearchXpackMlDatafeed("300s"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackMlDatafeedState("elasticsearch_xpack_ml_datafeed.test", "started"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ml_datafeed.test", "frequency", "300s"),
+				),
+			},
+			{
+				ResourceName:            "elasticsearch_xpack_ml_datafeed.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"query"},
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchXpackMlDatafeedState(name string, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No machine learning datafeed ID is set")
+		}
+
+		state, err := elasticsearchGetMlDatafeedState(testAccXPackProvider.Meta(), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if state != expected {
+			return fmt.Errorf("Machine learning datafeed %s is %s, expected %s", rs.Primary.ID, state, expected)
+		}
+
+		return nil
+	}
+}
+
+func testCheckElasticsearchXpackMlDatafeedDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_xpack_ml_datafeed" {
+			continue
+		}
+
+		datafeed, err := elasticsearchGetMlDatafeed(testAccXPackProvider.Meta(), rs.Primary.ID)
+		if err != nil || datafeed == nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Machine learning datafeed %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchXpackMlDatafeed(frequency string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_index" "test" {
+  name               = "terraform-test-datafeed"
+  number_of_shards   = 1
+  number_of_replicas = 0
+  mappings = jsonencode({
+    properties = {
+      "@timestamp"  = { type = "date" }
+      responsetime = { type = "double" }
+    }
+  })
+}
+
+resource "elasticsearch_xpack_ml_job" "test" {
+  name = "terraform-test-datafeed"
+
+  analysis_config = jsonencode({
+    bucket_span = "15m"
+    detectors = [
+      {
+        function   = "mean"
+        field_name = "responsetime"
+      }
+    ]
+  })
+  data_description = jsonencode({
+    time_field = "@timestamp"
+  })
+}
+
+resource "elasticsearch_xpack_ml_datafeed" "test" {
+  name      = "datafeed-terraform-test"
+  job_id    = elasticsearch_xpack_ml_job.test.name
+  indices   = [elasticsearch_index.test.name]
+  frequency = "%s"
+
+  query = jsonencode({
+    range = {
+      responsetime = { gte = 0 }
+    }
+  })
+}
+`, frequency)
+}
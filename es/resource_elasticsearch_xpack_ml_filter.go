@@ -0,0 +1,189 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/olivere/elastic/uritemplates"
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func resourceElasticsearchXpackMlFilter() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an Elasticsearch XPack machine learning filter, a list of values used by the custom rules of anomaly detection jobs, e.g. to skip known hosts.",
+		Create:      resourceElasticsearchXpackMlFilterCreate,
+		Read:        resourceElasticsearchXpackMlFilterRead,
+		Update:      resourceElasticsearchXpackMlFilterUpdate,
+		Delete:      resourceElasticsearchXpackMlFilterDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Identifier of the filter.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the filter.",
+			},
+			"items": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The values of the filter, which can contain `*` wildcards.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchXpackMlFilterCreate(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+
+	if err := elastic7CheckMlSupported(meta); err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"description": d.Get("description").(string),
+		"items":       expandStringList(d.Get("items").(*schema.Set).List()),
+	}
+
+	path, err := mlFilterPath(name, "")
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "PUT", path, body); err != nil {
+		return err
+	}
+
+	d.SetId(name)
+	return resourceElasticsearchXpackMlFilterRead(d, meta)
+}
+
+func resourceElasticsearchXpackMlFilterRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	if err := elastic7CheckMlSupported(meta); err != nil {
+		return err
+	}
+
+	filter, err := elasticsearchGetMlFilter(meta, id)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Machine learning filter (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	if filter == nil {
+		log.Printf("[WARN] Machine learning filter (%s) not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("name", id)
+	ds.set("description", filter.Description)
+	ds.set("items", filter.Items)
+	return ds.err
+}
+
+func resourceElasticsearchXpackMlFilterUpdate(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	if err := elastic7CheckMlSupported(meta); err != nil {
+		return err
+	}
+
+	// only the changed items are sent, so jobs using the filter see the
+	// other items for the whole update
+	o, n := d.GetChange("items")
+	oldItems := o.(*schema.Set)
+	newItems := n.(*schema.Set)
+
+	body := map[string]interface{}{
+		"description":  d.Get("description").(string),
+		"add_items":    expandStringList(newItems.Difference(oldItems).List()),
+		"remove_items": expandStringList(oldItems.Difference(newItems).List()),
+	}
+
+	path, err := mlFilterPath(id, "/_update")
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "POST", path, body); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchXpackMlFilterRead(d, meta)
+}
+
+func resourceElasticsearchXpackMlFilterDelete(d *schema.ResourceData, meta interface{}) error {
+	if err := elastic7CheckMlSupported(meta); err != nil {
+		return err
+	}
+
+	path, err := mlFilterPath(d.Id(), "")
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "DELETE", path, nil); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func elasticsearchGetMlFilter(meta interface{}, name string) (*MlFilter, error) {
+	path, err := mlFilterPath(name, "")
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := elasticsearchPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(MlFilterGetResponse)
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling machine learning filter body: %+v: %+v", err, body)
+	}
+
+	for _, filter := range response.Filters {
+		if filter.FilterID == name {
+			return &filter, nil
+		}
+	}
+	return nil, nil
+}
+
+func mlFilterPath(name string, suffix string) (string, error) {
+	path, err := uritemplates.Expand("/_ml/filters/{name}", map[string]string{
+		"name": name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for machine learning filter: %+v", err)
+	}
+	return path + suffix, nil
+}
+
+type MlFilterGetResponse struct {
+	Filters []MlFilter `json:"filters"`
+}
+
+type MlFilter struct {
+	FilterID    string   `json:"filter_id"`
+	Description string   `json:"description"`
+	Items       []string `json:"items"`
+}
@@ -0,0 +1,114 @@
+package es
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchXpackMlFilter(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	_, allowed := esClient.(*elastic7.Client)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Machine learning filters only supported on ES >= 7")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchXpackMlFilterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchXpackMlFilter(`["*.example.com", "safe.org"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackMlFilterItems("elasticsearch_xpack_ml_filter.test", "*.example.com,safe.org"),
+				),
+			},
+			{
+				// items are added and removed through the update API
+				Config: testAccElasticsearchXpackMlFilter(`["*.example.com", "trusted.org"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackMlFilterItems("elasticsearch_xpack_ml_filter.test", "*.example.com,trusted.org"),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_xpack_ml_filter.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchXpackMlFilterItems(name string, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No machine learning filter ID is set")
+		}
+
+		filter, err := elasticsearchGetMlFilter(testAccXPackProvider.Meta(), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if filter == nil {
+			return fmt.Errorf("Machine learning filter %q not found", rs.Primary.ID)
+		}
+
+		items := filter.Items
+		sort.Strings(items)
+		if actual := strings.Join(items, ","); actual != expected {
+			return fmt.Errorf("Machine learning filter %s has items %s, expected %s", rs.Primary.ID, actual, expected)
+		}
+
+		return nil
+	}
+}
+
+func testCheckElasticsearchXpackMlFilterDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_xpack_ml_filter" {
+			continue
+		}
+
+		filter, err := elasticsearchGetMlFilter(testAccXPackProvider.Meta(), rs.Primary.ID)
+		if err != nil || filter == nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Machine learning filter %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchXpackMlFilter(items string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_xpack_ml_filter" "test" {
+  name        = "terraform-test"
+  description = "Safe domains"
+  items       = %s
+}
+`, items)
+}
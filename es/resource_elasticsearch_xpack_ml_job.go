@@ -0,0 +1,409 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func resourceElasticsearchXpackMlJob() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an Elasticsearch XPack machine learning anomaly detection job. The analysis and the description of the data can't be changed, so changing them replaces the job, the other fields are updated in place. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/ml-put-job.html) for more details.",
+		Create:      resourceElasticsearchXpackMlJobCreate,
+		Read:        resourceElasticsearchXpackMlJobRead,
+		Update:      resourceElasticsearchXpackMlJobUpdate,
+		Delete:      resourceElasticsearchXpackMlJobDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Identifier of the job.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the job.",
+			},
+			"groups": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The groups of the job, used to manage several jobs at once.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"analysis_config": {
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJson,
+				Description:      "A JSON string of the analysis of the job, the `bucket_span`, `detectors` and `influencers`.",
+			},
+			"data_description": {
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJson,
+				Description:      "A JSON string of the description of the data analyzed, e.g. the `time_field`.",
+			},
+			"model_memory_limit": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The maximum memory used by the analysis, e.g. `512mb`. Changing the limit closes the job during the update when it is opened.",
+			},
+			"categorization_examples_limit": {
+				Type:        schema.TypeInt,
+				ForceNew:    true,
+				Optional:    true,
+				Computed:    true,
+				Description: "The maximum number of examples stored per category.",
+			},
+			"model_snapshot_retention_days": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The number of days model snapshots are retained for.",
+			},
+			"results_retention_days": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The number of days results are retained for, results are retained forever if not set.",
+			},
+			"results_index_name": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Optional:    true,
+				Computed:    true,
+				Description: "The suffix of the index the results are stored in, `shared` by default.",
+			},
+			"custom_settings": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJson,
+				Description:      "A JSON string of custom metadata of the job, e.g. `custom_urls`.",
+			},
+			"allow_lazy_open": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the job can be opened when there is no machine learning node with enough capacity, waiting for one instead of failing.",
+			},
+			"opened": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the job is opened, ready to receive and analyze data.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchXpackMlJobCreate(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+
+	if err := elastic7CheckMlSupported(meta); err != nil {
+		return err
+	}
+
+	body := expandMlJobUpdatableFields(d)
+	body["analysis_config"] = json.RawMessage(d.Get("analysis_config").(string))
+	body["data_description"] = json.RawMessage(d.Get("data_description").(string))
+	if resultsIndexName, ok := d.GetOk("results_index_name"); ok {
+		body["results_index_name"] = resultsIndexName.(string)
+	}
+	analysisLimits := expandMlJobAnalysisLimits(d)
+	if limit, ok := d.GetOk("categorization_examples_limit"); ok {
+		analysisLimits["categorization_examples_limit"] = limit.(int)
+	}
+	body["analysis_limits"] = analysisLimits
+
+	path, err := mlJobPath(name, "")
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "PUT", path, body); err != nil {
+		return err
+	}
+	d.SetId(name)
+
+	if d.Get("opened").(bool) {
+		if err := elasticsearchSetMlJobOpened(meta, name, true); err != nil {
+			return err
+		}
+	}
+
+	return resourceElasticsearchXpackMlJobRead(d, meta)
+}
+
+func resourceElasticsearchXpackMlJobRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	if err := elastic7CheckMlSupported(meta); err != nil {
+		return err
+	}
+
+	job, err := elasticsearchGetMlJob(meta, id)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Machine learning job (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	if job == nil {
+		log.Printf("[WARN] Machine learning job (%s) not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	state, err := elasticsearchGetMlJobState(meta, id)
+	if err != nil {
+		return err
+	}
+
+	var customSettings string
+	if len(job.CustomSettings) > 0 {
+		customSettings = string(job.CustomSettings)
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("name", id)
+	ds.set("description", job.Description)
+	ds.set("groups", job.Groups)
+	// the analysis and the description of the data can't change, and are
+	// read back with defaults added, so they are only read back on import
+	if d.Get("analysis_config").(string) == "" {
+		ds.set("analysis_config", string(job.AnalysisConfig))
+	}
+	if d.Get("data_description").(string) == "" {
+		ds.set("data_description", string(job.DataDescription))
+	}
+	ds.set("model_memory_limit", job.AnalysisLimits.ModelMemoryLimit)
+	ds.set("categorization_examples_limit", job.AnalysisLimits.CategorizationExamplesLimit)
+	ds.set("model_snapshot_retention_days", job.ModelSnapshotRetentionDays)
+	ds.set("results_retention_days", job.ResultsRetentionDays)
+	// custom results indices are read back with a prefix
+	ds.set("results_index_name", strings.TrimPrefix(job.ResultsIndexName, "custom-"))
+	ds.set("custom_settings", customSettings)
+	ds.set("allow_lazy_open", job.AllowLazyOpen)
+	ds.set("opened", state == "opened" || state == "opening")
+	return ds.err
+}
+
+func resourceElasticsearchXpackMlJobUpdate(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	if err := elastic7CheckMlSupported(meta); err != nil {
+		return err
+	}
+
+	o, _ := d.GetChange("opened")
+	wasOpened := o.(bool)
+
+	if d.HasChanges("description", "groups", "model_memory_limit", "model_snapshot_retention_days", "results_retention_days", "custom_settings", "allow_lazy_open") {
+		// the memory limit can't be changed while the job is opened
+		if d.HasChange("model_memory_limit") && wasOpened {
+			if err := elasticsearchSetMlJobOpened(meta, id, false); err != nil {
+				return err
+			}
+			wasOpened = false
+		}
+
+		body := expandMlJobUpdatableFields(d)
+		if d.HasChange("model_memory_limit") {
+			body["analysis_limits"] = expandMlJobAnalysisLimits(d)
+		}
+
+		path, err := mlJobPath(id, "/_update")
+		if err != nil {
+			return err
+		}
+		if _, err := elasticsearchPerformRequest(meta, "POST", path, body); err != nil {
+			return err
+		}
+	}
+
+	if opened := d.Get("opened").(bool); opened != wasOpened {
+		if err := elasticsearchSetMlJobOpened(meta, id, opened); err != nil {
+			return err
+		}
+	}
+
+	return resourceElasticsearchXpackMlJobRead(d, meta)
+}
+
+func resourceElasticsearchXpackMlJobDelete(d *schema.ResourceData, meta interface{}) error {
+	if err := elastic7CheckMlSupported(meta); err != nil {
+		return err
+	}
+
+	// force also deletes opened jobs
+	path, err := mlJobPath(d.Id(), "?force=true")
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "DELETE", path, nil); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// expandMlJobUpdatableFields returns the fields of the job which can be
+// changed through the update API, except the analysis limits which can't be
+// sent while the job is opened
+func expandMlJobUpdatableFields(d *schema.ResourceData) map[string]interface{} {
+	body := map[string]interface{}{
+		"description":     d.Get("description").(string),
+		"groups":          expandStringList(d.Get("groups").([]interface{})),
+		"allow_lazy_open": d.Get("allow_lazy_open").(bool),
+	}
+	if days, ok := d.GetOk("model_snapshot_retention_days"); ok {
+		body["model_snapshot_retention_days"] = days.(int)
+	}
+	if days, ok := d.GetOk("results_retention_days"); ok {
+		body["results_retention_days"] = days.(int)
+	}
+	if customSettings, ok := d.GetOk("custom_settings"); ok {
+		body["custom_settings"] = json.RawMessage(customSettings.(string))
+	}
+	return body
+}
+
+func expandMlJobAnalysisLimits(d *schema.ResourceData) map[string]interface{} {
+	analysisLimits := make(map[string]interface{})
+	if limit, ok := d.GetOk("model_memory_limit"); ok {
+		analysisLimits["model_memory_limit"] = limit.(string)
+	}
+	return analysisLimits
+}
+
+func elasticsearchSetMlJobOpened(meta interface{}, name string, opened bool) error {
+	suffix := "/_close"
+	if opened {
+		suffix = "/_open"
+	}
+
+	path, err := mlJobPath(name, suffix)
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "POST", path, nil); err != nil {
+		return fmt.Errorf("error setting opened to %t for machine learning job %s: %+v", opened, name, err)
+	}
+	return nil
+}
+
+func elasticsearchGetMlJob(meta interface{}, name string) (*MlJob, error) {
+	path, err := mlJobPath(name, "")
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := elasticsearchPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(MlJobGetResponse)
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling machine learning job body: %+v: %+v", err, body)
+	}
+
+	for _, job := range response.Jobs {
+		if job.JobID == name {
+			return &job, nil
+		}
+	}
+	return nil, nil
+}
+
+func elasticsearchGetMlJobState(meta interface{}, name string) (string, error) {
+	path, err := mlJobPath(name, "/_stats")
+	if err != nil {
+		return "", err
+	}
+
+	body, err := elasticsearchPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		return "", err
+	}
+
+	response := new(MlJobStatsResponse)
+	if err := json.Unmarshal(body, response); err != nil {
+		return "", fmt.Errorf("error unmarshalling machine learning job stats body: %+v: %+v", err, body)
+	}
+
+	for _, stats := range response.Jobs {
+		if stats.JobID == name {
+			return stats.State, nil
+		}
+	}
+	return "", fmt.Errorf("machine learning job %s not found in stats", name)
+}
+
+func elastic7CheckMlSupported(meta interface{}) error {
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+
+	if _, ok := esClient.(*elastic7.Client); !ok {
+		return fmt.Errorf("machine learning only available from ElasticSearch >= 7.0, got version < 7.0.0")
+	}
+	return nil
+}
+
+func mlJobPath(name string, suffix string) (string, error) {
+	path, err := uritemplates.Expand("/_ml/anomaly_detectors/{name}", map[string]string{
+		"name": name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for machine learning job: %+v", err)
+	}
+	return path + suffix, nil
+}
+
+type MlJobGetResponse struct {
+	Jobs []MlJob `json:"jobs"`
+}
+
+type MlJob struct {
+	JobID           string          `json:"job_id"`
+	Description     string          `json:"description"`
+	Groups          []string        `json:"groups"`
+	AnalysisConfig  json.RawMessage `json:"analysis_config"`
+	DataDescription json.RawMessage `json:"data_description"`
+	AnalysisLimits  struct {
+		ModelMemoryLimit            string `json:"model_memory_limit"`
+		CategorizationExamplesLimit int    `json:"categorization_examples_limit"`
+	} `json:"analysis_limits"`
+	ModelSnapshotRetentionDays int             `json:"model_snapshot_retention_days"`
+	ResultsRetentionDays       int             `json:"results_retention_days"`
+	ResultsIndexName           string          `json:"results_index_name"`
+	CustomSettings             json.RawMessage `json:"custom_settings,omitempty"`
+	AllowLazyOpen              bool            `json:"allow_lazy_open"`
+}
+
+type MlJobStatsResponse struct {
+	Jobs []struct {
+		JobID string `json:"job_id"`
+		State string `json:"state"`
+	} `json:"jobs"`
+}
@@ -0,0 +1,128 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchXpackMlJob(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	_, allowed := esClient.(*elastic7.Client)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Machine learning jobs only supported on ES >= 7")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchXpackMlJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchXpackMlJob("Response times", "64mb"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackMlJobState("elasticsearch_xpack_ml_job.test", "opened"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ml_job.test", "model_memory_limit", "64mb"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ml_job.test", "results_index_name", "shared"),
+				),
+			},
+			{
+				// the job is closed to change the memory limit, and opened again
+				Config: testAccElasticsearchXpackMlJob("Mean response times", "128mb"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackMlJobState("elasticsearch_xpack_ml_job.test", "opened"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ml_job.test", "description", "Mean response times"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_ml_job.test", "model_memory_limit", "128mb"),
+				),
+			},
+			{
+				ResourceName:            "elasticsearch_xpack_ml_job.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"analysis_config", "data_description"},
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchXpackMlJobState(name string, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No machine learning job ID is set")
+		}
+
+		state, err := elasticsearchGetMlJobState(testAccXPackProvider.Meta(), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if state != expected {
+			return fmt.Errorf("Machine learning job %s is %s, expected %s", rs.Primary.ID, state, expected)
+		}
+
+		return nil
+	}
+}
+
+func testCheckElasticsearchXpackMlJobDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_xpack_ml_job" {
+			continue
+		}
+
+		job, err := elasticsearchGetMlJob(testAccXPackProvider.Meta(), rs.Primary.ID)
+		if err != nil || job == nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Machine learning job %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchXpackMlJob(description string, modelMemoryLimit string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_xpack_ml_job" "test" {
+  name               = "terraform-test"
+  description        = "%s"
+  groups             = ["terraform"]
+  model_memory_limit = "%s"
+
+  analysis_config = jsonencode({
+    bucket_span = "15m"
+    detectors = [
+      {
+        function   = "mean"
+        field_name = "responsetime"
+      }
+    ]
+  })
+  data_description = jsonencode({
+    time_field = "@timestamp"
+  })
+  custom_settings = jsonencode({
+    team = "terraform"
+  })
+}
+`, description, modelMemoryLimit)
+}
@@ -6,13 +6,20 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
 	elastic7 "github.com/olivere/elastic/v7"
 	elastic5 "gopkg.in/olivere/elastic.v5"
 	elastic6 "gopkg.in/olivere/elastic.v6"
 )
 
+// kibanaApplication is the application of the privileges of Kibana, for its
+// default kibana.index
+const kibanaApplication = "kibana-.kibana"
+
 func resourceElasticsearchXpackRole() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceElasticsearchXpackRoleCreate,
@@ -23,6 +30,7 @@ func resourceElasticsearchXpackRole() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"role_name": {
 				Type:     schema.TypeString,
+				ForceNew: true,
 				Required: true,
 			},
 			"indices": {
@@ -72,6 +80,12 @@ func resourceElasticsearchXpackRole() *schema.Resource {
 								},
 							},
 						},
+						"allow_restricted_indices": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the `names` patterns also cover restricted indices such as `.security`, available from Elasticsearch >= 6.7.",
+						},
 					},
 				},
 			},
@@ -101,6 +115,50 @@ func resourceElasticsearchXpackRole() *schema.Resource {
 					},
 				},
 			},
+			"kibana": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The privileges of the role in Kibana spaces, set as the application privileges of Kibana instead of in `applications`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"spaces": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The identifiers of the spaces of the privileges, `*` for all of them.",
+						},
+						"base": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{"all", "read"}, false),
+							},
+							Description: "The privileges on all the features of the spaces, `all` or `read`.",
+						},
+						"feature": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "The privileges on single features of the spaces, instead of the `base` privileges.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The identifier of the feature, e.g. `discover` or `dashboard`.",
+									},
+									"privileges": {
+										Type:        schema.TypeSet,
+										Required:    true,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Description: "The privileges on the feature, e.g. `all`, `read` or `minimal_read`.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 			"cluster": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -179,10 +237,11 @@ func resourceElasticsearchXpackRoleRead(d *schema.ResourceData, m interface{}) e
 		indices := make([]map[string]interface{}, 0, len(role.Indices))
 		for _, v := range role.Indices {
 			ip := map[string]interface{}{
-				"names":          v.Names,
-				"privileges":     v.Privileges,
-				"field_security": v.FieldSecurity,
-				"query":          v.Query,
+				"names":                    v.Names,
+				"privileges":               v.Privileges,
+				"field_security":           v.FieldSecurity,
+				"query":                    v.Query,
+				"allow_restricted_indices": v.AllowRestrictedIndices,
 			}
 			indices = append(indices, ip)
 		}
@@ -191,9 +250,25 @@ func resourceElasticsearchXpackRoleRead(d *schema.ResourceData, m interface{}) e
 
 	ds.set("cluster", role.Cluster)
 
+	// the privileges of Kibana are read back in the kibana blocks, unless they
+	// are configured in the applications
+	kibanaApplications := true
+	for _, a := range d.Get("applications").(*schema.Set).List() {
+		if a.(map[string]interface{})["application"] == kibanaApplication {
+			kibanaApplications = false
+		}
+	}
+
+	kibana := make([]map[string]interface{}, 0)
 	if len(role.Applications) > 0 {
 		applications := make([]map[string]interface{}, 0, len(role.Applications))
 		for _, va := range role.Applications {
+			if kibanaApplications && va.Application == kibanaApplication {
+				if k, ok := flattenKibanaRolePrivileges(va); ok {
+					kibana = append(kibana, k)
+					continue
+				}
+			}
 			ap := map[string]interface{}{
 				"application": va.Application,
 				"privileges":  va.Privileges,
@@ -201,8 +276,11 @@ func resourceElasticsearchXpackRoleRead(d *schema.ResourceData, m interface{}) e
 			}
 			applications = append(applications, ap)
 		}
-		ds.set("applications", applications)
+		if len(applications) > 0 {
+			ds.set("applications", applications)
+		}
 	}
+	ds.set("kibana", kibana)
 
 	ds.set("global", role.Global)
 	ds.set("run_as", role.RunAs)
@@ -244,6 +322,7 @@ func resourceElasticsearchXpackRoleDelete(d *schema.ResourceData, m interface{})
 			d.SetId("")
 			return nil
 		}
+		return err
 	}
 	d.SetId("")
 	return nil
@@ -262,6 +341,12 @@ func buildPutRoleBody(d *schema.ResourceData, m interface{}) (string, error) {
 		applicationsBody = append(applicationsBody, putApp)
 	}
 
+	kibana, err := expandKibanaRolePrivileges(d.Get("kibana").(*schema.Set).List())
+	if err != nil {
+		return "", err
+	}
+	applicationsBody = append(applicationsBody, kibana...)
+
 	indicesPrivileges, err := expandIndicesPermissionSet(d.Get("indices").(*schema.Set).List())
 	if err != nil {
 		log.Printf("Error in indices get : %v", err)
@@ -271,10 +356,11 @@ func buildPutRoleBody(d *schema.ResourceData, m interface{}) (string, error) {
 	var indicesBody []PutRoleIndicesPermissions
 	for _, indice := range indicesPrivileges {
 		putIndex := PutRoleIndicesPermissions{
-			Names:         indice.Names,
-			Privileges:    indice.Privileges,
-			FieldSecurity: indice.FieldSecurity,
-			Query:         optionalInterfaceJson(indice.Query.(string)),
+			Names:                  indice.Names,
+			Privileges:             indice.Privileges,
+			FieldSecurity:          indice.FieldSecurity,
+			Query:                  optionalInterfaceJson(indice.Query.(string)),
+			AllowRestrictedIndices: indice.AllowRestrictedIndices,
 		}
 		indicesBody = append(indicesBody, putIndex)
 	}
@@ -300,6 +386,111 @@ func buildPutRoleBody(d *schema.ResourceData, m interface{}) (string, error) {
 	return string(body[:]), err
 }
 
+// expandKibanaRolePrivileges returns the application privileges of Kibana of
+// the kibana blocks, e.g. space_read on space:marketing
+func expandKibanaRolePrivileges(raw []interface{}) ([]PutRoleApplicationPrivileges, error) {
+	applications := make([]PutRoleApplicationPrivileges, 0, len(raw))
+	for _, k := range raw {
+		data := k.(map[string]interface{})
+		spaces := expandStringList(data["spaces"].(*schema.Set).List())
+		base := expandStringList(data["base"].(*schema.Set).List())
+		features := data["feature"].(*schema.Set).List()
+
+		if len(base) > 0 && len(features) > 0 {
+			return nil, fmt.Errorf("the kibana privileges of the spaces %v cannot set both base and feature privileges", spaces)
+		}
+		if len(base) == 0 && len(features) == 0 {
+			return nil, fmt.Errorf("the kibana privileges of the spaces %v must set base or feature privileges", spaces)
+		}
+
+		allSpaces := false
+		resources := make([]string, 0, len(spaces))
+		for _, space := range spaces {
+			if space == "*" {
+				allSpaces = true
+				resources = append(resources, "*")
+			} else {
+				resources = append(resources, "space:"+space)
+			}
+		}
+		if allSpaces && len(spaces) > 1 {
+			return nil, fmt.Errorf("the kibana privileges of all the spaces cannot list other spaces: %v", spaces)
+		}
+
+		privileges := make([]string, 0)
+		for _, b := range base {
+			if allSpaces {
+				privileges = append(privileges, b)
+			} else {
+				privileges = append(privileges, "space_"+b)
+			}
+		}
+		for _, f := range features {
+			feature := f.(map[string]interface{})
+			for _, p := range expandStringList(feature["privileges"].(*schema.Set).List()) {
+				privileges = append(privileges, fmt.Sprintf("feature_%s.%s", feature["name"], p))
+			}
+		}
+
+		applications = append(applications, PutRoleApplicationPrivileges{
+			Application: kibanaApplication,
+			Privileges:  privileges,
+			Resources:   resources,
+		})
+	}
+	return applications, nil
+}
+
+// flattenKibanaRolePrivileges returns the kibana block of the application
+// privileges of Kibana, if they are base or feature privileges on spaces
+func flattenKibanaRolePrivileges(application XPackSecurityApplicationPrivileges) (map[string]interface{}, bool) {
+	spaces := make([]interface{}, 0, len(application.Resources))
+	for _, r := range application.Resources {
+		switch {
+		case r == "*":
+			spaces = append(spaces, r)
+		case strings.HasPrefix(r, "space:"):
+			spaces = append(spaces, strings.TrimPrefix(r, "space:"))
+		default:
+			return nil, false
+		}
+	}
+
+	base := make([]interface{}, 0)
+	var names []string
+	features := make(map[string][]interface{})
+	for _, p := range application.Privileges {
+		switch {
+		case p == "all" || p == "read":
+			base = append(base, p)
+		case p == "space_all" || p == "space_read":
+			base = append(base, strings.TrimPrefix(p, "space_"))
+		case strings.HasPrefix(p, "feature_") && strings.Contains(p, "."):
+			parts := strings.SplitN(strings.TrimPrefix(p, "feature_"), ".", 2)
+			if _, ok := features[parts[0]]; !ok {
+				names = append(names, parts[0])
+			}
+			features[parts[0]] = append(features[parts[0]], parts[1])
+		default:
+			return nil, false
+		}
+	}
+
+	feature := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		feature = append(feature, map[string]interface{}{
+			"name":       name,
+			"privileges": features[name],
+		})
+	}
+
+	return map[string]interface{}{
+		"spaces":  spaces,
+		"base":    base,
+		"feature": feature,
+	}, true
+}
+
 func xpackPutRole(d *schema.ResourceData, m interface{}, name string, body string) error {
 	esClient, err := getClient(m.(*ProviderConf))
 	if err != nil {
@@ -373,76 +564,63 @@ func elastic5GetRole(client *elastic5.Client, name string) (XPackSecurityRole, e
 }
 
 func elastic6GetRole(client *elastic6.Client, name string) (XPackSecurityRole, error) {
-	res, err := client.XPackSecurityGetRole(name).Do(context.Background())
+	path, err := uritemplates.Expand("/_xpack/security/role/{name}", map[string]string{
+		"name": name,
+	})
 	if err != nil {
-		return XPackSecurityRole{}, err
+		return XPackSecurityRole{}, fmt.Errorf("error building URL path for role: %+v", err)
 	}
-	obj := (*res)[name]
-	role := XPackSecurityRole{}
-	role.Name = name
-	role.Cluster = obj.Cluster
 
-	// if we have field security settings, we have to flatten them for tf
-	if len(obj.Indices) > 0 {
-		if data, err := flattenIndicesPermissionSetv6(obj.Indices); err == nil {
-			role.Indices = data
-		} else {
-			log.Printf("[INFO] Data: %+v", data)
-			return role, err
-		}
+	res, err := client.PerformRequest(context.Background(), elastic6.PerformRequestOptions{
+		Method: "GET",
+		Path:   path,
+	})
+	if err != nil {
+		return XPackSecurityRole{}, err
 	}
 
-	if data, err := json.Marshal(obj.Applications); err == nil {
-		if err := json.Unmarshal(data, &role.Applications); err != nil {
-			log.Printf("[INFO] Data: %+v", data)
-			return role, err
-		}
-	}
-	if global, err := json.Marshal(obj.Global); err != nil {
-		return role, err
-	} else {
-		// The Elastic API will not return the field unless it exists, which force us to check for null compared to Metadata
-		if string(global) == "null" {
-			role.Global = ""
-		} else {
-			role.Global = string(global)
-		}
-	}
-	if metadata, err := json.Marshal(obj.Metadata); err != nil {
-		return role, err
-	} else {
-		role.Metadata = string(metadata)
-	}
-	return role, err
+	return xpackSecurityRoleFromResponse(name, res.Body)
 }
 
 func elastic7GetRole(client *elastic7.Client, name string) (XPackSecurityRole, error) {
-	res, err := client.XPackSecurityGetRole(name).Do(context.Background())
+	path, err := uritemplates.Expand("/_security/role/{name}", map[string]string{
+		"name": name,
+	})
+	if err != nil {
+		return XPackSecurityRole{}, fmt.Errorf("error building URL path for role: %+v", err)
+	}
+
+	res, err := client.PerformRequest(context.Background(), elastic7.PerformRequestOptions{
+		Method: "GET",
+		Path:   path,
+	})
 	if err != nil {
 		return XPackSecurityRole{}, err
 	}
-	obj := (*res)[name]
+
+	return xpackSecurityRoleFromResponse(name, res.Body)
+}
+
+// The upstream clients don't model every field of a role (e.g.
+// allow_restricted_indices), so the response is decoded here instead
+func xpackSecurityRoleFromResponse(name string, body json.RawMessage) (XPackSecurityRole, error) {
+	var res map[string]XPackSecurityRoleResponse
+	if err := json.Unmarshal(body, &res); err != nil {
+		return XPackSecurityRole{}, fmt.Errorf("error unmarshalling role body: %+v: %+v", err, body)
+	}
+
+	obj := res[name]
 	role := XPackSecurityRole{}
 	role.Name = name
 	role.Cluster = obj.Cluster
+	role.RunAs = obj.RunAs
+	role.Applications = obj.Applications
 
 	// if we have field security settings, we have to flatten them for tf
 	if len(obj.Indices) > 0 {
-		if data, err := flattenIndicesPermissionSetv7(obj.Indices); err == nil {
-			role.Indices = data
-		} else {
-			log.Printf("Data: %v\n", data)
-
-			return role, err
-		}
+		role.Indices = flattenIndicesPermissionSet(obj.Indices)
 	}
 
-	if data, err := json.Marshal(obj.Applications); err == nil {
-		if err := json.Unmarshal(data, &role.Applications); err != nil {
-			log.Printf("Data : %s\n", data)
-			return role, err
-		}
-	}
 	if global, err := json.Marshal(obj.Global); err != nil {
 		return role, err
 	} else {
@@ -458,7 +636,7 @@ func elastic7GetRole(client *elastic7.Client, name string) (XPackSecurityRole, e
 	} else {
 		role.Metadata = string(metadata)
 	}
-	return role, err
+	return role, nil
 }
 
 func elastic5DeleteRole(client *elastic5.Client, name string) error {
@@ -492,10 +670,11 @@ type PutRoleApplicationPrivileges struct {
 }
 
 type PutRoleIndicesPermissions struct {
-	Names         []string            `json:"names"`
-	Privileges    []string            `json:"privileges"`
-	FieldSecurity map[string][]string `json:"field_security,omitempty"`
-	Query         interface{}         `json:"query,omitempty"`
+	Names                  []string            `json:"names"`
+	Privileges             []string            `json:"privileges"`
+	FieldSecurity          map[string][]string `json:"field_security,omitempty"`
+	Query                  interface{}         `json:"query,omitempty"`
+	AllowRestrictedIndices bool                `json:"allow_restricted_indices,omitempty"`
 }
 
 type XPackSecurityRole struct {
@@ -517,8 +696,28 @@ type XPackSecurityApplicationPrivileges struct {
 
 // XPackSecurityIndicesPermissions is the indices permission object of Elasticsearch
 type XPackSecurityIndicesPermissions struct {
-	Names         []string                 `json:"names"`
-	Privileges    []string                 `json:"privileges"`
-	FieldSecurity []map[string]interface{} `json:"field_security"`
-	Query         string                   `json:"query"`
+	Names                  []string                 `json:"names"`
+	Privileges             []string                 `json:"privileges"`
+	FieldSecurity          []map[string]interface{} `json:"field_security"`
+	Query                  string                   `json:"query"`
+	AllowRestrictedIndices bool                     `json:"allow_restricted_indices"`
+}
+
+// XPackSecurityRoleResponse is a role as returned by the get role API
+type XPackSecurityRoleResponse struct {
+	Cluster      []string                             `json:"cluster"`
+	Indices      []XPackSecurityRoleResponseIndices   `json:"indices"`
+	Applications []XPackSecurityApplicationPrivileges `json:"applications"`
+	RunAs        []string                             `json:"run_as"`
+	Global       interface{}                          `json:"global,omitempty"`
+	Metadata     interface{}                          `json:"metadata"`
+}
+
+// XPackSecurityRoleResponseIndices is the indices permission object as returned by the get role API
+type XPackSecurityRoleResponseIndices struct {
+	Names                  []string    `json:"names"`
+	Privileges             []string    `json:"privileges"`
+	FieldSecurity          interface{} `json:"field_security,omitempty"`
+	Query                  string      `json:"query"`
+	AllowRestrictedIndices bool        `json:"allow_restricted_indices"`
 }
@@ -8,6 +8,8 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
 	elastic7 "github.com/olivere/elastic/v7"
 	elastic5 "gopkg.in/olivere/elastic.v5"
 	elastic6 "gopkg.in/olivere/elastic.v6"
@@ -24,6 +26,7 @@ func resourceElasticsearchXpackRoleMapping() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"role_mapping_name": {
 				Type:        schema.TypeString,
+				ForceNew:    true,
 				Required:    true,
 				Description: "The distinct name that identifies the role mapping, used solely as an identifier.",
 			},
@@ -36,16 +39,26 @@ func resourceElasticsearchXpackRoleMapping() *schema.Resource {
 			"rules": {
 				Type:             schema.TypeString,
 				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
 				DiffSuppressFunc: suppressEquivalentJson,
-				Description:      "A list of mustache templates that will be evaluated to determine the roles names that should granted to the users that match the role mapping rules. This matches fields of users, rules can be grouped into `all` and `any` top level keys.",
+				Description:      "A JSON string of the rules matching the fields of users, e.g. `username` or `groups`. Rules can be combined with the `all` and `any` keys, and negated with the `except` key.",
 			},
 			"roles": {
 				Type: schema.TypeSet,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
-				Required:    true,
-				Description: "A list of role names that are granted to the users that match the role mapping rules.",
+				Optional:     true,
+				ExactlyOneOf: []string{"roles", "role_templates"},
+				Description:  "A list of role names that are granted to the users that match the role mapping rules.",
+			},
+			"role_templates": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: diffSuppressRoleMappingRoleTemplates,
+				ExactlyOneOf:     []string{"roles", "role_templates"},
+				Description:      "A JSON array of mustache templates evaluated to determine the names of the roles granted to the users that match the role mapping rules, e.g. `[{\"template\": {\"source\": \"{{#tojson}}groups{{/tojson}}\"}, \"format\": \"json\"}]`.",
 			},
 			"metadata": {
 				Type:             schema.TypeString,
@@ -102,6 +115,7 @@ func resourceElasticsearchXpackRoleMappingRead(d *schema.ResourceData, m interfa
 	ds := &resourceDataSetter{d: d}
 	ds.set("role_mapping_name", roleMapping.Name)
 	ds.set("roles", roleMapping.Roles)
+	ds.set("role_templates", roleMapping.RoleTemplates)
 	ds.set("enabled", roleMapping.Enabled)
 	ds.set("rules", roleMapping.Rules)
 	ds.set("metadata", roleMapping.Metadata)
@@ -142,6 +156,7 @@ func resourceElasticsearchXpackRoleMappingDelete(d *schema.ResourceData, m inter
 			d.SetId("")
 			return nil
 		}
+		return err
 	}
 	d.SetId("")
 	return nil
@@ -153,11 +168,14 @@ func buildPutRoleMappingBody(d *schema.ResourceData, m interface{}) (string, err
 	roles := expandStringList(d.Get("roles").(*schema.Set).List())
 	metadata := d.Get("metadata").(string)
 
+	roleTemplates := d.Get("role_templates").(string)
+
 	roleMapping := PutRoleMappingBody{
-		Roles:    roles,
-		Enabled:  enabled,
-		Rules:    json.RawMessage(rules),
-		Metadata: optionalInterfaceJson(metadata),
+		Roles:         roles,
+		RoleTemplates: optionalInterfaceJson(roleTemplates),
+		Enabled:       enabled,
+		Rules:         json.RawMessage(rules),
+		Metadata:      optionalInterfaceJson(metadata),
 	}
 
 	body, err := json.Marshal(roleMapping)
@@ -240,35 +258,52 @@ func elastic5GetRoleMapping(client *elastic5.Client, name string) (XPackSecurity
 }
 
 func elastic6GetRoleMapping(client *elastic6.Client, name string) (XPackSecurityRoleMapping, error) {
-	res, err := client.XPackSecurityGetRoleMapping(name).Do(context.Background())
+	path, err := uritemplates.Expand("/_xpack/security/role_mapping/{name}", map[string]string{
+		"name": name,
+	})
 	if err != nil {
-		return XPackSecurityRoleMapping{}, err
-	}
-	obj := (*res)[name]
-	roleMapping := XPackSecurityRoleMapping{}
-	roleMapping.Name = name
-	roleMapping.Roles = obj.Roles
-	roleMapping.Enabled = obj.Enabled
-	if rules, err := json.Marshal(obj.Rules); err != nil {
-		return roleMapping, err
-	} else {
-		roleMapping.Rules = string(rules)
+		return XPackSecurityRoleMapping{}, fmt.Errorf("error building URL path for role mapping: %+v", err)
 	}
-	if metadata, err := json.Marshal(obj.Metadata); err != nil {
-		return roleMapping, err
-	} else {
-		roleMapping.Metadata = string(metadata)
+
+	res, err := client.PerformRequest(context.Background(), elastic6.PerformRequestOptions{
+		Method: "GET",
+		Path:   path,
+	})
+	if err != nil {
+		return XPackSecurityRoleMapping{}, err
 	}
 
-	return roleMapping, err
+	return xpackSecurityRoleMappingFromResponse(name, res.Body)
 }
 
 func elastic7GetRoleMapping(client *elastic7.Client, name string) (XPackSecurityRoleMapping, error) {
-	res, err := client.XPackSecurityGetRoleMapping(name).Do(context.Background())
+	path, err := uritemplates.Expand("/_security/role_mapping/{name}", map[string]string{
+		"name": name,
+	})
+	if err != nil {
+		return XPackSecurityRoleMapping{}, fmt.Errorf("error building URL path for role mapping: %+v", err)
+	}
+
+	res, err := client.PerformRequest(context.Background(), elastic7.PerformRequestOptions{
+		Method: "GET",
+		Path:   path,
+	})
 	if err != nil {
 		return XPackSecurityRoleMapping{}, err
 	}
-	obj := (*res)[name]
+
+	return xpackSecurityRoleMappingFromResponse(name, res.Body)
+}
+
+// The upstream clients don't model the role templates of role mappings, so
+// the response is decoded here instead
+func xpackSecurityRoleMappingFromResponse(name string, body json.RawMessage) (XPackSecurityRoleMapping, error) {
+	var res map[string]XPackSecurityRoleMappingResponse
+	if err := json.Unmarshal(body, &res); err != nil {
+		return XPackSecurityRoleMapping{}, fmt.Errorf("error unmarshalling role mapping body: %+v: %+v", err, body)
+	}
+
+	obj := res[name]
 	roleMapping := XPackSecurityRoleMapping{}
 	roleMapping.Name = name
 	roleMapping.Roles = obj.Roles
@@ -278,13 +313,21 @@ func elastic7GetRoleMapping(client *elastic7.Client, name string) (XPackSecurity
 	} else {
 		roleMapping.Rules = string(rules)
 	}
+	// role templates are only returned by mappings using them instead of roles
+	if obj.RoleTemplates != nil {
+		if roleTemplates, err := json.Marshal(obj.RoleTemplates); err != nil {
+			return roleMapping, err
+		} else {
+			roleMapping.RoleTemplates = string(roleTemplates)
+		}
+	}
 	if metadata, err := json.Marshal(obj.Metadata); err != nil {
 		return roleMapping, err
 	} else {
 		roleMapping.Metadata = string(metadata)
 	}
 
-	return roleMapping, err
+	return roleMapping, nil
 }
 
 func elastic5DeleteRoleMapping(client *elastic5.Client, name string) error {
@@ -303,16 +346,28 @@ func elastic7DeleteRoleMapping(client *elastic7.Client, name string) error {
 }
 
 type PutRoleMappingBody struct {
-	Roles    []string    `json:"roles"`
-	Enabled  bool        `json:"enabled"`
-	Rules    interface{} `json:"rules"`
-	Metadata interface{} `json:"metadata,omitempty"`
+	Roles         []string    `json:"roles,omitempty"`
+	RoleTemplates interface{} `json:"role_templates,omitempty"`
+	Enabled       bool        `json:"enabled"`
+	Rules         interface{} `json:"rules"`
+	Metadata      interface{} `json:"metadata,omitempty"`
 }
 
 type XPackSecurityRoleMapping struct {
-	Name     string   `json:"name"`
-	Roles    []string `json:"roles"`
-	Enabled  bool     `json:"enabled"`
-	Rules    string   `json:"rules"`
-	Metadata string   `json:"metadata"`
+	Name          string   `json:"name"`
+	Roles         []string `json:"roles"`
+	RoleTemplates string   `json:"role_templates"`
+	Enabled       bool     `json:"enabled"`
+	Rules         string   `json:"rules"`
+	Metadata      string   `json:"metadata"`
+}
+
+// XPackSecurityRoleMappingResponse is a role mapping as returned by the get
+// role mapping API
+type XPackSecurityRoleMappingResponse struct {
+	Roles         []string    `json:"roles"`
+	RoleTemplates interface{} `json:"role_templates,omitempty"`
+	Enabled       bool        `json:"enabled"`
+	Rules         interface{} `json:"rules"`
+	Metadata      interface{} `json:"metadata"`
 }
@@ -94,6 +94,21 @@ func TestAccElasticsearchXpackRoleMapping(t *testing.T) {
 					),
 				),
 			},
+			{
+				Config: testAccRoleMappingResource_RoleTemplates(randomName),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRoleMappingExists("elasticsearch_xpack_role_mapping.test"),
+					resource.TestCheckResourceAttr(
+						"elasticsearch_xpack_role_mapping.test",
+						"roles.#",
+						"0",
+					),
+					resource.TestCheckResourceAttrSet(
+						"elasticsearch_xpack_role_mapping.test",
+						"role_templates",
+					),
+				),
+			},
 		},
 	})
 }
@@ -272,3 +287,29 @@ func TestAccRoleMappingResource_importBasic(t *testing.T) {
 		},
 	})
 }
+
+func testAccRoleMappingResource_RoleTemplates(resourceName string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_xpack_role_mapping" "test" {
+  role_mapping_name = "%s"
+  role_templates = <<-EOF
+  [
+    {
+      "template": {
+        "source": "{{#tojson}}groups{{/tojson}}"
+      },
+      "format": "json"
+    }
+  ]
+  EOF
+  rules = <<-EOF
+  {
+    "field": {
+      "realm.name": "ldap1"
+    }
+  }
+  EOF
+  enabled = true
+}
+`, resourceName)
+}
@@ -77,6 +77,11 @@ func TestAccElasticsearchXpackRole(t *testing.T) {
 						"metadata",
 						`{"foo":"bar"}`,
 					),
+					resource.TestCheckResourceAttr(
+						"elasticsearch_xpack_role.test",
+						"indices.#",
+						"3",
+					),
 				),
 			},
 			{
@@ -90,6 +95,35 @@ func TestAccElasticsearchXpackRole(t *testing.T) {
 					),
 				),
 			},
+			{
+				Config: testAccRoleResource_Kibana(randomName),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRoleExists("elasticsearch_xpack_role.test"),
+					resource.TestCheckResourceAttr(
+						"elasticsearch_xpack_role.test",
+						"kibana.#",
+						"2",
+					),
+					resource.TestCheckResourceAttr(
+						"elasticsearch_xpack_role.test",
+						"applications.#",
+						"1",
+					),
+				),
+			},
+			{
+				// renaming replaces the role, the old one is deleted
+				Config: testAccRoleResource(randomName + "renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRoleExists("elasticsearch_xpack_role.test"),
+					testCheckRoleNotExists(randomName),
+					resource.TestCheckResourceAttr(
+						"elasticsearch_xpack_role.test",
+						"id",
+						randomName+"renamed",
+					),
+				),
+			},
 		},
 	})
 }
@@ -163,6 +197,34 @@ func testCheckRoleExists(name string) resource.TestCheckFunc {
 	}
 }
 
+func testCheckRoleNotExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		meta := testAccXPackProvider.Meta()
+		esClient, err := getClient(meta.(*ProviderConf))
+		if err != nil {
+			return err
+		}
+
+		if client, ok := esClient.(*elastic7.Client); ok {
+			_, err = client.XPackSecurityGetRole(name).Do(context.TODO())
+			if elasticErr, ok := err.(*elastic7.Error); ok && elasticErr.Status == 404 {
+				return nil
+			}
+		} else {
+			client := esClient.(*elastic6.Client)
+			_, err = client.XPackSecurityGetRole(name).Do(context.TODO())
+			if elasticErr, ok := err.(*elastic6.Error); ok && elasticErr.Status == 404 {
+				return nil
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("Role %q still exists", name)
+	}
+}
+
 func testAccRoleResource(resourceName string) string {
 	return fmt.Sprintf(`
 	resource "elasticsearch_xpack_role" "test" {
@@ -211,6 +273,11 @@ func testAccRoleResource_Updated(resourceName string) string {
 			names 	 = ["testIndice2"]
 			privileges = ["write"]
 		}
+		indices {
+			names 	 = [".security*"]
+			privileges = ["read"]
+			allow_restricted_indices = true
+		}
 		cluster = [
 		"all"
 		]
@@ -281,6 +348,38 @@ func testAccRoleResource_Global(resourceName string) string {
 	`, resourceName)
 }
 
+func testAccRoleResource_Kibana(resourceName string) string {
+	return fmt.Sprintf(`
+	resource "elasticsearch_xpack_role" "test" {
+		role_name = "%s"
+		applications {
+			application = "testapp"
+			privileges = [
+			"read",
+			]
+			resources = [
+			"*",
+			]
+		}
+		kibana {
+			spaces = ["*"]
+			base   = ["read"]
+		}
+		kibana {
+			spaces = ["default", "marketing"]
+			feature {
+				name       = "discover"
+				privileges = ["all"]
+			}
+			feature {
+				name       = "dashboard"
+				privileges = ["read"]
+			}
+		}
+	}
+	`, resourceName)
+}
+
 func TestAccRoleResource_importBasic(t *testing.T) {
 	provider := Provider().(*schema.Provider)
 	err := provider.Configure(&terraform.ResourceConfig{})
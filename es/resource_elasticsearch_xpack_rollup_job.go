@@ -0,0 +1,277 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func resourceElasticsearchXpackRollupJob() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an Elasticsearch XPack rollup job, summarizing the documents of the source indices into a rollup index on a schedule. Jobs can't be updated, so changing them replaces the job. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/rollup-apis.html) for more details.",
+		Create:      resourceElasticsearchXpackRollupJobCreate,
+		Read:        resourceElasticsearchXpackRollupJobRead,
+		Update:      resourceElasticsearchXpackRollupJobUpdate,
+		Delete:      resourceElasticsearchXpackRollupJobDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Identifier of the rollup job.",
+			},
+			"index_pattern": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "The index, or wildcard expression of indices, to roll up.",
+			},
+			"rollup_index": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "The index to store the rollup documents in.",
+			},
+			"cron": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "The schedule of the job, a cron expression, e.g. `0 0 * * * ?`.",
+			},
+			"page_size": {
+				Type:         schema.TypeInt,
+				ForceNew:     true,
+				Optional:     true,
+				Default:      1000,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The number of bucket results processed on each iteration of the job.",
+			},
+			"groups": {
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: diffSuppressRollupJobGroups,
+				Description:      "A JSON string of the groups of the rollup documents, the `date_histogram` and optionally `terms` and `histogram` groups.",
+			},
+			"metrics": {
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJson,
+				Description:      "A JSON string of the list of metrics collected for fields, e.g. `[{\"field\": \"bytes\", \"metrics\": [\"sum\", \"max\"]}]`.",
+			},
+			"started": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the job is started.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceElasticsearchXpackRollupJobCreate(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+
+	if err := elastic7CheckRollupJobSupported(meta); err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"index_pattern": d.Get("index_pattern").(string),
+		"rollup_index":  d.Get("rollup_index").(string),
+		"cron":          d.Get("cron").(string),
+		"page_size":     d.Get("page_size").(int),
+		"groups":        json.RawMessage(d.Get("groups").(string)),
+	}
+	if metrics, ok := d.GetOk("metrics"); ok {
+		body["metrics"] = json.RawMessage(metrics.(string))
+	}
+
+	path, err := rollupJobPath(name, "")
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "PUT", path, body); err != nil {
+		return err
+	}
+	d.SetId(name)
+
+	if d.Get("started").(bool) {
+		if err := elasticsearchSetRollupJobStarted(meta, name, true); err != nil {
+			return err
+		}
+	}
+
+	return resourceElasticsearchXpackRollupJobRead(d, meta)
+}
+
+func resourceElasticsearchXpackRollupJobRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	if err := elastic7CheckRollupJobSupported(meta); err != nil {
+		return err
+	}
+
+	job, err := elasticsearchGetRollupJob(meta, id)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Rollup job (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	if job == nil {
+		log.Printf("[WARN] Rollup job (%s) not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	var metrics string
+	// jobs without metrics are read back with an empty list
+	if len(job.Config.Metrics) > 0 && string(job.Config.Metrics) != "[]" {
+		metrics = string(job.Config.Metrics)
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("name", id)
+	ds.set("index_pattern", job.Config.IndexPattern)
+	ds.set("rollup_index", job.Config.RollupIndex)
+	ds.set("cron", job.Config.Cron)
+	ds.set("page_size", job.Config.PageSize)
+	ds.set("groups", string(job.Config.Groups))
+	ds.set("metrics", metrics)
+	ds.set("started", job.Status.JobState != "stopped")
+	return ds.err
+}
+
+func resourceElasticsearchXpackRollupJobUpdate(d *schema.ResourceData, meta interface{}) error {
+	// all the fields of the job force a new resource, so only the state of
+	// the job can change
+	if d.HasChange("started") {
+		if err := elastic7CheckRollupJobSupported(meta); err != nil {
+			return err
+		}
+		if err := elasticsearchSetRollupJobStarted(meta, d.Id(), d.Get("started").(bool)); err != nil {
+			return err
+		}
+	}
+
+	return resourceElasticsearchXpackRollupJobRead(d, meta)
+}
+
+func resourceElasticsearchXpackRollupJobDelete(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	if err := elastic7CheckRollupJobSupported(meta); err != nil {
+		return err
+	}
+
+	// started jobs can't be deleted
+	if err := elasticsearchSetRollupJobStarted(meta, id, false); err != nil {
+		return err
+	}
+
+	path, err := rollupJobPath(id, "")
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "DELETE", path, nil); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func elasticsearchSetRollupJobStarted(meta interface{}, name string, started bool) error {
+	suffix := "/_stop?wait_for_completion=true"
+	if started {
+		suffix = "/_start"
+	}
+
+	path, err := rollupJobPath(name, suffix)
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "POST", path, nil); err != nil {
+		return fmt.Errorf("error setting started to %t for rollup job %s: %+v", started, name, err)
+	}
+	return nil
+}
+
+func elasticsearchGetRollupJob(meta interface{}, name string) (*RollupJob, error) {
+	path, err := rollupJobPath(name, "")
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := elasticsearchPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(RollupJobGetResponse)
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling rollup job body: %+v: %+v", err, body)
+	}
+
+	for _, job := range response.Jobs {
+		if job.Config.ID == name {
+			return &job, nil
+		}
+	}
+	return nil, nil
+}
+
+func elastic7CheckRollupJobSupported(meta interface{}) error {
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+
+	if _, ok := esClient.(*elastic7.Client); !ok {
+		return fmt.Errorf("rollup jobs only available from ElasticSearch >= 7.0, got version < 7.0.0")
+	}
+	return nil
+}
+
+func rollupJobPath(name string, suffix string) (string, error) {
+	path, err := uritemplates.Expand("/_rollup/job/{name}", map[string]string{
+		"name": name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for rollup job: %+v", err)
+	}
+	return path + suffix, nil
+}
+
+type RollupJobGetResponse struct {
+	Jobs []RollupJob `json:"jobs"`
+}
+
+type RollupJob struct {
+	Config struct {
+		ID           string          `json:"id"`
+		IndexPattern string          `json:"index_pattern"`
+		RollupIndex  string          `json:"rollup_index"`
+		Cron         string          `json:"cron"`
+		PageSize     int             `json:"page_size"`
+		Groups       json.RawMessage `json:"groups"`
+		Metrics      json.RawMessage `json:"metrics"`
+	} `json:"config"`
+	Status struct {
+		JobState string `json:"job_state"`
+	} `json:"status"`
+}
@@ -0,0 +1,147 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchXpackRollupJob(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	_, allowed := esClient.(*elastic7.Client)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Rollup jobs only supported on ES >= 7")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchXpackRollupJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchXpackRollupJob(true),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackRollupJobStarted("elasticsearch_xpack_rollup_job.test", true),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_rollup_job.test", "page_size", "1000"),
+				),
+			},
+			{
+				Config: testAccElasticsearchXpackRollupJob(false),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackRollupJobStarted("elasticsearch_xpack_rollup_job.test", false),
+				),
+			},
+			{
+				// deleting stops the job first
+				Config: testAccElasticsearchXpackRollupJob(true),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackRollupJobStarted("elasticsearch_xpack_rollup_job.test", true),
+				),
+			},
+			{
+				ResourceName:      "elasticsearch_xpack_rollup_job.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchXpackRollupJobStarted(name string, started bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No rollup job ID is set")
+		}
+
+		job, err := elasticsearchGetRollupJob(testAccXPackProvider.Meta(), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if job == nil {
+			return fmt.Errorf("Rollup job %q not found", rs.Primary.ID)
+		}
+		if (job.Status.JobState != "stopped") != started {
+			return fmt.Errorf("Rollup job %s is %s, expected started to be %t", rs.Primary.ID, job.Status.JobState, started)
+		}
+
+		return nil
+	}
+}
+
+func testCheckElasticsearchXpackRollupJobDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_xpack_rollup_job" {
+			continue
+		}
+
+		job, err := elasticsearchGetRollupJob(testAccXPackProvider.Meta(), rs.Primary.ID)
+		if err != nil || job == nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Rollup job %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchXpackRollupJob(started bool) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_index" "test" {
+  name               = "terraform-test-rollup-source"
+  number_of_shards   = 1
+  number_of_replicas = 0
+  mappings = jsonencode({
+    properties = {
+      "@timestamp" = { type = "date" }
+      host         = { type = "keyword" }
+      bytes        = { type = "long" }
+    }
+  })
+}
+
+resource "elasticsearch_xpack_rollup_job" "test" {
+  name          = "terraform-test"
+  index_pattern = elasticsearch_index.test.name
+  rollup_index  = "terraform-test-rollup"
+  cron          = "0 0 * * * ?"
+  started       = %t
+
+  groups = jsonencode({
+    date_histogram = {
+      field          = "@timestamp"
+      fixed_interval = "1h"
+    }
+    terms = {
+      fields = ["host"]
+    }
+  })
+  metrics = jsonencode([
+    {
+      field   = "bytes"
+      metrics = ["sum", "max"]
+    }
+  ])
+}
+`, started)
+}
@@ -0,0 +1,214 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+var serviceTokenMinimalVersion, _ = version.NewVersion("7.13.0")
+
+func resourceElasticsearchXpackServiceToken() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an Elasticsearch XPack service account token, used by Elastic services such as Fleet Server to authenticate. The token is only returned when it is created, and deleted on destroy. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/security-api-create-service-token.html) for more details.",
+		Create:      resourceElasticsearchXpackServiceTokenCreate,
+		Read:        resourceElasticsearchXpackServiceTokenRead,
+		Delete:      resourceElasticsearchXpackServiceTokenDelete,
+		Schema: map[string]*schema.Schema{
+			"service_account": {
+				Type:         schema.TypeString,
+				ForceNew:     true,
+				Required:     true,
+				ValidateFunc: validateServiceAccount,
+				Description:  "The service account of the token, as `namespace/service`, e.g. `elastic/fleet-server`.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Name of the token, unique for the service account.",
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The bearer token, used in the `Authorization: Bearer` header.",
+			},
+		},
+	}
+}
+
+func resourceElasticsearchXpackServiceTokenCreate(d *schema.ResourceData, meta interface{}) error {
+	serviceAccount := d.Get("service_account").(string)
+	name := d.Get("name").(string)
+
+	if err := elastic7CheckServiceTokenVersion(meta); err != nil {
+		return err
+	}
+
+	path, err := serviceTokenPath(serviceAccount, "/credential/token/"+url.PathEscape(name))
+	if err != nil {
+		return err
+	}
+	res, err := elasticsearchPerformRequest(meta, "POST", path, nil)
+	if err != nil {
+		return err
+	}
+
+	response := new(ServiceTokenCreateResponse)
+	if err := json.Unmarshal(res, response); err != nil {
+		return fmt.Errorf("error unmarshalling service token body: %+v", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", serviceAccount, name))
+	if err := d.Set("value", response.Token.Value); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchXpackServiceTokenRead(d, meta)
+}
+
+func resourceElasticsearchXpackServiceTokenRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+	serviceAccount, name, err := parseServiceTokenID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := elastic7CheckServiceTokenVersion(meta); err != nil {
+		return err
+	}
+
+	path, err := serviceTokenPath(serviceAccount, "/credential")
+	if err != nil {
+		return err
+	}
+	body, err := elasticsearchPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Service token (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	response := new(ServiceAccountCredentialsResponse)
+	if err := json.Unmarshal(body, response); err != nil {
+		return fmt.Errorf("error unmarshalling service account credentials body: %+v: %+v", err, body)
+	}
+	if _, ok := response.Tokens[name]; !ok {
+		log.Printf("[WARN] Service token (%s) not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("service_account", serviceAccount)
+	ds.set("name", name)
+	return ds.err
+}
+
+func resourceElasticsearchXpackServiceTokenDelete(d *schema.ResourceData, meta interface{}) error {
+	serviceAccount, name, err := parseServiceTokenID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if err := elastic7CheckServiceTokenVersion(meta); err != nil {
+		return err
+	}
+
+	path, err := serviceTokenPath(serviceAccount, "/credential/token/"+url.PathEscape(name))
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "DELETE", path, nil); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func validateServiceAccount(v interface{}, k string) (ws []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if parts := strings.Split(value, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		errors = append(errors, fmt.Errorf("%q must be a service account as namespace/service, e.g. elastic/fleet-server, got: %s", k, value))
+	}
+	return
+}
+
+// parseServiceTokenID splits the namespace/service/name ID of a token into
+// its service account and its name
+func parseServiceTokenID(id string) (string, string, error) {
+	i := strings.LastIndex(id, "/")
+	if i < 0 || strings.Count(id, "/") != 2 {
+		return "", "", fmt.Errorf("unexpected format of ID (%s), expected namespace/service/name", id)
+	}
+	return id[:i], id[i+1:], nil
+}
+
+func elastic7CheckServiceTokenVersion(meta interface{}) error {
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+
+	client, ok := esClient.(*elastic7.Client)
+	if !ok {
+		return fmt.Errorf("service tokens only available from ElasticSearch >= 7.13, got version < 7.0.0")
+	}
+
+	elasticVersion, err := elastic7GetVersion(client)
+	if err != nil {
+		return err
+	}
+	if elasticVersion.LessThan(serviceTokenMinimalVersion) {
+		return fmt.Errorf("service tokens only available from ElasticSearch >= 7.13, got version %s", elasticVersion.String())
+	}
+	return nil
+}
+
+func serviceTokenPath(serviceAccount string, suffix string) (string, error) {
+	parts := strings.SplitN(serviceAccount, "/", 2)
+	if len(parts) != 2 {
+		return "", fmt.Errorf("unexpected format of service account (%s), expected namespace/service", serviceAccount)
+	}
+
+	path, err := uritemplates.Expand("/_security/service/{namespace}/{service}", map[string]string{
+		"namespace": parts[0],
+		"service":   parts[1],
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for service account: %+v", err)
+	}
+	return path + suffix, nil
+}
+
+type ServiceTokenCreateResponse struct {
+	Created bool `json:"created"`
+	Token   struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"token"`
+}
+
+type ServiceAccountCredentialsResponse struct {
+	ServiceAccount string                     `json:"service_account"`
+	Count          int                        `json:"count"`
+	Tokens         map[string]json.RawMessage `json:"tokens"`
+}
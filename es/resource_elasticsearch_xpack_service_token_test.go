@@ -0,0 +1,127 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchXpackServiceToken(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		elasticVersion, err := elastic7GetVersion(client)
+		if err != nil {
+			t.Skipf("err: %s", err)
+		}
+		allowed = !elasticVersion.LessThan(serviceTokenMinimalVersion)
+	default:
+		allowed = false
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Service tokens only supported on ES >= 7.13")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchXpackServiceTokenDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchXpackServiceToken,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackServiceTokenExists("elasticsearch_xpack_service_token.test"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_service_token.test", "id", "elastic/fleet-server/terraform-test"),
+					resource.TestCheckResourceAttrSet("elasticsearch_xpack_service_token.test", "value"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchXpackServiceTokenExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No service token ID is set")
+		}
+
+		exists, err := testElasticsearchXpackServiceTokenExists(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("Service token %q not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testCheckElasticsearchXpackServiceTokenDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_xpack_service_token" {
+			continue
+		}
+
+		exists, err := testElasticsearchXpackServiceTokenExists(rs.Primary.ID)
+		if err != nil || !exists {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Service token %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testElasticsearchXpackServiceTokenExists(id string) (bool, error) {
+	serviceAccount, name, err := parseServiceTokenID(id)
+	if err != nil {
+		return false, err
+	}
+	path, err := serviceTokenPath(serviceAccount, "/credential")
+	if err != nil {
+		return false, err
+	}
+
+	body, err := elasticsearchPerformRequest(testAccXPackProvider.Meta(), "GET", path, nil)
+	if err != nil {
+		return false, err
+	}
+	response := new(ServiceAccountCredentialsResponse)
+	if err := json.Unmarshal(body, response); err != nil {
+		return false, err
+	}
+
+	_, ok := response.Tokens[name]
+	return ok, nil
+}
+
+var testAccElasticsearchXpackServiceToken = `
+resource "elasticsearch_xpack_service_token" "test" {
+  service_account = "elastic/fleet-server"
+  name            = "terraform-test"
+}
+`
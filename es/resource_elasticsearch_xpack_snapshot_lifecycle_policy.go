@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"log"
 	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -32,6 +33,31 @@ func resourceElasticsearchXpackSnapshotLifecyclePolicy() *schema.Resource {
 				ValidateFunc:     validation.StringIsJSON,
 				Description:      "See the policy definition defined in the [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/slm-api-put-policy.html#slm-api-put-request-body)",
 			},
+			"next_execution": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time of the next snapshot of the policy, in RFC 3339 format.",
+			},
+			"last_success_snapshot": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the last successful snapshot of the policy.",
+			},
+			"last_success_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time of the last successful snapshot of the policy, in RFC 3339 format.",
+			},
+			"last_failure_snapshot": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the last failed snapshot of the policy.",
+			},
+			"last_failure_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time of the last failed snapshot of the policy, in RFC 3339 format.",
+			},
 		},
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
@@ -45,13 +71,13 @@ func resourceElasticsearchXpackSnapshotLifecyclePolicyCreate(d *schema.ResourceD
 		return err
 	}
 	d.SetId(d.Get("name").(string))
-	return nil
+	return resourceElasticsearchXpackSnapshotLifecyclePolicyRead(d, meta)
 }
 
 func resourceElasticsearchXpackSnapshotLifecyclePolicyRead(d *schema.ResourceData, meta interface{}) error {
 	id := d.Id()
 
-	var result string
+	var result *SnapshotLifecyclePolicyResponse
 	var err error
 	esClient, err := getClient(meta.(*ProviderConf))
 	if err != nil {
@@ -63,23 +89,38 @@ func resourceElasticsearchXpackSnapshotLifecyclePolicyRead(d *schema.ResourceDat
 	default:
 		err = errors.New("Snapshot Lifecycle Management is only supported by the elastic library >= v7!")
 	}
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Snapshot lifecycle policy (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	policy, err := json.Marshal(result.Policy)
 	if err != nil {
 		return err
 	}
 
 	ds := &resourceDataSetter{d: d}
 	ds.set("name", d.Id())
-	ds.set("body", result)
+	ds.set("body", string(policy))
+	ds.set("next_execution", formatSnapshotTime(result.NextExecutionMillis))
+	ds.set("last_success_snapshot", result.LastSuccess.SnapshotName)
+	ds.set("last_success_time", formatSnapshotTime(result.LastSuccess.Time))
+	ds.set("last_failure_snapshot", result.LastFailure.SnapshotName)
+	ds.set("last_failure_time", formatSnapshotTime(result.LastFailure.Time))
 	return ds.err
 }
 
-func elastic7SnapshotGetLifecyclePolicy(client *elastic7.Client, id string) (string, error) {
+func elastic7SnapshotGetLifecyclePolicy(client *elastic7.Client, id string) (*SnapshotLifecyclePolicyResponse, error) {
 	res, err := client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
 		Method: http.MethodGet,
 		Path:   "/_slm/policy/" + id,
 	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// GET /_slm/policy/{id} returns a more unique object than other similar
@@ -90,27 +131,17 @@ func elastic7SnapshotGetLifecyclePolicy(client *elastic7.Client, id string) (str
 
 	// so we need to do our part to reduce this to just the policy object
 	// which is the equivalent of our "body" elsewhere
-	var resp map[string]interface{}
+	var resp map[string]SnapshotLifecyclePolicyResponse
 	if err := json.Unmarshal(res.Body, &resp); err != nil {
-		return "", err
+		return nil, err
 	}
 
 	policy, ok := resp[id]
 	if !ok {
-		return "", errors.New("Snapshot Lifecycle Management unsuccessfully parsed")
-	}
-
-	typedPolicy, ok := policy.(map[string]interface{})
-	if !ok {
-		return "", errors.New("Snapshot Lifecycle Management unsuccessfully parsed")
-	}
-
-	tj, err := json.Marshal(typedPolicy["policy"])
-	if err != nil {
-		return "", err
+		return nil, errors.New("Snapshot Lifecycle Management unsuccessfully parsed")
 	}
 
-	return string(tj), nil
+	return &policy, nil
 }
 
 func resourceElasticsearchXpackSnapshotLifecyclePolicyUpdate(d *schema.ResourceData, meta interface{}) error {
@@ -174,3 +205,15 @@ func elastic7SnapshotPutLifecyclePolicy(client *elastic7.Client, name string, bo
 	})
 	return err
 }
+
+type SnapshotLifecyclePolicyResponse struct {
+	Policy              map[string]interface{}         `json:"policy"`
+	NextExecutionMillis int64                          `json:"next_execution_millis"`
+	LastSuccess         SnapshotLifecyclePolicyAttempt `json:"last_success"`
+	LastFailure         SnapshotLifecyclePolicyAttempt `json:"last_failure"`
+}
+
+type SnapshotLifecyclePolicyAttempt struct {
+	SnapshotName string `json:"snapshot_name"`
+	Time         int64  `json:"time"`
+}
@@ -52,6 +52,7 @@ func TestAccElasticsearchXpackSnapshotLifecyclePolicy(t *testing.T) {
 				Config: testAccElasticsearchXpackSnapshotLifecyclePolicy,
 				Check: resource.ComposeTestCheckFunc(
 					testCheckElasticsearchXpackSnapshotLifecyclePolicyExists("elasticsearch_xpack_snapshot_lifecycle_policy.terraform-test"),
+					resource.TestCheckResourceAttrSet("elasticsearch_xpack_snapshot_lifecycle_policy.terraform-test", "next_execution"),
 				),
 			},
 		},
@@ -0,0 +1,371 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+var transformMinimalVersion, _ = version.NewVersion("7.5.0")
+
+func resourceElasticsearchXpackTransform() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an Elasticsearch XPack transform, pivoting or taking the latest documents of the source indices into a destination index, once for batch transforms or continuously for transforms with `sync`. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/transform-apis.html) for more details.",
+		Create:      resourceElasticsearchXpackTransformCreate,
+		Read:        resourceElasticsearchXpackTransformRead,
+		Update:      resourceElasticsearchXpackTransformUpdate,
+		Delete:      resourceElasticsearchXpackTransformDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Identifier of the transform.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the transform.",
+			},
+			"source": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: diffSuppressTransformSource,
+				StateFunc:        normalizeJSONString,
+				Description:      "A JSON string of the source of the transform, the `index` to transform and optionally a `query` and `runtime_mappings`.",
+			},
+			"dest": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJson,
+				StateFunc:        normalizeJSONString,
+				Description:      "A JSON string of the destination of the transform, the `index` to write to and optionally an ingest `pipeline`.",
+			},
+			"pivot": {
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Optional:         true,
+				ExactlyOneOf:     []string{"pivot", "latest"},
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJson,
+				StateFunc:        normalizeJSONString,
+				Description:      "A JSON string of the `group_by` and `aggregations` of a pivot transform.",
+			},
+			"latest": {
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJson,
+				StateFunc:        normalizeJSONString,
+				Description:      "A JSON string of the `unique_key` and `sort` field of a transform keeping the latest document of each key, only available from ElasticSearch >= 7.11.",
+			},
+			"sync": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: diffSuppressTransformSync,
+				StateFunc:        normalizeJSONString,
+				Description:      "A JSON string of the sync of a continuous transform, e.g. the `time` field used to find new documents. Transforms without sync are batch transforms and run once.",
+			},
+			"frequency": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The interval between checks for changes of the source indices of continuous transforms, e.g. `5m`.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the transform is started. Batch transforms stop once they complete, so their state is only read back for continuous transforms.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: resourceElasticsearchXpackTransformImport,
+		},
+	}
+}
+
+func resourceElasticsearchXpackTransformCreate(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+
+	if err := elastic7CheckTransformVersion(meta); err != nil {
+		return err
+	}
+
+	body := expandTransformUpdatableFields(d)
+	if pivot, ok := d.GetOk("pivot"); ok {
+		body["pivot"] = json.RawMessage(pivot.(string))
+	}
+	if latest, ok := d.GetOk("latest"); ok {
+		body["latest"] = json.RawMessage(latest.(string))
+	}
+
+	path, err := transformPath(name, "")
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "PUT", path, body); err != nil {
+		return err
+	}
+	d.SetId(name)
+
+	if d.Get("enabled").(bool) {
+		if err := elasticsearchSetTransformEnabled(meta, name, true); err != nil {
+			return err
+		}
+	}
+
+	return resourceElasticsearchXpackTransformRead(d, meta)
+}
+
+func resourceElasticsearchXpackTransformRead(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	if err := elastic7CheckTransformVersion(meta); err != nil {
+		return err
+	}
+
+	transform, err := elasticsearchGetTransform(meta, id)
+	if err != nil {
+		if elastic7.IsNotFound(err) {
+			log.Printf("[WARN] Transform (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	if transform == nil {
+		log.Printf("[WARN] Transform (%s) not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("name", id)
+	ds.set("description", transform.Description)
+	ds.set("source", string(transform.Source))
+	ds.set("dest", string(transform.Dest))
+	ds.set("pivot", transformRawString(transform.Pivot))
+	ds.set("latest", transformRawString(transform.Latest))
+	ds.set("sync", transformRawString(transform.Sync))
+	ds.set("frequency", transform.Frequency)
+
+	if len(transform.Sync) > 0 {
+		state, err := elasticsearchGetTransformState(meta, id)
+		if err != nil {
+			return err
+		}
+		ds.set("enabled", state != "stopped")
+	}
+
+	return ds.err
+}
+
+func resourceElasticsearchXpackTransformUpdate(d *schema.ResourceData, meta interface{}) error {
+	id := d.Id()
+
+	if err := elastic7CheckTransformVersion(meta); err != nil {
+		return err
+	}
+
+	// pivot and latest force a new transform, the other fields are updated
+	// in place
+	if d.HasChanges("description", "source", "dest", "sync", "frequency") {
+		path, err := transformPath(id, "/_update")
+		if err != nil {
+			return err
+		}
+		if _, err := elasticsearchPerformRequest(meta, "POST", path, expandTransformUpdatableFields(d)); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("enabled") {
+		if err := elasticsearchSetTransformEnabled(meta, id, d.Get("enabled").(bool)); err != nil {
+			return err
+		}
+	}
+
+	return resourceElasticsearchXpackTransformRead(d, meta)
+}
+
+func resourceElasticsearchXpackTransformDelete(d *schema.ResourceData, meta interface{}) error {
+	if err := elastic7CheckTransformVersion(meta); err != nil {
+		return err
+	}
+
+	// force also deletes started transforms
+	path, err := transformPath(d.Id(), "?force=true")
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "DELETE", path, nil); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceElasticsearchXpackTransformImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// the read only reads back the state of continuous transforms
+	ds := &resourceDataSetter{d: d}
+	ds.set("enabled", true)
+	return []*schema.ResourceData{d}, ds.err
+}
+
+// expandTransformUpdatableFields returns the fields of the transform which
+// can be changed through the update API
+func expandTransformUpdatableFields(d *schema.ResourceData) map[string]interface{} {
+	body := map[string]interface{}{
+		"description": d.Get("description").(string),
+		"source":      json.RawMessage(d.Get("source").(string)),
+		"dest":        json.RawMessage(d.Get("dest").(string)),
+	}
+	if sync, ok := d.GetOk("sync"); ok {
+		body["sync"] = json.RawMessage(sync.(string))
+	}
+	if frequency, ok := d.GetOk("frequency"); ok {
+		body["frequency"] = frequency.(string)
+	}
+	return body
+}
+
+func elasticsearchSetTransformEnabled(meta interface{}, name string, enabled bool) error {
+	suffix := "/_stop?wait_for_completion=true"
+	if enabled {
+		suffix = "/_start"
+	}
+
+	path, err := transformPath(name, suffix)
+	if err != nil {
+		return err
+	}
+	if _, err := elasticsearchPerformRequest(meta, "POST", path, nil); err != nil {
+		return fmt.Errorf("error setting enabled to %t for transform %s: %+v", enabled, name, err)
+	}
+	return nil
+}
+
+func elasticsearchGetTransform(meta interface{}, name string) (*Transform, error) {
+	path, err := transformPath(name, "")
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := elasticsearchPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(TransformGetResponse)
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, fmt.Errorf("error unmarshalling transform body: %+v: %+v", err, body)
+	}
+
+	for _, transform := range response.Transforms {
+		if transform.ID == name {
+			return &transform, nil
+		}
+	}
+	return nil, nil
+}
+
+func elasticsearchGetTransformState(meta interface{}, name string) (string, error) {
+	path, err := transformPath(name, "/_stats")
+	if err != nil {
+		return "", err
+	}
+
+	body, err := elasticsearchPerformRequest(meta, "GET", path, nil)
+	if err != nil {
+		return "", err
+	}
+
+	response := new(TransformStatsResponse)
+	if err := json.Unmarshal(body, response); err != nil {
+		return "", fmt.Errorf("error unmarshalling transform stats body: %+v: %+v", err, body)
+	}
+
+	for _, stats := range response.Transforms {
+		if stats.ID == name {
+			return stats.State, nil
+		}
+	}
+	return "", fmt.Errorf("transform %s not found in stats", name)
+}
+
+func elastic7CheckTransformVersion(meta interface{}) error {
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+
+	client, ok := esClient.(*elastic7.Client)
+	if !ok {
+		return fmt.Errorf("transforms only available from ElasticSearch >= 7.5, got version < 7.0.0")
+	}
+
+	elasticVersion, err := elastic7GetVersion(client)
+	if err != nil {
+		return err
+	}
+	if elasticVersion.LessThan(transformMinimalVersion) {
+		return fmt.Errorf("transforms only available from ElasticSearch >= 7.5, got version %s", elasticVersion.String())
+	}
+	return nil
+}
+
+func transformPath(name string, suffix string) (string, error) {
+	path, err := uritemplates.Expand("/_transform/{name}", map[string]string{
+		"name": name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building URL path for transform: %+v", err)
+	}
+	return path + suffix, nil
+}
+
+func transformRawString(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	return string(raw)
+}
+
+func normalizeJSONString(v interface{}) string {
+	json, _ := structure.NormalizeJsonString(v)
+	return json
+}
+
+type TransformGetResponse struct {
+	Transforms []Transform `json:"transforms"`
+}
+
+type Transform struct {
+	ID          string          `json:"id"`
+	Description string          `json:"description"`
+	Source      json.RawMessage `json:"source"`
+	Dest        json.RawMessage `json:"dest"`
+	Pivot       json.RawMessage `json:"pivot,omitempty"`
+	Latest      json.RawMessage `json:"latest,omitempty"`
+	Sync        json.RawMessage `json:"sync,omitempty"`
+	Frequency   string          `json:"frequency"`
+}
+
+type TransformStatsResponse struct {
+	Transforms []struct {
+		ID    string `json:"id"`
+		State string `json:"state"`
+	} `json:"transforms"`
+}
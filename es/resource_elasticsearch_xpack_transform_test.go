@@ -0,0 +1,154 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic7 "github.com/olivere/elastic/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchXpackTransform(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		elasticVersion, err := elastic7GetVersion(client)
+		if err != nil {
+			t.Skipf("err: %s", err)
+		}
+		allowed = !elasticVersion.LessThan(transformMinimalVersion)
+	default:
+		allowed = false
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Transforms only supported on ES >= 7.5")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchXpackTransformDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchXpackTransform("Users by event", "1m", true),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackTransformState("elasticsearch_xpack_transform.test", true),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_transform.test", "enabled", "true"),
+				),
+			},
+			{
+				// updated in place and stopped
+				Config: testAccElasticsearchXpackTransform("Users by event type", "5m", false),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackTransformState("elasticsearch_xpack_transform.test", false),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_transform.test", "description", "Users by event type"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_transform.test", "frequency", "5m"),
+				),
+			},
+			{
+				ResourceName:            "elasticsearch_xpack_transform.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"source", "sync"},
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchXpackTransformState(name string, enabled bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No transform ID is set")
+		}
+
+		state, err := elasticsearchGetTransformState(testAccXPackProvider.Meta(), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if (state != "stopped") != enabled {
+			return fmt.Errorf("Transform %s is %s, expected enabled to be %t", rs.Primary.ID, state, enabled)
+		}
+
+		return nil
+	}
+}
+
+func testCheckElasticsearchXpackTransformDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticsearch_xpack_transform" {
+			continue
+		}
+
+		transform, err := elasticsearchGetTransform(testAccXPackProvider.Meta(), rs.Primary.ID)
+		if err != nil || transform == nil {
+			return nil // should be not found error
+		}
+
+		return fmt.Errorf("Transform %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccElasticsearchXpackTransform(description string, frequency string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_index" "test" {
+  name               = "terraform-test-transform-source"
+  number_of_shards   = 1
+  number_of_replicas = 0
+  mappings = jsonencode({
+    properties = {
+      "@timestamp" = { type = "date" }
+      user         = { type = "keyword" }
+      event        = { type = "keyword" }
+    }
+  })
+}
+
+resource "elasticsearch_xpack_transform" "test" {
+  name        = "terraform-test"
+  description = "%s"
+  frequency   = "%s"
+  enabled     = %t
+
+  source = jsonencode({
+    index = [elasticsearch_index.test.name]
+  })
+  dest = jsonencode({
+    index = "terraform-test-transform-dest"
+  })
+  pivot = jsonencode({
+    group_by = {
+      user = { terms = { field = "user" } }
+    }
+    aggregations = {
+      events = { value_count = { field = "event" } }
+    }
+  })
+  sync = jsonencode({
+    time = {
+      field = "@timestamp"
+    }
+  })
+}
+`, description, frequency, enabled)
+}
@@ -24,6 +24,7 @@ func resourceElasticsearchXpackUser() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"username": {
 				Type:        schema.TypeString,
+				ForceNew:    true,
 				Required:    true,
 				Description: "An identifier for the user. \n\n Usernames must be at least 1 and no more than 1024 characters. They can contain alphanumeric characters (a-z, A-Z, 0-9), spaces, punctuation, and printable symbols in the Basic Latin (ASCII) block. Leading or trailing whitespace is not allowed.",
 			},
@@ -47,20 +48,22 @@ func resourceElasticsearchXpackUser() *schema.Resource {
 				Description: "Specifies whether the user is enabled, defaults to true.",
 			},
 			"password": {
-				Type:        schema.TypeString,
-				Sensitive:   true,
-				Required:    false,
-				Optional:    true,
-				StateFunc:   hashSum,
-				Description: "The user’s password. Passwords must be at least 6 characters long. Mutually exclusive with `password_hash`, one of which must be provided at creation.",
+				Type:          schema.TypeString,
+				Sensitive:     true,
+				Required:      false,
+				Optional:      true,
+				StateFunc:     hashSum,
+				ConflictsWith: []string{"password_hash"},
+				Description:   "The user’s password. Passwords must be at least 6 characters long. Mutually exclusive with `password_hash`, one of which must be provided at creation.",
 			},
 			"password_hash": {
-				Type:        schema.TypeString,
-				Required:    false,
-				Sensitive:   true,
-				Optional:    true,
-				StateFunc:   hashSum,
-				Description: "A hash of the user’s password. This must be produced using the same hashing algorithm as has been configured for password storage. Mutually exclusive with `password`, one of which must be provided at creation.",
+				Type:          schema.TypeString,
+				Required:      false,
+				Sensitive:     true,
+				Optional:      true,
+				StateFunc:     hashSum,
+				ConflictsWith: []string{"password"},
+				Description:   "A hash of the user’s password. This must be produced using the same hashing algorithm as has been configured for password storage. Mutually exclusive with `password`, one of which must be provided at creation.",
 			},
 			"roles": {
 				Type:     schema.TypeSet,
@@ -167,6 +170,7 @@ func resourceElasticsearchXpackUserDelete(d *schema.ResourceData, m interface{})
 			d.SetId("")
 			return nil
 		}
+		return err
 	}
 	d.SetId("")
 	return nil
@@ -191,6 +195,7 @@ func buildPutUserBody(d *schema.ResourceData, m interface{}) (string, error) {
 		Metadata: optionalInterfaceJson(metadata),
 	}
 
+	// the password is only sent when it changes, it is updated in place
 	if d.HasChange("password") {
 		user.Password = password
 	}
@@ -327,7 +332,7 @@ type XPackSecurityUser struct {
 	Fullname     string      `json:"full_name,omitempty"`
 	Email        string      `json:"email,omitempty"`
 	Metadata     interface{} `json:"metadata,omitempty"`
-	Enabled      bool        `json:"enabled,omitempty"`
+	Enabled      bool        `json:"enabled"`
 	Password     string      `json:"password,omitempty"`
 	PasswordHash string      `json:"password_hash,omitempty"`
 }
@@ -52,7 +52,7 @@ func TestAccElasticsearchXpackUser(t *testing.T) {
 				Config: testAccUserResource(randomName),
 				Check: resource.ComposeTestCheckFunc(
 					testCheckUserExists("elasticsearch_xpack_user.test"),
-					testCheckUserCanLogIn("elasticsearch_xpack_user.test"),
+					testCheckUserCanLogIn("elasticsearch_xpack_user.test", "secret"),
 					resource.TestCheckResourceAttr(
 						"elasticsearch_xpack_user.test",
 						"id",
@@ -74,7 +74,7 @@ func TestAccElasticsearchXpackUser(t *testing.T) {
 				Config: testAccUserResource_Updated(randomName),
 				Check: resource.ComposeTestCheckFunc(
 					testCheckUserExists("elasticsearch_xpack_user.test"),
-					testCheckUserCanLogIn("elasticsearch_xpack_user.test"),
+					testCheckUserCanLogIn("elasticsearch_xpack_user.test", "secret"),
 					resource.TestCheckResourceAttr(
 						"elasticsearch_xpack_user.test",
 						"metadata",
@@ -82,6 +82,24 @@ func TestAccElasticsearchXpackUser(t *testing.T) {
 					),
 				),
 			},
+			{
+				Config: testAccUserResource_Password(randomName, "secret2", true),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckUserExists("elasticsearch_xpack_user.test"),
+					testCheckUserCanLogIn("elasticsearch_xpack_user.test", "secret2"),
+				),
+			},
+			{
+				Config: testAccUserResource_Password(randomName, "secret2", false),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckUserExists("elasticsearch_xpack_user.test"),
+					resource.TestCheckResourceAttr(
+						"elasticsearch_xpack_user.test",
+						"enabled",
+						"false",
+					),
+				),
+			},
 			{
 				Config: testAccUserResource_Global(randomName),
 				Check: resource.ComposeTestCheckFunc(
@@ -155,7 +173,7 @@ func testCheckUserExists(name string) resource.TestCheckFunc {
 }
 
 // test the password works by creating a new client
-func testCheckUserCanLogIn(name string) resource.TestCheckFunc {
+func testCheckUserCanLogIn(name string, password string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
 		if !ok {
@@ -178,7 +196,7 @@ func testCheckUserCanLogIn(name string) resource.TestCheckFunc {
 				elastic7.SetURL(url),
 				elastic7.SetScheme(config.parsedUrl.Scheme),
 				elastic7.SetSniff(false),
-				elastic7.SetBasicAuth(rs.Primary.ID, "secret"),
+				elastic7.SetBasicAuth(rs.Primary.ID, password),
 				elastic7.SetHealthcheck(false),
 			)
 			if err != nil {
@@ -223,6 +241,17 @@ resource "elasticsearch_xpack_user" "test" {
 `, resourceName)
 }
 
+func testAccUserResource_Password(resourceName string, password string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_xpack_user" "test" {
+	username = "%s"
+	password = "%s"
+	enabled  = %t
+	roles    = ["superuser"]
+}
+`, resourceName, password, enabled)
+}
+
 func testAccUserResource_Global(resourceName string) string {
 	return fmt.Sprintf(`
 resource "elasticsearch_xpack_user" "test" {
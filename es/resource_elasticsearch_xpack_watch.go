@@ -6,10 +6,14 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
 
 	elastic7 "github.com/olivere/elastic/v7"
 	elastic6 "gopkg.in/olivere/elastic.v6"
@@ -23,13 +27,60 @@ var xPackWatchSchema = map[string]*schema.Schema{
 	},
 	"body": {
 		Type:             schema.TypeString,
-		Required:         true,
-		ValidateFunc:     validation.StringIsJSON,
-		DiffSuppressFunc: suppressEquivalentJson,
+		Optional:         true,
+		Computed:         true,
+		ExactlyOneOf:     []string{"body", "trigger"},
+		ValidateFunc:     validation.All(validation.StringIsJSON, validateWatchActions),
+		DiffSuppressFunc: diffSuppressWatch,
 		StateFunc: func(v interface{}) string {
 			json, _ := structure.NormalizeJsonString(v)
 			return json
 		},
+		Description: "The JSON body of the watch. Either the body or the structured `trigger`, `input`, `condition` and `actions` must be set, the body is then composed from them.",
+	},
+	"trigger": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		ExactlyOneOf:     []string{"body", "trigger"},
+		ValidateFunc:     validation.StringIsJSON,
+		DiffSuppressFunc: suppressEquivalentJson,
+		Description:      "The JSON trigger of the watch, e.g. `{\"schedule\": {\"interval\": \"10m\"}}`, instead of `body`.",
+	},
+	"input": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		RequiredWith:     []string{"trigger"},
+		ValidateFunc:     validation.StringIsJSON,
+		DiffSuppressFunc: suppressEquivalentJson,
+		Description:      "The JSON input of the watch, loading the payload, defaults to the `none` input.",
+	},
+	"condition": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		RequiredWith:     []string{"trigger"},
+		ValidateFunc:     validation.StringIsJSON,
+		DiffSuppressFunc: suppressEquivalentJson,
+		Description:      "The JSON condition of the watch, defaults to the `always` condition.",
+	},
+	"actions": {
+		Type:         schema.TypeMap,
+		Optional:     true,
+		RequiredWith: []string{"trigger"},
+		Elem:         &schema.Schema{Type: schema.TypeString},
+		Description:  "The actions of the watch, keyed by the action name, each as a JSON action, e.g. `jsonencode({ logging = { text = \"executed\" } })`.",
+	},
+	"metadata": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateFunc:     validation.StringIsJSON,
+		DiffSuppressFunc: suppressEquivalentJson,
+		Description:      "The JSON metadata of the watch. Along with `body`, the keys are merged into the metadata of the body, taking precedence.",
+	},
+	"throttle_period": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validateElasticsearchDuration,
+		Description:  "The minimum time between the executions of the actions of the watch, e.g. `5m`. Along with `body`, it takes precedence over the throttle period of the body.",
 	},
 	"active": {
 		Type:        schema.TypeBool,
@@ -38,6 +89,85 @@ var xPackWatchSchema = map[string]*schema.Schema{
 		Default:     true,
 		Description: "Boolean to activate the xpack watcher, defaults `true`",
 	},
+	"master_timeout": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validateElasticsearchDuration,
+		Description:  "Explicit timeout for the connection to the master node when putting the watch, e.g. `30s`.",
+	},
+	"request_timeout": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validateElasticsearchDuration,
+		Description:  "Timeout for the put watch request as a whole, e.g. `1m`. Independent of the resource timeouts.",
+	},
+	"action_secrets": {
+		Type:         schema.TypeMap,
+		Optional:     true,
+		Sensitive:    true,
+		Elem:         &schema.Schema{Type: schema.TypeString},
+		ValidateFunc: validateWatchActionSecrets,
+		Description:  "Secret values merged into the watch actions when putting the watch, keyed by the dotted path inside `actions`, e.g. `email_ops.email.password`. The secrets are kept out of `body`, and are merged after the `secrets` placeholders are substituted, replacing the value at their path.",
+	},
+	"secrets": {
+		Type:         schema.TypeMap,
+		Optional:     true,
+		Sensitive:    true,
+		Elem:         &schema.Schema{Type: schema.TypeString},
+		ValidateFunc: validateWatchSecrets,
+		Description:  "Secret values substituted into the `{{secrets.<name>}}` placeholders of the watch when putting it, keyed by name. The values are never read back, the placeholders are kept in `body`. Placeholders in `action_secrets` values are not substituted.",
+	},
+	"bump_metadata_version": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Increment `metadata.version` of the watch every time the body is updated, starting from the version in `body` or 1.",
+	},
+	"metadata_version": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "The `metadata.version` of the watch when `bump_metadata_version` is set.",
+	},
+	"acked_actions": {
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "The names of the actions of the watch which are acknowledged, so they are throttled until the condition of the watch is no longer met.",
+	},
+	"overwrite_existing": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Take ownership of an existing watch with the same `watch_id` when creating the resource, putting the configured watch over it, instead of failing.",
+	},
+	"force_overwrite": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Overwrite the watch even if it was changed since it was last read, e.g. in Kibana, by default such an update fails with a conflict. Only applies from ElasticSearch 7.",
+	},
+	"seq_no": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "The sequence number of the watch when it was last read, used to detect concurrent changes.",
+	},
+	"primary_term": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "The primary term of the watch when it was last read, used to detect concurrent changes.",
+	},
+	"ignore_server_defaults": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: "Ignore the differences of `body` with the watch returned by watcher in another form than configured, e.g. throttle periods in milliseconds, schedule intervals in another unit or unset fields returned as null.",
+	},
+	"simulate_execution": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Run the watch through the execute API in simulation mode when planning changes of its body, with all the actions skipped, so watches failing their input, condition or transform fail the plan rather than being stored.",
+	},
 }
 
 func resourceElasticsearchDeprecatedWatch() *schema.Resource {
@@ -50,6 +180,7 @@ func resourceElasticsearchDeprecatedWatch() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff:      resourceElasticsearchWatchCustomizeDiff,
 		DeprecationMessage: "elasticsearch_watch is deprecated, please use elasticsearch_xpack_watch resource instead.",
 	}
 }
@@ -64,18 +195,93 @@ func resourceElasticsearchXpackWatch() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: resourceElasticsearchWatchCustomizeDiff,
 	}
 }
 
+func resourceElasticsearchWatchCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	_, structured := d.GetOk("trigger")
+	known := d.NewValueKnown("action_secrets") && d.NewValueKnown("secrets")
+	for _, key := range watchBodyKeys {
+		known = known && d.NewValueKnown(key)
+	}
+
+	if known {
+		body, err := watchConfiguredBody(d)
+		if err != nil {
+			return err
+		}
+		// the actions composed into the body are only validated here
+		if structured {
+			if _, errs := validateWatchActions(body, "actions"); len(errs) > 0 {
+				return errs[0]
+			}
+		}
+
+		// secrets of webhook actions may be set in action_secrets, so the
+		// webhooks are validated here rather than in the ValidateFunc of body
+		secrets := d.Get("action_secrets").(map[string]interface{})
+		if err := validateWatchWebhookActions(body, secrets); err != nil {
+			return err
+		}
+	}
+
+	changed := false
+	for _, key := range watchBodyKeys {
+		changed = changed || d.HasChange(key)
+	}
+
+	// offline plans only run the local validation, the execution needs the cluster
+	offline := m.(*ProviderConf).offline
+	if known && !offline && d.Get("simulate_execution").(bool) && (d.Id() == "" || changed || d.HasChange("action_secrets") || d.HasChange("secrets")) {
+		body, err := watchConfiguredBody(d)
+		if err != nil {
+			return err
+		}
+		body, err = substituteWatchSecrets(body, d.Get("secrets").(map[string]interface{}))
+		if err != nil {
+			return err
+		}
+		if secrets := d.Get("action_secrets").(map[string]interface{}); len(secrets) > 0 {
+			body, err = mergeWatchActionSecrets(body, secrets)
+			if err != nil {
+				return err
+			}
+		}
+		if err := simulateWatchExecution(m, d.Get("watch_id").(string), body); err != nil {
+			return err
+		}
+	}
+	if d.Id() != "" && structured && changed {
+		if err := d.SetNewComputed("body"); err != nil {
+			return err
+		}
+	}
+	if d.Id() != "" && (changed || d.HasChange("action_secrets") || d.HasChange("secrets")) {
+		if err := d.SetNewComputed("seq_no"); err != nil {
+			return err
+		}
+		if err := d.SetNewComputed("primary_term"); err != nil {
+			return err
+		}
+	}
+	if d.Id() != "" && d.Get("bump_metadata_version").(bool) && changed {
+		return d.SetNewComputed("metadata_version")
+	}
+	return nil
+}
+
 func resourceElasticsearchWatchCreate(d *schema.ResourceData, m interface{}) error {
 	// Determine whether the watch already exists, otherwise the API will
 	// override an existing watch with the name.
 	watchID := d.Get("watch_id").(string)
 	_, err := resourceElasticsearchGetWatch(watchID, m)
 
-	if err == nil {
+	if err == nil && d.Get("overwrite_existing").(bool) {
+		log.Printf("[INFO] watch exists, overwriting it: %s", watchID)
+	} else if err == nil {
 		log.Printf("[INFO] watch exists: %+v", err)
-		return fmt.Errorf("watch already exists with ID: %v", watchID)
+		return fmt.Errorf("watch already exists with ID: %v, import it or set overwrite_existing", watchID)
 	} else if err != nil && !elastic6.IsNotFound(err) && !elastic7.IsNotFound(err) {
 		return err
 	}
@@ -107,6 +313,7 @@ func resourceElasticsearchWatchRead(d *schema.ResourceData, m interface{}) error
 	}
 
 	var watch []byte
+	var seqNo, primaryTerm int64
 	status := false
 
 	esClient, err := getClient(m.(*ProviderConf))
@@ -118,6 +325,9 @@ func resourceElasticsearchWatchRead(d *schema.ResourceData, m interface{}) error
 		watchResponse := res.(*elastic7.XPackWatcherGetWatchResponse)
 		watch, err = json.Marshal(watchResponse.Watch)
 		status = watchResponse.Status.State.Active
+		if err == nil {
+			seqNo, primaryTerm, err = elastic7GetWatchSeqNo(m, d.Id())
+		}
 	case *elastic6.Client:
 		watchResponse := res.(*elastic6.XPackWatcherGetWatchResponse)
 		watch, err = json.Marshal(watchResponse.Watch)
@@ -128,19 +338,80 @@ func resourceElasticsearchWatchRead(d *schema.ResourceData, m interface{}) error
 		return err
 	}
 
+	// secrets are only kept in action_secrets, keep them out of the body
+	if secrets := d.Get("action_secrets").(map[string]interface{}); len(secrets) > 0 {
+		watch, err = stripWatchActionSecrets(watch, secrets)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the values of the secrets are never read back, keep their placeholders
+	if secrets := d.Get("secrets").(map[string]interface{}); len(secrets) > 0 {
+		configured, err := watchConfiguredBody(d)
+		if err != nil {
+			return err
+		}
+		watch, err = restoreWatchSecretPlaceholders(watch, configured)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the bumped version is tracked in metadata_version, keep the body as
+	// configured
+	var metadataVersion int
+	if d.Get("bump_metadata_version").(bool) {
+		configured, err := watchConfiguredBody(d)
+		if err != nil {
+			return err
+		}
+		watch, metadataVersion, err = normalizeWatchMetadataVersion(watch, configured)
+		if err != nil {
+			return err
+		}
+	}
+
 	ds := &resourceDataSetter{d: d}
+	if _, ok := d.GetOk("trigger"); ok {
+		if err := flattenWatchBody(ds, watch); err != nil {
+			return err
+		}
+	} else {
+		// the attributes merged into the body are kept out of it
+		watch, err = splitWatchBodyAttributes(ds, watch)
+		if err != nil {
+			return err
+		}
+	}
 	ds.set("body", string(watch))
 	ds.set("watch_id", d.Id())
 	ds.set("active", status)
+	ds.set("metadata_version", metadataVersion)
+	ds.set("acked_actions", watchAckedActions(res))
+	ds.set("seq_no", seqNo)
+	ds.set("primary_term", primaryTerm)
 
 	return ds.err
 }
 
 func resourceElasticsearchWatchUpdate(d *schema.ResourceData, m interface{}) error {
-	_, err := resourceElasticsearchPutWatch(d, m)
-
-	if err != nil {
-		return err
+	// Putting the watch resets its status, so only put it when the definition
+	// changed, toggling active only needs the watch to be (de)activated
+	if d.HasChanges(append(watchBodyKeys, "action_secrets", "secrets")...) {
+		_, err := resourceElasticsearchPutWatch(d, m)
+		if err != nil {
+			return err
+		}
+	} else if d.HasChange("active") {
+		esClient, err := getClient(m.(*ProviderConf))
+		if err != nil {
+			return err
+		}
+		_, err = activateWatcher(esClient, d.Id(), d.Get("active").(bool))
+		if err != nil {
+			return err
+		}
 	}
 
 	return resourceElasticsearchWatchRead(d, m)
@@ -185,23 +456,74 @@ func resourceElasticsearchGetWatch(watchID string, m interface{}) (interface{},
 
 func resourceElasticsearchPutWatch(d *schema.ResourceData, m interface{}) (string, error) {
 	watchID := d.Get("watch_id").(string)
-	watchJSON := d.Get("body").(string)
+	watchJSON, err := watchConfiguredBody(d)
+	if err != nil {
+		return "", err
+	}
+	watchJSON, err = substituteWatchSecrets(watchJSON, d.Get("secrets").(map[string]interface{}))
+	if err != nil {
+		return "", err
+	}
 	isActive := d.Get("active").(bool)
+	masterTimeout := d.Get("master_timeout").(string)
+
+	if d.Get("bump_metadata_version").(bool) {
+		// the planned version is unknown, bump the one last read
+		metadataVersion, _ := d.GetChange("metadata_version")
+		watchJSON, err = bumpWatchMetadataVersion(watchJSON, metadataVersion.(int), d.HasChanges(watchBodyKeys...))
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// action_secrets are merged last, as is, replacing any value at their path
+	if secrets := d.Get("action_secrets").(map[string]interface{}); len(secrets) > 0 {
+		watchJSON, err = mergeWatchActionSecrets(watchJSON, secrets)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	ctx := context.TODO()
+	if requestTimeout, ok := d.GetOk("request_timeout"); ok {
+		timeout, err := parseElasticsearchDuration(requestTimeout.(string))
+		if err != nil {
+			return "", err
+		}
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+	}
 
-	var err error
 	esClient, err := getClient(m.(*ProviderConf))
 	if err != nil {
 		return "", err
 	}
 	switch client := esClient.(type) {
 	case *elastic7.Client:
-		_, err = client.XPackWatchPut(watchID).
-			Body(watchJSON).
-			Do(context.TODO())
+		put := client.XPackWatchPut(watchID).Body(watchJSON)
+		if masterTimeout != "" {
+			put = put.MasterTimeout(masterTimeout)
+		}
+		// only update the watch as it was last read
+		seqNo, _ := d.GetChange("seq_no")
+		primaryTerm, _ := d.GetChange("primary_term")
+		checkConcurrency := d.Id() != "" && !d.Get("force_overwrite").(bool) && primaryTerm.(int) > 0
+		if checkConcurrency {
+			put = put.IfSeqNo(int64(seqNo.(int))).IfPrimaryTerm(int64(primaryTerm.(int)))
+		}
+		_, err = put.Do(ctx)
+		if checkConcurrency && elastic7.IsConflict(err) {
+			return "", fmt.Errorf("watch %s was changed since it was last read, e.g. in Kibana, refresh to review the changes or set force_overwrite: %+v", watchID, err)
+		}
 	case *elastic6.Client:
-		_, err = client.XPackWatchPut(watchID).
-			Body(watchJSON).
-			Do(context.TODO())
+		put := client.XPackWatchPut(watchID).Body(watchJSON)
+		if masterTimeout != "" {
+			put = put.MasterTimeout(masterTimeout)
+		}
+		_, err = put.Do(ctx)
 	default:
 		err = errors.New("watch resource not implemented prior to Elastic v6")
 	}
@@ -219,6 +541,320 @@ func resourceElasticsearchPutWatch(d *schema.ResourceData, m interface{}) (strin
 	return watchID, nil
 }
 
+// watchBodyKeys are the attributes the watch body is composed of
+var watchBodyKeys = []string{"body", "trigger", "input", "condition", "actions", "metadata", "throttle_period"}
+
+// watchConfiguredBody returns the body of the watch as configured, before
+// bumping its version and merging its secrets
+func watchConfiguredBody(d interface {
+	Get(string) interface{}
+}) (string, error) {
+	if d.Get("trigger").(string) != "" {
+		return expandWatchBody(d)
+	}
+	return mergeWatchBodyAttributes(d.Get("body").(string), d.Get("metadata").(string), d.Get("throttle_period").(string))
+}
+
+// mergeWatchBodyAttributes merges the metadata and throttle period attributes
+// into the watch body, taking precedence over the body
+func mergeWatchBodyAttributes(body string, metadata string, throttlePeriod string) (string, error) {
+	if metadata == "" && throttlePeriod == "" {
+		return body, nil
+	}
+
+	var watch map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &watch); err != nil {
+		return "", fmt.Errorf("fail to unmarshal: %v", err)
+	}
+
+	if metadata != "" {
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(metadata), &m); err != nil {
+			return "", fmt.Errorf("fail to unmarshal metadata: %v", err)
+		}
+		merged, ok := watch["metadata"].(map[string]interface{})
+		if !ok {
+			merged = make(map[string]interface{})
+			watch["metadata"] = merged
+		}
+		for k, v := range m {
+			merged[k] = v
+		}
+	}
+	if throttlePeriod != "" {
+		delete(watch, "throttle_period_in_millis")
+		watch["throttle_period"] = throttlePeriod
+	}
+
+	merged, err := json.Marshal(watch)
+	if err != nil {
+		return "", err
+	}
+	return string(merged), nil
+}
+
+// splitWatchBodyAttributes sets the metadata and throttle period attributes
+// from the watch read from the cluster, returning the watch without them
+func splitWatchBodyAttributes(ds *resourceDataSetter, body []byte) ([]byte, error) {
+	metadata := ds.d.Get("metadata").(string)
+	throttlePeriod := ds.d.Get("throttle_period").(string)
+	if metadata == "" && throttlePeriod == "" {
+		return body, nil
+	}
+
+	var watch map[string]interface{}
+	if err := json.Unmarshal(body, &watch); err != nil {
+		return nil, fmt.Errorf("fail to unmarshal: %v", err)
+	}
+
+	if metadata != "" {
+		var configured map[string]interface{}
+		if err := json.Unmarshal([]byte(metadata), &configured); err != nil {
+			return nil, fmt.Errorf("fail to unmarshal metadata: %v", err)
+		}
+		current := make(map[string]interface{})
+		if watchMetadata, ok := watch["metadata"].(map[string]interface{}); ok {
+			for k := range configured {
+				if v, ok := watchMetadata[k]; ok {
+					current[k] = v
+					delete(watchMetadata, k)
+				}
+			}
+			if len(watchMetadata) == 0 {
+				delete(watch, "metadata")
+			}
+		}
+		j, err := json.Marshal(current)
+		if err != nil {
+			return nil, err
+		}
+		ds.set("metadata", string(j))
+	}
+	if throttlePeriod != "" {
+		current, _ := watch["throttle_period"].(string)
+		if millis, ok := watch["throttle_period_in_millis"].(float64); ok && current == "" {
+			current = fmt.Sprintf("%dms", int64(millis))
+		}
+		delete(watch, "throttle_period")
+		delete(watch, "throttle_period_in_millis")
+		ds.set("throttle_period", current)
+	}
+
+	return json.Marshal(watch)
+}
+
+// expandWatchBody composes the watch body from the structured attributes,
+// only called when trigger is set, rather than body
+func expandWatchBody(d interface {
+	Get(string) interface{}
+}) (string, error) {
+	watch := make(map[string]interface{})
+	for _, key := range []string{"trigger", "input", "condition", "metadata"} {
+		value := d.Get(key).(string)
+		if value == "" {
+			continue
+		}
+		var v interface{}
+		if err := json.Unmarshal([]byte(value), &v); err != nil {
+			return "", fmt.Errorf("fail to unmarshal %s: %v", key, err)
+		}
+		watch[key] = v
+	}
+
+	actions := make(map[string]interface{})
+	for name, value := range d.Get("actions").(map[string]interface{}) {
+		var action interface{}
+		if err := json.Unmarshal([]byte(value.(string)), &action); err != nil {
+			return "", fmt.Errorf("fail to unmarshal action %s: %v", name, err)
+		}
+		actions[name] = action
+	}
+	watch["actions"] = actions
+
+	if throttlePeriod := d.Get("throttle_period").(string); throttlePeriod != "" {
+		watch["throttle_period"] = throttlePeriod
+	}
+
+	body, err := json.Marshal(watch)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// flattenWatchBody sets the structured attributes from the watch read from the
+// cluster
+func flattenWatchBody(ds *resourceDataSetter, body []byte) error {
+	var watch map[string]interface{}
+	if err := json.Unmarshal(body, &watch); err != nil {
+		return fmt.Errorf("fail to unmarshal: %v", err)
+	}
+	// the defaults of input and condition stored by watcher are only kept if
+	// they are configured
+	normalized := make(map[string]interface{}, len(watch))
+	for key, value := range watch {
+		normalized[key] = value
+	}
+	normalizeWatch(normalized)
+
+	for _, key := range []string{"trigger", "input", "condition", "metadata"} {
+		value := ""
+		if _, ok := normalized[key]; !ok && ds.d.Get(key).(string) == "" {
+			ds.set(key, value)
+			continue
+		}
+		if v, ok := watch[key]; ok && v != nil {
+			j, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			value = string(j)
+		}
+		ds.set(key, value)
+	}
+
+	actions := make(map[string]interface{})
+	if watchActions, ok := watch["actions"].(map[string]interface{}); ok {
+		for name, action := range watchActions {
+			j, err := json.Marshal(action)
+			if err != nil {
+				return err
+			}
+			actions[name] = string(j)
+		}
+	}
+	ds.set("actions", actions)
+
+	throttlePeriod, _ := watch["throttle_period"].(string)
+	if millis, ok := watch["throttle_period_in_millis"].(float64); ok && throttlePeriod == "" {
+		throttlePeriod = fmt.Sprintf("%dms", int64(millis))
+	}
+	ds.set("throttle_period", throttlePeriod)
+	return nil
+}
+
+// simulateWatchExecution runs the watch inline with all the actions skipped
+// and without recording the execution, returning the failures of the run
+func simulateWatchExecution(m interface{}, watchID string, body string) error {
+	var watch map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &watch); err != nil {
+		return fmt.Errorf("fail to unmarshal: %v", err)
+	}
+	execute := map[string]interface{}{
+		"watch": watch,
+		"action_modes": map[string]interface{}{
+			"_all": "skip",
+		},
+		"record_execution": false,
+	}
+
+	esClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	var state string
+	var messages []string
+	var result map[string]interface{}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		res, err := client.XPackWatchExecute().BodyJson(execute).Do(context.TODO())
+		if err != nil {
+			return fmt.Errorf("error simulating the execution of watch %s: %+v", watchID, err)
+		}
+		if res.WatchRecord != nil {
+			state, messages, result = res.WatchRecord.State, res.WatchRecord.Messages, res.WatchRecord.Result
+		}
+	case *elastic6.Client:
+		res, err := client.XPackWatchExecute().BodyJson(execute).Do(context.TODO())
+		if err != nil {
+			return fmt.Errorf("error simulating the execution of watch %s: %+v", watchID, err)
+		}
+		if res.WatchRecord != nil {
+			state, messages, result = res.WatchRecord.State, res.WatchRecord.Messages, res.WatchRecord.Result
+		}
+	default:
+		return errors.New("watch resource not implemented prior to Elastic v6")
+	}
+
+	return watchExecutionFailure(watchID, state, messages, result)
+}
+
+// watchExecutionFailure returns the failures of the input, condition,
+// transform and actions of the result of a watch execution, if any
+func watchExecutionFailure(watchID string, state string, messages []string, result map[string]interface{}) error {
+	var failures []string
+	for _, step := range []string{"input", "condition", "transform"} {
+		if r, ok := result[step].(map[string]interface{}); ok && r["status"] == "failure" {
+			failures = append(failures, fmt.Sprintf("%s: %v", step, r["reason"]))
+		}
+	}
+	if actions, ok := result["actions"].([]interface{}); ok {
+		for _, a := range actions {
+			if action, ok := a.(map[string]interface{}); ok && action["status"] == "failure" {
+				failures = append(failures, fmt.Sprintf("action %v: %v", action["id"], action["reason"]))
+			}
+		}
+	}
+	if len(failures) == 0 && state == "failed" {
+		failures = append(failures, messages...)
+		if len(failures) == 0 {
+			failures = append(failures, "unknown failure")
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("simulated execution of watch %s failed, %s", watchID, strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// elastic7GetWatchSeqNo returns the sequence number and primary term of the
+// watch, which the get watch response of the client does not include
+func elastic7GetWatchSeqNo(m interface{}, watchID string) (int64, int64, error) {
+	path, err := uritemplates.Expand("/_watcher/watch/{id}", map[string]string{
+		"id": watchID,
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("error building URL path for watch: %+v", err)
+	}
+	res, err := elasticsearchPerformRequest(m, "GET", path, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	response := new(WatchGetResponse)
+	if err := json.Unmarshal(res, response); err != nil {
+		return 0, 0, fmt.Errorf("error unmarshalling watch body: %+v: %+v", err, res)
+	}
+	return response.SeqNo, response.PrimaryTerm, nil
+}
+
+// watchAckedActions returns the sorted names of the acknowledged actions in
+// the status of the get watch response
+func watchAckedActions(res interface{}) []string {
+	acked := make([]string, 0)
+	switch watchResponse := res.(type) {
+	case *elastic7.XPackWatcherGetWatchResponse:
+		if watchResponse.Status != nil {
+			for name, action := range watchResponse.Status.Actions {
+				if action != nil && action.AckStatus != nil && action.AckStatus.State == "acked" {
+					acked = append(acked, name)
+				}
+			}
+		}
+	case *elastic6.XPackWatcherGetWatchResponse:
+		if watchResponse.Status != nil {
+			for name, action := range watchResponse.Status.Actions {
+				if action != nil && action.AckStatus != nil && action.AckStatus.State == "acked" {
+					acked = append(acked, name)
+				}
+			}
+		}
+	}
+	sort.Strings(acked)
+	return acked
+}
+
 // turn on or off the watcher
 func activateWatcher(esClient interface{}, watchID string, isActive bool) (string, error) {
 	var err error
@@ -245,3 +881,419 @@ func activateWatcher(esClient interface{}, watchID string, isActive bool) (strin
 
 	return "", err
 }
+
+// watchActionTypes are the action types supported by watcher, other keys of
+// an action besides watchActionOptions are left to the cluster, e.g. hipchat
+// on 6.x
+var watchActionTypes = map[string]bool{
+	"email":     true,
+	"webhook":   true,
+	"index":     true,
+	"logging":   true,
+	"slack":     true,
+	"pagerduty": true,
+	"jira":      true,
+}
+
+var watchActionOptions = map[string]bool{
+	"condition":                 true,
+	"transform":                 true,
+	"throttle_period":           true,
+	"throttle_period_in_millis": true,
+	"foreach":                   true,
+	"max_iterations":            true,
+}
+
+func validateWatchActions(v interface{}, k string) (ws []string, errors []error) {
+	var watch struct {
+		Actions map[string]map[string]interface{} `json:"actions"`
+	}
+	// invalid JSON is reported by validation.StringIsJSON
+	if err := json.Unmarshal([]byte(v.(string)), &watch); err != nil {
+		return
+	}
+
+	for name, action := range watch.Actions {
+		var types []string
+		unknown := false
+		for key := range action {
+			if watchActionOptions[key] {
+				continue
+			}
+			if !watchActionTypes[key] {
+				// an action type of another version, or a plugin
+				unknown = true
+				continue
+			}
+			types = append(types, key)
+		}
+
+		switch {
+		case len(types) == 0 && !unknown:
+			errors = append(errors, fmt.Errorf("%q: action %s has no action type, expected one of email, webhook, index, logging, slack, pagerduty or jira", k, name))
+		case len(types) > 1:
+			sort.Strings(types)
+			errors = append(errors, fmt.Errorf("%q: action %s has multiple action types, expected exactly one, got: %s", k, name, strings.Join(types, ", ")))
+		}
+	}
+	return
+}
+
+var watchWebhookMethods = map[string]bool{
+	"head":   true,
+	"get":    true,
+	"post":   true,
+	"put":    true,
+	"delete": true,
+}
+
+// validateWatchWebhookActions checks the webhook actions of the watch for the
+// fields the put watch API requires
+func validateWatchWebhookActions(body string, secrets map[string]interface{}) error {
+	var watch struct {
+		Actions map[string]struct {
+			Webhook *struct {
+				Host   string `json:"host"`
+				Port   int    `json:"port"`
+				URL    string `json:"url"`
+				Method string `json:"method"`
+				Auth   *struct {
+					Basic *struct {
+						Username string `json:"username"`
+						Password string `json:"password"`
+					} `json:"basic"`
+				} `json:"auth"`
+			} `json:"webhook"`
+		} `json:"actions"`
+	}
+	// invalid JSON is reported by the ValidateFunc of body
+	if err := json.Unmarshal([]byte(body), &watch); err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(watch.Actions))
+	for name := range watch.Actions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		webhook := watch.Actions[name].Webhook
+		if webhook == nil {
+			continue
+		}
+
+		if webhook.URL == "" && (webhook.Host == "" || webhook.Port == 0) {
+			return fmt.Errorf("webhook action %s must set either url, or host and port", name)
+		}
+		if webhook.Method != "" && !watchWebhookMethods[strings.ToLower(webhook.Method)] {
+			return fmt.Errorf("webhook action %s has an unsupported method %s, expected one of head, get, post, put or delete", name, webhook.Method)
+		}
+		if webhook.Auth != nil && webhook.Auth.Basic != nil {
+			if webhook.Auth.Basic.Username == "" {
+				return fmt.Errorf("webhook action %s must set auth.basic.username", name)
+			}
+			if _, ok := secrets[name+".webhook.auth.basic.password"]; webhook.Auth.Basic.Password == "" && !ok {
+				return fmt.Errorf("webhook action %s must set auth.basic.password, in the body or in action_secrets", name)
+			}
+		}
+	}
+	return nil
+}
+
+func validateWatchActionSecrets(v interface{}, k string) (ws []string, errors []error) {
+	secrets, ok := v.(map[string]interface{})
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be a map", k))
+		return
+	}
+
+	for key := range secrets {
+		if len(strings.Split(key, ".")) < 2 {
+			errors = append(errors, fmt.Errorf("%q keys must be a dotted path of an action name and a field, e.g. email_ops.email.password, got: %s", k, key))
+		}
+	}
+	return
+}
+
+var watchSecretPlaceholderRegexp = regexp.MustCompile(`\{\{secrets\.([A-Za-z0-9_-]+)\}\}`)
+
+var watchSecretNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+func validateWatchSecrets(v interface{}, k string) (ws []string, errors []error) {
+	secrets, ok := v.(map[string]interface{})
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be a map", k))
+		return
+	}
+
+	for name := range secrets {
+		if !watchSecretNameRegexp.MatchString(name) {
+			errors = append(errors, fmt.Errorf("%q keys must only contain letters, digits, underscores and hyphens, got: %s", k, name))
+		}
+	}
+	return
+}
+
+// substituteWatchSecrets replaces the secret placeholders in the string values
+// of the watch body with the secrets
+func substituteWatchSecrets(body string, secrets map[string]interface{}) (string, error) {
+	if !watchSecretPlaceholderRegexp.MatchString(body) {
+		return body, nil
+	}
+
+	var watch interface{}
+	if err := json.Unmarshal([]byte(body), &watch); err != nil {
+		return "", fmt.Errorf("fail to unmarshal: %v", err)
+	}
+
+	var missing []string
+	watch = mapWatchStrings(watch, func(value string) string {
+		return watchSecretPlaceholderRegexp.ReplaceAllStringFunc(value, func(placeholder string) string {
+			name := watchSecretPlaceholderRegexp.FindStringSubmatch(placeholder)[1]
+			secret, ok := secrets[name]
+			if !ok {
+				missing = append(missing, name)
+				return placeholder
+			}
+			return secret.(string)
+		})
+	})
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return "", fmt.Errorf("the watch references undefined secrets: %s", strings.Join(missing, ", "))
+	}
+
+	substituted, err := json.Marshal(watch)
+	if err != nil {
+		return "", err
+	}
+	return string(substituted), nil
+}
+
+// restoreWatchSecretPlaceholders sets back the string values of the configured
+// watch having secret placeholders into the watch read from the cluster, where
+// they match with any secret values, or are redacted
+func restoreWatchSecretPlaceholders(body []byte, configured string) ([]byte, error) {
+	var watch, configuredWatch interface{}
+	if err := json.Unmarshal(body, &watch); err != nil {
+		return nil, fmt.Errorf("fail to unmarshal: %v", err)
+	}
+	if err := json.Unmarshal([]byte(configured), &configuredWatch); err != nil {
+		return body, nil
+	}
+
+	return json.Marshal(restoreWatchSecretValues(watch, configuredWatch))
+}
+
+func restoreWatchSecretValues(current interface{}, configured interface{}) interface{} {
+	switch c := configured.(type) {
+	case map[string]interface{}:
+		if m, ok := current.(map[string]interface{}); ok {
+			for k, v := range m {
+				if cv, ok := c[k]; ok {
+					m[k] = restoreWatchSecretValues(v, cv)
+				}
+			}
+		}
+	case []interface{}:
+		if l, ok := current.([]interface{}); ok && len(l) == len(c) {
+			for i := range l {
+				l[i] = restoreWatchSecretValues(l[i], c[i])
+			}
+		}
+	case string:
+		value, ok := current.(string)
+		if !ok || !watchSecretPlaceholderRegexp.MatchString(c) {
+			break
+		}
+		parts := watchSecretPlaceholderRegexp.Split(c, -1)
+		for i := range parts {
+			parts[i] = regexp.QuoteMeta(parts[i])
+		}
+		matcher := regexp.MustCompile("^" + strings.Join(parts, "(?s:.*)") + "$")
+		if value == "::es_redacted::" || matcher.MatchString(value) {
+			return c
+		}
+	}
+	return current
+}
+
+// mapWatchStrings applies f to every string value of the decoded JSON
+func mapWatchStrings(v interface{}, f func(string) string) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, e := range value {
+			value[k] = mapWatchStrings(e, f)
+		}
+	case []interface{}:
+		for i, e := range value {
+			value[i] = mapWatchStrings(e, f)
+		}
+	case string:
+		return f(value)
+	}
+	return v
+}
+
+// mergeWatchActionSecrets sets each secret into the watch actions, keyed by
+// the dotted path starting with the action name
+func mergeWatchActionSecrets(body string, secrets map[string]interface{}) (string, error) {
+	var watch map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &watch); err != nil {
+		return "", fmt.Errorf("fail to unmarshal: %v", err)
+	}
+
+	actions, ok := watch["actions"].(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("action_secrets given but the watch body has no actions")
+	}
+
+	for key, value := range secrets {
+		path := strings.Split(key, ".")
+		if _, ok := actions[path[0]]; !ok {
+			return "", fmt.Errorf("action_secrets key %s does not match an action in the watch body", key)
+		}
+
+		node := actions
+		for _, p := range path[:len(path)-1] {
+			next, ok := node[p].(map[string]interface{})
+			if !ok {
+				next = make(map[string]interface{})
+				node[p] = next
+			}
+			node = next
+		}
+		node[path[len(path)-1]] = value
+	}
+
+	merged, err := json.Marshal(watch)
+	if err != nil {
+		return "", err
+	}
+	return string(merged), nil
+}
+
+// stripWatchActionSecrets removes each of the secret paths from the watch
+// actions, along with any objects left empty by doing so
+func stripWatchActionSecrets(body []byte, secrets map[string]interface{}) ([]byte, error) {
+	var watch map[string]interface{}
+	if err := json.Unmarshal(body, &watch); err != nil {
+		return nil, fmt.Errorf("fail to unmarshal: %v", err)
+	}
+
+	if actions, ok := watch["actions"].(map[string]interface{}); ok {
+		for key := range secrets {
+			removeWatchPath(actions, strings.Split(key, "."))
+		}
+	}
+
+	return json.Marshal(watch)
+}
+
+func removeWatchPath(node map[string]interface{}, path []string) {
+	if len(path) == 1 {
+		delete(node, path[0])
+		return
+	}
+
+	next, ok := node[path[0]].(map[string]interface{})
+	if !ok {
+		return
+	}
+	removeWatchPath(next, path[1:])
+	// don't leave behind containers which only held secrets, but keep the
+	// action itself
+	if len(next) == 0 && len(path) > 2 {
+		delete(node, path[0])
+	}
+}
+
+// bumpWatchMetadataVersion sets metadata.version of the watch to the current
+// version, incremented if the body changed, or to the version in the body,
+// defaulting to 1, if there is no current version
+func bumpWatchMetadataVersion(body string, current int, changed bool) (string, error) {
+	var watch map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &watch); err != nil {
+		return "", fmt.Errorf("fail to unmarshal: %v", err)
+	}
+
+	version := current
+	if changed {
+		version++
+	}
+	if current == 0 {
+		bodyVersion, ok, err := watchMetadataVersion(watch)
+		if err != nil {
+			return "", err
+		}
+		version = 1
+		if ok {
+			version = bodyVersion
+		}
+	}
+
+	metadata, ok := watch["metadata"].(map[string]interface{})
+	if !ok {
+		metadata = make(map[string]interface{})
+		watch["metadata"] = metadata
+	}
+	metadata["version"] = version
+
+	bumped, err := json.Marshal(watch)
+	if err != nil {
+		return "", err
+	}
+	return string(bumped), nil
+}
+
+// normalizeWatchMetadataVersion returns metadata.version of the watch read from
+// the cluster, along with the watch with the version of the configured body
+func normalizeWatchMetadataVersion(body []byte, configured string) ([]byte, int, error) {
+	var watch map[string]interface{}
+	if err := json.Unmarshal(body, &watch); err != nil {
+		return nil, 0, fmt.Errorf("fail to unmarshal: %v", err)
+	}
+	version, _, err := watchMetadataVersion(watch)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var configuredWatch map[string]interface{}
+	var configuredVersion interface{}
+	if err := json.Unmarshal([]byte(configured), &configuredWatch); err == nil {
+		if metadata, ok := configuredWatch["metadata"].(map[string]interface{}); ok {
+			configuredVersion = metadata["version"]
+		}
+	}
+
+	if metadata, ok := watch["metadata"].(map[string]interface{}); ok {
+		if configuredVersion != nil {
+			metadata["version"] = configuredVersion
+		} else {
+			delete(metadata, "version")
+			if len(metadata) == 0 {
+				delete(watch, "metadata")
+			}
+		}
+	}
+
+	normalized, err := json.Marshal(watch)
+	return normalized, version, err
+}
+
+func watchMetadataVersion(watch map[string]interface{}) (int, bool, error) {
+	metadata, ok := watch["metadata"].(map[string]interface{})
+	if !ok {
+		return 0, false, nil
+	}
+	v, ok := metadata["version"]
+	if !ok {
+		return 0, false, nil
+	}
+	version, ok := v.(float64)
+	if !ok {
+		return 0, false, fmt.Errorf("metadata.version of the watch must be a number to be bumped, got: %v", v)
+	}
+	return int(version), true, nil
+}
@@ -0,0 +1,104 @@
+package es
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+func resourceElasticsearchXpackWatchAck() *schema.Resource {
+	return &schema.Resource{
+		Description: "Acknowledges actions of an Elasticsearch XPack watch, which throttles them until the condition of the watch is no longer met. Changing `triggers` acknowledges the actions again. Acknowledgements cannot be undone, so destroying the resource only removes it from the state. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/watcher-api-ack-watch.html) for more details.",
+		Create:      resourceElasticsearchXpackWatchAckCreate,
+		Read:        resourceElasticsearchXpackWatchAckRead,
+		Delete:      resourceElasticsearchXpackWatchAckDelete,
+		Schema: map[string]*schema.Schema{
+			"watch_id": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Identifier of the watch.",
+			},
+			"action_ids": {
+				Type:        schema.TypeList,
+				ForceNew:    true,
+				Optional:    true,
+				Description: "The names of the actions to acknowledge, all the actions of the watch if not set.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				ForceNew:    true,
+				Optional:    true,
+				Description: "Arbitrary values which acknowledge the actions again when changed, e.g. an incident identifier.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"acked_actions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The names of the actions of the watch which are acknowledged.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceElasticsearchXpackWatchAckCreate(d *schema.ResourceData, meta interface{}) error {
+	watchID := d.Get("watch_id").(string)
+	actionIDs := expandStringList(d.Get("action_ids").([]interface{}))
+
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		_, err = client.XPackWatchAck(watchID).ActionId(actionIDs...).Do(context.TODO())
+	case *elastic6.Client:
+		_, err = client.XPackWatchAck(watchID).ActionId(actionIDs...).Do(context.TODO())
+	default:
+		err = errors.New("watch ack resource not implemented prior to Elastic v6")
+	}
+	if err != nil {
+		return fmt.Errorf("error acknowledging watch %s: %+v", watchID, err)
+	}
+
+	d.SetId(watchID)
+	return resourceElasticsearchXpackWatchAckRead(d, meta)
+}
+
+func resourceElasticsearchXpackWatchAckRead(d *schema.ResourceData, meta interface{}) error {
+	res, err := resourceElasticsearchGetWatch(d.Get("watch_id").(string), meta)
+	if err != nil {
+		if elastic7.IsNotFound(err) || elastic6.IsNotFound(err) {
+			log.Printf("[WARN] Watch (%s) not found, removing ack from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	// the actions being no longer acknowledged, after the condition of the
+	// watch is no longer met, is expected and not drift
+	ds := &resourceDataSetter{d: d}
+	ds.set("acked_actions", watchAckedActions(res))
+	return ds.err
+}
+
+func resourceElasticsearchXpackWatchAckDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Acknowledgements of watch %s cannot be undone, removing from state only", d.Id())
+	d.SetId("")
+	return nil
+}
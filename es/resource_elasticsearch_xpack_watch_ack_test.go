@@ -0,0 +1,98 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	elastic5 "gopkg.in/olivere/elastic.v5"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchXpackWatchAck(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	_, isElastic5 := esClient.(*elastic5.Client)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if isElastic5 {
+				t.Skip("Watches only supported on ES >= 6")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchWatchDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchXpackWatchAck("1"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackWatchAckExists("elasticsearch_xpack_watch_ack.test"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_watch_ack.test", "watch_id", "my_watch"),
+				),
+			},
+			{
+				Config: testAccElasticsearchXpackWatchAck("2"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackWatchAckExists("elasticsearch_xpack_watch_ack.test"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_watch_ack.test", "triggers.incident", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchXpackWatchAckExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No watch ack ID is set")
+		}
+
+		_, err := resourceElasticsearchGetWatch(rs.Primary.ID, testAccXPackProvider.Meta())
+		return err
+	}
+}
+
+func testAccElasticsearchXpackWatchAck(incident string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_xpack_watch" "test_watch" {
+  watch_id = "my_watch"
+
+  trigger = jsonencode({
+    schedule = {
+      cron = "0 0/1 * * * ?"
+    }
+  })
+  actions = {
+    test_log = jsonencode({
+      logging = {
+        text = "executed"
+      }
+    })
+  }
+}
+
+resource "elasticsearch_xpack_watch_ack" "test" {
+  watch_id   = elasticsearch_xpack_watch.test_watch.id
+  action_ids = ["test_log"]
+
+  triggers = {
+    incident = %q
+  }
+}
+`, incident)
+}
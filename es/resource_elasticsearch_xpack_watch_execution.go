@@ -0,0 +1,224 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+var watchActionModes = map[string]bool{
+	"simulate":       true,
+	"force_simulate": true,
+	"execute":        true,
+	"force_execute":  true,
+	"skip":           true,
+}
+
+func resourceElasticsearchXpackWatchExecution() *schema.Resource {
+	return &schema.Resource{
+		Description: "Executes an Elasticsearch XPack watch when applied and records the result of the execution, e.g. to smoke test the alerting of a new environment. Changing any argument executes the watch again, destroying the resource only removes it from the state. See the upstream [docs](https://www.elastic.co/guide/en/elasticsearch/reference/current/watcher-api-execute-watch.html) for more details.",
+		Create:      resourceElasticsearchXpackWatchExecutionCreate,
+		Read:        resourceElasticsearchXpackWatchExecutionRead,
+		Delete:      resourceElasticsearchXpackWatchExecutionDelete,
+		Schema: map[string]*schema.Schema{
+			"watch_id": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Identifier of the watch to execute.",
+			},
+			"action_modes": {
+				Type:         schema.TypeMap,
+				ForceNew:     true,
+				Optional:     true,
+				ValidateFunc: validateWatchActionModes,
+				Description:  "The mode of each action, keyed by the action name or `_all`, one of `simulate`, `force_simulate`, `execute`, `force_execute` or `skip`.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"trigger_data": {
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJson,
+				Description:      "The JSON trigger data of the execution, e.g. `{\"scheduled_time\": \"now\"}`.",
+			},
+			"alternative_input": {
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJson,
+				Description:      "The JSON payload used instead of the input of the watch.",
+			},
+			"ignore_condition": {
+				Type:        schema.TypeBool,
+				ForceNew:    true,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the actions run regardless of the condition of the watch.",
+			},
+			"record_execution": {
+				Type:        schema.TypeBool,
+				ForceNew:    true,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the execution is recorded in the watch history, and updates the status of the watch, e.g. for throttling.",
+			},
+			"fail_on_error": {
+				Type:        schema.TypeBool,
+				ForceNew:    true,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether a failure of the input, condition, transform or of an action fails the apply.",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				ForceNew:    true,
+				Optional:    true,
+				Description: "Arbitrary values which execute the watch again when changed, e.g. the identifier of the environment.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the execution, e.g. `executed` or `execution_not_needed`.",
+			},
+			"condition_met": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the condition of the watch was met.",
+			},
+			"result": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The JSON result of the execution, with the results of the input, condition and actions.",
+			},
+		},
+	}
+}
+
+func resourceElasticsearchXpackWatchExecutionCreate(d *schema.ResourceData, meta interface{}) error {
+	watchID := d.Get("watch_id").(string)
+
+	body := map[string]interface{}{
+		"ignore_condition": d.Get("ignore_condition").(bool),
+		"record_execution": d.Get("record_execution").(bool),
+	}
+	if modes := d.Get("action_modes").(map[string]interface{}); len(modes) > 0 {
+		body["action_modes"] = modes
+	}
+	for _, key := range []string{"trigger_data", "alternative_input"} {
+		if value, ok := d.GetOk(key); ok {
+			var v interface{}
+			if err := json.Unmarshal([]byte(value.(string)), &v); err != nil {
+				return fmt.Errorf("fail to unmarshal %s: %v", key, err)
+			}
+			body[key] = v
+		}
+	}
+
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	var id, state string
+	var messages []string
+	var result map[string]interface{}
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		res, err := client.XPackWatchExecute().Id(watchID).BodyJson(body).Do(context.TODO())
+		if err != nil {
+			return fmt.Errorf("error executing watch %s: %+v", watchID, err)
+		}
+		id = res.Id
+		if res.WatchRecord != nil {
+			state, messages, result = res.WatchRecord.State, res.WatchRecord.Messages, res.WatchRecord.Result
+		}
+	case *elastic6.Client:
+		res, err := client.XPackWatchExecute().Id(watchID).BodyJson(body).Do(context.TODO())
+		if err != nil {
+			return fmt.Errorf("error executing watch %s: %+v", watchID, err)
+		}
+		id = res.Id
+		if res.WatchRecord != nil {
+			state, messages, result = res.WatchRecord.State, res.WatchRecord.Messages, res.WatchRecord.Result
+		}
+	default:
+		return errors.New("watch execution resource not implemented prior to Elastic v6")
+	}
+
+	if d.Get("fail_on_error").(bool) {
+		if err := watchExecutionFailure(watchID, state, messages, result); err != nil {
+			return err
+		}
+	}
+
+	conditionMet := false
+	if condition, ok := result["condition"].(map[string]interface{}); ok {
+		conditionMet, _ = condition["met"].(bool)
+	}
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	// the record identifier is unique to the execution
+	if id == "" {
+		id = watchID
+	}
+	d.SetId(id)
+	ds := &resourceDataSetter{d: d}
+	ds.set("state", state)
+	ds.set("condition_met", conditionMet)
+	ds.set("result", string(resultJSON))
+	return ds.err
+}
+
+func resourceElasticsearchXpackWatchExecutionRead(d *schema.ResourceData, meta interface{}) error {
+	// the execution is a past event, it is only checked that the watch still
+	// exists
+	_, err := resourceElasticsearchGetWatch(d.Get("watch_id").(string), meta)
+	if err != nil {
+		if elastic7.IsNotFound(err) || elastic6.IsNotFound(err) {
+			log.Printf("[WARN] Watch (%s) not found, removing execution from state", d.Get("watch_id").(string))
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func resourceElasticsearchXpackWatchExecutionDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Execution %s of a watch cannot be undone, removing from state only", d.Id())
+	d.SetId("")
+	return nil
+}
+
+func validateWatchActionModes(v interface{}, k string) (ws []string, errors []error) {
+	modes, ok := v.(map[string]interface{})
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be a map", k))
+		return
+	}
+
+	for action, mode := range modes {
+		if !watchActionModes[fmt.Sprint(mode)] {
+			errors = append(errors, fmt.Errorf("%q: mode of action %s must be one of simulate, force_simulate, execute, force_execute or skip, got: %v", k, action, mode))
+		}
+	}
+	return
+}
@@ -0,0 +1,83 @@
+package es
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	elastic5 "gopkg.in/olivere/elastic.v5"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchXpackWatchExecution(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	_, isElastic5 := esClient.(*elastic5.Client)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if isElastic5 {
+				t.Skip("Watches only supported on ES >= 6")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchWatchDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccElasticsearchXpackWatchExecution("invalid"),
+				ExpectError: regexp.MustCompile("must be one of"),
+			},
+			{
+				Config: testAccElasticsearchXpackWatchExecution("simulate"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticsearch_xpack_watch_execution.test", "state", "executed"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_watch_execution.test", "condition_met", "true"),
+					resource.TestCheckResourceAttrSet("elasticsearch_xpack_watch_execution.test", "result"),
+				),
+			},
+		},
+	})
+}
+
+func testAccElasticsearchXpackWatchExecution(mode string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_xpack_watch" "test_watch" {
+  watch_id = "my_watch"
+  active   = false
+
+  trigger = jsonencode({
+    schedule = {
+      interval = "10m"
+    }
+  })
+  actions = {
+    test_log = jsonencode({
+      logging = {
+        text = "executed"
+      }
+    })
+  }
+}
+
+resource "elasticsearch_xpack_watch_execution" "test" {
+  watch_id         = elasticsearch_xpack_watch.test_watch.id
+  ignore_condition = true
+
+  action_modes = {
+    _all = %q
+  }
+}
+`, mode)
+}
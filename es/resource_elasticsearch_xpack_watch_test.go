@@ -3,6 +3,8 @@ package es
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
 	elastic7 "github.com/olivere/elastic/v7"
@@ -48,13 +50,485 @@ func TestAccElasticsearchWatch(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testCheckElasticsearchWatchExists("elasticsearch_xpack_watch.test_watch"),
 					testCheckElasticsearchWatchDeactivated("elasticsearch_xpack_watch.test_watch"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_watch.test_watch", "master_timeout", "30s"),
 				),
 			},
 		},
 	})
 }
 
+func TestAccElasticsearchWatch_createInactive(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch esClient.(type) {
+	case *elastic5.Client:
+		allowed = false
+	default:
+		allowed = true
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Watches only supported on ES >= 6")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchWatchDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchWatchActivation(false),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchWatchExists("elasticsearch_xpack_watch.test_watch"),
+					testCheckElasticsearchWatchActive("elasticsearch_xpack_watch.test_watch", false),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_watch.test_watch", "active", "false"),
+				),
+			},
+			{
+				Config: testAccElasticsearchWatchActivation(true),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchWatchActive("elasticsearch_xpack_watch.test_watch", true),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_watch.test_watch", "active", "true"),
+				),
+			},
+			{
+				Config: testAccElasticsearchWatchActivation(false),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchWatchActive("elasticsearch_xpack_watch.test_watch", false),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_watch.test_watch", "active", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchWatch_bumpMetadataVersion(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch esClient.(type) {
+	case *elastic5.Client:
+		allowed = false
+	default:
+		allowed = true
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Watches only supported on ES >= 6")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchWatchDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchWatchBumpMetadataVersion(5),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchWatchExists("elasticsearch_xpack_watch.test_watch"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_watch.test_watch", "metadata_version", "1"),
+				),
+			},
+			{
+				Config: testAccElasticsearchWatchBumpMetadataVersion(10),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticsearch_xpack_watch.test_watch", "metadata_version", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchWatch_serverDefaults(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch esClient.(type) {
+	case *elastic5.Client:
+		allowed = false
+	default:
+		allowed = true
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Watches only supported on ES >= 6")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchWatchDestroy,
+		Steps: []resource.TestStep{
+			{
+				// input and condition are stored as none and always
+				Config: testAccElasticsearchWatchDefaults(""),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchWatchExists("elasticsearch_xpack_watch.test_watch"),
+				),
+			},
+			{
+				Config: testAccElasticsearchWatchDefaults(`"condition": {},`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchWatchExists("elasticsearch_xpack_watch.test_watch"),
+				),
+			},
+			{
+				Config: testAccElasticsearchWatchDefaults(`"input": { "none": {} }, "condition": { "always": {} },`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchWatchExists("elasticsearch_xpack_watch.test_watch"),
+				),
+			},
+			{
+				// the throttle period may be returned in milliseconds
+				Config: testAccElasticsearchWatchDefaults(`"throttle_period": "5m", "metadata": {},`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchWatchExists("elasticsearch_xpack_watch.test_watch"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchWatch_actionSecrets(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch esClient.(type) {
+	case *elastic5.Client:
+		allowed = false
+	default:
+		allowed = true
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Watches only supported on ES >= 6")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchWatchDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchWatchActionSecrets,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchWatchExists("elasticsearch_xpack_watch.test_watch"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_watch.test_watch", "action_secrets.%", "1"),
+				),
+			},
+			{
+				Config: testAccElasticsearchWatchSecrets("s3cr3t"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchWatchExists("elasticsearch_xpack_watch.test_watch"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_watch.test_watch", "actions.notify", `{"webhook":{"headers":{"Authorization":"Bearer {{secrets.token}}"},"method":"post","url":"http://localhost:9200/"}}`),
+				),
+			},
+			{
+				Config: testAccElasticsearchWatchSecrets("rotated"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchWatchExists("elasticsearch_xpack_watch.test_watch"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchWatch_structured(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch esClient.(type) {
+	case *elastic5.Client:
+		allowed = false
+	default:
+		allowed = true
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Watches only supported on ES >= 6")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchWatchDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchWatchStructured("executed"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchWatchExists("elasticsearch_xpack_watch.test_watch"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_watch.test_watch", "actions.%", "1"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_watch.test_watch", "throttle_period", "5m"),
+				),
+			},
+			{
+				Config: testAccElasticsearchWatchStructured("updated"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchWatchExists("elasticsearch_xpack_watch.test_watch"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_watch.test_watch", "actions.test_log", `{"logging":{"text":"updated"}}`),
+					resource.TestCheckResourceAttrSet("elasticsearch_xpack_watch.test_watch", "seq_no"),
+					resource.TestCheckResourceAttrSet("elasticsearch_xpack_watch.test_watch", "primary_term"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchWatch_bodyAttributes(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch esClient.(type) {
+	case *elastic5.Client:
+		allowed = false
+	default:
+		allowed = true
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Watches only supported on ES >= 6")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchWatchDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchWatchBodyAttributes("staging", "5m"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchWatchExists("elasticsearch_xpack_watch.test_watch"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_watch.test_watch", "metadata", `{"environment":"staging"}`),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_watch.test_watch", "throttle_period", "5m"),
+				),
+			},
+			{
+				Config: testAccElasticsearchWatchBodyAttributes("production", "15m"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchWatchExists("elasticsearch_xpack_watch.test_watch"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_watch.test_watch", "metadata", `{"environment":"production"}`),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_watch.test_watch", "throttle_period", "15m"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchWatch_overwriteExisting(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch esClient.(type) {
+	case *elastic5.Client:
+		allowed = false
+	default:
+		allowed = true
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Watches only supported on ES >= 6")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchWatchDestroy,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					body := `{"trigger": {"schedule": {"interval": "1h"}}, "actions": {"seeded": {"logging": {"text": "seeded"}}}}`
+					var err error
+					switch client := esClient.(type) {
+					case *elastic7.Client:
+						_, err = client.XPackWatchPut("my_watch").Body(body).Do(context.TODO())
+					case *elastic6.Client:
+						_, err = client.XPackWatchPut("my_watch").Body(body).Do(context.TODO())
+					}
+					if err != nil {
+						t.Fatalf("err: %s", err)
+					}
+				},
+				Config: testAccElasticsearchWatchOverwriteExisting,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchWatchExists("elasticsearch_xpack_watch.test_watch"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_watch.test_watch", "actions.%", "1"),
+					resource.TestCheckResourceAttrSet("elasticsearch_xpack_watch.test_watch", "actions.test_log"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccElasticsearchWatch_simulateExecution(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	meta := provider.Meta()
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+	var allowed bool
+	switch esClient.(type) {
+	case *elastic5.Client:
+		allowed = false
+	default:
+		allowed = true
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !allowed {
+				t.Skip("Watches only supported on ES >= 6")
+			}
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchWatchDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccElasticsearchWatchSimulateExecution(`{ "search": { "request": { "indices": ["terraform-test-*"], "body": { "query": { "no_such_query": {} } } } } }`),
+				ExpectError: regexp.MustCompile("simulat"),
+			},
+			{
+				Config: testAccElasticsearchWatchSimulateExecution(`{ "simple": { "send": "yes" } }`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchWatchExists("elasticsearch_xpack_watch.test_watch"),
+				),
+			},
+		},
+	})
+}
+
+func TestValidateWatchActions(t *testing.T) {
+	for body, expected := range map[string]string{
+		`{"actions": {"test_log": {"throttle_period": "5m"}}}`:                                     "action test_log has no action type",
+		`{"actions": {"test_log": {"logging": {"text": "executed"}, "index": {"index": "test"}}}}`: "action test_log has multiple action types, expected exactly one, got: index, logging",
+	} {
+		_, errs := validateWatchActions(body, "body")
+		if len(errs) != 1 || !strings.Contains(errs[0].Error(), expected) {
+			t.Errorf("expected %s to fail with %q, got: %v", body, expected, errs)
+		}
+	}
+
+	for _, body := range []string{
+		`{"actions": {"test_log": {"logging": {"text": "executed"}, "throttle_period": "5m"}}}`,
+		`{"actions": {"notify": {"hipchat": {"account": "integration", "message": {"body": "executed"}}}}}`,
+		`{"actions": {"notify": {"slack": {"message": {"text": "executed"}}, "condition": {"always": {}}}}}`,
+		`{"trigger": {"schedule": {"interval": "1m"}}}`,
+	} {
+		if _, errs := validateWatchActions(body, "body"); len(errs) > 0 {
+			t.Errorf("expected %s to pass, got: %v", body, errs)
+		}
+	}
+}
+
+func TestAccElasticsearchWatch_invalidActions(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	err := provider.Configure(&terraform.ResourceConfig{})
+	if err != nil {
+		t.Skipf("err: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchWatchDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccElasticsearchWatchActions(`"test_log": { "throttle_period": "5m" }`),
+				ExpectError: regexp.MustCompile("action test_log has no action type"),
+			},
+			{
+				Config:      testAccElasticsearchWatchActions(`"test_log": { "logging": { "text": "executed" }, "index": { "index": "test" } }`),
+				ExpectError: regexp.MustCompile("action test_log has multiple action types, expected exactly one, got: index, logging"),
+			},
+			{
+				Config:      testAccElasticsearchWatchActions(`"notify": { "webhook": { "host": "localhost", "path": "/" } }`),
+				ExpectError: regexp.MustCompile("webhook action notify must set either url, or host and port"),
+			},
+			{
+				Config:      testAccElasticsearchWatchActions(`"notify": { "webhook": { "url": "http://localhost:9200/", "method": "patch" } }`),
+				ExpectError: regexp.MustCompile("webhook action notify has an unsupported method patch"),
+			},
+			{
+				Config:      testAccElasticsearchWatchActions(`"notify": { "webhook": { "host": "localhost", "port": 9200, "auth": { "basic": { "username": "elastic" } } } }`),
+				ExpectError: regexp.MustCompile("webhook action notify must set auth.basic.password, in the body or in action_secrets"),
+			},
+		},
+	})
+}
+
 func testCheckElasticsearchWatchDeactivated(name string) resource.TestCheckFunc {
+	return testCheckElasticsearchWatchActive(name, false)
+}
+
+func testCheckElasticsearchWatchActive(name string, active bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
 		if !ok {
@@ -73,20 +547,20 @@ func testCheckElasticsearchWatchDeactivated(name string) resource.TestCheckFunc
 		}
 		switch client := esClient.(type) {
 		case *elastic7.Client:
-			watcher, err := client.XPackWatchGet("my_watch").Do(context.TODO())
+			watcher, err := client.XPackWatchGet(rs.Primary.ID).Do(context.TODO())
 			if err != nil {
 				return err
 			}
-			if watcher.Status.State.Active {
-				return fmt.Errorf("Watcher should be in deactivate state")
+			if watcher.Status.State.Active != active {
+				return fmt.Errorf("Watcher should have active state %t", active)
 			}
 		case *elastic6.Client:
-			watcher, err := client.XPackWatchGet("my_watch").Do(context.TODO())
+			watcher, err := client.XPackWatchGet(rs.Primary.ID).Do(context.TODO())
 			if err != nil {
 				return err
 			}
-			if watcher.Status.State.Active {
-				return fmt.Errorf("Watcher should be in deactivate state")
+			if watcher.Status.State.Active != active {
+				return fmt.Errorf("Watcher should have active state %t", active)
 			}
 		default:
 		}
@@ -167,6 +641,8 @@ var testAccElasticsearchWatch = `
 resource "elasticsearch_xpack_watch" "test_watch" {
   watch_id = "my_watch"
   active = false
+  master_timeout = "30s"
+  request_timeout = "1m"
   body = <<EOF
 {
   "input": {
@@ -198,3 +674,319 @@ resource "elasticsearch_xpack_watch" "test_watch" {
 EOF
 }
 `
+
+var testAccElasticsearchWatchActionSecrets = `
+resource "elasticsearch_xpack_watch" "test_watch" {
+  watch_id = "my_watch"
+  active = false
+  action_secrets = {
+    "notify.webhook.auth.basic.password" = "secret"
+  }
+  body = <<EOF
+{
+  "input": {
+    "simple": {
+      "payload": {
+        "send": "yes"
+      }
+    }
+  },
+  "condition": {
+    "always": {}
+  },
+  "trigger": {
+    "schedule": {
+      "hourly": {
+        "minute": [0, 5]
+      }
+    }
+  },
+  "actions": {
+    "notify": {
+      "webhook": {
+        "scheme": "http",
+        "host": "localhost",
+        "port": 9200,
+        "method": "post",
+        "path": "/",
+        "params": {},
+        "headers": {},
+        "auth": {
+          "basic": {
+            "username": "elastic"
+          }
+        }
+      }
+    }
+  }
+}
+EOF
+}
+`
+
+func testAccElasticsearchWatchActions(actions string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_xpack_watch" "test_watch" {
+  watch_id = "my_watch"
+  body = <<EOF
+{
+  "input": {
+    "simple": {}
+  },
+  "condition": {
+    "always": {}
+  },
+  "trigger": {
+    "schedule": {
+      "interval": "10m"
+    }
+  },
+  "actions": {
+    %s
+  }
+}
+EOF
+}
+`, actions)
+}
+
+func testAccElasticsearchWatchActivation(active bool) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_xpack_watch" "test_watch" {
+  watch_id = "my_watch"
+  active = %t
+  body = <<EOF
+{
+  "input": {
+    "simple": {
+      "payload": {
+        "send": "yes"
+      }
+    }
+  },
+  "condition": {
+    "always": {}
+  },
+  "trigger": {
+    "schedule": {
+      "hourly": {
+        "minute": [0, 5]
+      }
+    }
+  },
+  "actions": {
+    "test_log": {
+      "logging": {
+        "level": "info",
+        "text": "executed at {{ctx.execution_time}}"
+      }
+    }
+  }
+}
+EOF
+}
+`, active)
+}
+
+func testAccElasticsearchWatchBumpMetadataVersion(minute int) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_xpack_watch" "test_watch" {
+  watch_id = "my_watch"
+  active = false
+  bump_metadata_version = true
+  body = <<EOF
+{
+  "input": {
+    "simple": {}
+  },
+  "condition": {
+    "always": {}
+  },
+  "trigger": {
+    "schedule": {
+      "hourly": {
+        "minute": [0, %d]
+      }
+    }
+  },
+  "actions": {
+    "test_log": {
+      "logging": {
+        "level": "info",
+        "text": "executed at {{ctx.execution_time}}"
+      }
+    }
+  },
+  "metadata": {
+    "team": "search"
+  }
+}
+EOF
+}
+`, minute)
+}
+
+func testAccElasticsearchWatchDefaults(defaults string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_xpack_watch" "test_watch" {
+  watch_id = "my_watch"
+  active = false
+  body = <<EOF
+{
+  %s
+  "trigger": {
+    "schedule": {
+      "interval": "10m"
+    }
+  },
+  "actions": {
+    "test_log": {
+      "logging": {
+        "level": "info",
+        "text": "executed at {{ctx.execution_time}}"
+      }
+    }
+  }
+}
+EOF
+}
+`, defaults)
+}
+
+func testAccElasticsearchWatchStructured(text string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_xpack_watch" "test_watch" {
+  watch_id = "my_watch"
+  active   = false
+
+  trigger = jsonencode({
+    schedule = {
+      cron = "0 0/1 * * * ?"
+    }
+  })
+  condition = jsonencode({
+    always = {}
+  })
+  actions = {
+    test_log = jsonencode({
+      logging = {
+        text = %q
+      }
+    })
+  }
+  metadata = jsonencode({
+    team = "ops"
+  })
+  throttle_period = "5m"
+}
+`, text)
+}
+
+func testAccElasticsearchWatchSimulateExecution(input string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_xpack_watch" "test_watch" {
+  watch_id           = "my_watch"
+  active             = false
+  simulate_execution = true
+
+  trigger = jsonencode({
+    schedule = {
+      cron = "0 0/1 * * * ?"
+    }
+  })
+  input = %q
+  actions = {
+    test_log = jsonencode({
+      logging = {
+        text = "executed"
+      }
+    })
+  }
+}
+`, input)
+}
+
+func testAccElasticsearchWatchBodyAttributes(environment string, throttlePeriod string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_xpack_watch" "test_watch" {
+  watch_id = "my_watch"
+  active   = false
+
+  metadata = jsonencode({
+    environment = %q
+  })
+  throttle_period = %q
+
+  body = <<EOF
+{
+  "trigger": {
+    "schedule": {
+      "interval": "10m"
+    }
+  },
+  "actions": {
+    "test_log": {
+      "logging": {
+        "text": "executed"
+      }
+    }
+  },
+  "metadata": {
+    "team": "ops"
+  }
+}
+EOF
+}
+`, environment, throttlePeriod)
+}
+
+var testAccElasticsearchWatchOverwriteExisting = `
+resource "elasticsearch_xpack_watch" "test_watch" {
+  watch_id           = "my_watch"
+  active             = false
+  overwrite_existing = true
+
+  trigger = jsonencode({
+    schedule = {
+      interval = "10m"
+    }
+  })
+  actions = {
+    test_log = jsonencode({
+      logging = {
+        text = "executed"
+      }
+    })
+  }
+}
+`
+
+func testAccElasticsearchWatchSecrets(token string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_xpack_watch" "test_watch" {
+  watch_id = "my_watch"
+  active   = false
+
+  trigger = jsonencode({
+    schedule = {
+      interval = "10m"
+    }
+  })
+  actions = {
+    notify = jsonencode({
+      webhook = {
+        url    = "http://localhost:9200/"
+        method = "post"
+        headers = {
+          Authorization = "Bearer {{secrets.token}}"
+        }
+      }
+    })
+  }
+
+  secrets = {
+    token = %q
+  }
+}
+`, token)
+}
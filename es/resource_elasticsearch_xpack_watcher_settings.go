@@ -0,0 +1,183 @@
+package es
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// the cluster has a single set of watcher settings
+const watcherSettingsID = "watcher_settings"
+
+// the dynamic watcher settings, by the name of their attribute, with their
+// default, which is reset rather than set
+var watcherSettings = []struct {
+	name         string
+	setting      string
+	defaultValue interface{}
+}{
+	{"history_cleaner_service_enabled", "xpack.watcher.history.cleaner_service.enabled", true},
+	{"email_default_account", "xpack.notification.email.default_account", ""},
+	{"slack_default_account", "xpack.notification.slack.default_account", ""},
+	{"pagerduty_default_account", "xpack.notification.pagerduty.default_account", ""},
+	{"jira_default_account", "xpack.notification.jira.default_account", ""},
+}
+
+const watcherNotificationSettingsPrefix = "xpack.notification."
+
+func resourceElasticsearchXpackWatcherSettings() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages the dynamic Elasticsearch XPack watcher settings, used by all the watches, through the persistent cluster settings. Settings which are no longer managed, including on destroy, are reset to their default. The secure settings of the notification accounts, e.g. the Slack webhook URLs, have to be set in the keystore of the nodes, and the other watcher settings in their configuration.",
+		Create:      resourceElasticsearchXpackWatcherSettingsCreate,
+		Read:        resourceElasticsearchXpackWatcherSettingsRead,
+		Update:      resourceElasticsearchXpackWatcherSettingsUpdate,
+		Delete:      resourceElasticsearchXpackWatcherSettingsDelete,
+		Schema: map[string]*schema.Schema{
+			"history_cleaner_service_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the watcher history indices older than their retention are deleted, set as `xpack.watcher.history.cleaner_service.enabled`.",
+			},
+			"email_default_account": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The email account used by the email actions without an account, set as `xpack.notification.email.default_account`.",
+			},
+			"slack_default_account": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The Slack account used by the Slack actions without an account, set as `xpack.notification.slack.default_account`.",
+			},
+			"pagerduty_default_account": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The PagerDuty account used by the PagerDuty actions without an account, set as `xpack.notification.pagerduty.default_account`.",
+			},
+			"jira_default_account": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The Jira account used by the Jira actions without an account, set as `xpack.notification.jira.default_account`.",
+			},
+			"notification": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Further notification settings, keyed by the flat name of the setting under `xpack.notification.`, e.g. `slack.account.monitoring.message_defaults.from`.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceElasticsearchXpackWatcherSettingsCreate(d *schema.ResourceData, meta interface{}) error {
+	if err := elasticsearchPutWatcherSettings(d, meta, false); err != nil {
+		return err
+	}
+
+	d.SetId(watcherSettingsID)
+	return resourceElasticsearchXpackWatcherSettingsRead(d, meta)
+}
+
+func resourceElasticsearchXpackWatcherSettingsRead(d *schema.ResourceData, meta interface{}) error {
+	current, err := elasticsearchGetClusterSettings(meta)
+	if err != nil {
+		return err
+	}
+	persistent := current["persistent"]
+
+	ds := &resourceDataSetter{d: d}
+	for _, setting := range watcherSettings {
+		value, ok := persistent[setting.setting]
+		if !ok {
+			ds.set(setting.name, setting.defaultValue)
+			continue
+		}
+		if _, isBool := setting.defaultValue.(bool); isBool {
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("error parsing %s: %+v", setting.setting, err)
+			}
+			ds.set(setting.name, enabled)
+		} else {
+			ds.set(setting.name, value)
+		}
+	}
+
+	// only read back the managed notification settings
+	notification := make(map[string]interface{})
+	for key := range d.Get("notification").(map[string]interface{}) {
+		if value, ok := persistent[watcherNotificationSettingsPrefix+key]; ok {
+			notification[key] = value
+		}
+	}
+	ds.set("notification", notification)
+	return ds.err
+}
+
+func resourceElasticsearchXpackWatcherSettingsUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := elasticsearchPutWatcherSettings(d, meta, false); err != nil {
+		return err
+	}
+
+	return resourceElasticsearchXpackWatcherSettingsRead(d, meta)
+}
+
+func resourceElasticsearchXpackWatcherSettingsDelete(d *schema.ResourceData, meta interface{}) error {
+	if err := elasticsearchPutWatcherSettings(d, meta, true); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// elasticsearchPutWatcherSettings sets the configured watcher settings, and
+// resets the ones set to their default or no longer configured, or all of
+// them on destroy
+func elasticsearchPutWatcherSettings(d *schema.ResourceData, meta interface{}, destroy bool) error {
+	settings := make(map[string]interface{})
+	for _, setting := range watcherSettings {
+		o, n := d.GetChange(setting.name)
+
+		// null resets the setting to its default
+		var value interface{}
+		if !destroy && n != setting.defaultValue {
+			value = n
+		}
+		// the settings are all set on create, replacing the existing ones
+		if value == nil && o == setting.defaultValue && d.Id() != "" {
+			continue
+		}
+		settings[setting.setting] = value
+	}
+
+	o, n := d.GetChange("notification")
+	oldNotification := o.(map[string]interface{})
+	newNotification := n.(map[string]interface{})
+	if destroy {
+		newNotification = map[string]interface{}{}
+	}
+	for key, value := range newNotification {
+		settings[watcherNotificationSettingsPrefix+key] = value
+	}
+	for key := range oldNotification {
+		if _, ok := newNotification[key]; !ok {
+			settings[watcherNotificationSettingsPrefix+key] = nil
+		}
+	}
+
+	if len(settings) == 0 {
+		return nil
+	}
+
+	body := map[string]interface{}{
+		"persistent": settings,
+	}
+	if _, err := elasticsearchPerformRequest(meta, "PUT", "/_cluster/settings", body); err != nil {
+		return fmt.Errorf("error updating watcher settings: %+v", err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccElasticsearchXpackWatcherSettings(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccXPackProviders,
+		CheckDestroy: testCheckElasticsearchXpackWatcherSettingsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElasticsearchXpackWatcherSettings("false", "ops@example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackWatcherSetting("xpack.watcher.history.cleaner_service.enabled", "false"),
+					testCheckElasticsearchXpackWatcherSetting("xpack.notification.slack.account.monitoring.message_defaults.from", "ops@example.com"),
+				),
+			},
+			{
+				Config: testAccElasticsearchXpackWatcherSettings("true", "alerts@example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckElasticsearchXpackWatcherSetting("xpack.watcher.history.cleaner_service.enabled", ""),
+					testCheckElasticsearchXpackWatcherSetting("xpack.notification.slack.account.monitoring.message_defaults.from", "alerts@example.com"),
+					resource.TestCheckResourceAttr("elasticsearch_xpack_watcher_settings.test", "history_cleaner_service_enabled", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckElasticsearchXpackWatcherSetting(key string, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		current, err := elasticsearchGetClusterSettings(testAccXPackProvider.Meta())
+		if err != nil {
+			return err
+		}
+		if value := current["persistent"][key]; value != expected {
+			return fmt.Errorf("Persistent setting %s is %q, expected %q", key, value, expected)
+		}
+		return nil
+	}
+}
+
+func testCheckElasticsearchXpackWatcherSettingsDestroy(s *terraform.State) error {
+	current, err := elasticsearchGetClusterSettings(testAccXPackProvider.Meta())
+	if err != nil {
+		return err
+	}
+
+	for _, key := range []string{
+		"xpack.watcher.history.cleaner_service.enabled",
+		"xpack.notification.slack.account.monitoring.message_defaults.from",
+	} {
+		if value, ok := current["persistent"][key]; ok {
+			return fmt.Errorf("Persistent setting %s still set to %q", key, value)
+		}
+	}
+	return nil
+}
+
+func testAccElasticsearchXpackWatcherSettings(cleanerEnabled string, from string) string {
+	return fmt.Sprintf(`
+resource "elasticsearch_xpack_watcher_settings" "test" {
+  history_cleaner_service_enabled = %s
+
+  notification = {
+    "slack.account.monitoring.message_defaults.from" = "%s"
+  }
+}
+`, cleanerEnabled, from)
+}
@@ -8,9 +8,11 @@ import (
 	"fmt"
 	"log"
 	"reflect"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/hashicorp/go-version"
@@ -23,6 +25,17 @@ import (
 
 var (
 	errObjNotFound = fmt.Errorf("object not found")
+
+	elasticsearchDurationRegexp = regexp.MustCompile(`^(-1|0|[0-9]+(d|h|m|s|ms|micros|nanos))$`)
+	elasticsearchDurationUnits  = map[string]time.Duration{
+		"d":      24 * time.Hour,
+		"h":      time.Hour,
+		"m":      time.Minute,
+		"s":      time.Second,
+		"ms":     time.Millisecond,
+		"micros": time.Microsecond,
+		"nanos":  time.Nanosecond,
+	}
 )
 
 func elastic7GetObject(client *elastic7.Client, index string, id string) (*elastic7.GetResult, error) {
@@ -81,6 +94,131 @@ func normalizeDestination(tpl map[string]interface{}) {
 	delete(tpl, "schema_version")
 }
 
+// normalizeOpenSearchTransform removes the fields the index management plugin
+// adds to transform jobs, and the enabled flag managed by its own argument
+func normalizeOpenSearchTransform(tpl map[string]interface{}) {
+	delete(tpl, "transform_id")
+	delete(tpl, "enabled")
+	delete(tpl, "enabled_at")
+	delete(tpl, "updated_at")
+	delete(tpl, "schema_version")
+	delete(tpl, "metadata_id")
+	delete(tpl, "user")
+	removeNullValues(tpl)
+
+	if schedule, ok := tpl["schedule"].(map[string]interface{}); ok {
+		if interval, ok := schedule["interval"].(map[string]interface{}); ok {
+			delete(interval, "start_time")
+		}
+	}
+	if query, ok := tpl["data_selection_query"].(map[string]interface{}); ok {
+		if _, matchAll := query["match_all"]; matchAll && len(query) == 1 {
+			delete(tpl, "data_selection_query")
+		}
+	}
+}
+
+// normalizeSmPolicy removes the fields the index management plugin adds to
+// snapshot management policies, and the enabled flag managed by its own
+// argument
+func normalizeSmPolicy(tpl map[string]interface{}) {
+	delete(tpl, "name")
+	delete(tpl, "enabled")
+	delete(tpl, "enabled_time")
+	delete(tpl, "last_updated_time")
+	delete(tpl, "schema_version")
+	delete(tpl, "schedule")
+	delete(tpl, "user")
+	removeNullValues(tpl)
+}
+
+// normalizeRollupJob removes the fields the index management plugin adds to
+// rollup jobs, and the enabled flag managed by its own argument
+func normalizeRollupJob(tpl map[string]interface{}) {
+	delete(tpl, "rollup_id")
+	delete(tpl, "enabled")
+	delete(tpl, "enabled_time")
+	delete(tpl, "last_updated_time")
+	delete(tpl, "schema_version")
+	delete(tpl, "metadata_id")
+	delete(tpl, "user")
+	removeNullValues(tpl)
+
+	if schedule, ok := tpl["schedule"].(map[string]interface{}); ok {
+		if interval, ok := schedule["interval"].(map[string]interface{}); ok {
+			delete(interval, "start_time")
+		}
+	}
+}
+
+// normalizeAnomalyDetector removes the fields the anomaly detection plugin
+// adds to detectors, and the defaults of the shingle size and filter query
+func normalizeAnomalyDetector(tpl map[string]interface{}) {
+	delete(tpl, "_id")
+	delete(tpl, "schema_version")
+	delete(tpl, "last_update_time")
+	delete(tpl, "user")
+	delete(tpl, "detector_type")
+
+	if features, ok := tpl["feature_attributes"].([]interface{}); ok {
+		for _, f := range features {
+			if feature, ok := f.(map[string]interface{}); ok {
+				delete(feature, "feature_id")
+			}
+		}
+	}
+	if size, ok := tpl["shingle_size"].(float64); ok && size == 8 {
+		delete(tpl, "shingle_size")
+	}
+	if filter, ok := tpl["filter_query"].(map[string]interface{}); ok {
+		if _, matchAll := filter["match_all"]; matchAll && len(filter) == 1 {
+			delete(tpl, "filter_query")
+		}
+	}
+}
+
+// normalizeChannelConfiguration removes the defaults the notifications plugin
+// returns for the omitted fields of a channel
+func normalizeChannelConfiguration(tpl map[string]interface{}) {
+	if enabled, ok := tpl["is_enabled"].(bool); ok && enabled {
+		delete(tpl, "is_enabled")
+	}
+	if description, ok := tpl["description"].(string); ok && description == "" {
+		delete(tpl, "description")
+	}
+	if webhook, ok := tpl["webhook"].(map[string]interface{}); ok {
+		if method, ok := webhook["method"].(string); ok && method == "POST" {
+			delete(webhook, "method")
+		}
+		if headers, ok := webhook["header_params"].(map[string]interface{}); ok && len(headers) == 0 {
+			delete(webhook, "header_params")
+		}
+	}
+}
+
+// normalizeAlertingWorkflow removes the fields the alerting plugin adds to
+// workflows, and the defaults of the omitted type and enabled flag
+func normalizeAlertingWorkflow(tpl map[string]interface{}) {
+	if triggers, ok := tpl["triggers"].([]interface{}); ok {
+		normalizeMonitorTriggers(triggers)
+	}
+	if workflowType, ok := tpl["workflow_type"].(string); ok && workflowType == "composite" {
+		delete(tpl, "workflow_type")
+	}
+	if enabled, ok := tpl["enabled"].(bool); ok && enabled {
+		delete(tpl, "enabled")
+	}
+
+	delete(tpl, "id")
+	delete(tpl, "type")
+	delete(tpl, "owner")
+	delete(tpl, "last_update_time")
+	delete(tpl, "enabled_time")
+	delete(tpl, "schema_version")
+	delete(tpl, "user")
+	removeNullValues(tpl)
+}
+
 func normalizeMonitor(tpl map[string]interface{}) {
 	if triggers, ok := tpl["triggers"].([]interface{}); ok {
 		normalizeMonitorTriggers(triggers)
@@ -112,6 +250,112 @@ func normalizeMonitorTriggerActions(actions []interface{}) {
 	}
 }
 
+// normalizeWatch removes the input and condition watcher stores when they are
+// omitted or empty, `none` and `always` respectively
+func normalizeWatch(watch map[string]interface{}) {
+	if input, ok := watch["input"].(map[string]interface{}); ok {
+		if none, ok := input["none"].(map[string]interface{}); len(input) == 0 || (len(input) == 1 && ok && len(none) == 0) {
+			delete(watch, "input")
+		}
+	}
+	if condition, ok := watch["condition"].(map[string]interface{}); ok {
+		if always, ok := condition["always"].(map[string]interface{}); len(condition) == 0 || (len(condition) == 1 && ok && len(always) == 0) {
+			delete(watch, "condition")
+		}
+	}
+}
+
+// normalizeWatchServerDefaults converges the values watcher may return in a
+// different form than configured: unset fields returned as null, throttle
+// periods as milliseconds, schedule intervals with another unit and single
+// cron expressions as lists
+func normalizeWatchServerDefaults(watch map[string]interface{}) {
+	removeNullValues(watch)
+	if metadata, ok := watch["metadata"].(map[string]interface{}); ok && len(metadata) == 0 {
+		delete(watch, "metadata")
+	}
+	normalizeWatchThrottlePeriod(watch)
+
+	if actions, ok := watch["actions"].(map[string]interface{}); ok {
+		for _, a := range actions {
+			if action, ok := a.(map[string]interface{}); ok {
+				removeNullValues(action)
+				normalizeWatchThrottlePeriod(action)
+			}
+		}
+	}
+
+	trigger, _ := watch["trigger"].(map[string]interface{})
+	if schedule, ok := trigger["schedule"].(map[string]interface{}); ok {
+		switch interval := schedule["interval"].(type) {
+		case string:
+			if d, err := parseElasticsearchDuration(interval); err == nil {
+				schedule["interval"] = float64(d.Milliseconds())
+			}
+		case float64:
+			// a number of seconds
+			schedule["interval"] = interval * 1000
+		}
+		if cron, ok := schedule["cron"].(string); ok {
+			schedule["cron"] = []interface{}{cron}
+		}
+	}
+}
+
+// normalizeWatchThrottlePeriod converts the throttle period of a watch or an
+// action into milliseconds
+func normalizeWatchThrottlePeriod(m map[string]interface{}) {
+	period, ok := m["throttle_period"].(string)
+	if !ok {
+		return
+	}
+	if d, err := parseElasticsearchDuration(period); err == nil {
+		delete(m, "throttle_period")
+		m["throttle_period_in_millis"] = float64(d.Milliseconds())
+	}
+}
+
+func removeNullValues(m map[string]interface{}) {
+	for k, v := range m {
+		if v == nil {
+			delete(m, k)
+		}
+	}
+}
+
+func normalizeIngestPipeline(pipeline map[string]interface{}) {
+	// pipelines are read back with an empty description if it is not set
+	if description, ok := pipeline["description"]; ok && description == "" {
+		delete(pipeline, "description")
+	}
+}
+
+func normalizeTransformSource(source map[string]interface{}) {
+	// a single index is read back as a list, and the default query is added
+	if index, ok := source["index"].(string); ok {
+		source["index"] = []interface{}{index}
+	}
+	if query, ok := source["query"].(map[string]interface{}); ok && len(query) == 1 {
+		if matchAll, ok := query["match_all"].(map[string]interface{}); ok && len(matchAll) == 0 {
+			delete(source, "query")
+		}
+	}
+}
+
+func normalizeTransformSync(sync map[string]interface{}) {
+	// the default delay is added to time based sync
+	if timeSync, ok := sync["time"].(map[string]interface{}); ok && timeSync["delay"] == "60s" {
+		delete(timeSync, "delay")
+	}
+}
+
+func normalizeRollupJobGroups(groups map[string]interface{}) {
+	// the default time zone is added to the date histogram
+	if dateHistogram, ok := groups["date_histogram"].(map[string]interface{}); ok && dateHistogram["time_zone"] == "UTC" {
+		delete(dateHistogram, "time_zone")
+	}
+}
+
 func normalizePolicy(tpl map[string]interface{}) {
 	delete(tpl, "last_updated_time")
 	delete(tpl, "policy_id")
@@ -157,6 +401,12 @@ For legacy index templates (ES < 7.8) or /_template endpoint on ES >= 7.8 see no
 */
 func normalizeComposableIndexTemplate(tpl map[string]interface{}) {
 	delete(tpl, "version")
+	// 7.11 returns the defaults of data streams
+	if dataStream, ok := tpl["data_stream"].(map[string]interface{}); ok {
+		if hidden, ok := dataStream["hidden"].(bool); ok && !hidden {
+			delete(dataStream, "hidden")
+		}
+	}
 	if innerTpl, ok := tpl["template"]; ok {
 		if innerTplMap, ok := innerTpl.(map[string]interface{}); ok {
 			if settings, ok := innerTplMap["settings"]; ok {
@@ -218,6 +468,23 @@ func normalizeSnapshotLifecyclePolicy(pol map[string]interface{}) {
 	}
 }
 
+// normalizeRoleMappingRoleTemplates decodes the templates of role templates,
+// which are stored as JSON strings
+func normalizeRoleMappingRoleTemplates(templates []interface{}) {
+	for _, t := range templates {
+		roleTemplate, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if source, ok := roleTemplate["template"].(string); ok {
+			var template interface{}
+			if err := json.Unmarshal([]byte(source), &template); err == nil {
+				roleTemplate["template"] = template
+			}
+		}
+	}
+}
+
 func normalizedIndexLifecyclePolicy(policy map[string]interface{}) map[string]interface{} {
 	f := flattenMap(policy)
 	for k, v := range f {
@@ -249,52 +516,22 @@ func flattenIndicesFieldSecurity(rawSettings map[string]interface{}) []map[strin
 	return out
 }
 
-func flattenIndicesPermissionSetv6(resourcesArray []elastic6.XPackSecurityIndicesPermissions) ([]XPackSecurityIndicesPermissions, error) {
+func flattenIndicesPermissionSet(resourcesArray []XPackSecurityRoleResponseIndices) []XPackSecurityIndicesPermissions {
 	vperm := make([]XPackSecurityIndicesPermissions, 0, len(resourcesArray))
 	for _, item := range resourcesArray {
-		if item.FieldSecurity != nil {
-			obj := XPackSecurityIndicesPermissions{
-				Names:         item.Names,
-				Privileges:    item.Privileges,
-				FieldSecurity: flattenIndicesFieldSecurity(item.FieldSecurity.(map[string]interface{})),
-				Query:         item.Query,
-			}
-			vperm = append(vperm, obj)
-		} else {
-			obj := XPackSecurityIndicesPermissions{
-				Names:      item.Names,
-				Privileges: item.Privileges,
-				Query:      item.Query,
-			}
-			vperm = append(vperm, obj)
+		obj := XPackSecurityIndicesPermissions{
+			Names:                  item.Names,
+			Privileges:             item.Privileges,
+			Query:                  item.Query,
+			AllowRestrictedIndices: item.AllowRestrictedIndices,
 		}
-	}
-
-	return vperm, nil
-}
-
-func flattenIndicesPermissionSetv7(resourcesArray []elastic7.XPackSecurityIndicesPermissions) ([]XPackSecurityIndicesPermissions, error) {
-	vperm := make([]XPackSecurityIndicesPermissions, 0, len(resourcesArray))
-	for _, item := range resourcesArray {
-		if item.FieldSecurity != nil {
-			obj := XPackSecurityIndicesPermissions{
-				Names:         item.Names,
-				Privileges:    item.Privileges,
-				FieldSecurity: flattenIndicesFieldSecurity(item.FieldSecurity.(map[string]interface{})),
-				Query:         item.Query,
-			}
-			vperm = append(vperm, obj)
-		} else {
-			obj := XPackSecurityIndicesPermissions{
-				Names:      item.Names,
-				Privileges: item.Privileges,
-				Query:      item.Query,
-			}
-			vperm = append(vperm, obj)
+		if fieldSecurity, ok := item.FieldSecurity.(map[string]interface{}); ok {
+			obj.FieldSecurity = flattenIndicesFieldSecurity(fieldSecurity)
 		}
+		vperm = append(vperm, obj)
 	}
 
-	return vperm, nil
+	return vperm
 }
 
 func expandIndicesFieldSecurity(collapsedSettings []interface{}) map[string][]string {
@@ -377,10 +614,11 @@ func expandIndicesPermissionSet(resourcesArray []interface{}) ([]PutRoleIndicesP
 
 		if len(data["names"].(*schema.Set).List()) > 0 && len(data["privileges"].(*schema.Set).List()) > 0 {
 			obj := PutRoleIndicesPermissions{
-				Names:         expandStringList(data["names"].(*schema.Set).List()),
-				Privileges:    expandStringList(data["privileges"].(*schema.Set).List()),
-				FieldSecurity: expandIndicesFieldSecurity(data["field_security"].([]interface{})),
-				Query:         data["query"].(string),
+				Names:                  expandStringList(data["names"].(*schema.Set).List()),
+				Privileges:             expandStringList(data["privileges"].(*schema.Set).List()),
+				FieldSecurity:          expandIndicesFieldSecurity(data["field_security"].([]interface{})),
+				Query:                  data["query"].(string),
+				AllowRestrictedIndices: data["allow_restricted_indices"].(bool),
 			}
 			vperm = append(vperm, obj)
 		}
@@ -623,6 +861,124 @@ func tenantPermissionsHash(v interface{}) int {
 	return hashcode.String(buf.String())
 }
 
+// mergeJSONObjects sets the values of src into dst, the objects set in both
+// are merged recursively
+func mergeJSONObjects(dst, src map[string]interface{}) {
+	for key, value := range src {
+		srcObject, srcIsObject := value.(map[string]interface{})
+		dstObject, dstIsObject := dst[key].(map[string]interface{})
+		if srcIsObject && dstIsObject {
+			mergeJSONObjects(dstObject, srcObject)
+		} else {
+			dst[key] = value
+		}
+	}
+}
+
+// filterJSONObject returns the values of object at the keys of filter, the
+// objects of both are filtered recursively
+func filterJSONObject(object, filter map[string]interface{}) map[string]interface{} {
+	filtered := make(map[string]interface{})
+	for key, filterValue := range filter {
+		value, ok := object[key]
+		if !ok {
+			continue
+		}
+		valueObject, valueIsObject := value.(map[string]interface{})
+		filterObject, filterIsObject := filterValue.(map[string]interface{})
+		if valueIsObject && filterIsObject {
+			filtered[key] = filterJSONObject(valueObject, filterObject)
+		} else {
+			filtered[key] = value
+		}
+	}
+	return filtered
+}
+
+// openDistroPath returns the path of an Open Distro plugin endpoint, moved to
+// _plugins by OpenSearch which deprecated the _opendistro endpoints
+func openDistroPath(meta interface{}, path string) string {
+	if clusterFlavor(meta.(*ProviderConf)) == flavorOpenSearch {
+		return strings.Replace(path, "/_opendistro/", "/_plugins/", 1)
+	}
+	return path
+}
+
+// elasticsearchPerformRequest performs a raw request with any of the clients,
+// for APIs without a service in all the client versions
+func elasticsearchPerformRequest(meta interface{}, method string, path string, body interface{}) (json.RawMessage, error) {
+	esClient, err := getClient(meta.(*ProviderConf))
+	if err != nil {
+		return nil, err
+	}
+
+	switch client := esClient.(type) {
+	case *elastic7.Client:
+		var res *elastic7.Response
+		res, err = client.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+			Method: method,
+			Path:   path,
+			Body:   body,
+		})
+		if err == nil {
+			return res.Body, nil
+		}
+	case *elastic6.Client:
+		var res *elastic6.Response
+		res, err = client.PerformRequest(context.TODO(), elastic6.PerformRequestOptions{
+			Method: method,
+			Path:   path,
+			Body:   body,
+		})
+		if err == nil {
+			return res.Body, nil
+		}
+	default:
+		elastic5Client := esClient.(*elastic5.Client)
+
+		var res *elastic5.Response
+		res, err = elastic5Client.PerformRequest(context.TODO(), method, path, nil, body)
+		if err == nil {
+			return res.Body, nil
+		}
+	}
+
+	return nil, err
+}
+
+// kibanaPerformRequest performs a raw request to the Kibana API, the Kibana
+// client is only available from Elastic v7
+func kibanaPerformRequest(meta interface{}, method string, path string, body interface{}) (json.RawMessage, error) {
+	kibanaClient, err := getKibanaClient(meta.(*ProviderConf))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := kibanaClient.(*elastic7.Client).PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+		Method: method,
+		Path:   path,
+		Body:   body,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Body, nil
+}
+
+// openSearchCompatibleVersion is the Elasticsearch version OpenSearch was
+// forked from, whose APIs it implements from its 1.0 version
+var openSearchCompatibleVersion, _ = version.NewVersion("7.10.2")
+
+// elastic7GetCompatibleVersion returns the version of the cluster for the
+// checks of the Elasticsearch APIs available on both flavors, the versions of
+// OpenSearch are not comparable to those of Elasticsearch
+func elastic7GetCompatibleVersion(meta interface{}, client *elastic7.Client) (*version.Version, error) {
+	if clusterFlavor(meta.(*ProviderConf)) == flavorOpenSearch {
+		return openSearchCompatibleVersion, nil
+	}
+	return elastic7GetVersion(client)
+}
+
 func elastic7GetVersion(client *elastic7.Client) (*version.Version, error) {
 	urls := reflect.ValueOf(client).Elem().FieldByName("urls")
 	versionString, err := client.ElasticsearchVersion(urls.Index(0).String())
@@ -678,3 +1034,37 @@ func toUnderscore(s string) string {
 	}
 	return string(res)
 }
+
+// validateElasticsearchDuration checks a value is an Elasticsearch time unit,
+// e.g. `30s`, `1m` or `-1`, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/common-options.html#time-units
+func validateElasticsearchDuration(v interface{}, k string) (ws []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if !elasticsearchDurationRegexp.MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q must be an Elasticsearch duration (e.g. 30s, 5m, 1d), got: %s", k, value))
+	}
+	return
+}
+
+// parseElasticsearchDuration converts an Elasticsearch time unit into a
+// time.Duration, `-1` and `0` both convert to a zero duration.
+func parseElasticsearchDuration(value string) (time.Duration, error) {
+	m := elasticsearchDurationRegexp.FindStringSubmatch(value)
+	if m == nil {
+		return 0, fmt.Errorf("invalid Elasticsearch duration: %s", value)
+	}
+	if m[2] == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseInt(strings.TrimSuffix(value, m[2]), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(n) * elasticsearchDurationUnits[m[2]], nil
+}
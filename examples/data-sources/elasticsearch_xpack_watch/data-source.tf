@@ -0,0 +1,7 @@
+data "elasticsearch_xpack_watch" "cluster_health" {
+  watch_id = "cluster_health"
+}
+
+output "cluster_health_last_checked" {
+  value = data.elasticsearch_xpack_watch.cluster_health.last_checked
+}
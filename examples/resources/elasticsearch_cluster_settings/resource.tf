@@ -0,0 +1,9 @@
+resource "elasticsearch_cluster_settings" "global" {
+  persistent = {
+    "cluster.routing.allocation.enable"  = "all"
+    "indices.recovery.max_bytes_per_sec" = "50mb"
+  }
+  transient = {
+    "cluster.routing.rebalance.enable" = "none"
+  }
+}
@@ -0,0 +1,22 @@
+resource "elasticsearch_composable_index_template" "logs" {
+  name = "logs"
+  body = <<EOF
+{
+  "index_patterns": ["logs-*"],
+  "data_stream": {},
+  "template": {
+    "settings": {
+      "index": {
+        "number_of_shards": 1
+      }
+    }
+  }
+}
+EOF
+}
+
+resource "elasticsearch_data_stream" "app" {
+  name = "logs-app"
+
+  depends_on = [elasticsearch_composable_index_template.logs]
+}
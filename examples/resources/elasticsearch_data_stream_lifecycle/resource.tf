@@ -0,0 +1,4 @@
+resource "elasticsearch_data_stream_lifecycle" "logs" {
+  name           = "logs-app"
+  data_retention = "30d"
+}
@@ -0,0 +1,28 @@
+resource "elasticsearch_enrich_policy" "users" {
+  name          = "users-policy"
+  indices       = ["users"]
+  match_field   = "email"
+  enrich_fields = ["first_name", "last_name", "city"]
+
+  # execute the policy again when the users index is reloaded
+  execute_triggers = {
+    users_loaded_at = var.users_loaded_at
+  }
+}
+
+resource "elasticsearch_ingest_pipeline" "users" {
+  name = "users-lookup"
+  body = <<EOF
+{
+  "processors": [
+    {
+      "enrich": {
+        "policy_name": "${elasticsearch_enrich_policy.users.name}",
+        "field": "email",
+        "target_field": "user"
+      }
+    }
+  ]
+}
+EOF
+}
@@ -0,0 +1,23 @@
+resource "elasticsearch_index" "logs_v1" {
+  name = "logs-v1"
+}
+
+# Moving the alias to another index is a single atomic request
+resource "elasticsearch_index_alias" "logs" {
+  name           = "logs"
+  indices        = [elasticsearch_index.logs_v1.name]
+  is_write_index = true
+}
+
+# A filtered alias
+resource "elasticsearch_index_alias" "errors" {
+  name    = "logs-errors"
+  indices = [elasticsearch_index.logs_v1.name]
+  filter  = <<EOF
+{
+  "term": {
+    "level": "error"
+  }
+}
+EOF
+}
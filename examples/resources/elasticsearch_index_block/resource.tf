@@ -0,0 +1,5 @@
+# Freeze the writes to the indices before migrating them
+resource "elasticsearch_index_block" "logs" {
+  index = "logs-*"
+  block = "write"
+}
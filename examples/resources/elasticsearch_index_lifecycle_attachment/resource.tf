@@ -0,0 +1,6 @@
+# Attach a policy to indices created by an application
+resource "elasticsearch_index_lifecycle_attachment" "logs" {
+  index          = "logs-*"
+  policy_name    = elasticsearch_xpack_ilm_policy.logs.name
+  rollover_alias = "logs"
+}
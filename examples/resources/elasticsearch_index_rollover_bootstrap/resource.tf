@@ -0,0 +1,6 @@
+# Bootstrap daily indices rolled over by the policy of the template
+resource "elasticsearch_index_rollover_bootstrap" "logs" {
+  alias         = "logs"
+  initial_index = "<logs-{now/d}-000001>"
+  template      = elasticsearch_composable_index_template.logs.name
+}
@@ -0,0 +1,9 @@
+# Tune the settings of indices created by an application
+resource "elasticsearch_index_settings" "logs" {
+  index = "logs-*"
+  settings = {
+    "index.number_of_replicas"                  = "2"
+    "index.refresh_interval"                    = "30s"
+    "index.search.slowlog.threshold.query.warn" = "10s"
+  }
+}
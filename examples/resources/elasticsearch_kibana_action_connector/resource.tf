@@ -0,0 +1,16 @@
+resource "elasticsearch_kibana_action_connector" "slack" {
+  name              = "Search team channel"
+  connector_type_id = ".slack"
+  secrets = jsonencode({
+    webhookUrl = var.slack_webhook_url
+  })
+}
+
+resource "elasticsearch_kibana_action_connector" "alerts_index" {
+  name              = "Alerts history"
+  connector_type_id = ".index"
+  config = jsonencode({
+    index              = "alerts-history"
+    executionTimeField = "@timestamp"
+  })
+}
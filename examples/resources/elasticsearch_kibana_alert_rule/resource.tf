@@ -0,0 +1,25 @@
+resource "elasticsearch_kibana_alert_rule" "errors" {
+  name         = "Too many errors"
+  rule_type_id = ".index-threshold"
+  interval     = "1m"
+  tags         = ["logs"]
+  notify_when  = "onActionGroupChange"
+  params = jsonencode({
+    index               = ["logs-*"]
+    timeField           = "@timestamp"
+    aggType             = "count"
+    groupBy             = "all"
+    thresholdComparator = ">"
+    threshold           = [100]
+    timeWindowSize      = 5
+    timeWindowUnit      = "m"
+  })
+
+  action {
+    id    = elasticsearch_kibana_action_connector.slack.connector_id
+    group = "threshold met"
+    params = jsonencode({
+      message = "{{rule.name}} is active"
+    })
+  }
+}
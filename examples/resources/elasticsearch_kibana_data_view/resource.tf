@@ -0,0 +1,19 @@
+resource "elasticsearch_kibana_data_view" "logs" {
+  space_id        = "search-team"
+  data_view_id    = "logs"
+  title           = "logs-*"
+  name            = "Logs"
+  time_field_name = "@timestamp"
+
+  runtime_field {
+    name   = "hour_of_day"
+    type   = "long"
+    script = "emit(doc['@timestamp'].value.getHour())"
+  }
+
+  field_format {
+    field  = "http.response.bytes"
+    id     = "bytes"
+    params = jsonencode({ pattern = "0,0.[0]b" })
+  }
+}
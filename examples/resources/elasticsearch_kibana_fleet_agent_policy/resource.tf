@@ -0,0 +1,7 @@
+resource "elasticsearch_kibana_fleet_agent_policy" "hosts" {
+  name               = "Search hosts"
+  namespace          = "search"
+  description        = "Hosts of the search team"
+  monitoring_enabled = ["logs", "metrics"]
+  sys_monitoring     = true
+}
@@ -0,0 +1,32 @@
+resource "elasticsearch_kibana_saved_object" "logs" {
+  space_id    = "search-team"
+  object_type = "index-pattern"
+  object_id   = "logs"
+  overwrite   = true
+  attributes = jsonencode({
+    title         = "logs-*"
+    timeFieldName = "@timestamp"
+  })
+}
+
+resource "elasticsearch_kibana_saved_object" "errors" {
+  space_id    = "search-team"
+  object_type = "search"
+  attributes = jsonencode({
+    title   = "Errors"
+    columns = ["message"]
+    kibanaSavedObjectMeta = {
+      searchSourceJSON = jsonencode({
+        indexRefName = "kibanaSavedObjectMeta.searchSourceJSON.index"
+        query        = { query = "log.level:error", language = "kuery" }
+        filter       = []
+      })
+    }
+  })
+
+  reference {
+    name = "kibanaSavedObjectMeta.searchSourceJSON.index"
+    type = "index-pattern"
+    id   = elasticsearch_kibana_saved_object.logs.object_id
+  }
+}
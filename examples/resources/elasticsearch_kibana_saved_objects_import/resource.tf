@@ -0,0 +1,5 @@
+resource "elasticsearch_kibana_saved_objects_import" "dashboards" {
+  space_id  = "search-team"
+  file      = "${path.module}/dashboards.ndjson"
+  overwrite = true
+}
@@ -0,0 +1,8 @@
+resource "elasticsearch_kibana_space" "search" {
+  space_id          = "search-team"
+  name              = "Search team"
+  description       = "Dashboards of the search team"
+  disabled_features = ["dev_tools", "ml"]
+  color             = "#3b82f6"
+  initials          = "ST"
+}
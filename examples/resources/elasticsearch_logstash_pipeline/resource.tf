@@ -0,0 +1,20 @@
+resource "elasticsearch_logstash_pipeline" "beats" {
+  pipeline_id = "beats"
+  description = "Index the events of beats"
+  pipeline    = <<-EOF
+    input {
+      beats {
+        port => 5044
+      }
+    }
+    output {
+      elasticsearch {
+        hosts => ["http://localhost:9200"]
+      }
+    }
+  EOF
+  pipeline_settings = jsonencode({
+    "pipeline.workers" = 2
+    "queue.type"       = "persisted"
+  })
+}
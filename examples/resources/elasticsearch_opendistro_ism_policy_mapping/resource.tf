@@ -1,5 +1,5 @@
-resource "elasticsearch_opendistro_ism_policy_mapping" "test" {
-	policy_id = "policy_1"
-	indexes = "test_index"
-  state = "delete"
+# Bring the existing indices of a log shipper under the policy
+resource "elasticsearch_opendistro_ism_policy_mapping" "logs" {
+  policy_id = elasticsearch_opendistro_ism_policy.logs.policy_id
+  indexes   = "filebeat-*"
 }
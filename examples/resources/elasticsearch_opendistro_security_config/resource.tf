@@ -0,0 +1,17 @@
+resource "elasticsearch_opendistro_security_config" "config" {
+  body = jsonencode({
+    kibana = {
+      multitenancy_enabled = true
+    }
+    auth_failure_listeners = {
+      ip_rate_limiting = {
+        type                 = "ip"
+        allowed_tries        = 10
+        time_window_seconds  = 3600
+        block_expiry_seconds = 600
+        max_blocked_clients  = 100000
+        max_tracked_clients  = 100000
+      }
+    }
+  })
+}
@@ -0,0 +1,18 @@
+resource "elasticsearch_opendistro_kibana_tenant" "team" {
+  tenant_name = "search-team"
+  description = "Dashboards of the search team"
+}
+
+resource "elasticsearch_opendistro_user_tenants" "alice" {
+  username       = "alice"
+  default_tenant = elasticsearch_opendistro_kibana_tenant.team.tenant_name
+
+  tenant {
+    name = elasticsearch_opendistro_kibana_tenant.team.tenant_name
+  }
+
+  tenant {
+    name   = "global_tenant"
+    access = "read"
+  }
+}
@@ -0,0 +1,29 @@
+resource "elasticsearch_opensearch_alerting_workflow" "errors" {
+  body = jsonencode({
+    name     = "errors-after-deploys"
+    schedule = { period = { interval = 5, unit = "MINUTES" } }
+    inputs = [{
+      composite_input = {
+        sequence = {
+          delegates = [
+            { order = 1, monitor_id = elasticsearch_opendistro_monitor.deploys.id },
+            { order = 2, monitor_id = elasticsearch_opendistro_monitor.errors.id },
+          ]
+        }
+      }
+    }]
+    triggers = [{
+      chained_alert_trigger = {
+        name     = "errors-after-deploys"
+        severity = "1"
+        condition = {
+          script = {
+            source = "monitor[id=${elasticsearch_opendistro_monitor.deploys.id}] && monitor[id=${elasticsearch_opendistro_monitor.errors.id}]"
+            lang   = "painless"
+          }
+        }
+        actions = []
+      }
+    }]
+  })
+}
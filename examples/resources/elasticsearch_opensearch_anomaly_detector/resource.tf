@@ -0,0 +1,29 @@
+resource "elasticsearch_opensearch_anomaly_detector" "latency" {
+  enabled = true
+  body = jsonencode({
+    name        = "latency"
+    description = "Anomalies of the latency of the requests"
+    time_field  = "@timestamp"
+    indices     = ["logs-*"]
+    feature_attributes = [{
+      feature_name    = "avg_latency"
+      feature_enabled = true
+      aggregation_query = {
+        avg_latency = {
+          avg = { field = "latency" }
+        }
+      }
+    }]
+    filter_query = {
+      bool = {
+        filter = [{ term = { service = "api" } }]
+      }
+    }
+    detection_interval = {
+      period = { interval = 10, unit = "Minutes" }
+    }
+    window_delay = {
+      period = { interval = 1, unit = "Minutes" }
+    }
+  })
+}
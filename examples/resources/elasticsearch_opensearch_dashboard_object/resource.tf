@@ -0,0 +1,30 @@
+resource "elasticsearch_opensearch_dashboard_object" "logs" {
+  object_type = "index-pattern"
+  object_id   = "logs"
+  tenant      = "global"
+  attributes = jsonencode({
+    title         = "logs-*"
+    timeFieldName = "@timestamp"
+  })
+}
+
+resource "elasticsearch_opensearch_dashboard_object" "errors" {
+  object_type = "search"
+  tenant      = "global"
+  attributes = jsonencode({
+    title   = "Errors"
+    columns = ["service", "message"]
+    kibanaSavedObjectMeta = {
+      searchSourceJSON = jsonencode({
+        query        = { query = "level:error", language = "kuery" }
+        indexRefName = "kibanaSavedObjectMeta.searchSourceJSON.index"
+      })
+    }
+  })
+
+  reference {
+    name = "kibanaSavedObjectMeta.searchSourceJSON.index"
+    type = "index-pattern"
+    id   = elasticsearch_opensearch_dashboard_object.logs.object_id
+  }
+}
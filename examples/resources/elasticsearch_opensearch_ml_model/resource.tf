@@ -0,0 +1,13 @@
+resource "elasticsearch_opensearch_ml_model_group" "embeddings" {
+  name        = "embeddings"
+  description = "Text embedding models of the semantic search"
+}
+
+resource "elasticsearch_opensearch_ml_model" "minilm" {
+  model_group_id = elasticsearch_opensearch_ml_model_group.embeddings.id
+  body = jsonencode({
+    name         = "huggingface/sentence-transformers/all-MiniLM-L6-v2"
+    version      = "1.0.1"
+    model_format = "TORCH_SCRIPT"
+  })
+}
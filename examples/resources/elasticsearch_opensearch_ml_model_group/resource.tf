@@ -0,0 +1,6 @@
+resource "elasticsearch_opensearch_ml_model_group" "embeddings" {
+  name          = "embeddings"
+  description   = "Text embedding models of the semantic search"
+  access_mode   = "restricted"
+  backend_roles = ["search"]
+}
@@ -0,0 +1,7 @@
+resource "elasticsearch_opensearch_replication" "orders" {
+  follower_index        = "orders"
+  leader_alias          = "primary"
+  leader_index          = "orders"
+  leader_cluster_role   = "cross_cluster_replication_leader_full_access"
+  follower_cluster_role = "cross_cluster_replication_follower_full_access"
+}
@@ -0,0 +1,7 @@
+resource "elasticsearch_opensearch_replication_autofollow_rule" "logs" {
+  name                  = "logs"
+  leader_alias          = "primary"
+  pattern               = "logs-*"
+  leader_cluster_role   = "cross_cluster_replication_leader_full_access"
+  follower_cluster_role = "cross_cluster_replication_follower_full_access"
+}
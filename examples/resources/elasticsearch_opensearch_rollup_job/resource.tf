@@ -0,0 +1,33 @@
+resource "elasticsearch_opensearch_rollup_job" "hourly_latency" {
+  rollup_id = "hourly-latency"
+  body = jsonencode({
+    source_index = "logs-*"
+    target_index = "rollup-latency"
+    page_size    = 1000
+    description  = "Hourly latency of the services"
+    continuous   = true
+    schedule = {
+      interval = {
+        period     = 1
+        unit       = "Hours"
+        start_time = 1602100553
+      }
+    }
+    dimensions = [
+      {
+        date_histogram = {
+          source_field   = "@timestamp"
+          fixed_interval = "1h"
+          timezone       = "UTC"
+        }
+      },
+      {
+        terms = { source_field = "service" }
+      },
+    ]
+    metrics = [{
+      source_field = "latency"
+      metrics      = [{ avg = {} }, { max = {} }, { value_count = {} }]
+    }]
+  })
+}
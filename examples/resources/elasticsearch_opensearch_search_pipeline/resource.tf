@@ -0,0 +1,20 @@
+resource "elasticsearch_opensearch_search_pipeline" "published" {
+  name = "published-only"
+  body = jsonencode({
+    description = "Only return the published articles"
+    request_processors = [{
+      filter_query = {
+        tag         = "published"
+        description = "Filter out the drafts"
+        query = {
+          term = { status = "published" }
+        }
+      }
+    }]
+    response_processors = [{
+      truncate_hits = {
+        target_size = 20
+      }
+    }]
+  })
+}
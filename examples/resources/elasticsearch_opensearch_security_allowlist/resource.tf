@@ -0,0 +1,18 @@
+resource "elasticsearch_opensearch_security_allowlist" "allowlist" {
+  enabled = true
+
+  request {
+    path    = "/_cat/nodes"
+    methods = ["GET"]
+  }
+
+  request {
+    path    = "/_cat/indices"
+    methods = ["GET"]
+  }
+
+  request {
+    path    = "/_cluster/settings"
+    methods = ["GET", "PUT"]
+  }
+}
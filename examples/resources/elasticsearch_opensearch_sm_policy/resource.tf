@@ -0,0 +1,37 @@
+resource "elasticsearch_opensearch_sm_policy" "daily" {
+  policy_name = "daily-snapshots"
+  body = jsonencode({
+    description = "Daily snapshots, kept for a month"
+    creation = {
+      schedule = {
+        cron = {
+          expression = "0 2 * * *"
+          timezone   = "UTC"
+        }
+      }
+      time_limit = "1h"
+    }
+    deletion = {
+      schedule = {
+        cron = {
+          expression = "0 3 * * *"
+          timezone   = "UTC"
+        }
+      }
+      condition = {
+        max_age   = "30d"
+        max_count = 40
+        min_count = 7
+      }
+      time_limit = "1h"
+    }
+    snapshot_config = {
+      date_format          = "yyyy-MM-dd-HH:mm"
+      timezone             = "UTC"
+      indices              = "logs-*"
+      repository           = "backups"
+      ignore_unavailable   = true
+      include_global_state = false
+    }
+  })
+}
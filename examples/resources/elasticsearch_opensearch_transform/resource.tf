@@ -0,0 +1,30 @@
+resource "elasticsearch_opensearch_transform" "latency_by_service" {
+  transform_id = "latency-by-service"
+  body = jsonencode({
+    source_index = "logs-*"
+    target_index = "latency-by-service"
+    page_size    = 1000
+    description  = "Latency of each service"
+    continuous   = true
+    data_selection_query = {
+      range = { "@timestamp" = { gte = "now-7d" } }
+    }
+    schedule = {
+      interval = {
+        period     = 1
+        unit       = "Hours"
+        start_time = 1602100553
+      }
+    }
+    groups = [{
+      terms = {
+        source_field = "service"
+        target_field = "service"
+      }
+    }]
+    aggregations = {
+      avg_latency = { avg = { field = "latency" } }
+      max_latency = { max = { field = "latency" } }
+    }
+  })
+}
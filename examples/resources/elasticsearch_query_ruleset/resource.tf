@@ -0,0 +1,31 @@
+resource "elasticsearch_query_ruleset" "products" {
+  name = "products"
+
+  # Promote the documentation of the maintenance page to the top
+  rule {
+    rule_id = "maintenance"
+    type    = "pinned"
+
+    criteria {
+      type     = "contains"
+      metadata = "user_query"
+      values   = ["maintenance", "downtime"]
+    }
+
+    docs {
+      index = "pages"
+      id    = "maintenance"
+    }
+  }
+
+  rule {
+    rule_id = "discontinued"
+    type    = "exclude"
+
+    criteria {
+      type = "always"
+    }
+
+    ids = ["product-42"]
+  }
+}
@@ -0,0 +1,15 @@
+# Copy the documents of an index to a new index with updated mappings
+resource "elasticsearch_reindex" "logs" {
+  source = jsonencode({
+    index = "logs-v1"
+  })
+  dest = jsonencode({
+    index   = elasticsearch_index.logs_v2.name
+    op_type = "create"
+  })
+  script = jsonencode({
+    source = "ctx._source.remove('legacy_field')"
+  })
+  conflicts = "proceed"
+  slices    = "auto"
+}
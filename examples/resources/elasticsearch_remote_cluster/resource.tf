@@ -0,0 +1,14 @@
+# Discover the primary data center from its seed nodes
+resource "elasticsearch_remote_cluster" "primary" {
+  name             = "primary"
+  seeds            = ["10.0.0.1:9300", "10.0.0.2:9300"]
+  skip_unavailable = true
+}
+
+# Connect to a cluster behind a load balancer
+resource "elasticsearch_remote_cluster" "archive" {
+  name          = "archive"
+  mode          = "proxy"
+  proxy_address = "archive.example.com:9300"
+  server_name   = "archive.example.com"
+}
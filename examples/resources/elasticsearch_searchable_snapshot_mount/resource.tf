@@ -0,0 +1,8 @@
+# Mount a snapshotted index into the frozen tier
+resource "elasticsearch_searchable_snapshot_mount" "logs_2021" {
+  name       = "logs-2021"
+  repository = "archive"
+  snapshot   = "logs-2021"
+  index      = "logs-2021"
+  storage    = "shared_cache"
+}
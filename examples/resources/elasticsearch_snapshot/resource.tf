@@ -0,0 +1,11 @@
+# Take a snapshot of the indices before an upgrade
+resource "elasticsearch_snapshot" "pre_upgrade" {
+  repository           = elasticsearch_snapshot_repository.backups.name
+  name                 = "pre-upgrade"
+  indices              = ["logs-*", "metrics-*"]
+  include_global_state = false
+
+  timeouts {
+    create = "1h"
+  }
+}
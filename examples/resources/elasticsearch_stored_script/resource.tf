@@ -0,0 +1,20 @@
+resource "elasticsearch_stored_script" "score" {
+  name   = "my_score"
+  lang   = "painless"
+  source = "Math.log(_score * 2) + params['my_modifier']"
+}
+
+# A search template
+resource "elasticsearch_stored_script" "search" {
+  name   = "my_search_template"
+  lang   = "mustache"
+  source = <<EOF
+{
+  "query": {
+    "match": {
+      "title": "{{query_string}}"
+    }
+  }
+}
+EOF
+}
@@ -0,0 +1,13 @@
+resource "elasticsearch_synonyms_set" "products" {
+  name = "products"
+
+  rule {
+    id       = "phones"
+    synonyms = "phone, mobile, cellphone"
+  }
+
+  rule {
+    id       = "tv"
+    synonyms = "tv, television => tv"
+  }
+}
@@ -0,0 +1,14 @@
+# A key only able to read logs, replaced a week before it expires
+resource "elasticsearch_xpack_api_key" "log_reader" {
+  name                         = "log-reader"
+  expiration                   = "90d"
+  force_recreate_before_expiry = "7d"
+  role_descriptors = jsonencode({
+    "read-logs" = {
+      indices = [{
+        names      = ["logs-*"]
+        privileges = ["read"]
+      }]
+    }
+  })
+}
@@ -0,0 +1,16 @@
+# Scale the hot tier with the default deciders of its roles
+resource "elasticsearch_xpack_autoscaling_policy" "hot" {
+  name  = "hot"
+  roles = ["data_hot", "data_content"]
+}
+
+# Scale the machine learning nodes, keeping one down scaled for a day
+resource "elasticsearch_xpack_autoscaling_policy" "ml" {
+  name  = "ml"
+  roles = ["ml"]
+  deciders = jsonencode({
+    ml = {
+      down_scale_delay = "1d"
+    }
+  })
+}
@@ -0,0 +1,8 @@
+# Replicate the new log indices of the primary data center, except the debug ones
+resource "elasticsearch_xpack_ccr_auto_follow_pattern" "logs" {
+  name                            = "logs"
+  remote_cluster                  = "primary"
+  leader_index_patterns           = ["logs-*"]
+  leader_index_exclusion_patterns = ["logs-debug-*"]
+  follow_index_pattern            = "{{leader_index}}-replica"
+}
@@ -0,0 +1,8 @@
+# Replicate the orders index of the primary data center
+resource "elasticsearch_xpack_ccr_follow" "orders" {
+  name                  = "orders"
+  remote_cluster        = "primary"
+  leader_index          = "orders"
+  max_read_request_size = "16mb"
+  read_poll_timeout     = "30s"
+}
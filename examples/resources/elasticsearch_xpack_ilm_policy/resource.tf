@@ -0,0 +1,33 @@
+resource "elasticsearch_xpack_ilm_policy" "logs" {
+  name = "logs"
+
+  hot {
+    rollover {
+      max_size = "50gb"
+      max_age  = "7d"
+    }
+
+    set_priority {
+      priority = 100
+    }
+  }
+
+  warm {
+    min_age = "7d"
+
+    forcemerge {
+      max_num_segments = 1
+    }
+
+    allocate {
+      number_of_replicas = 1
+      require = {
+        box_type = "warm"
+      }
+    }
+  }
+
+  delete {
+    min_age = "30d"
+  }
+}
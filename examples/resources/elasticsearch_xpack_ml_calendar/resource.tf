@@ -0,0 +1,5 @@
+resource "elasticsearch_xpack_ml_calendar" "maintenance" {
+  name        = "maintenance"
+  description = "Planned maintenance windows"
+  job_ids     = [elasticsearch_xpack_ml_job.response_times.name]
+}
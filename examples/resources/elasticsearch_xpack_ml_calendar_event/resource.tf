@@ -0,0 +1,6 @@
+resource "elasticsearch_xpack_ml_calendar_event" "release" {
+  calendar_id = elasticsearch_xpack_ml_calendar.maintenance.name
+  description = "Release weekend"
+  start_time  = "2021-06-05T22:00:00Z"
+  end_time    = "2021-06-07T06:00:00Z"
+}
@@ -0,0 +1,13 @@
+# Feed the response times of production services to the job
+resource "elasticsearch_xpack_ml_datafeed" "response_times" {
+  name        = "datafeed-response-times"
+  job_id      = elasticsearch_xpack_ml_job.response_times.name
+  indices     = ["logs-*"]
+  query_delay = "90s"
+
+  query = jsonencode({
+    term = {
+      "service.environment" = "production"
+    }
+  })
+}
@@ -0,0 +1,6 @@
+# Domains skipped by the rules of the detectors of a job
+resource "elasticsearch_xpack_ml_filter" "safe_domains" {
+  name        = "safe-domains"
+  description = "Domains which are never anomalous"
+  items       = ["*.example.com", "trusted.org"]
+}
@@ -0,0 +1,22 @@
+# Detect unusual response times of each service
+resource "elasticsearch_xpack_ml_job" "response_times" {
+  name               = "response-times"
+  description        = "Mean response times by service"
+  groups             = ["web"]
+  model_memory_limit = "256mb"
+
+  analysis_config = jsonencode({
+    bucket_span = "15m"
+    detectors = [
+      {
+        function             = "mean"
+        field_name           = "event.duration"
+        partition_field_name = "service.name"
+      }
+    ]
+    influencers = ["service.name", "host.name"]
+  })
+  data_description = jsonencode({
+    time_field = "@timestamp"
+  })
+}
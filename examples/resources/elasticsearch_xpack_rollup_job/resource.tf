@@ -0,0 +1,24 @@
+# Roll up the hourly traffic of each host
+resource "elasticsearch_xpack_rollup_job" "traffic" {
+  name          = "traffic"
+  index_pattern = "traffic-*"
+  rollup_index  = "traffic-rollup"
+  cron          = "0 0 * * * ?"
+
+  groups = jsonencode({
+    date_histogram = {
+      field          = "@timestamp"
+      fixed_interval = "1h"
+      delay          = "1d"
+    }
+    terms = {
+      fields = ["host.name"]
+    }
+  })
+  metrics = jsonencode([
+    {
+      field   = "network.bytes"
+      metrics = ["sum", "max", "avg"]
+    }
+  ])
+}
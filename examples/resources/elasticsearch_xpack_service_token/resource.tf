@@ -0,0 +1,10 @@
+# A token for Fleet Server, passed to it as a sensitive value
+resource "elasticsearch_xpack_service_token" "fleet_server" {
+  service_account = "elastic/fleet-server"
+  name            = "fleet-server-1"
+}
+
+output "fleet_server_token" {
+  value     = elasticsearch_xpack_service_token.fleet_server.value
+  sensitive = true
+}
@@ -0,0 +1,31 @@
+# Continuously count the events of each user
+resource "elasticsearch_xpack_transform" "user_events" {
+  name        = "user-events"
+  description = "Events by user"
+  frequency   = "5m"
+
+  source = jsonencode({
+    index = ["events-*"]
+    query = {
+      term = { "event.category" = "authentication" }
+    }
+  })
+  dest = jsonencode({
+    index = "user-events"
+  })
+  pivot = jsonencode({
+    group_by = {
+      user = { terms = { field = "user.name" } }
+    }
+    aggregations = {
+      events = { value_count = { field = "event.id" } }
+      last   = { max = { field = "@timestamp" } }
+    }
+  })
+  sync = jsonencode({
+    time = {
+      field = "@timestamp"
+      delay = "120s"
+    }
+  })
+}
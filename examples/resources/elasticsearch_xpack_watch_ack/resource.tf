@@ -0,0 +1,9 @@
+# Acknowledge the paging action of a watch while an incident is handled
+resource "elasticsearch_xpack_watch_ack" "page_ops" {
+  watch_id   = elasticsearch_xpack_watch.watch_1.id
+  action_ids = ["page_ops"]
+
+  triggers = {
+    incident = var.incident_id
+  }
+}
@@ -0,0 +1,12 @@
+# Smoke test the alerting of a new environment, simulating the actions
+resource "elasticsearch_xpack_watch_execution" "smoke_test" {
+  watch_id         = elasticsearch_xpack_watch.watch_1.id
+  ignore_condition = true
+
+  action_modes = {
+    _all = "simulate"
+  }
+  trigger_data = jsonencode({
+    scheduled_time = "now"
+  })
+}
@@ -0,0 +1,8 @@
+resource "elasticsearch_xpack_watcher_settings" "watcher" {
+  slack_default_account = "monitoring"
+
+  notification = {
+    "slack.account.monitoring.message_defaults.from" = "watcher"
+    "slack.account.monitoring.message_defaults.icon" = "https://example.com/watcher.png"
+  }
+}